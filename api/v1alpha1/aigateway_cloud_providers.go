@@ -28,6 +28,11 @@ const (
 	//
 	// They are known for models such as mistral-tiny.
 	AICloudProviderMistral AICloudProviderName = "mistral"
+
+	// AICloudProviderAnthropic is the Anthropic cloud provider.
+	//
+	// They are known for models such as Claude.
+	AICloudProviderAnthropic AICloudProviderName = "anthropic"
 )
 
 // -----------------------------------------------------------------------------
@@ -40,7 +45,7 @@ type AICloudProvider struct {
 	// Name is the unique name of an LLM provider.
 	//
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Enum=openai;azure;cohere;mistral
+	// +kubebuilder:validation:Enum=openai;azure;cohere;mistral;anthropic
 	Name AICloudProviderName `json:"name"`
 }
 
@@ -72,6 +72,23 @@ const (
 	// You may want to reference the controller logs as well for additional
 	// details about what may have gone wrong.
 	AIGatewayConditionTypeEndpointReady string = "EndpointReady"
+
+	// AIGatewayConditionTypeEnterpriseRequired indicates whether the AIGateway
+	// uses features which are provided by plugins only available in Kong
+	// Gateway Enterprise (e.g. token quotas of consumers).
+	//
+	// Possible reasons for this condition to be "True" include:
+	//
+	//   - "EnterprisePluginsConfigured"
+	//
+	// Possible reasons for this condition to be "False" include:
+	//
+	//   - "NoEnterprisePluginsConfigured"
+	//
+	// When "True", the DataPlanes of the AIGateway's GatewayClass have to run
+	// a Kong Gateway Enterprise image, otherwise their configuration can't be
+	// applied. The condition's message lists the features requiring it.
+	AIGatewayConditionTypeEnterpriseRequired string = "EnterpriseRequired"
 )
 
 // -----------------------------------------------------------------------------
@@ -136,6 +153,21 @@ const (
 	AIGatewayConditionReasonFailed string = "Failed"
 )
 
+// -----------------------------------------------------------------------------
+// AIGateway API - Conditions - "EnterpriseRequired" Reasons
+// -----------------------------------------------------------------------------
+
+const (
+	// AIGatewayConditionReasonEnterprisePluginsConfigured indicates that the
+	// AIGateway is configured with plugins only available in Kong Gateway
+	// Enterprise.
+	AIGatewayConditionReasonEnterprisePluginsConfigured string = "EnterprisePluginsConfigured"
+
+	// AIGatewayConditionReasonNoEnterprisePluginsConfigured indicates that
+	// the AIGateway can be served by Kong Gateway OSS.
+	AIGatewayConditionReasonNoEnterprisePluginsConfigured string = "NoEnterprisePluginsConfigured"
+)
+
 // -----------------------------------------------------------------------------
 // AIGateway - ConditionsAware Implementation
 // -----------------------------------------------------------------------------
@@ -0,0 +1,85 @@
+package v1alpha1
+
+// -----------------------------------------------------------------------------
+// AIGateway API - Consumers
+// -----------------------------------------------------------------------------
+
+// AIGatewayConsumer is a consumer (e.g. a team) of the AIGateway's endpoints.
+//
+// Each consumer is provided with its own credentials, which are stored in a
+// Secret referenced by the AIGateway's endpoints, and can be limited in the
+// number of requests and tokens it sends to each of the models.
+type AIGatewayConsumer struct {
+	// Name is the unique name of the consumer.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Quotas is a list of per model budgets for the consumer. Models without
+	// a quota can be accessed by the consumer without any limits.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=64
+	Quotas []AIGatewayConsumerQuota `json:"quotas,omitempty"`
+}
+
+// AIGatewayConsumerQuota is the budget of requests and tokens a consumer can
+// spend on a model within a time window.
+//
+// +kubebuilder:validation:XValidation:message="At least one of requests or tokens has to be set",rule="has(self.requests) || has(self.tokens)"
+type AIGatewayConsumerQuota struct {
+	// Model is the identifier of the LLM the quota applies to.
+	//
+	// +kubebuilder:validation:Required
+	Model string `json:"model"`
+
+	// Window is the time window the quota applies to.
+	//
+	// If not specified, "minute" will be used as the default.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=minute;hour;day
+	// +kubebuilder:default=minute
+	Window AIGatewayQuotaWindow `json:"window,omitempty"`
+
+	// Requests is the maximum number of requests the consumer can send to the
+	// model within the window.
+	//
+	// Requests are counted by each of the Gateway's DataPlane replicas on its
+	// own, hence with multiple replicas the consumer can send up to this
+	// number of requests to each of them.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	Requests *int `json:"requests,omitempty"`
+
+	// Tokens is the maximum number of tokens the consumer can spend on the
+	// model within the window.
+	//
+	// Token budgets are enforced by the ai-rate-limiting-advanced plugin which
+	// is only available in Kong Gateway Enterprise. For LLMs with multiple
+	// backends the budget applies to each of the backends' cloud providers.
+	// AIGateways using token budgets report this through their
+	// "EnterpriseRequired" condition.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	Tokens *int `json:"tokens,omitempty"`
+}
+
+// AIGatewayQuotaWindow is the time window of a consumer's quota.
+type AIGatewayQuotaWindow string
+
+const (
+	// AIGatewayQuotaWindowMinute indicates a quota renewed every minute.
+	AIGatewayQuotaWindowMinute AIGatewayQuotaWindow = "minute"
+
+	// AIGatewayQuotaWindowHour indicates a quota renewed every hour.
+	AIGatewayQuotaWindowHour AIGatewayQuotaWindow = "hour"
+
+	// AIGatewayQuotaWindowDay indicates a quota renewed every day.
+	AIGatewayQuotaWindowDay AIGatewayQuotaWindow = "day"
+)
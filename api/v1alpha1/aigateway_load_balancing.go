@@ -0,0 +1,92 @@
+package v1alpha1
+
+// -----------------------------------------------------------------------------
+// AIGateway API - Load Balancing
+// -----------------------------------------------------------------------------
+
+// CloudHostedLLMBackend is an additional cloud provider and model combination
+// serving a cloud hosted LLM.
+type CloudHostedLLMBackend struct {
+	// AICloudProvider defines the cloud provider that will fulfill the LLM
+	// requests sent to this backend.
+	//
+	// +kubebuilder:validation:Required
+	AICloudProvider AICloudProvider `json:"aiCloudProvider"`
+
+	// Model is the model name of the LLM served by this backend.
+	//
+	// If not specified, whatever the cloud provider specifies as the default
+	// model will be used.
+	//
+	// +kubebuilder:validation:Optional
+	Model *string `json:"model,omitempty"`
+
+	// Weight is the relative share of the requests sent to this backend when
+	// the "weighted" load balancing strategy is used.
+	//
+	// If not specified, 100 will be used as the default.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:default=100
+	Weight *int `json:"weight,omitempty"`
+}
+
+// LLMLoadBalancing configures how the requests for an LLM are spread across
+// its backends.
+type LLMLoadBalancing struct {
+	// Strategy is the strategy used to pick the backend of a request.
+	//
+	// If "weighted" is specified, requests are spread across the backends
+	// according to their weights and a failed request is retried on another
+	// backend.
+	//
+	// If "failover" is specified, requests are sent to the primary backend and
+	// only fall back to the other backends, in the order they are listed, when
+	// the previous ones fail.
+	//
+	// If not specified, "weighted" will be used as the default.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=weighted;failover
+	// +kubebuilder:default=weighted
+	Strategy LLMLoadBalancingStrategy `json:"strategy,omitempty"`
+
+	// PrimaryWeight is the relative share of the requests sent to the primary
+	// backend (the AICloudProvider and Model of the LLM) when the "weighted"
+	// load balancing strategy is used.
+	//
+	// If not specified, 100 will be used as the default.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:default=100
+	PrimaryWeight *int `json:"primaryWeight,omitempty"`
+
+	// Retries is the number of times a failed request is retried on another
+	// backend.
+	//
+	// If not specified, a failed request is retried once on each of the
+	// other backends.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=32767
+	Retries *int `json:"retries,omitempty"`
+}
+
+// LLMLoadBalancingStrategy is the strategy used to spread the requests for an
+// LLM across its backends.
+type LLMLoadBalancingStrategy string
+
+const (
+	// LLMLoadBalancingStrategyWeighted spreads the requests across the
+	// backends according to their weights.
+	LLMLoadBalancingStrategyWeighted LLMLoadBalancingStrategy = "weighted"
+
+	// LLMLoadBalancingStrategyFailover sends the requests to the backends in
+	// order, falling back to the next backend when the previous ones fail.
+	LLMLoadBalancingStrategyFailover LLMLoadBalancingStrategy = "failover"
+)
@@ -94,7 +94,7 @@ type AIGatewaySpec struct {
 	// future iterations we may support other model types.
 	//
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:XValidation:message="At least one class of LLMs has been configured",rule="(self.cloudHosted.size() != 0)"
+	// +kubebuilder:validation:XValidation:message="At least one class of LLMs has been configured",rule="(has(self.cloudHosted) && self.cloudHosted.size() != 0) || (has(self.selfHosted) && self.selfHosted.size() != 0) || (has(self.catalog) && self.catalog.size() != 0)"
 	LargeLanguageModels *LargeLanguageModels `json:"largeLanguageModels,omitempty"`
 
 	// CloudProviderCredentials is a reference to an object (e.g. a Kubernetes
@@ -124,12 +124,22 @@ type AIGatewaySpec struct {
 	// duplicates endpoints failures conditions will be emitted and endpoints
 	// will not be configured until the duplicates are resolved.
 	//
-	// This is currently considered required, but in future iterations will be
-	// optional as we do things like enable configuring credentials at the model
-	// level.
+	// This is required when any cloud hosted LLMs are configured. Self hosted
+	// LLMs and LLMs from AIModelCatalogs don't use it.
 	//
-	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Optional
 	CloudProviderCredentials *AICloudProviderAPITokenRef `json:"cloudProviderCredentials,omitempty"`
+
+	// Consumers is a list of consumers allowed to access the AIGateway's
+	// endpoints.
+	//
+	// If any consumers are configured, requests to the AIGateway's endpoints
+	// have to be authenticated with the credentials of one of them. If not
+	// specified, the endpoints can be accessed without credentials.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=64
+	Consumers []AIGatewayConsumer `json:"consumers,omitempty"`
 }
 
 // -----------------------------------------------------------------------------
@@ -142,14 +152,33 @@ type AIGatewaySpec struct {
 type LargeLanguageModels struct {
 	// CloudHosted configures LLMs hosted and served by cloud providers.
 	//
-	// This is currently a required field, requiring at least one cloud-hosted
-	// LLM be specified, however in future iterations we may add other hosting
-	// options such as self-hosted LLMs as separate fields.
+	// At least one cloud hosted, self hosted or catalog LLM has to be
+	// specified.
 	//
-	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=64
+	CloudHosted []CloudHostedLargeLanguageModel `json:"cloudHosted,omitempty"`
+
+	// SelfHosted configures LLMs hosted in the cluster and served by a
+	// Kubernetes Service.
+	//
+	// At least one cloud hosted, self hosted or catalog LLM has to be
+	// specified.
+	//
+	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:MaxItems=64
-	CloudHosted []CloudHostedLargeLanguageModel `json:"cloudHosted"`
+	SelfHosted []SelfHostedLargeLanguageModel `json:"selfHosted,omitempty"`
+
+	// Catalog configures LLMs defined by models of AIModelCatalogs in the
+	// AIGateway's namespace. They are served the same way as cloud hosted
+	// LLMs, using the credentials referenced by their AIModelCatalog.
+	//
+	// At least one cloud hosted, self hosted or catalog LLM has to be
+	// specified.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=64
+	Catalog []AIModelCatalogModelRef `json:"catalog,omitempty"`
 }
 
 // CloudHostedLargeLanguageModel is the configuration for Large Language Models
@@ -201,6 +230,14 @@ type CloudHostedLargeLanguageModel struct {
 	// +kubebuilder:validation:MaxItems=64
 	DefaultPrompts []LLMPrompt `json:"defaultPrompts"`
 
+	// AppendedPrompts is a list of prompts that should be provided to the LLM
+	// after the prompts of any and every inference request, for instance to
+	// remind the LLM of constraints on its responses.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=64
+	AppendedPrompts []LLMPrompt `json:"appendedPrompts,omitempty"`
+
 	// DefaultPromptParams configures the parameters which will be sent with
 	// any and every inference request.
 	//
@@ -216,6 +253,167 @@ type CloudHostedLargeLanguageModel struct {
 	//
 	// +kubebuilder:validation:Required
 	AICloudProvider AICloudProvider `json:"aiCloudProvider"`
+
+	// Logging configures what is logged about the requests made to the LLM.
+	//
+	// If not specified, usage statistics are logged and payloads are not.
+	//
+	// +kubebuilder:validation:Optional
+	Logging *LLMLogging `json:"logging,omitempty"`
+
+	// Backends is a list of additional cloud provider and model combinations
+	// serving the LLM, e.g. to keep serving requests when the AICloudProvider
+	// has an outage.
+	//
+	// When backends are specified, the AICloudProvider and Model of the LLM
+	// are its primary backend and requests are spread across all backends
+	// according to LoadBalancing. This relies on the ai-proxy-advanced plugin
+	// which is only available in Kong Gateway Enterprise. AIGateways using
+	// backends report this through their "EnterpriseRequired" condition.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=16
+	Backends []CloudHostedLLMBackend `json:"backends,omitempty"`
+
+	// LoadBalancing configures how requests are spread across the backends
+	// of the LLM. It's only used when Backends are specified.
+	//
+	// +kubebuilder:validation:Optional
+	LoadBalancing *LLMLoadBalancing `json:"loadBalancing,omitempty"`
+}
+
+// SelfHostedLargeLanguageModel is the configuration for Large Language Models
+// (LLM) hosted in the cluster and served by a Kubernetes Service.
+type SelfHostedLargeLanguageModel struct {
+	// Identifier is the unique name which identifies the LLM. This will be used
+	// as part of the requests made to an AIGateway endpoint, the same way as
+	// for cloud hosted LLMs. Identifiers have to be unique across both cloud
+	// hosted and self hosted LLMs.
+	//
+	// +kubebuilder:validation:Required
+	Identifier string `json:"identifier"`
+
+	// Model is the model name of the LLM (e.g. llama2, mistral, e.t.c.).
+	//
+	// If not specified, whatever the upstream specifies as the default model
+	// will be used.
+	//
+	// +kubebuilder:validation:Optional
+	Model *string `json:"model"`
+
+	// PromptType is the type of prompt to be used for inference requests to
+	// the LLM (e.g. "chat", "completions").
+	//
+	// If not specified, "completions" will be used as the default.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=chat;completions
+	// +kubebuilder:default=completions
+	PromptType *LLMPromptType `json:"promptType"`
+
+	// DefaultPrompts is a list of prompts that should be provided to the LLM
+	// by default.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=64
+	DefaultPrompts []LLMPrompt `json:"defaultPrompts"`
+
+	// AppendedPrompts is a list of prompts that should be provided to the LLM
+	// after the prompts of any and every inference request.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=64
+	AppendedPrompts []LLMPrompt `json:"appendedPrompts,omitempty"`
+
+	// DefaultPromptParams configures the parameters which will be sent with
+	// any and every inference request.
+	//
+	// +kubebuilder:validation:Optional
+	DefaultPromptParams *LLMPromptParams `json:"defaultPromptParams"`
+
+	// Logging configures what is logged about the requests made to the LLM.
+	//
+	// If not specified, usage statistics are logged and payloads are not.
+	//
+	// +kubebuilder:validation:Optional
+	Logging *LLMLogging `json:"logging,omitempty"`
+
+	// Format is the format of the API exposed by the upstream serving the LLM.
+	//
+	// If "openai" is specified, the upstream is expected to expose an OpenAI
+	// compatible API.
+	//
+	// If "llama2" is specified, the upstream is expected to expose the raw
+	// llama2 API.
+	//
+	// If not specified, "openai" will be used as the default.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=openai;llama2
+	// +kubebuilder:default=openai
+	Format SelfHostedLLMFormat `json:"format,omitempty"`
+
+	// ServiceRef is a reference to the Kubernetes Service serving the LLM.
+	//
+	// +kubebuilder:validation:Required
+	ServiceRef SelfHostedLLMServiceRef `json:"serviceRef"`
+}
+
+// SelfHostedLLMFormat is the format of the API exposed by a self hosted LLM.
+type SelfHostedLLMFormat string
+
+const (
+	// SelfHostedLLMFormatOpenAI indicates an OpenAI compatible API.
+	SelfHostedLLMFormatOpenAI SelfHostedLLMFormat = "openai"
+
+	// SelfHostedLLMFormatLlama2 indicates the raw llama2 API.
+	SelfHostedLLMFormatLlama2 SelfHostedLLMFormat = "llama2"
+)
+
+// SelfHostedLLMServiceRef is a reference to the Kubernetes Service serving a
+// self hosted LLM. The Service has to be in the same namespace as the
+// AIGateway.
+type SelfHostedLLMServiceRef struct {
+	// Name is the name of the Service.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Port is the port of the Service the LLM is served on.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+
+	// Path is the path of the inference API on the Service.
+	//
+	// If not specified, for the "openai" format the path of the OpenAI API
+	// matching the prompt type is used ("/v1/chat/completions" or
+	// "/v1/completions"), while for the "llama2" format the root path is used.
+	//
+	// +kubebuilder:validation:Optional
+	Path *string `json:"path,omitempty"`
+}
+
+// LLMLogging is the logging policy of a Large Language Model (LLM).
+type LLMLogging struct {
+	// LogStatistics indicates whether the usage statistics of the requests
+	// made to the LLM (e.g. the number of prompt and completion tokens) are
+	// logged.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=true
+	LogStatistics *bool `json:"logStatistics,omitempty"`
+
+	// LogPayloads indicates whether the payloads of the requests made to the
+	// LLM and of its responses are logged. Payloads may contain sensitive
+	// information and therefore are not logged by default.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	LogPayloads *bool `json:"logPayloads,omitempty"`
 }
 
 // -----------------------------------------------------------------------------
@@ -0,0 +1,161 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// AIModelCatalogKind holds the kind for the AIModelCatalog.
+	AIModelCatalogKind = "AIModelCatalog"
+)
+
+// -----------------------------------------------------------------------------
+// AIModelCatalog API - Resources
+// -----------------------------------------------------------------------------
+
+// AIModelCatalog is a catalog of reusable AI model definitions (e.g. Large
+// Language Models hosted by cloud providers) along with the credentials needed
+// to access them.
+//
+// Instead of repeating the cloud provider, model name, prompt type and default
+// prompts of a model in each AIGateway, AIGateways in the same namespace can
+// reference the catalog's models by name. Any changes to the catalog are
+// rolled out to all the AIGateways referencing it.
+//
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories=kong
+type AIModelCatalog struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the desired state of the AIModelCatalog.
+	Spec AIModelCatalogSpec `json:"spec,omitempty"`
+}
+
+// AIModelCatalogList contains a list of AIModelCatalogs.
+//
+// +kubebuilder:object:root=true
+type AIModelCatalogList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items is the list of AIModelCatalogs.
+	Items []AIModelCatalog `json:"items"`
+}
+
+// -----------------------------------------------------------------------------
+// AIModelCatalog API - Specification
+// -----------------------------------------------------------------------------
+
+// AIModelCatalogSpec defines the desired state of an AIModelCatalog.
+type AIModelCatalogSpec struct {
+	// Models is the list of models in the catalog.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=64
+	// +listType=map
+	// +listMapKey=name
+	Models []AIModelCatalogEntry `json:"models"`
+
+	// CloudProviderCredentials is a reference to an object (e.g. a Kubernetes
+	// Secret) which contains the credentials needed to access the APIs of the
+	// cloud providers of the catalog's models. The keys of the Secret MUST be
+	// named according to their providers (e.g. "openai", "azure", "cohere",
+	// e.t.c.), the same way as for the AIGateway's CloudProviderCredentials.
+	//
+	// If the namespace of the reference is not specified, the namespace of
+	// the AIModelCatalog is used.
+	//
+	// +kubebuilder:validation:Required
+	CloudProviderCredentials AICloudProviderAPITokenRef `json:"cloudProviderCredentials"`
+}
+
+// AIModelCatalogEntry is a reusable definition of a Large Language Model
+// (LLM) hosted by a known and supported AI cloud provider.
+type AIModelCatalogEntry struct {
+	// Name is the unique name of the model within the catalog, which
+	// AIGateways use to reference it.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Model is the model name of the LLM (e.g. gpt-3.5-turbo, phi-2, e.t.c.).
+	//
+	// If not specified, whatever the cloud provider specifies as the default
+	// model will be used.
+	//
+	// +kubebuilder:validation:Optional
+	Model *string `json:"model,omitempty"`
+
+	// PromptType is the type of prompt to be used for inference requests to
+	// the LLM (e.g. "chat", "completions").
+	//
+	// If not specified, "completions" will be used as the default.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=chat;completions
+	// +kubebuilder:default=completions
+	PromptType *LLMPromptType `json:"promptType,omitempty"`
+
+	// DefaultPrompts is a list of prompts that should be provided to the LLM
+	// by default.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=64
+	DefaultPrompts []LLMPrompt `json:"defaultPrompts,omitempty"`
+
+	// DefaultPromptParams configures the parameters which will be sent with
+	// any and every inference request.
+	//
+	// +kubebuilder:validation:Optional
+	DefaultPromptParams *LLMPromptParams `json:"defaultPromptParams,omitempty"`
+
+	// AICloudProvider defines the cloud provider that will fulfill the LLM
+	// requests.
+	//
+	// +kubebuilder:validation:Required
+	AICloudProvider AICloudProvider `json:"aiCloudProvider"`
+}
+
+// -----------------------------------------------------------------------------
+// AIModelCatalog API - References
+// -----------------------------------------------------------------------------
+
+// AIModelCatalogModelRef is a reference from an AIGateway to a model of an
+// AIModelCatalog, which the AIGateway serves as a cloud hosted LLM.
+type AIModelCatalogModelRef struct {
+	// Identifier is the unique name which identifies the LLM in the
+	// AIGateway, the same way as for cloud hosted and self hosted LLMs.
+	// Identifiers have to be unique across all the LLMs of an AIGateway.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Identifier string `json:"identifier"`
+
+	// CatalogName is the name of the AIModelCatalog, which has to be in the
+	// same namespace as the AIGateway.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	CatalogName string `json:"catalogName"`
+
+	// ModelName is the name of the model within the AIModelCatalog.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	ModelName string `json:"modelName"`
+}
+
+// -----------------------------------------------------------------------------
+// AIModelCatalog API - Setup
+// -----------------------------------------------------------------------------
+
+func init() {
+	SchemeBuilder.Register(&AIModelCatalog{}, &AIModelCatalogList{})
+}
@@ -0,0 +1,21 @@
+package v1alpha1
+
+import "k8s.io/apimachinery/pkg/runtime/schema"
+
+// DataPlaneMetricsExtensionGVR returns current package DataPlaneMetricsExtension GVR.
+func DataPlaneMetricsExtensionGVR() schema.GroupVersionResource {
+	return schema.GroupVersionResource{
+		Group:    SchemeGroupVersion.Group,
+		Version:  SchemeGroupVersion.Version,
+		Resource: "dataplanemetricsextensions",
+	}
+}
+
+// AIModelCatalogGVR returns current package AIModelCatalog GVR.
+func AIModelCatalogGVR() schema.GroupVersionResource {
+	return schema.GroupVersionResource{
+		Group:    SchemeGroupVersion.Group,
+		Version:  SchemeGroupVersion.Version,
+		Resource: "aimodelcatalogs",
+	}
+}
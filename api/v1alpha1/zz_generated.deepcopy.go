@@ -92,6 +92,53 @@ func (in *AIGateway) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AIGatewayConsumer) DeepCopyInto(out *AIGatewayConsumer) {
+	*out = *in
+	if in.Quotas != nil {
+		in, out := &in.Quotas, &out.Quotas
+		*out = make([]AIGatewayConsumerQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AIGatewayConsumer.
+func (in *AIGatewayConsumer) DeepCopy() *AIGatewayConsumer {
+	if in == nil {
+		return nil
+	}
+	out := new(AIGatewayConsumer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AIGatewayConsumerQuota) DeepCopyInto(out *AIGatewayConsumerQuota) {
+	*out = *in
+	if in.Requests != nil {
+		in, out := &in.Requests, &out.Requests
+		*out = new(int)
+		**out = **in
+	}
+	if in.Tokens != nil {
+		in, out := &in.Tokens, &out.Tokens
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AIGatewayConsumerQuota.
+func (in *AIGatewayConsumerQuota) DeepCopy() *AIGatewayConsumerQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(AIGatewayConsumerQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AIGatewayConsumerRef) DeepCopyInto(out *AIGatewayConsumerRef) {
 	*out = *in
@@ -180,6 +227,13 @@ func (in *AIGatewaySpec) DeepCopyInto(out *AIGatewaySpec) {
 		*out = new(AICloudProviderAPITokenRef)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Consumers != nil {
+		in, out := &in.Consumers, &out.Consumers
+		*out = make([]AIGatewayConsumer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AIGatewaySpec.
@@ -221,6 +275,166 @@ func (in *AIGatewayStatus) DeepCopy() *AIGatewayStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AIModelCatalog) DeepCopyInto(out *AIModelCatalog) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AIModelCatalog.
+func (in *AIModelCatalog) DeepCopy() *AIModelCatalog {
+	if in == nil {
+		return nil
+	}
+	out := new(AIModelCatalog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AIModelCatalog) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AIModelCatalogEntry) DeepCopyInto(out *AIModelCatalogEntry) {
+	*out = *in
+	if in.Model != nil {
+		in, out := &in.Model, &out.Model
+		*out = new(string)
+		**out = **in
+	}
+	if in.PromptType != nil {
+		in, out := &in.PromptType, &out.PromptType
+		*out = new(LLMPromptType)
+		**out = **in
+	}
+	if in.DefaultPrompts != nil {
+		in, out := &in.DefaultPrompts, &out.DefaultPrompts
+		*out = make([]LLMPrompt, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DefaultPromptParams != nil {
+		in, out := &in.DefaultPromptParams, &out.DefaultPromptParams
+		*out = new(LLMPromptParams)
+		(*in).DeepCopyInto(*out)
+	}
+	out.AICloudProvider = in.AICloudProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AIModelCatalogEntry.
+func (in *AIModelCatalogEntry) DeepCopy() *AIModelCatalogEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(AIModelCatalogEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AIModelCatalogList) DeepCopyInto(out *AIModelCatalogList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AIModelCatalog, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AIModelCatalogList.
+func (in *AIModelCatalogList) DeepCopy() *AIModelCatalogList {
+	if in == nil {
+		return nil
+	}
+	out := new(AIModelCatalogList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AIModelCatalogList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AIModelCatalogModelRef) DeepCopyInto(out *AIModelCatalogModelRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AIModelCatalogModelRef.
+func (in *AIModelCatalogModelRef) DeepCopy() *AIModelCatalogModelRef {
+	if in == nil {
+		return nil
+	}
+	out := new(AIModelCatalogModelRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AIModelCatalogSpec) DeepCopyInto(out *AIModelCatalogSpec) {
+	*out = *in
+	if in.Models != nil {
+		in, out := &in.Models, &out.Models
+		*out = make([]AIModelCatalogEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.CloudProviderCredentials.DeepCopyInto(&out.CloudProviderCredentials)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AIModelCatalogSpec.
+func (in *AIModelCatalogSpec) DeepCopy() *AIModelCatalogSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AIModelCatalogSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudHostedLLMBackend) DeepCopyInto(out *CloudHostedLLMBackend) {
+	*out = *in
+	out.AICloudProvider = in.AICloudProvider
+	if in.Model != nil {
+		in, out := &in.Model, &out.Model
+		*out = new(string)
+		**out = **in
+	}
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudHostedLLMBackend.
+func (in *CloudHostedLLMBackend) DeepCopy() *CloudHostedLLMBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudHostedLLMBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudHostedLargeLanguageModel) DeepCopyInto(out *CloudHostedLargeLanguageModel) {
 	*out = *in
@@ -241,12 +455,36 @@ func (in *CloudHostedLargeLanguageModel) DeepCopyInto(out *CloudHostedLargeLangu
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.AppendedPrompts != nil {
+		in, out := &in.AppendedPrompts, &out.AppendedPrompts
+		*out = make([]LLMPrompt, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.DefaultPromptParams != nil {
 		in, out := &in.DefaultPromptParams, &out.DefaultPromptParams
 		*out = new(LLMPromptParams)
 		(*in).DeepCopyInto(*out)
 	}
 	out.AICloudProvider = in.AICloudProvider
+	if in.Logging != nil {
+		in, out := &in.Logging, &out.Logging
+		*out = new(LLMLogging)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Backends != nil {
+		in, out := &in.Backends, &out.Backends
+		*out = make([]CloudHostedLLMBackend, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LoadBalancing != nil {
+		in, out := &in.LoadBalancing, &out.LoadBalancing
+		*out = new(LLMLoadBalancing)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudHostedLargeLanguageModel.
@@ -371,6 +609,56 @@ func (in *ExtensionRef) DeepCopy() *ExtensionRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LLMLoadBalancing) DeepCopyInto(out *LLMLoadBalancing) {
+	*out = *in
+	if in.PrimaryWeight != nil {
+		in, out := &in.PrimaryWeight, &out.PrimaryWeight
+		*out = new(int)
+		**out = **in
+	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LLMLoadBalancing.
+func (in *LLMLoadBalancing) DeepCopy() *LLMLoadBalancing {
+	if in == nil {
+		return nil
+	}
+	out := new(LLMLoadBalancing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LLMLogging) DeepCopyInto(out *LLMLogging) {
+	*out = *in
+	if in.LogStatistics != nil {
+		in, out := &in.LogStatistics, &out.LogStatistics
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LogPayloads != nil {
+		in, out := &in.LogPayloads, &out.LogPayloads
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LLMLogging.
+func (in *LLMLogging) DeepCopy() *LLMLogging {
+	if in == nil {
+		return nil
+	}
+	out := new(LLMLogging)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LLMPrompt) DeepCopyInto(out *LLMPrompt) {
 	*out = *in
@@ -436,6 +724,18 @@ func (in *LargeLanguageModels) DeepCopyInto(out *LargeLanguageModels) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SelfHosted != nil {
+		in, out := &in.SelfHosted, &out.SelfHosted
+		*out = make([]SelfHostedLargeLanguageModel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Catalog != nil {
+		in, out := &in.Catalog, &out.Catalog
+		*out = make([]AIModelCatalogModelRef, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LargeLanguageModels.
@@ -483,6 +783,76 @@ func (in *NamespacedRef) DeepCopy() *NamespacedRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelfHostedLLMServiceRef) DeepCopyInto(out *SelfHostedLLMServiceRef) {
+	*out = *in
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelfHostedLLMServiceRef.
+func (in *SelfHostedLLMServiceRef) DeepCopy() *SelfHostedLLMServiceRef {
+	if in == nil {
+		return nil
+	}
+	out := new(SelfHostedLLMServiceRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelfHostedLargeLanguageModel) DeepCopyInto(out *SelfHostedLargeLanguageModel) {
+	*out = *in
+	if in.Model != nil {
+		in, out := &in.Model, &out.Model
+		*out = new(string)
+		**out = **in
+	}
+	if in.PromptType != nil {
+		in, out := &in.PromptType, &out.PromptType
+		*out = new(LLMPromptType)
+		**out = **in
+	}
+	if in.DefaultPrompts != nil {
+		in, out := &in.DefaultPrompts, &out.DefaultPrompts
+		*out = make([]LLMPrompt, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AppendedPrompts != nil {
+		in, out := &in.AppendedPrompts, &out.AppendedPrompts
+		*out = make([]LLMPrompt, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DefaultPromptParams != nil {
+		in, out := &in.DefaultPromptParams, &out.DefaultPromptParams
+		*out = new(LLMPromptParams)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Logging != nil {
+		in, out := &in.Logging, &out.Logging
+		*out = new(LLMLogging)
+		(*in).DeepCopyInto(*out)
+	}
+	in.ServiceRef.DeepCopyInto(&out.ServiceRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelfHostedLargeLanguageModel.
+func (in *SelfHostedLargeLanguageModel) DeepCopy() *SelfHostedLargeLanguageModel {
+	if in == nil {
+		return nil
+	}
+	out := new(SelfHostedLargeLanguageModel)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceSelector) DeepCopyInto(out *ServiceSelector) {
 	*out = *in
@@ -79,6 +79,13 @@ type ControlPlaneSpec struct {
 	//
 	// +optional
 	IngressClass *string `json:"ingressClass,omitempty"`
+
+	// CertificateIssuer indicates how the certificates used for mTLS between
+	// the ControlPlane and its DataPlane are issued.
+	// When omitted, the issuer configured for the operator is used.
+	//
+	// +optional
+	CertificateIssuer *CertificateIssuer `json:"certificateIssuer,omitempty"`
 }
 
 // ControlPlaneOptions indicates the specific information needed to
@@ -106,20 +113,31 @@ type ControlPlaneOptions struct {
 // includes options for managing Deployments such as the the number of replicas
 // or pod options like container image and resource requirements.
 // version, as well as Env variable overrides.
+//
+// +kubebuilder:validation:XValidation:message="Using both replicas and scaling fields is not allowed.",rule="!(has(self.scaling) && has(self.replicas))"
 type ControlPlaneDeploymentOptions struct {
 	// Replicas describes the number of desired pods.
 	// This is a pointer to distinguish between explicit zero and not specified.
-	// This only affects the DataPlane deployments for now, for more details on
-	// ControlPlane scaling please see https://github.com/Kong/gateway-operator/issues/736.
+	// When neither replicas nor scaling is set, 1 replica is deployed.
 	//
 	// +optional
-	// +kubebuilder:default=1
 	Replicas *int32 `json:"replicas,omitempty"`
 
+	// Scaling defines the scaling options for the deployment.
+	//
+	// +optional
+	Scaling *Scaling `json:"scaling,omitempty"`
+
 	// PodTemplateSpec defines PodTemplateSpec for Deployment's pods.
 	//
 	// +optional
 	PodTemplateSpec *corev1.PodTemplateSpec `json:"podTemplateSpec,omitempty"`
+
+	// PodDisruptionBudget defines the PodDisruptionBudget protecting
+	// the Deployment's pods from voluntary disruptions such as node drains.
+	//
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudget `json:"podDisruptionBudget,omitempty"`
 }
 
 // ControlPlaneStatus defines the observed state of ControlPlane
@@ -54,6 +54,13 @@ type DataPlaneList struct {
 // DataPlaneSpec defines the desired state of DataPlane
 type DataPlaneSpec struct {
 	DataPlaneOptions `json:",inline"`
+
+	// CertificateIssuer indicates how the certificates used for mTLS between
+	// the DataPlane and its ControlPlane are issued.
+	// When omitted, the issuer configured for the operator is used.
+	//
+	// +optional
+	CertificateIssuer *CertificateIssuer `json:"certificateIssuer,omitempty"`
 }
 
 // DataPlaneOptions defines the information specifically needed to
@@ -107,11 +114,25 @@ type DataPlaneServices struct {
 // DataPlaneServiceOptions contains Services related DataPlane configuration.
 type DataPlaneServiceOptions struct {
 	// Ports defines the list of ports that are exposed by the service.
-	// The ports field allows defining the name, port and targetPort of
-	// the underlying service ports, while the protocol is defaulted to TCP,
-	// as it is the only protocol currently supported.
+	// The ports field allows defining the name, port, targetPort and protocol
+	// of the underlying service ports. The protocol is defaulted to TCP.
 	Ports []DataPlaneServicePort `json:"ports,omitempty"`
 
+	// LoadBalancerIP is the IP address requested for the Service when its type
+	// is `LoadBalancer`. This feature depends on whether the underlying
+	// cloud-provider supports specifying the loadBalancerIP when a load balancer
+	// is created.
+	//
+	// +optional
+	LoadBalancerIP string `json:"loadBalancerIP,omitempty"`
+
+	// ExternalIPs is a list of IP addresses for which nodes in the cluster will
+	// also accept traffic for the Service. These IPs are not managed by
+	// Kubernetes.
+	//
+	// +optional
+	ExternalIPs []string `json:"externalIPs,omitempty"`
+
 	// ServiceOptions is the struct containing service options shared with
 	// the GatewayConfiguration.
 	ServiceOptions `json:",inline"`
@@ -140,6 +161,13 @@ type DataPlaneServicePort struct {
 	// More info: https://kubernetes.io/docs/concepts/services-networking/service/#defining-a-service
 	// +optional
 	TargetPort intstr.IntOrString `json:"targetPort,omitempty"`
+
+	// The IP protocol for this port. Supports "TCP" and "UDP".
+	// Default is TCP.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=TCP;UDP
+	Protocol corev1.Protocol `json:"protocol,omitempty"`
 }
 
 // ServiceOptions is used to includes options to customize the ingress service,
@@ -246,6 +274,18 @@ type DataPlaneRolloutStatus struct {
 	// Deployment contains the information about the preview deployment.
 	Deployment *DataPlaneRolloutStatusDeployment `json:"deployment,omitempty"`
 
+	// Canary contains the progress of the Canary rollout.
+	// It is set only if the Canary rollout strategy was configured in the spec.
+	//
+	// +optional
+	Canary *DataPlaneRolloutStatusCanary `json:"canary,omitempty"`
+
+	// Analysis contains the result of the analysis of the preview resources.
+	// It is set only if an analysis was configured in the spec.
+	//
+	// +optional
+	Analysis *DataPlaneRolloutStatusAnalysis `json:"analysis,omitempty"`
+
 	// Conditions contains the status conditions about the rollout.
 	//
 	// +listType=map
@@ -295,6 +335,75 @@ type DataPlaneRolloutStatusDeployment struct {
 	Selector string `json:"selector,omitempty"`
 }
 
+// DataPlaneRolloutStatusAnalysis is a rollout status field which contains
+// the result of the analysis of the preview resources.
+type DataPlaneRolloutStatusAnalysis struct {
+	// ObservedGeneration is the DataPlane's generation for which the analysis was run.
+	ObservedGeneration int64 `json:"observedGeneration"`
+
+	// Runs is the number of completed runs of the analysis checks.
+	//
+	// +optional
+	Runs int32 `json:"runs,omitempty"`
+
+	// Failures is the number of failed runs of the analysis checks.
+	//
+	// +optional
+	Failures int32 `json:"failures,omitempty"`
+
+	// LastRunTime is the time at which the analysis checks were last run.
+	//
+	// +optional
+	LastRunTime *metav1.Time `json:"lastRunTime,omitempty"`
+
+	// Completed indicates whether the analysis has finished, either because
+	// all its runs completed or because the failure threshold was reached.
+	Completed bool `json:"completed"`
+
+	// Passed indicates whether the analysis completed without reaching
+	// the failure threshold.
+	Passed bool `json:"passed"`
+
+	// Message describes the failed checks of the last failed run.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// DataPlaneRolloutStatusCanary is a rollout status field which contains
+// the progress of the Canary rollout.
+type DataPlaneRolloutStatusCanary struct {
+	// Step is the index of the Canary step the rollout is at.
+	// It is equal to the number of configured steps once all of them completed.
+	//
+	// +kubebuilder:validation:Minimum=0
+	Step int32 `json:"step"`
+
+	// Weight is the percentage of traffic which is configured to be handled by
+	// the preview Deployment at the current step.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Weight int32 `json:"weight"`
+
+	// EffectiveWeight is the percentage of traffic which is actually handled by
+	// the preview Deployment at the current step. The traffic is split between
+	// the live and the preview Pods proportionally to their number, hence it
+	// differs from Weight when the DataPlane doesn't run enough replicas to
+	// represent it, e.g. a single replica DataPlane splits the traffic evenly.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	EffectiveWeight int32 `json:"effectiveWeight,omitempty"`
+
+	// StepStartedAt is the time at which the preview Deployment became ready
+	// at the current step. The step's pause is counted from this time.
+	//
+	// +optional
+	StepStartedAt *metav1.Time `json:"stepStartedAt,omitempty"`
+}
+
 // RolloutStatusService is a struct which contains status information about
 // services that are exposed as part of the rollout.
 type RolloutStatusService struct {
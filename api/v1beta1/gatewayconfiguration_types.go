@@ -51,6 +51,13 @@ type GatewayConfigurationSpec struct {
 	//
 	// +optional
 	ControlPlaneOptions *ControlPlaneOptions `json:"controlPlaneOptions,omitempty"`
+
+	// CertificateIssuer indicates how the certificates used for mTLS between
+	// the ControlPlane and the DataPlane created for the Gateway are issued.
+	// When omitted, the issuer configured for the operator is used.
+	//
+	// +optional
+	CertificateIssuer *CertificateIssuer `json:"certificateIssuer,omitempty"`
 }
 
 // GatewayConfigDataPlaneOptions indicates the specific information needed to
@@ -99,6 +106,17 @@ type GatewayConfigurationStatus struct {
 	// +listMapKey=type
 	// +kubebuilder:validation:MaxItems=8
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// GatewayClasses lists the names of the GatewayClasses which reference
+	// this GatewayConfiguration in their parametersRef.
+	//
+	// +optional
+	GatewayClasses []string `json:"gatewayClasses,omitempty"`
+
+	// Gateways lists the Gateways which use this GatewayConfiguration.
+	//
+	// +optional
+	Gateways []NamespacedName `json:"gateways,omitempty"`
 }
 
 //+kubebuilder:object:root=true
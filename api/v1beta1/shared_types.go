@@ -3,6 +3,9 @@ package v1beta1
 import (
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeploymentOptions is a shared type used on objects to indicate that their
@@ -33,6 +36,43 @@ type DeploymentOptions struct {
 	//
 	// +optional
 	PodTemplateSpec *corev1.PodTemplateSpec `json:"podTemplateSpec,omitempty"`
+
+	// PodDisruptionBudget defines the PodDisruptionBudget protecting
+	// the Deployment's pods from voluntary disruptions such as node drains.
+	//
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudget `json:"podDisruptionBudget,omitempty"`
+}
+
+// PodDisruptionBudget defines the options of the PodDisruptionBudget managed
+// by the Operator for a Deployment.
+// It holds all the options from the PodDisruptionBudgetSpec besides the
+// Selector which is being controlled by the Operator.
+// When neither minAvailable nor maxUnavailable is set, at most 1 pod can be
+// unavailable at a time.
+//
+// +kubebuilder:validation:XValidation:message="Using both minAvailable and maxUnavailable fields is not allowed.",rule="!(has(self.minAvailable) && has(self.maxUnavailable))"
+type PodDisruptionBudget struct {
+	// MinAvailable is the number (or percentage) of pods which must still be
+	// available after an eviction.
+	//
+	// +optional
+	// +kubebuilder:validation:XIntOrString
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// MaxUnavailable is the number (or percentage) of pods which can be
+	// unavailable after an eviction.
+	//
+	// +optional
+	// +kubebuilder:validation:XIntOrString
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// UnhealthyPodEvictionPolicy defines the criteria for when unhealthy pods
+	// should be considered for eviction.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=IfHealthyBudget;AlwaysAllow
+	UnhealthyPodEvictionPolicy *policyv1.UnhealthyPodEvictionPolicyType `json:"unhealthyPodEvictionPolicy,omitempty"`
 }
 
 // Scaling defines the scaling options for the deployment.
@@ -84,11 +124,18 @@ type Rollout struct {
 }
 
 // RolloutStrategy holds the rollout strategy options.
+//
+// +kubebuilder:validation:XValidation:message="Using both blueGreen and canary fields is not allowed.",rule="!(has(self.blueGreen) && has(self.canary))"
 type RolloutStrategy struct {
 	// BlueGreen holds the options specific for Blue Green Deployments.
 	//
 	// +optional
 	BlueGreen *BlueGreenStrategy `json:"blueGreen,omitempty"`
+
+	// Canary holds the options specific for Canary Deployments.
+	//
+	// +optional
+	Canary *CanaryStrategy `json:"canary,omitempty"`
 }
 
 // BlueGreenStrategy defines the Blue Green deployment strategy.
@@ -102,6 +149,153 @@ type BlueGreenStrategy struct {
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default={"plan":{"deployment":"ScaleDownOnPromotionScaleUpOnRollout"}}
 	Resources RolloutResources `json:"resources,omitempty"`
+
+	// Analysis defines the checks run against the preview resources before
+	// they are promoted. When the analysis fails, the promotion is aborted
+	// and the preview resources are rolled back to the live ones until the
+	// DataPlane's spec changes.
+	//
+	// +optional
+	Analysis *RolloutAnalysis `json:"analysis,omitempty"`
+}
+
+// RolloutAnalysis defines the checks run against the preview resources before
+// they are promoted.
+//
+// The checks are run count times, interval apart. The analysis fails as soon as
+// failureThreshold runs have failed and passes once all the runs completed
+// without reaching it.
+//
+// +kubebuilder:validation:XValidation:message="failureThreshold must not be greater than count.",rule="!has(self.failureThreshold) || !has(self.count) || self.failureThreshold <= self.count"
+type RolloutAnalysis struct {
+	// Interval is the time between two consecutive runs of the checks.
+	//
+	// +optional
+	// +kubebuilder:default="10s"
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Count is the number of times the checks are run.
+	//
+	// +optional
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Count int32 `json:"count,omitempty"`
+
+	// FailureThreshold is the number of failed runs after which the analysis
+	// fails. A run fails when any of its checks fails.
+	//
+	// +optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+
+	// HTTPChecks defines the HTTP requests sent to the preview Services.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	HTTPChecks []RolloutHTTPCheck `json:"httpChecks,omitempty"`
+
+	// MetricChecks defines the checks of the Kong metrics scraped from
+	// the preview Deployment's status API (`/metrics`).
+	// The Prometheus plugin has to be enabled for Kong to expose the metrics.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	MetricChecks []RolloutMetricCheck `json:"metricChecks,omitempty"`
+}
+
+// RolloutHTTPCheck defines an HTTP request sent to a preview Service and its
+// expected outcome.
+type RolloutHTTPCheck struct {
+	// Name identifies the check.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// Service is the preview Service the request is sent to.
+	// Requests sent to the AdminAPI Service target Kong's status API
+	// (port 8100 by default) because the Admin API requires mTLS.
+	//
+	// +kubebuilder:validation:Enum=Ingress;AdminAPI
+	// +kubebuilder:default=Ingress
+	Service RolloutAnalysisService `json:"service,omitempty"`
+
+	// Port is the port the request is sent to. Defaults to the first port of
+	// the preview ingress Service for the Ingress Service and to Kong's status
+	// API port for the AdminAPI Service.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port *int32 `json:"port,omitempty"`
+
+	// Path is the path of the request.
+	//
+	// +kubebuilder:default="/"
+	// +kubebuilder:validation:Pattern=`^/`
+	Path string `json:"path,omitempty"`
+
+	// Method is the HTTP method of the request.
+	//
+	// +kubebuilder:validation:Enum=GET;HEAD;POST;PUT;PATCH;DELETE;OPTIONS
+	// +kubebuilder:default=GET
+	Method string `json:"method,omitempty"`
+
+	// Headers are the headers sent with the request.
+	//
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Body is the body sent with the request.
+	//
+	// +optional
+	Body string `json:"body,omitempty"`
+
+	// ExpectedStatusCodes are the response status codes for which the check
+	// passes. Defaults to 200.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	ExpectedStatusCodes []int32 `json:"expectedStatusCodes,omitempty"`
+
+	// Timeout is the timeout of the request. Defaults to 5s.
+	//
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// RolloutAnalysisService is the preview Service targeted by a rollout analysis check.
+type RolloutAnalysisService string
+
+const (
+	// RolloutAnalysisServiceIngress is the preview ingress Service.
+	RolloutAnalysisServiceIngress RolloutAnalysisService = "Ingress"
+
+	// RolloutAnalysisServiceAdminAPI is the preview Admin API Service.
+	RolloutAnalysisServiceAdminAPI RolloutAnalysisService = "AdminAPI"
+)
+
+// RolloutMetricCheck defines a threshold for a Kong metric exposed by
+// the preview Deployment.
+type RolloutMetricCheck struct {
+	// Name is the name of the metric, e.g. `kong_http_requests_total`.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Labels selects the series of the metric whose values are summed up
+	// and compared with the threshold. All the series are selected when unset.
+	//
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Max is the maximum value of the metric for which the check passes.
+	//
+	// +kubebuilder:validation:Pattern=`^-?[0-9]+(\.[0-9]+)?$`
+	Max string `json:"max"`
 }
 
 // Promotion is a type that contains fields that define how the operator handles
@@ -141,6 +335,70 @@ const (
 	BreakBeforePromotion PromotionStrategy = "BreakBeforePromotion"
 )
 
+// CanaryStrategy defines the Canary deployment strategy.
+//
+// The preview (canary) Deployment runs alongside the live one behind the live
+// ingress Service and traffic is shifted to it in steps. The share of traffic
+// handled by the preview Deployment is approximated by the share of the
+// DataPlane's replicas it runs, hence the precision of the weights depends on
+// the number of replicas configured for the DataPlane.
+// Once the last step completes the preview resources are promoted.
+type CanaryStrategy struct {
+	// Steps defines the steps in which traffic is shifted to the preview Deployment.
+	// Weights of the consecutive steps have to be increasing.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	Steps []CanaryStep `json:"steps"`
+
+	// Advancement indicates how the operator advances to the next step once
+	// the current step's pause elapses.
+	//
+	// +kubebuilder:validation:Enum=Automatic;Manual
+	// +kubebuilder:default=Automatic
+	// +optional
+	Advancement CanaryAdvancement `json:"advancement,omitempty"`
+}
+
+// CanaryStep defines a single step of a Canary rollout.
+type CanaryStep struct {
+	// Weight is the percentage of traffic which should be handled by
+	// the preview Deployment during this step.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Weight int32 `json:"weight"`
+
+	// Pause is the duration for which the rollout stays at this step, once
+	// the preview Deployment is ready, before it can advance to the next one.
+	//
+	// +optional
+	Pause *metav1.Duration `json:"pause,omitempty"`
+}
+
+// CanaryAdvancement is the type of the Canary steps advancement.
+//
+// Allowed values:
+//
+//   - `Automatic` makes the operator advance to the next step as soon as
+//     the current step's pause elapses.
+//   - `Manual` makes the operator wait, after the current step's pause elapses,
+//     until the user indicates that the rollout should advance to the next step.
+//     That can be done by annotating the `DataPlane` object with
+//     `"gateway-operator.konghq.com/advance-canary-step": "true"`.
+type CanaryAdvancement string
+
+const (
+	// CanaryAdvancementAutomatic indicates that the Canary rollout advances to
+	// the next step as soon as the current step's pause elapses.
+	CanaryAdvancementAutomatic CanaryAdvancement = "Automatic"
+
+	// CanaryAdvancementManual indicates that the Canary rollout advances to
+	// the next step only when the user annotates the DataPlane object with
+	// `"gateway-operator.konghq.com/advance-canary-step": "true"`.
+	CanaryAdvancementManual CanaryAdvancement = "Manual"
+)
+
 // RolloutResources is the type which contains the fields which control how the operator
 // manages the resources it manages during or after the rollout concludes.
 type RolloutResources struct {
@@ -213,6 +471,17 @@ const (
 	// DataPlanePromoteWhenReadyAnnotationTrue is the annotation value that needs to be set to the DataPlane's
 	// DataPlanePromoteWhenReadyAnnotationKey annotation to signal that the new resources should be promoted.
 	DataPlanePromoteWhenReadyAnnotationTrue = "true"
+
+	// DataPlaneAdvanceCanaryStepAnnotationKey is the annotation key which can be used
+	// to annotate a DataPlane object to signal that the Canary rollout should advance
+	// to its next step. It is used in conjunction with the Manual Canary advancement.
+	// It has to be set to `true` to take effect. Once the operator detects the annotation, it will advance
+	// to the next step and remove the annotation.
+	DataPlaneAdvanceCanaryStepAnnotationKey = "gateway-operator.konghq.com/advance-canary-step"
+
+	// DataPlaneAdvanceCanaryStepAnnotationTrue is the annotation value that needs to be set to the DataPlane's
+	// DataPlaneAdvanceCanaryStepAnnotationKey annotation to signal that the Canary rollout should advance.
+	DataPlaneAdvanceCanaryStepAnnotationTrue = "true"
 )
 
 // KonnectCertificateOptions indicates how the operator should manage the certificates that managed entities will use
@@ -230,3 +499,68 @@ type NamespacedName struct {
 	Namespace string `json:"namespace"`
 	Name      string `json:"name"`
 }
+
+// CertificateIssuer indicates how the operator issues the certificates used for
+// mTLS between ControlPlanes and DataPlanes.
+//
+// +kubebuilder:validation:XValidation:message="certificateSigningRequest must be set when type is CertificateSigningRequest",rule="self.type == 'CertificateSigningRequest' ? has(self.certificateSigningRequest) : true"
+// +kubebuilder:validation:XValidation:message="secret must be set when type is Secret",rule="self.type == 'Secret' ? has(self.secret) : true"
+type CertificateIssuer struct {
+	// Type is the type of the issuer.
+	//
+	// +kubebuilder:validation:Enum=LocalCA;CertificateSigningRequest;Secret
+	Type CertificateIssuerType `json:"type"`
+
+	// CertificateSigningRequest configures the issuer of type CertificateSigningRequest.
+	//
+	// +optional
+	CertificateSigningRequest *CertificateSigningRequestIssuer `json:"certificateSigningRequest,omitempty"`
+
+	// Secret configures the issuer of type Secret.
+	//
+	// +optional
+	Secret *SecretIssuer `json:"secret,omitempty"`
+}
+
+// CertificateIssuerType is the type of a CertificateIssuer.
+type CertificateIssuerType string
+
+const (
+	// CertificateIssuerTypeLocalCA indicates that certificates are signed by the
+	// operator using the cluster CA. This is the default.
+	CertificateIssuerTypeLocalCA CertificateIssuerType = "LocalCA"
+
+	// CertificateIssuerTypeCertificateSigningRequest indicates that certificates are
+	// requested through the certificates.k8s.io CertificateSigningRequest API and
+	// signed by an external signer.
+	CertificateIssuerTypeCertificateSigningRequest CertificateIssuerType = "CertificateSigningRequest"
+
+	// CertificateIssuerTypeSecret indicates that certificates are read from a
+	// pre-provisioned Secret, e.g. one managed by a corporate PKI or cert-manager.
+	CertificateIssuerTypeSecret CertificateIssuerType = "Secret"
+)
+
+// CertificateSigningRequestIssuer configures an issuer which requests certificates
+// through the certificates.k8s.io CertificateSigningRequest API.
+// The trust bundle distributed along with the issued certificates is read from
+// the cluster CA Secret which has to be provisioned with the CA certificates of
+// the signer.
+type CertificateSigningRequestIssuer struct {
+	// SignerName is the name of the signer which CertificateSigningRequests are
+	// addressed to. The signer has to approve and sign them.
+	//
+	// +kubebuilder:validation:MinLength=1
+	SignerName string `json:"signerName"`
+}
+
+// SecretIssuer configures an issuer which reads certificates from a
+// pre-provisioned Secret holding the tls.crt, tls.key and ca.crt keys.
+type SecretIssuer struct {
+	// Name is the name prefix of the Secrets. A Secret is read per purpose,
+	// named after the prefix, the kind of the resource which the certificate is
+	// issued for and "server" or "client", e.g. <name>-dataplane-server. The
+	// Secrets have to reside in the namespace of that resource.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
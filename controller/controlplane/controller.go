@@ -10,11 +10,15 @@ import (
 	"github.com/go-logr/logr"
 	admregv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -28,6 +32,7 @@ import (
 	"github.com/kong/gateway-operator/controller/pkg/controlplane"
 	"github.com/kong/gateway-operator/controller/pkg/log"
 	"github.com/kong/gateway-operator/controller/pkg/op"
+	"github.com/kong/gateway-operator/controller/pkg/secrets"
 	operatorerrors "github.com/kong/gateway-operator/internal/errors"
 	"github.com/kong/gateway-operator/internal/versions"
 	"github.com/kong/gateway-operator/pkg/consts"
@@ -42,6 +47,18 @@ type Reconciler struct {
 	ClusterCASecretName      string
 	ClusterCASecretNamespace string
 	DevelopmentMode          bool
+
+	// ClusterCertificateRenewBefore is the duration before the expiry of
+	// the ControlPlane's certificates at which they get reissued.
+	ClusterCertificateRenewBefore time.Duration
+	// CertificateIssuer is the issuer of the ControlPlane's certificates used
+	// unless the ControlPlane configures its own.
+	CertificateIssuer operatorv1beta1.CertificateIssuer
+	// ClusterCertificateKeyType is the type of the private keys generated for
+	// the ControlPlane's certificates.
+	ClusterCertificateKeyType secrets.KeyType
+
+	eventRecorder record.EventRecorder
 }
 
 const requeueWithoutBackoff = time.Millisecond * 200
@@ -64,6 +81,8 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return r.validatingWebhookConfigurationHasControlPlaneOwner(e.ObjectOld)
 	}
 
+	r.eventRecorder = mgr.GetEventRecorderFor("controlplane")
+
 	return ctrl.NewControllerManagedBy(mgr).
 		// watch ControlPlane objects
 		For(&operatorv1beta1.ControlPlane{}).
@@ -75,6 +94,10 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&appsv1.Deployment{}).
 		// watch for changes in Services created by the controlplane controller
 		Owns(&corev1.Service{}).
+		// watch for changes in HPAs created by the controlplane controller
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
+		// watch for changes in PodDisruptionBudgets created by the controlplane controller
+		Owns(&policyv1.PodDisruptionBudget{}).
 		// watch for changes in ValidatingWebhookConfigurations created by the controlplane controller.
 		// Since the ValidatingWebhookConfigurations are cluster-wide but controlplanes are namespaced,
 		// we need to manually detect the owner by means of the UID
@@ -109,6 +132,15 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(
 			&appsv1.Deployment{},
 			handler.EnqueueRequestsFromMapFunc(r.getControlPlanesFromDataPlaneDeployment)).
+		// watch for changes in the cluster CA Secret in order to reissue the
+		// ControlPlanes' certificates as soon as the CA is rolled over
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.getControlPlanesForClusterCA),
+			builder.WithPredicates(secrets.ClusterCASecretPredicate(types.NamespacedName{
+				Namespace: r.ClusterCASecretNamespace,
+				Name:      r.ClusterCASecretName,
+			}))).
 		Complete(r)
 }
 
@@ -354,6 +386,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 
 	log.Trace(logger, "creating mTLS certificate", cp)
 	res, adminCertificate, err := r.ensureAdminMTLSCertificateSecret(ctx, cp)
+	if errors.Is(err, secrets.ErrCertificatePending) {
+		log.Debug(logger, "waiting for mTLS certificate to be issued", cp)
+		return ctrl.Result{RequeueAfter: secrets.CertificatePendingRequeueAfter}, nil
+	}
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -374,6 +410,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 
 	log.Trace(logger, "creating admission webhook certificate", cp)
 	res, admissionWebhookCertificateSecret, err := r.ensureAdmissionWebhookCertificateSecret(ctx, cp, admissionWebhookService)
+	if errors.Is(err, secrets.ErrCertificatePending) {
+		log.Debug(logger, "waiting for admission webhook certificate to be issued", cp)
+		return ctrl.Result{RequeueAfter: secrets.CertificatePendingRequeueAfter}, nil
+	}
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -418,6 +458,25 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		}
 		return ctrl.Result{}, nil // requeue will be triggered by the creation or update of the owned object
 	}
+
+	log.Trace(logger, "ensuring HPA for ControlPlane", cp)
+	res, _, err = r.ensureHPA(ctx, logger, cp, controlplaneDeployment.Name)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if res != op.Noop {
+		return ctrl.Result{}, nil // requeue will be triggered by the creation or update of the owned object
+	}
+
+	log.Trace(logger, "ensuring PodDisruptionBudget for ControlPlane", cp)
+	res, _, err = r.ensurePodDisruptionBudget(ctx, logger, cp)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if res != op.Noop {
+		return ctrl.Result{}, nil // requeue will be triggered by the creation or update of the owned object
+	}
+
 	log.Trace(logger, "checking readiness of ControlPlane deployments", cp)
 
 	if controlplaneDeployment.Status.Replicas == 0 || controlplaneDeployment.Status.AvailableReplicas < controlplaneDeployment.Status.Replicas {
@@ -454,7 +513,13 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	}
 
 	log.Debug(logger, "reconciliation complete for ControlPlane resource", cp)
-	return ctrl.Result{}, nil
+	// Requeue in order to renew the certificates before they expire.
+	return ctrl.Result{
+		RequeueAfter: min(
+			secrets.RenewalRequeueAfter(adminCertificate, r.ClusterCertificateRenewBefore),
+			secrets.RenewalRequeueAfter(admissionWebhookCertificateSecret, r.ClusterCertificateRenewBefore),
+		),
+	}, nil
 }
 
 // validateControlPlane validates the control plane.
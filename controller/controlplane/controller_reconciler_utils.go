@@ -10,8 +10,10 @@ import (
 	"github.com/samber/lo"
 	admregv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	certificatesv1 "k8s.io/api/certificates/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -191,6 +193,7 @@ func (r *Reconciler) ensureDeployment(
 	if err != nil {
 		return op.Noop, nil, err
 	}
+	k8sresources.InheritInfrastructureMetadata(params.ControlPlane, generatedDeployment, &generatedDeployment.Spec.Template)
 
 	if count == 1 {
 		var updated bool
@@ -198,7 +201,8 @@ func (r *Reconciler) ensureDeployment(
 		oldExistingDeployment := existingDeployment.DeepCopy()
 
 		// ensure that object metadata is up to date
-		updated, existingDeployment.ObjectMeta = k8sutils.EnsureObjectMetaIsUpdated(existingDeployment.ObjectMeta, generatedDeployment.ObjectMeta)
+		updated, existingDeployment.ObjectMeta = k8sutils.EnsureObjectMetaIsUpdated(existingDeployment.ObjectMeta, generatedDeployment.ObjectMeta,
+			k8sresources.EnsureInfrastructureAnnotationsAreUpdated)
 
 		// some custom comparison rules are needed for some PodTemplateSpec sub-attributes, in particular
 		// resources and affinity.
@@ -214,6 +218,7 @@ func (r *Reconciler) ensureDeployment(
 
 		// ensure that replication strategy is up to date
 		replicas := params.ControlPlane.Spec.ControlPlaneOptions.Deployment.Replicas
+		scaling := params.ControlPlane.Spec.ControlPlaneOptions.Deployment.Scaling
 		switch {
 		case !dataplaneIsSet && (replicas == nil || *replicas != numReplicasWhenNoDataPlane):
 			// DataPlane was just unset, so we need to scale down the Deployment.
@@ -221,6 +226,16 @@ func (r *Reconciler) ensureDeployment(
 				existingDeployment.Spec.Replicas = lo.ToPtr(int32(numReplicasWhenNoDataPlane))
 				updated = true
 			}
+		case dataplaneIsSet && scaling != nil && scaling.HorizontalScaling != nil:
+			// When horizontal scaling is enabled the replicas are managed by the HPA,
+			// hence they are only enforced when they are below the minReplicas,
+			// e.g. after the Deployment was scaled down, as the HPA does not
+			// scale Deployments up from 0 replicas.
+			minReplicas := lo.FromPtrOr(scaling.HorizontalScaling.MinReplicas, 1)
+			if existingDeployment.Spec.Replicas != nil && *existingDeployment.Spec.Replicas < minReplicas {
+				existingDeployment.Spec.Replicas = lo.ToPtr(minReplicas)
+				updated = true
+			}
 		case dataplaneIsSet && (replicas != nil && *replicas != numReplicasWhenNoDataPlane):
 			// DataPlane was just set, so we need to scale up the Deployment
 			// and ensure the env variables that might have been changed in
@@ -245,6 +260,129 @@ func (r *Reconciler) ensureDeployment(
 	return op.Created, generatedDeployment, nil
 }
 
+func (r *Reconciler) ensureHPA(
+	ctx context.Context,
+	logger logr.Logger,
+	cp *operatorv1beta1.ControlPlane,
+	deploymentName string,
+) (op.CreatedUpdatedOrNoop, *autoscalingv2.HorizontalPodAutoscaler, error) {
+	hpas, err := k8sutils.ListHPAsForOwner(ctx,
+		r.Client,
+		cp.Namespace,
+		cp.UID,
+		k8sresources.GetManagedLabelForOwner(cp),
+	)
+	if err != nil {
+		return op.Noop, nil, fmt.Errorf("failed listing HPAs for ControlPlane %s/%s: %w", cp.Namespace, cp.Name, err)
+	}
+
+	if scaling := cp.Spec.Deployment.Scaling; scaling == nil || scaling.HorizontalScaling == nil {
+		if err := k8sreduce.ReduceHPAs(ctx, r.Client, hpas, k8sreduce.FilterNone); err != nil {
+			return op.Noop, nil, fmt.Errorf("failed reducing HPAs for ControlPlane %s/%s: %w", cp.Namespace, cp.Name, err)
+		}
+		return op.Noop, nil, nil
+	}
+
+	if len(hpas) > 1 {
+		if err := k8sreduce.ReduceHPAs(ctx, r.Client, hpas, k8sreduce.FilterHPAs); err != nil {
+			return op.Noop, nil, fmt.Errorf("failed reducing HPAs for ControlPlane %s/%s: %w", cp.Namespace, cp.Name, err)
+		}
+		return op.Noop, nil, nil
+	}
+
+	generatedHPA, err := k8sresources.GenerateHPAForControlPlane(cp, deploymentName)
+	if err != nil {
+		return op.Noop, nil, err
+	}
+	k8sresources.InheritInfrastructureMetadata(cp, generatedHPA)
+
+	if len(hpas) == 1 {
+		var updated bool
+		existingHPA := &hpas[0]
+		oldExistingHPA := existingHPA.DeepCopy()
+
+		// ensure that object metadata is up to date
+		updated, existingHPA.ObjectMeta = k8sutils.EnsureObjectMetaIsUpdated(existingHPA.ObjectMeta, generatedHPA.ObjectMeta,
+			k8sresources.EnsureInfrastructureAnnotationsAreUpdated)
+
+		// ensure that the scaling options are up to date
+		if !cmp.Equal(existingHPA.Spec, generatedHPA.Spec) {
+			existingHPA.Spec = generatedHPA.Spec
+			updated = true
+		}
+
+		return patch.ApplyPatchIfNonEmpty(ctx, r.Client, logger, existingHPA, oldExistingHPA, cp, updated)
+	}
+
+	if err := r.Client.Create(ctx, generatedHPA); err != nil {
+		return op.Noop, nil, fmt.Errorf("failed creating HPA for ControlPlane %s: %w", cp.Name, err)
+	}
+
+	log.Debug(logger, "HPA for ControlPlane created", cp, "hpa", generatedHPA.Name)
+	return op.Created, generatedHPA, nil
+}
+
+func (r *Reconciler) ensurePodDisruptionBudget(
+	ctx context.Context,
+	logger logr.Logger,
+	cp *operatorv1beta1.ControlPlane,
+) (op.CreatedUpdatedOrNoop, *policyv1.PodDisruptionBudget, error) {
+	pdbs, err := k8sutils.ListPodDisruptionBudgetsForOwner(ctx,
+		r.Client,
+		cp.Namespace,
+		cp.UID,
+		k8sresources.GetManagedLabelForOwner(cp),
+	)
+	if err != nil {
+		return op.Noop, nil, fmt.Errorf("failed listing PodDisruptionBudgets for ControlPlane %s/%s: %w", cp.Namespace, cp.Name, err)
+	}
+
+	if cp.Spec.Deployment.PodDisruptionBudget == nil {
+		if err := k8sreduce.ReducePodDisruptionBudgets(ctx, r.Client, pdbs, k8sreduce.FilterNone); err != nil {
+			return op.Noop, nil, fmt.Errorf("failed reducing PodDisruptionBudgets for ControlPlane %s/%s: %w", cp.Namespace, cp.Name, err)
+		}
+		return op.Noop, nil, nil
+	}
+
+	if len(pdbs) > 1 {
+		if err := k8sreduce.ReducePodDisruptionBudgets(ctx, r.Client, pdbs, k8sreduce.FilterPodDisruptionBudgets); err != nil {
+			return op.Noop, nil, fmt.Errorf("failed reducing PodDisruptionBudgets for ControlPlane %s/%s: %w", cp.Namespace, cp.Name, err)
+		}
+		return op.Noop, nil, nil
+	}
+
+	generatedPDB, err := k8sresources.GeneratePodDisruptionBudgetForControlPlane(cp)
+	if err != nil {
+		return op.Noop, nil, err
+	}
+	k8sresources.InheritInfrastructureMetadata(cp, generatedPDB)
+
+	if len(pdbs) == 1 {
+		var updated bool
+		existingPDB := &pdbs[0]
+		oldExistingPDB := existingPDB.DeepCopy()
+
+		// ensure that object metadata is up to date
+		updated, existingPDB.ObjectMeta = k8sutils.EnsureObjectMetaIsUpdated(existingPDB.ObjectMeta, generatedPDB.ObjectMeta,
+			k8sresources.EnsureInfrastructureAnnotationsAreUpdated)
+
+		// ensure that the disruption budget is up to date
+		if !cmp.Equal(existingPDB.Spec, generatedPDB.Spec) {
+			existingPDB.Spec = generatedPDB.Spec
+			updated = true
+		}
+
+		return patch.ApplyPatchIfNonEmpty(ctx, r.Client, logger, existingPDB, oldExistingPDB, cp, updated)
+	}
+
+	if err := r.Client.Create(ctx, generatedPDB); err != nil {
+		return op.Noop, nil, fmt.Errorf("failed creating PodDisruptionBudget for ControlPlane %s: %w", cp.Name, err)
+	}
+
+	log.Debug(logger, "PodDisruptionBudget for ControlPlane created", cp, "pdb", generatedPDB.Name)
+	return op.Created, generatedPDB, nil
+}
+
 func (r *Reconciler) ensureServiceAccount(
 	ctx context.Context,
 	controlplane *operatorv1beta1.ControlPlane,
@@ -272,11 +410,13 @@ func (r *Reconciler) ensureServiceAccount(
 
 	generatedServiceAccount := k8sresources.GenerateNewServiceAccountForControlPlane(controlplane.Namespace, controlplane.Name)
 	k8sutils.SetOwnerForObject(generatedServiceAccount, controlplane)
+	k8sresources.InheritInfrastructureMetadata(controlplane, generatedServiceAccount)
 
 	if count == 1 {
 		var updated bool
 		existingServiceAccount := &serviceAccounts[0]
-		updated, existingServiceAccount.ObjectMeta = k8sutils.EnsureObjectMetaIsUpdated(existingServiceAccount.ObjectMeta, generatedServiceAccount.ObjectMeta)
+		updated, existingServiceAccount.ObjectMeta = k8sutils.EnsureObjectMetaIsUpdated(existingServiceAccount.ObjectMeta, generatedServiceAccount.ObjectMeta,
+			k8sresources.EnsureInfrastructureAnnotationsAreUpdated)
 		if updated {
 			if err := r.Client.Update(ctx, existingServiceAccount); err != nil {
 				return false, existingServiceAccount, fmt.Errorf("failed updating ControlPlane's ServiceAccount %s: %w", existingServiceAccount.Name, err)
@@ -433,18 +573,23 @@ func (r *Reconciler) ensureAdminMTLSCertificateSecret(
 	matchingLabels := client.MatchingLabels{
 		consts.SecretUsedByServiceLabel: consts.ControlPlaneServiceKindAdmin,
 	}
+	issuer, err := r.certificateIssuer(controlplane)
+	if err != nil {
+		return op.Noop, nil, err
+	}
 	// this subject is arbitrary. data planes only care that client certificates are signed by the trusted CA, and will
 	// accept a certificate with any subject
 	return secrets.EnsureCertificate(ctx,
 		controlplane,
 		fmt.Sprintf("%s.%s", controlplane.Name, controlplane.Namespace),
-		k8stypes.NamespacedName{
-			Namespace: r.ClusterCASecretNamespace,
-			Name:      r.ClusterCASecretName,
-		},
+		r.clusterCASecretNN(),
 		usages,
 		r.Client,
 		matchingLabels,
+		secrets.WithRenewBefore(r.ClusterCertificateRenewBefore),
+		secrets.WithEventRecorder(r.eventRecorder),
+		secrets.WithIssuer(issuer),
+		secrets.WithKeyType(r.ClusterCertificateKeyType),
 	)
 }
 
@@ -467,19 +612,38 @@ func (r *Reconciler) ensureAdmissionWebhookCertificateSecret(
 	matchingLabels := client.MatchingLabels{
 		consts.SecretUsedByServiceLabel: consts.ControlPlaneServiceKindWebhook,
 	}
+	issuer, err := r.certificateIssuer(cp)
+	if err != nil {
+		return op.Noop, nil, err
+	}
 	return secrets.EnsureCertificate(ctx,
 		cp,
 		fmt.Sprintf("%s.%s.svc", admissionWebhookService.Name, admissionWebhookService.Namespace),
-		k8stypes.NamespacedName{
-			Namespace: r.ClusterCASecretNamespace,
-			Name:      r.ClusterCASecretName,
-		},
+		r.clusterCASecretNN(),
 		usages,
 		r.Client,
 		matchingLabels,
+		secrets.WithRenewBefore(r.ClusterCertificateRenewBefore),
+		secrets.WithEventRecorder(r.eventRecorder),
+		secrets.WithIssuer(issuer),
+		secrets.WithKeyType(r.ClusterCertificateKeyType),
 	)
 }
 
+// clusterCASecretNN returns the NamespacedName of the cluster CA Secret.
+func (r *Reconciler) clusterCASecretNN() k8stypes.NamespacedName {
+	return k8stypes.NamespacedName{
+		Namespace: r.ClusterCASecretNamespace,
+		Name:      r.ClusterCASecretName,
+	}
+}
+
+// certificateIssuer returns the Issuer of the ControlPlane's certificates: the one
+// configured in its spec or the default one configured for the Reconciler.
+func (r *Reconciler) certificateIssuer(cp *operatorv1beta1.ControlPlane) (secrets.Issuer, error) {
+	return secrets.NewIssuer(lo.FromPtrOr(cp.Spec.CertificateIssuer, r.CertificateIssuer), r.clusterCASecretNN())
+}
+
 // ensureOwnedClusterRolesDeleted removes all the owned ClusterRoles of the controlplane.
 // it is called on cleanup of owned cluster resources on controlplane deletion.
 // returns nil if all of owned ClusterRoles successfully deleted (ok if no owned CRs or NotFound on deleting CRs).
@@ -604,11 +768,13 @@ func (r *Reconciler) ensureAdmissionWebhookService(
 	if err != nil {
 		return op.Noop, nil, err
 	}
+	k8sresources.InheritInfrastructureMetadata(controlPlane, generatedService)
 
 	if count == 1 {
 		var updated bool
 		existingService := &services[0]
-		updated, existingService.ObjectMeta = k8sutils.EnsureObjectMetaIsUpdated(existingService.ObjectMeta, generatedService.ObjectMeta)
+		updated, existingService.ObjectMeta = k8sutils.EnsureObjectMetaIsUpdated(existingService.ObjectMeta, generatedService.ObjectMeta,
+			k8sresources.EnsureInfrastructureAnnotationsAreUpdated)
 
 		if !cmp.Equal(existingService.Spec.Selector, generatedService.Spec.Selector) {
 			existingService.Spec.Selector = generatedService.Spec.Selector
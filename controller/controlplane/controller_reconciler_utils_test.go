@@ -4,11 +4,16 @@ import (
 	"context"
 	"testing"
 
+	"github.com/go-logr/logr"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 	admregv1 "k8s.io/api/admissionregistration/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes/scheme"
 	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -166,3 +171,226 @@ func Test_ensureValidatingWebhookConfiguration(t *testing.T) {
 		})
 	}
 }
+
+func Test_ensureHPA(t *testing.T) {
+	const deploymentName = "controlplane-cp-abcde"
+
+	cp := &operatorv1beta1.ControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cp",
+			Namespace: "default",
+			UID:       "cp-uid",
+		},
+		Spec: operatorv1beta1.ControlPlaneSpec{
+			ControlPlaneOptions: operatorv1beta1.ControlPlaneOptions{
+				Deployment: operatorv1beta1.ControlPlaneDeploymentOptions{
+					Scaling: &operatorv1beta1.Scaling{
+						HorizontalScaling: &operatorv1beta1.HorizontalScaling{
+							MinReplicas: lo.ToPtr(int32(2)),
+							MaxReplicas: 5,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	fakeClient := fakectrlruntimeclient.
+		NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(cp).
+		Build()
+	r := &Reconciler{
+		Client: fakeClient,
+	}
+	listHPAs := func(t *testing.T) []autoscalingv2.HorizontalPodAutoscaler {
+		var hpas autoscalingv2.HorizontalPodAutoscalerList
+		require.NoError(t, fakeClient.List(ctx, &hpas))
+		return hpas.Items
+	}
+
+	t.Log("creating HPA")
+	res, _, err := r.ensureHPA(ctx, logr.Discard(), cp, deploymentName)
+	require.NoError(t, err)
+	require.Equal(t, op.Created, res)
+	hpas := listHPAs(t)
+	require.Len(t, hpas, 1)
+	require.Equal(t, deploymentName, hpas[0].Spec.ScaleTargetRef.Name)
+	require.Equal(t, lo.ToPtr(int32(2)), hpas[0].Spec.MinReplicas)
+	require.Equal(t, int32(5), hpas[0].Spec.MaxReplicas)
+
+	t.Log("running ensureHPA again without changes")
+	res, _, err = r.ensureHPA(ctx, logr.Discard(), cp, deploymentName)
+	require.NoError(t, err)
+	require.Equal(t, op.Noop, res)
+
+	t.Log("updating scaling options")
+	cp.Spec.Deployment.Scaling.HorizontalScaling.MaxReplicas = 10
+	res, _, err = r.ensureHPA(ctx, logr.Discard(), cp, deploymentName)
+	require.NoError(t, err)
+	require.Equal(t, op.Updated, res)
+	hpas = listHPAs(t)
+	require.Len(t, hpas, 1)
+	require.Equal(t, int32(10), hpas[0].Spec.MaxReplicas)
+
+	t.Log("disabling horizontal scaling")
+	cp.Spec.Deployment.Scaling = nil
+	res, _, err = r.ensureHPA(ctx, logr.Discard(), cp, deploymentName)
+	require.NoError(t, err)
+	require.Equal(t, op.Noop, res)
+	require.Empty(t, listHPAs(t))
+}
+
+func Test_ensurePodDisruptionBudget(t *testing.T) {
+	cp := &operatorv1beta1.ControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kong",
+			Namespace: "default",
+			UID:       "cp-uid",
+		},
+		Spec: operatorv1beta1.ControlPlaneSpec{
+			ControlPlaneOptions: operatorv1beta1.ControlPlaneOptions{
+				Deployment: operatorv1beta1.ControlPlaneDeploymentOptions{
+					PodDisruptionBudget: &operatorv1beta1.PodDisruptionBudget{
+						MinAvailable: lo.ToPtr(intstr.FromInt32(1)),
+					},
+				},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	fakeClient := fakectrlruntimeclient.
+		NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(cp).
+		Build()
+	r := &Reconciler{
+		Client: fakeClient,
+	}
+	listPDBs := func(t *testing.T) []policyv1.PodDisruptionBudget {
+		var pdbs policyv1.PodDisruptionBudgetList
+		require.NoError(t, fakeClient.List(ctx, &pdbs))
+		return pdbs.Items
+	}
+
+	t.Log("creating PodDisruptionBudget")
+	res, _, err := r.ensurePodDisruptionBudget(ctx, logr.Discard(), cp)
+	require.NoError(t, err)
+	require.Equal(t, op.Created, res)
+	pdbs := listPDBs(t)
+	require.Len(t, pdbs, 1)
+	require.Equal(t, lo.ToPtr(intstr.FromInt32(1)), pdbs[0].Spec.MinAvailable)
+	require.Nil(t, pdbs[0].Spec.MaxUnavailable)
+
+	t.Log("verifying that the PodDisruptionBudget selects the ControlPlane's Pods only")
+	selector, err := metav1.LabelSelectorAsSelector(pdbs[0].Spec.Selector)
+	require.NoError(t, err)
+	cpDeployment, err := resources.GenerateNewDeploymentForControlPlane(resources.GenerateNewDeploymentForControlPlaneParams{
+		ControlPlane:      cp,
+		ControlPlaneImage: consts.DefaultControlPlaneImage,
+	})
+	require.NoError(t, err)
+	require.True(t, selector.Matches(labels.Set(cpDeployment.Spec.Template.Labels)))
+	dpDeployment, err := resources.GenerateNewDeploymentForDataPlane(&operatorv1beta1.DataPlane{
+		ObjectMeta: metav1.ObjectMeta{Name: cp.Name, Namespace: cp.Namespace},
+	}, consts.DefaultDataPlaneImage)
+	require.NoError(t, err)
+	require.False(t, selector.Matches(labels.Set(dpDeployment.Spec.Template.Labels)))
+
+	t.Log("running ensurePodDisruptionBudget again without changes")
+	res, _, err = r.ensurePodDisruptionBudget(ctx, logr.Discard(), cp)
+	require.NoError(t, err)
+	require.Equal(t, op.Noop, res)
+
+	t.Log("updating the disruption budget")
+	cp.Spec.Deployment.PodDisruptionBudget = &operatorv1beta1.PodDisruptionBudget{
+		MaxUnavailable: lo.ToPtr(intstr.FromString("50%")),
+	}
+	res, _, err = r.ensurePodDisruptionBudget(ctx, logr.Discard(), cp)
+	require.NoError(t, err)
+	require.Equal(t, op.Updated, res)
+	pdbs = listPDBs(t)
+	require.Len(t, pdbs, 1)
+	require.Nil(t, pdbs[0].Spec.MinAvailable)
+	require.Equal(t, lo.ToPtr(intstr.FromString("50%")), pdbs[0].Spec.MaxUnavailable)
+
+	t.Log("removing the disruption budget")
+	cp.Spec.Deployment.PodDisruptionBudget = nil
+	res, _, err = r.ensurePodDisruptionBudget(ctx, logr.Discard(), cp)
+	require.NoError(t, err)
+	require.Equal(t, op.Noop, res)
+	require.Empty(t, listPDBs(t))
+}
+
+func Test_ensureDeploymentReplicas(t *testing.T) {
+	cp := &operatorv1beta1.ControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cp",
+			Namespace: "default",
+			UID:       "cp-uid",
+		},
+		Spec: operatorv1beta1.ControlPlaneSpec{
+			ControlPlaneOptions: operatorv1beta1.ControlPlaneOptions{
+				DataPlane: lo.ToPtr("dp"),
+				Deployment: operatorv1beta1.ControlPlaneDeploymentOptions{
+					PodTemplateSpec: &corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  consts.ControlPlaneControllerContainerName,
+									Image: consts.DefaultControlPlaneImage,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	fakeClient := fakectrlruntimeclient.
+		NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(cp).
+		Build()
+	r := &Reconciler{
+		Client:          fakeClient,
+		DevelopmentMode: true,
+	}
+	params := ensureDeploymentParams{ControlPlane: cp}
+
+	t.Log("creating Deployment for a ControlPlane without replicas")
+	res, deployment, err := r.ensureDeployment(ctx, logr.Discard(), params)
+	require.NoError(t, err)
+	require.Equal(t, op.Created, res)
+	require.Equal(t, lo.ToPtr(int32(1)), deployment.Spec.Replicas)
+
+	t.Log("running ensureDeployment again without changes")
+	res, deployment, err = r.ensureDeployment(ctx, logr.Discard(), params)
+	require.NoError(t, err)
+	require.Equal(t, op.Noop, res)
+	require.Equal(t, lo.ToPtr(int32(1)), deployment.Spec.Replicas)
+
+	t.Log("enabling horizontal scaling with replicas below minReplicas")
+	cp.Spec.Deployment.Scaling = &operatorv1beta1.Scaling{
+		HorizontalScaling: &operatorv1beta1.HorizontalScaling{
+			MinReplicas: lo.ToPtr(int32(2)),
+			MaxReplicas: 5,
+		},
+	}
+	res, deployment, err = r.ensureDeployment(ctx, logr.Discard(), params)
+	require.NoError(t, err)
+	require.Equal(t, op.Updated, res)
+	require.Equal(t, lo.ToPtr(int32(2)), deployment.Spec.Replicas)
+
+	t.Log("replicas scaled up by the HPA are kept")
+	deployment.Spec.Replicas = lo.ToPtr(int32(4))
+	require.NoError(t, fakeClient.Update(ctx, deployment))
+	res, deployment, err = r.ensureDeployment(ctx, logr.Discard(), params)
+	require.NoError(t, err)
+	require.Equal(t, op.Noop, res)
+	require.Equal(t, lo.ToPtr(int32(4)), deployment.Spec.Replicas)
+}
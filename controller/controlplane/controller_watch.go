@@ -159,6 +159,24 @@ func (r *Reconciler) getControlPlaneRequestFromRefUID(ctx context.Context, obj c
 	return
 }
 
+func (r *Reconciler) getControlPlanesForClusterCA(ctx context.Context, _ client.Object) (recs []reconcile.Request) {
+	controlplanes := &operatorv1beta1.ControlPlaneList{}
+	if err := r.Client.List(ctx, controlplanes); err != nil {
+		log.FromContext(ctx).Error(err, "could not list controlplanes in map func")
+		return
+	}
+
+	for _, controlplane := range controlplanes.Items {
+		recs = append(recs, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: controlplane.Namespace,
+				Name:      controlplane.Name,
+			},
+		})
+	}
+	return
+}
+
 func (r *Reconciler) getControlPlanesFromDataPlaneDeployment(ctx context.Context, obj client.Object) (recs []reconcile.Request) {
 	deployment, ok := obj.(*appsv1.Deployment)
 	if !ok {
@@ -0,0 +1,125 @@
+package controlplane_extensions
+
+import (
+	"context"
+	"fmt"
+
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	operatorv1alpha1 "github.com/kong/gateway-operator/api/v1alpha1"
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/controller/pkg/log"
+	"github.com/kong/gateway-operator/pkg/consts"
+)
+
+// Reconciler reconciles the extensions referenced by ControlPlane objects
+// in their spec.extensions.
+//
+// Currently supported extensions:
+//   - DataPlaneMetricsExtension: a Prometheus KongPlugin is created for the extension
+//     and it's attached to the Services selected by the extension.
+type Reconciler struct {
+	client.Client
+	DevelopmentMode bool
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("controlplane_extensions").
+		// watch ControlPlane objects
+		For(&operatorv1beta1.ControlPlane{},
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		// watch for changes in DataPlaneMetricsExtensions which are referenced
+		// by ControlPlanes or which have a ControlPlane set in their status.
+		Watches(
+			&operatorv1alpha1.DataPlaneMetricsExtension{},
+			handler.EnqueueRequestsFromMapFunc(r.listControlPlanesForDataPlaneMetricsExtension),
+		).
+		// watch for changes in KongPlugins managed by this controller.
+		// Since the KongPlugins can live in a different namespace than the
+		// ControlPlane, the owner is determined by means of the managed-by labels.
+		Watches(
+			&configurationv1.KongPlugin{},
+			handler.EnqueueRequestsFromMapFunc(r.getControlPlaneForKongPlugin),
+			builder.WithPredicates(predicate.NewPredicateFuncs(kongPluginIsManagedByExtension)),
+		).
+		// watch for changes in Services so that annotations removed by users
+		// or Services created after the extension get the plugins attached.
+		Watches(
+			&corev1.Service{},
+			handler.EnqueueRequestsFromMapFunc(r.listControlPlanesForService),
+		).
+		Complete(r)
+}
+
+// Reconcile moves the current state of an object to the intended state.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.GetLogger(ctx, "controlplane_extensions", r.DevelopmentMode)
+
+	log.Trace(logger, "reconciling ControlPlane extensions", req)
+	cp := new(operatorv1beta1.ControlPlane)
+	if err := r.Client.Get(ctx, req.NamespacedName, cp); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		// The ControlPlane is gone: nothing is desired anymore so the cleanup
+		// below will remove everything that was created on its behalf.
+		cp = nil
+	}
+
+	desired := map[types.NamespacedName]*operatorv1alpha1.DataPlaneMetricsExtension{}
+	if cp != nil && cp.DeletionTimestamp.IsZero() {
+		var err error
+		desired, err = r.getDataPlaneMetricsExtensionsForControlPlane(ctx, logger, cp)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	for _, ext := range desired {
+		log.Trace(logger, "ensuring Prometheus KongPlugin for DataPlaneMetricsExtension", ext)
+		plugin, err := r.ensurePrometheusPluginForExtension(ctx, req.NamespacedName, ext)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed ensuring KongPlugin for DataPlaneMetricsExtension %s/%s: %w", ext.Namespace, ext.Name, err)
+		}
+
+		log.Trace(logger, "ensuring Services have the Prometheus KongPlugin attached", ext)
+		if err := r.ensureServicesAnnotatedWithPlugin(ctx, ext, plugin.Name); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed ensuring Services for DataPlaneMetricsExtension %s/%s: %w", ext.Namespace, ext.Name, err)
+		}
+
+		log.Trace(logger, "ensuring DataPlaneMetricsExtension status references the ControlPlane", ext)
+		if err := r.ensureExtensionStatusControlPlaneRef(ctx, ext, &req.NamespacedName); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed updating status of DataPlaneMetricsExtension %s/%s: %w", ext.Namespace, ext.Name, err)
+		}
+	}
+
+	log.Trace(logger, "cleaning up KongPlugins for DataPlaneMetricsExtensions no longer referenced by the ControlPlane", req)
+	if err := r.cleanupStalePlugins(ctx, req.NamespacedName, desired); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed cleaning up stale KongPlugins: %w", err)
+	}
+
+	log.Trace(logger, "cleaning up status of DataPlaneMetricsExtensions no longer referenced by the ControlPlane", req)
+	if err := r.cleanupStaleExtensionStatuses(ctx, req.NamespacedName, desired); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed cleaning up stale DataPlaneMetricsExtensions statuses: %w", err)
+	}
+
+	log.Debug(logger, "reconciliation complete for ControlPlane extensions", req)
+	return ctrl.Result{}, nil
+}
+
+// extensionPluginName returns the name of the Prometheus KongPlugin managed
+// for the provided DataPlaneMetricsExtension.
+func extensionPluginName(ext *operatorv1alpha1.DataPlaneMetricsExtension) string {
+	return ext.Name + consts.DataPlaneMetricsExtensionPluginNameSuffix
+}
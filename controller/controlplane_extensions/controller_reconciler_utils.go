@@ -0,0 +1,421 @@
+package controlplane_extensions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1"
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/kong/gateway-operator/api/v1alpha1"
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/controller/pkg/log"
+	"github.com/kong/gateway-operator/pkg/consts"
+)
+
+// prometheusPluginName is the name of the Kong Prometheus plugin.
+const prometheusPluginName = "prometheus"
+
+// prometheusPluginConfig is the configuration of the Kong Prometheus plugin.
+// Reference: https://docs.konghq.com/hub/kong-inc/prometheus/configuration/
+type prometheusPluginConfig struct {
+	LatencyMetrics        bool `json:"latency_metrics"`
+	BandwidthMetrics      bool `json:"bandwidth_metrics"`
+	UpstreamHealthMetrics bool `json:"upstream_health_metrics"`
+	StatusCodeMetrics     bool `json:"status_code_metrics"`
+}
+
+// -----------------------------------------------------------------------------
+// Reconciler - Extensions resolution
+// -----------------------------------------------------------------------------
+
+// getDataPlaneMetricsExtensionsForControlPlane resolves the DataPlaneMetricsExtensions
+// referenced in the provided ControlPlane's spec.extensions.
+// Extensions which do not exist or which are already associated with a different
+// ControlPlane (that still references them) are skipped.
+func (r *Reconciler) getDataPlaneMetricsExtensionsForControlPlane(
+	ctx context.Context,
+	logger logr.Logger,
+	cp *operatorv1beta1.ControlPlane,
+) (map[types.NamespacedName]*operatorv1alpha1.DataPlaneMetricsExtension, error) {
+	ret := map[types.NamespacedName]*operatorv1alpha1.DataPlaneMetricsExtension{}
+	for _, extRef := range cp.Spec.Extensions {
+		if !isDataPlaneMetricsExtensionRef(extRef) {
+			continue
+		}
+
+		nn := types.NamespacedName{
+			Namespace: cp.Namespace,
+			Name:      extRef.Name,
+		}
+		if extRef.Namespace != nil && *extRef.Namespace != "" {
+			nn.Namespace = *extRef.Namespace
+		}
+
+		ext := new(operatorv1alpha1.DataPlaneMetricsExtension)
+		if err := r.Client.Get(ctx, nn, ext); err != nil {
+			if k8serrors.IsNotFound(err) {
+				log.Debug(logger, "referenced DataPlaneMetricsExtension not found", cp, "extension", nn)
+				continue
+			}
+			return nil, fmt.Errorf("failed getting DataPlaneMetricsExtension %s: %w", nn, err)
+		}
+
+		claimed, err := r.isClaimedByAnotherControlPlane(ctx, ext, client.ObjectKeyFromObject(cp))
+		if err != nil {
+			return nil, err
+		}
+		if claimed {
+			log.Info(logger, "DataPlaneMetricsExtension is already associated with another ControlPlane, skipping", cp,
+				"extension", nn,
+				"associated_controlplane", fmt.Sprintf("%s/%s", lo.FromPtr(ext.Status.ControlPlaneRef.Namespace), ext.Status.ControlPlaneRef.Name),
+			)
+			continue
+		}
+
+		ret[nn] = ext
+	}
+	return ret, nil
+}
+
+// isClaimedByAnotherControlPlane returns true when the provided extension has
+// a ControlPlane, different from the provided one, set in its status and that
+// ControlPlane still references the extension.
+func (r *Reconciler) isClaimedByAnotherControlPlane(
+	ctx context.Context,
+	ext *operatorv1alpha1.DataPlaneMetricsExtension,
+	cpNN types.NamespacedName,
+) (bool, error) {
+	ref := ext.Status.ControlPlaneRef
+	if ref == nil {
+		return false, nil
+	}
+	otherNN := types.NamespacedName{
+		Namespace: lo.FromPtr(ref.Namespace),
+		Name:      ref.Name,
+	}
+	if otherNN.Namespace == "" {
+		otherNN.Namespace = ext.Namespace
+	}
+	if otherNN == cpNN {
+		return false, nil
+	}
+
+	other := new(operatorv1beta1.ControlPlane)
+	if err := r.Client.Get(ctx, otherNN, other); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed getting ControlPlane %s: %w", otherNN, err)
+	}
+	if !other.DeletionTimestamp.IsZero() {
+		return false, nil
+	}
+
+	return controlPlaneReferencesExtension(other, client.ObjectKeyFromObject(ext)), nil
+}
+
+// controlPlaneReferencesExtension returns true when the provided ControlPlane
+// references the DataPlaneMetricsExtension with the provided namespaced name.
+func controlPlaneReferencesExtension(cp *operatorv1beta1.ControlPlane, extNN types.NamespacedName) bool {
+	return lo.ContainsBy(cp.Spec.Extensions, func(extRef operatorv1alpha1.ExtensionRef) bool {
+		if !isDataPlaneMetricsExtensionRef(extRef) {
+			return false
+		}
+		namespace := cp.Namespace
+		if extRef.Namespace != nil && *extRef.Namespace != "" {
+			namespace = *extRef.Namespace
+		}
+		return extRef.Name == extNN.Name && namespace == extNN.Namespace
+	})
+}
+
+func isDataPlaneMetricsExtensionRef(extRef operatorv1alpha1.ExtensionRef) bool {
+	return extRef.Group == operatorv1alpha1.SchemeGroupVersion.Group &&
+		extRef.Kind == operatorv1alpha1.DataPlaneMetricsExtensionKind
+}
+
+// -----------------------------------------------------------------------------
+// Reconciler - KongPlugins
+// -----------------------------------------------------------------------------
+
+// generatePrometheusPluginForExtension generates the Prometheus KongPlugin
+// for the provided DataPlaneMetricsExtension.
+func generatePrometheusPluginForExtension(
+	cpNN types.NamespacedName,
+	ext *operatorv1alpha1.DataPlaneMetricsExtension,
+) (*configurationv1.KongPlugin, error) {
+	rawConfig, err := json.Marshal(prometheusPluginConfig{
+		LatencyMetrics:        ext.Spec.Config.Latency,
+		BandwidthMetrics:      ext.Spec.Config.Bandwidth,
+		UpstreamHealthMetrics: ext.Spec.Config.UpstreamHealth,
+		StatusCodeMetrics:     ext.Spec.Config.StatusCode,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed marshaling Prometheus plugin configuration: %w", err)
+	}
+
+	return &configurationv1.KongPlugin{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ext.Namespace,
+			Name:      extensionPluginName(ext),
+			Labels: map[string]string{
+				consts.GatewayOperatorManagedByLabel:          consts.ControlPlaneManagedLabelValue,
+				consts.GatewayOperatorManagedByNameLabel:      cpNN.Name,
+				consts.GatewayOperatorManagedByNamespaceLabel: cpNN.Namespace,
+				consts.DataPlaneMetricsExtensionLabel:         ext.Name,
+			},
+		},
+		PluginName: prometheusPluginName,
+		Config: apiextensionsv1.JSON{
+			Raw: rawConfig,
+		},
+	}, nil
+}
+
+// ensurePrometheusPluginForExtension ensures that the Prometheus KongPlugin
+// for the provided DataPlaneMetricsExtension exists and is up to date.
+func (r *Reconciler) ensurePrometheusPluginForExtension(
+	ctx context.Context,
+	cpNN types.NamespacedName,
+	ext *operatorv1alpha1.DataPlaneMetricsExtension,
+) (*configurationv1.KongPlugin, error) {
+	generated, err := generatePrometheusPluginForExtension(cpNN, ext)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := new(configurationv1.KongPlugin)
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(generated), existing); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed getting KongPlugin %s/%s: %w", generated.Namespace, generated.Name, err)
+		}
+		if err := r.Client.Create(ctx, generated); err != nil {
+			return nil, fmt.Errorf("failed creating KongPlugin %s/%s: %w", generated.Namespace, generated.Name, err)
+		}
+		return generated, nil
+	}
+
+	if maps.Equal(existing.Labels, generated.Labels) &&
+		existing.PluginName == generated.PluginName &&
+		bytes.Equal(existing.Config.Raw, generated.Config.Raw) {
+		return existing, nil
+	}
+
+	old := existing.DeepCopy()
+	existing.Labels = generated.Labels
+	existing.PluginName = generated.PluginName
+	existing.Config = generated.Config
+	if err := r.Client.Patch(ctx, existing, client.MergeFrom(old)); err != nil {
+		return nil, fmt.Errorf("failed patching KongPlugin %s/%s: %w", existing.Namespace, existing.Name, err)
+	}
+	return existing, nil
+}
+
+// cleanupStalePlugins removes the KongPlugins managed on behalf of the provided
+// ControlPlane that do not correspond to any of the desired extensions.
+// Before a KongPlugin is deleted it's detached from all the Services in its namespace.
+func (r *Reconciler) cleanupStalePlugins(
+	ctx context.Context,
+	cpNN types.NamespacedName,
+	desired map[types.NamespacedName]*operatorv1alpha1.DataPlaneMetricsExtension,
+) error {
+	var plugins configurationv1.KongPluginList
+	if err := r.Client.List(ctx, &plugins,
+		client.MatchingLabels{
+			consts.GatewayOperatorManagedByLabel:          consts.ControlPlaneManagedLabelValue,
+			consts.GatewayOperatorManagedByNameLabel:      cpNN.Name,
+			consts.GatewayOperatorManagedByNamespaceLabel: cpNN.Namespace,
+		},
+		client.HasLabels{consts.DataPlaneMetricsExtensionLabel},
+	); err != nil {
+		return fmt.Errorf("failed listing KongPlugins: %w", err)
+	}
+
+	for i := range plugins.Items {
+		plugin := &plugins.Items[i]
+		extNN := types.NamespacedName{
+			Namespace: plugin.Namespace,
+			Name:      plugin.Labels[consts.DataPlaneMetricsExtensionLabel],
+		}
+		if ext, ok := desired[extNN]; ok && extensionPluginName(ext) == plugin.Name {
+			continue
+		}
+
+		if err := r.removePluginFromServices(ctx, plugin.Namespace, plugin.Name); err != nil {
+			return err
+		}
+		if err := r.Client.Delete(ctx, plugin); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed deleting KongPlugin %s/%s: %w", plugin.Namespace, plugin.Name, err)
+		}
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// Reconciler - Services
+// -----------------------------------------------------------------------------
+
+// ensureServicesAnnotatedWithPlugin ensures that the Services selected by the
+// provided extension have the provided plugin set in their konghq.com/plugins
+// annotation and that all the other Services in the extension's namespace do not.
+func (r *Reconciler) ensureServicesAnnotatedWithPlugin(
+	ctx context.Context,
+	ext *operatorv1alpha1.DataPlaneMetricsExtension,
+	pluginName string,
+) error {
+	selected := lo.SliceToMap(ext.Spec.ServiceSelector.MatchNames, func(e operatorv1alpha1.ServiceSelectorEntry) (string, struct{}) {
+		return e.Name, struct{}{}
+	})
+
+	var services corev1.ServiceList
+	if err := r.Client.List(ctx, &services, client.InNamespace(ext.Namespace)); err != nil {
+		return fmt.Errorf("failed listing Services in namespace %s: %w", ext.Namespace, err)
+	}
+
+	for i := range services.Items {
+		svc := &services.Items[i]
+		_, isSelected := selected[svc.Name]
+		if err := r.ensureServicePluginAnnotation(ctx, svc, pluginName, isSelected); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removePluginFromServices removes the provided plugin from the konghq.com/plugins
+// annotation of all the Services in the provided namespace.
+func (r *Reconciler) removePluginFromServices(ctx context.Context, namespace, pluginName string) error {
+	var services corev1.ServiceList
+	if err := r.Client.List(ctx, &services, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("failed listing Services in namespace %s: %w", namespace, err)
+	}
+
+	for i := range services.Items {
+		if err := r.ensureServicePluginAnnotation(ctx, &services.Items[i], pluginName, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ensureServicePluginAnnotation adds (when present is true) or removes (when
+// present is false) the provided plugin to/from the konghq.com/plugins annotation
+// of the provided Service. The Service is patched only when needed.
+func (r *Reconciler) ensureServicePluginAnnotation(
+	ctx context.Context,
+	svc *corev1.Service,
+	pluginName string,
+	present bool,
+) error {
+	plugins := parsePluginsAnnotation(svc.Annotations[consts.KongPluginsAnnotation])
+	hasPlugin := slices.Contains(plugins, pluginName)
+	if hasPlugin == present {
+		return nil
+	}
+
+	if present {
+		plugins = append(plugins, pluginName)
+	} else {
+		plugins = lo.Without(plugins, pluginName)
+	}
+
+	old := svc.DeepCopy()
+	if len(plugins) == 0 {
+		delete(svc.Annotations, consts.KongPluginsAnnotation)
+	} else {
+		if svc.Annotations == nil {
+			svc.Annotations = map[string]string{}
+		}
+		svc.Annotations[consts.KongPluginsAnnotation] = strings.Join(plugins, ",")
+	}
+
+	if err := r.Client.Patch(ctx, svc, client.MergeFrom(old)); err != nil {
+		return fmt.Errorf("failed patching Service %s/%s: %w", svc.Namespace, svc.Name, err)
+	}
+	return nil
+}
+
+// parsePluginsAnnotation parses the comma separated konghq.com/plugins annotation value.
+func parsePluginsAnnotation(value string) []string {
+	var ret []string
+	for _, p := range strings.Split(value, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			ret = append(ret, p)
+		}
+	}
+	return ret
+}
+
+// -----------------------------------------------------------------------------
+// Reconciler - Status
+// -----------------------------------------------------------------------------
+
+// ensureExtensionStatusControlPlaneRef ensures that the provided extension's
+// status.controlPlaneRef is set to the provided ControlPlane or unset
+// when the provided ControlPlane is nil.
+func (r *Reconciler) ensureExtensionStatusControlPlaneRef(
+	ctx context.Context,
+	ext *operatorv1alpha1.DataPlaneMetricsExtension,
+	cpNN *types.NamespacedName,
+) error {
+	var ref *operatorv1alpha1.NamespacedRef
+	if cpNN != nil {
+		ref = &operatorv1alpha1.NamespacedRef{
+			Name:      cpNN.Name,
+			Namespace: lo.ToPtr(cpNN.Namespace),
+		}
+	}
+
+	current := ext.Status.ControlPlaneRef
+	if (current == nil && ref == nil) ||
+		(current != nil && ref != nil &&
+			current.Name == ref.Name && lo.FromPtr(current.Namespace) == lo.FromPtr(ref.Namespace)) {
+		return nil
+	}
+
+	old := ext.DeepCopy()
+	ext.Status.ControlPlaneRef = ref
+	return r.Client.Status().Patch(ctx, ext, client.MergeFrom(old))
+}
+
+// cleanupStaleExtensionStatuses unsets status.controlPlaneRef of all the
+// DataPlaneMetricsExtensions which point to the provided ControlPlane but are
+// not desired anymore.
+func (r *Reconciler) cleanupStaleExtensionStatuses(
+	ctx context.Context,
+	cpNN types.NamespacedName,
+	desired map[types.NamespacedName]*operatorv1alpha1.DataPlaneMetricsExtension,
+) error {
+	var extensions operatorv1alpha1.DataPlaneMetricsExtensionList
+	if err := r.Client.List(ctx, &extensions); err != nil {
+		return fmt.Errorf("failed listing DataPlaneMetricsExtensions: %w", err)
+	}
+
+	for i := range extensions.Items {
+		ext := &extensions.Items[i]
+		if _, ok := desired[client.ObjectKeyFromObject(ext)]; ok {
+			continue
+		}
+		ref := ext.Status.ControlPlaneRef
+		if ref == nil || ref.Name != cpNN.Name || lo.FromPtr(ref.Namespace) != cpNN.Namespace {
+			continue
+		}
+		if err := r.ensureExtensionStatusControlPlaneRef(ctx, ext, nil); err != nil {
+			return fmt.Errorf("failed updating status of DataPlaneMetricsExtension %s/%s: %w", ext.Namespace, ext.Name, err)
+		}
+	}
+	return nil
+}
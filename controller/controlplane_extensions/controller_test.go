@@ -0,0 +1,263 @@
+package controlplane_extensions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1alpha1 "github.com/kong/gateway-operator/api/v1alpha1"
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/pkg/consts"
+)
+
+func init() {
+	if err := operatorv1alpha1.AddToScheme(scheme.Scheme); err != nil {
+		fmt.Println("error while adding operatorv1alpha1 scheme")
+		os.Exit(1)
+	}
+	if err := operatorv1beta1.AddToScheme(scheme.Scheme); err != nil {
+		fmt.Println("error while adding operatorv1beta1 scheme")
+		os.Exit(1)
+	}
+	if err := configurationv1.AddToScheme(scheme.Scheme); err != nil {
+		fmt.Println("error while adding configurationv1 scheme")
+		os.Exit(1)
+	}
+}
+
+func TestReconciler_Reconcile(t *testing.T) {
+	const namespace = "default"
+
+	newControlPlane := func(name string, extensions ...string) *operatorv1beta1.ControlPlane {
+		return &operatorv1beta1.ControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: operatorv1beta1.ControlPlaneSpec{
+				ControlPlaneOptions: operatorv1beta1.ControlPlaneOptions{
+					Extensions: lo.Map(extensions, func(ext string, _ int) operatorv1alpha1.ExtensionRef {
+						return operatorv1alpha1.ExtensionRef{
+							Group: operatorv1alpha1.SchemeGroupVersion.Group,
+							Kind:  operatorv1alpha1.DataPlaneMetricsExtensionKind,
+							NamespacedRef: operatorv1alpha1.NamespacedRef{
+								Name: ext,
+							},
+						}
+					}),
+				},
+			},
+		}
+	}
+	newExtension := func(controlPlaneRef string) *operatorv1alpha1.DataPlaneMetricsExtension {
+		ext := &operatorv1alpha1.DataPlaneMetricsExtension{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "metrics",
+				Namespace: namespace,
+			},
+			Spec: operatorv1alpha1.DataPlaneMetricsExtensionSpec{
+				ServiceSelector: operatorv1alpha1.ServiceSelector{
+					MatchNames: []operatorv1alpha1.ServiceSelectorEntry{
+						{Name: "svc-1"},
+					},
+				},
+				Config: operatorv1alpha1.MetricsConfig{
+					Latency:    true,
+					StatusCode: true,
+				},
+			},
+		}
+		if controlPlaneRef != "" {
+			ext.Status.ControlPlaneRef = &operatorv1alpha1.NamespacedRef{
+				Name:      controlPlaneRef,
+				Namespace: lo.ToPtr(namespace),
+			}
+		}
+		return ext
+	}
+	newService := func(name string, plugins string) *corev1.Service {
+		svc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+		}
+		if plugins != "" {
+			svc.Annotations = map[string]string{
+				consts.KongPluginsAnnotation: plugins,
+			}
+		}
+		return svc
+	}
+	managedPlugin := &configurationv1.KongPlugin{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "metrics-prometheus",
+			Namespace: namespace,
+			Labels: map[string]string{
+				consts.GatewayOperatorManagedByLabel:          consts.ControlPlaneManagedLabelValue,
+				consts.GatewayOperatorManagedByNameLabel:      "cp",
+				consts.GatewayOperatorManagedByNamespaceLabel: namespace,
+				consts.DataPlaneMetricsExtensionLabel:         "metrics",
+			},
+		},
+		PluginName: "prometheus",
+	}
+
+	testCases := []struct {
+		name       string
+		objects    []client.Object
+		assertions func(t *testing.T, cl client.Client)
+	}{
+		{
+			name: "referenced extension gets a plugin attached to the selected services",
+			objects: []client.Object{
+				newControlPlane("cp", "metrics"),
+				newExtension(""),
+				newService("svc-1", "other-plugin"),
+				newService("svc-2", ""),
+			},
+			assertions: func(t *testing.T, cl client.Client) {
+				ctx := context.Background()
+
+				plugin := new(configurationv1.KongPlugin)
+				require.NoError(t, cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "metrics-prometheus"}, plugin))
+				require.Equal(t, "prometheus", plugin.PluginName)
+				require.Equal(t, "cp", plugin.Labels[consts.GatewayOperatorManagedByNameLabel])
+				require.Equal(t, namespace, plugin.Labels[consts.GatewayOperatorManagedByNamespaceLabel])
+				var cfg prometheusPluginConfig
+				require.NoError(t, json.Unmarshal(plugin.Config.Raw, &cfg))
+				require.Equal(t, prometheusPluginConfig{LatencyMetrics: true, StatusCodeMetrics: true}, cfg)
+
+				svc := new(corev1.Service)
+				require.NoError(t, cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "svc-1"}, svc))
+				require.Equal(t, "other-plugin,metrics-prometheus", svc.Annotations[consts.KongPluginsAnnotation])
+				require.NoError(t, cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "svc-2"}, svc))
+				require.NotContains(t, svc.Annotations, consts.KongPluginsAnnotation)
+
+				ext := new(operatorv1alpha1.DataPlaneMetricsExtension)
+				require.NoError(t, cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "metrics"}, ext))
+				require.NotNil(t, ext.Status.ControlPlaneRef)
+				require.Equal(t, "cp", ext.Status.ControlPlaneRef.Name)
+				require.Equal(t, namespace, lo.FromPtr(ext.Status.ControlPlaneRef.Namespace))
+			},
+		},
+		{
+			name: "extension no longer referenced gets its plugin and service annotations removed",
+			objects: []client.Object{
+				newControlPlane("cp"),
+				newExtension("cp"),
+				managedPlugin.DeepCopy(),
+				newService("svc-1", "metrics-prometheus"),
+				newService("svc-2", "metrics-prometheus,other-plugin"),
+			},
+			assertions: func(t *testing.T, cl client.Client) {
+				ctx := context.Background()
+
+				plugin := new(configurationv1.KongPlugin)
+				err := cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "metrics-prometheus"}, plugin)
+				require.True(t, k8serrors.IsNotFound(err))
+
+				svc := new(corev1.Service)
+				require.NoError(t, cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "svc-1"}, svc))
+				require.NotContains(t, svc.Annotations, consts.KongPluginsAnnotation)
+				require.NoError(t, cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "svc-2"}, svc))
+				require.Equal(t, "other-plugin", svc.Annotations[consts.KongPluginsAnnotation])
+
+				ext := new(operatorv1alpha1.DataPlaneMetricsExtension)
+				require.NoError(t, cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "metrics"}, ext))
+				require.Nil(t, ext.Status.ControlPlaneRef)
+			},
+		},
+		{
+			name: "extension associated with another ControlPlane is skipped",
+			objects: []client.Object{
+				newControlPlane("cp", "metrics"),
+				newControlPlane("other-cp", "metrics"),
+				newExtension("other-cp"),
+				newService("svc-1", ""),
+			},
+			assertions: func(t *testing.T, cl client.Client) {
+				ctx := context.Background()
+
+				plugin := new(configurationv1.KongPlugin)
+				err := cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "metrics-prometheus"}, plugin)
+				require.True(t, k8serrors.IsNotFound(err))
+
+				svc := new(corev1.Service)
+				require.NoError(t, cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "svc-1"}, svc))
+				require.NotContains(t, svc.Annotations, consts.KongPluginsAnnotation)
+
+				ext := new(operatorv1alpha1.DataPlaneMetricsExtension)
+				require.NoError(t, cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "metrics"}, ext))
+				require.Equal(t, "other-cp", ext.Status.ControlPlaneRef.Name)
+			},
+		},
+		{
+			name: "deleted ControlPlane gets its extensions cleaned up",
+			objects: []client.Object{
+				newExtension("cp"),
+				managedPlugin.DeepCopy(),
+				newService("svc-1", "metrics-prometheus"),
+			},
+			assertions: func(t *testing.T, cl client.Client) {
+				ctx := context.Background()
+
+				plugin := new(configurationv1.KongPlugin)
+				err := cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "metrics-prometheus"}, plugin)
+				require.True(t, k8serrors.IsNotFound(err))
+
+				svc := new(corev1.Service)
+				require.NoError(t, cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "svc-1"}, svc))
+				require.NotContains(t, svc.Annotations, consts.KongPluginsAnnotation)
+
+				ext := new(operatorv1alpha1.DataPlaneMetricsExtension)
+				require.NoError(t, cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "metrics"}, ext))
+				require.Nil(t, ext.Status.ControlPlaneRef)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fakectrlruntimeclient.
+				NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(tc.objects...).
+				WithStatusSubresource(&operatorv1alpha1.DataPlaneMetricsExtension{}).
+				Build()
+
+			reconciler := Reconciler{
+				Client: fakeClient,
+			}
+
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: namespace,
+					Name:      "cp",
+				},
+			}
+			_, err := reconciler.Reconcile(context.Background(), req)
+			require.NoError(t, err)
+			// Second reconciliation should be a noop.
+			_, err = reconciler.Reconcile(context.Background(), req)
+			require.NoError(t, err)
+
+			tc.assertions(t, fakeClient)
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package controlplane_extensions
+
+import (
+	"context"
+	"reflect"
+	"slices"
+
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1"
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1alpha1 "github.com/kong/gateway-operator/api/v1alpha1"
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	operatorerrors "github.com/kong/gateway-operator/internal/errors"
+	"github.com/kong/gateway-operator/internal/utils/index"
+	"github.com/kong/gateway-operator/pkg/consts"
+)
+
+// -----------------------------------------------------------------------------
+// Reconciler - Watch Predicates
+// -----------------------------------------------------------------------------
+
+func kongPluginIsManagedByExtension(obj client.Object) bool {
+	labels := obj.GetLabels()
+	if labels[consts.GatewayOperatorManagedByLabel] != consts.ControlPlaneManagedLabelValue {
+		return false
+	}
+	_, ok := labels[consts.DataPlaneMetricsExtensionLabel]
+	return ok
+}
+
+// -----------------------------------------------------------------------------
+// Reconciler - Watch Mapping Funcs
+// -----------------------------------------------------------------------------
+
+func (r *Reconciler) getControlPlaneForKongPlugin(ctx context.Context, obj client.Object) []reconcile.Request {
+	plugin, ok := obj.(*configurationv1.KongPlugin)
+	if !ok {
+		log.FromContext(ctx).Error(
+			operatorerrors.ErrUnexpectedObject,
+			"failed to run map funcs",
+			"expected", "KongPlugin", "found", reflect.TypeOf(obj),
+		)
+		return nil
+	}
+
+	name, ok := plugin.Labels[consts.GatewayOperatorManagedByNameLabel]
+	if !ok {
+		return nil
+	}
+	namespace, ok := plugin.Labels[consts.GatewayOperatorManagedByNamespaceLabel]
+	if !ok {
+		return nil
+	}
+
+	return []reconcile.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Namespace: namespace,
+				Name:      name,
+			},
+		},
+	}
+}
+
+func (r *Reconciler) listControlPlanesForDataPlaneMetricsExtension(ctx context.Context, obj client.Object) []reconcile.Request {
+	ext, ok := obj.(*operatorv1alpha1.DataPlaneMetricsExtension)
+	if !ok {
+		log.FromContext(ctx).Error(
+			operatorerrors.ErrUnexpectedObject,
+			"failed to run map funcs",
+			"expected", "DataPlaneMetricsExtension", "found", reflect.TypeOf(obj),
+		)
+		return nil
+	}
+
+	return r.controlPlaneRequestsForExtensions(ctx, []operatorv1alpha1.DataPlaneMetricsExtension{*ext})
+}
+
+func (r *Reconciler) listControlPlanesForService(ctx context.Context, obj client.Object) []reconcile.Request {
+	svc, ok := obj.(*corev1.Service)
+	if !ok {
+		log.FromContext(ctx).Error(
+			operatorerrors.ErrUnexpectedObject,
+			"failed to run map funcs",
+			"expected", "Service", "found", reflect.TypeOf(obj),
+		)
+		return nil
+	}
+
+	var extensions operatorv1alpha1.DataPlaneMetricsExtensionList
+	if err := r.Client.List(ctx, &extensions, client.InNamespace(svc.Namespace)); err != nil {
+		log.FromContext(ctx).Error(err, "could not list DataPlaneMetricsExtensions in map func")
+		return nil
+	}
+
+	plugins := parsePluginsAnnotation(svc.Annotations[consts.KongPluginsAnnotation])
+	matching := lo.Filter(extensions.Items, func(ext operatorv1alpha1.DataPlaneMetricsExtension, _ int) bool {
+		selected := lo.ContainsBy(ext.Spec.ServiceSelector.MatchNames, func(e operatorv1alpha1.ServiceSelectorEntry) bool {
+			return e.Name == svc.Name
+		})
+		return selected || slices.Contains(plugins, extensionPluginName(&ext))
+	})
+
+	return r.controlPlaneRequestsForExtensions(ctx, matching)
+}
+
+// controlPlaneRequestsForExtensions returns reconcile requests for all the
+// ControlPlanes that either reference the provided extensions or are set in
+// their status.
+func (r *Reconciler) controlPlaneRequestsForExtensions(
+	ctx context.Context,
+	extensions []operatorv1alpha1.DataPlaneMetricsExtension,
+) []reconcile.Request {
+	requests := map[types.NamespacedName]struct{}{}
+	for _, ext := range extensions {
+		if ref := ext.Status.ControlPlaneRef; ref != nil {
+			nn := types.NamespacedName{
+				Namespace: lo.FromPtr(ref.Namespace),
+				Name:      ref.Name,
+			}
+			if nn.Namespace == "" {
+				nn.Namespace = ext.Namespace
+			}
+			requests[nn] = struct{}{}
+		}
+
+		var controlPlanes operatorv1beta1.ControlPlaneList
+		if err := r.Client.List(ctx, &controlPlanes,
+			client.MatchingFields{
+				index.DataPlaneMetricsExtensionIndex: ext.Namespace + "/" + ext.Name,
+			},
+		); err != nil {
+			log.FromContext(ctx).Error(err, "could not list ControlPlanes in map func")
+			continue
+		}
+		for _, cp := range controlPlanes.Items {
+			requests[client.ObjectKeyFromObject(&cp)] = struct{}{}
+		}
+	}
+
+	return lo.MapToSlice(requests, func(nn types.NamespacedName, _ struct{}) reconcile.Request {
+		return reconcile.Request{NamespacedName: nn}
+	})
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"sort"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
@@ -13,19 +15,23 @@ import (
 	"github.com/samber/lo"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
 	"github.com/kong/gateway-operator/controller/pkg/address"
+	"github.com/kong/gateway-operator/controller/pkg/analysis"
 	"github.com/kong/gateway-operator/controller/pkg/ctxinjector"
 	"github.com/kong/gateway-operator/controller/pkg/dataplane"
 	"github.com/kong/gateway-operator/controller/pkg/log"
 	"github.com/kong/gateway-operator/controller/pkg/op"
+	"github.com/kong/gateway-operator/controller/pkg/secrets"
 	"github.com/kong/gateway-operator/pkg/consts"
 	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
 	k8sresources "github.com/kong/gateway-operator/pkg/utils/kubernetes/resources"
@@ -53,6 +59,15 @@ type BlueGreenReconciler struct {
 	// certificate data which will be used when generating certificates for DataPlane's
 	// Deployment.
 	ClusterCASecretNamespace string
+	// ClusterCertificateRenewBefore is the duration before the expiry of
+	// the DataPlane's certificate at which it gets reissued.
+	ClusterCertificateRenewBefore time.Duration
+	// CertificateIssuer is the issuer of the DataPlane's certificate used
+	// unless the DataPlane configures its own.
+	CertificateIssuer operatorv1beta1.CertificateIssuer
+	// ClusterCertificateKeyType is the type of the private key generated for
+	// the DataPlane's certificate.
+	ClusterCertificateKeyType secrets.KeyType
 
 	// DevelopmentMode indicates if the controller should run in development mode,
 	// which causes it to e.g. perform less validations.
@@ -61,9 +76,15 @@ type BlueGreenReconciler struct {
 	// Callbacks is a set of Callback functions to run at various stages of reconciliation.
 	Callbacks DataPlaneCallbacks
 
+	// AnalysisHTTPClient is the client sending the requests of the rollout
+	// analysis checks. http.DefaultClient is used when it's not set.
+	AnalysisHTTPClient analysis.HTTPClient
+
 	ContextInjector ctxinjector.CtxInjector
 
 	DefaultImage string
+
+	eventRecorder record.EventRecorder
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -72,8 +93,12 @@ func (r *BlueGreenReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	if !ok {
 		return fmt.Errorf("incorrect delegate controller type: %T", r.DataPlaneController)
 	}
-	delegate.eventRecorder = mgr.GetEventRecorderFor("dataplane")
-	return DataPlaneWatchBuilder(mgr).
+	r.eventRecorder = mgr.GetEventRecorderFor("dataplane")
+	delegate.eventRecorder = r.eventRecorder
+	return DataPlaneWatchBuilder(mgr, types.NamespacedName{
+		Namespace: r.ClusterCASecretNamespace,
+		Name:      r.ClusterCASecretName,
+	}).
 		Complete(r)
 }
 
@@ -95,12 +120,13 @@ func (r *BlueGreenReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	logger := log.GetLogger(ctx, "dataplaneBlueGreen", r.DevelopmentMode)
 
-	// Blue Green rollout strategy is not enabled, delegate to DataPlane controller.
-	if dataplane.Spec.Deployment.Rollout == nil || dataplane.Spec.Deployment.Rollout.Strategy.BlueGreen == nil {
+	// Neither Blue Green nor Canary rollout strategy is enabled, delegate to DataPlane controller.
+	if dataplane.Spec.Deployment.Rollout == nil ||
+		(dataplane.Spec.Deployment.Rollout.Strategy.BlueGreen == nil && dataplane.Spec.Deployment.Rollout.Strategy.Canary == nil) {
 		if err := r.prunePreviewSubresources(ctx, &dataplane); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed pruning preview DataPlane subresources: %w", err)
 		}
-		log.Trace(logger, "no Rollout with BlueGreen strategy specified, delegating to DataPlaneReconciler", req)
+		log.Trace(logger, "no Rollout with BlueGreen or Canary strategy specified, delegating to DataPlaneReconciler", req)
 		return r.DataPlaneController.Reconcile(ctx, req)
 	}
 
@@ -130,7 +156,11 @@ func (r *BlueGreenReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	} else if !ok || c.ObservedGeneration != dataplane.Generation {
 		// Otherwise we either don't have the RolledOut condition set yet or the
 		// DataPlane generation has progressed so set the RolledOut condition
-		// to "Rollout initialized"
+		// to "Rollout initialized". The Canary rollout, if any, starts over from its first step
+		// and the preview resources get analyzed again.
+		if err := r.resetRolloutProgressStatus(ctx, &dataplane); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed resetting rollout progress status: %w", err)
+		}
 		err := r.ensureRolledOutCondition(ctx, logger, &dataplane, metav1.ConditionFalse, consts.DataPlaneConditionReasonRolloutProgressing, consts.DataPlaneConditionMessageRolledOutRolloutInitialized)
 		if err != nil {
 			return ctrl.Result{}, err
@@ -160,11 +190,19 @@ func (r *BlueGreenReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			Namespace: r.ClusterCASecretNamespace,
 			Name:      r.ClusterCASecretName,
 		},
+		r.CertificateIssuer,
 		types.NamespacedName{
 			Namespace: dataplaneAdminService.Namespace,
 			Name:      dataplaneAdminService.Name,
 		},
+		secrets.WithRenewBefore(r.ClusterCertificateRenewBefore),
+		secrets.WithEventRecorder(r.eventRecorder),
+		secrets.WithKeyType(r.ClusterCertificateKeyType),
 	)
+	if errors.Is(err, secrets.ErrCertificatePending) {
+		log.Debug(logger, "waiting for mTLS certificate to be issued", dataplane)
+		return ctrl.Result{RequeueAfter: secrets.CertificatePendingRequeueAfter}, nil
+	}
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -173,6 +211,25 @@ func (r *BlueGreenReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, nil // requeue will be triggered by the creation or update of the owned object
 	}
 
+	log.Trace(logger, "ensuring Konnect client certificate", dataplane)
+	res, konnectCertSecret, err := ensureDataPlaneKonnectClientCertificate(ctx, r.Client, logger, &dataplane,
+		r.ClusterCASecretNamespace,
+		r.ClusterCertificateRenewBefore,
+		r.ClusterCertificateKeyType,
+	)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if res != op.Noop {
+		log.Debug(logger, "Konnect client certificate created/updated", dataplane)
+		// cert-manager Certificates are not watched hence the explicit requeue.
+		return ctrl.Result{RequeueAfter: konnectClientCertificateRetryInterval}, nil
+	}
+	if dataplane.Spec.Network.KonnectCertificateOptions != nil && konnectCertSecret == nil {
+		log.Debug(logger, "waiting for Konnect client certificate to be issued", dataplane)
+		return ctrl.Result{RequeueAfter: konnectClientCertificateRetryInterval}, nil
+	}
+
 	// Ensure "preview" Ingress service.
 	res, previewIngressService, err := r.ensurePreviewIngressService(ctx, logger, &dataplane)
 	if err != nil {
@@ -189,8 +246,25 @@ func (r *BlueGreenReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, nil
 	}
 
+	// If the analysis of the preview resources failed, roll the preview Deployment
+	// back to the live one until the DataPlane's spec changes.
+	if status := extractRolloutStatusAnalysis(&dataplane); status != nil && status.Completed && !status.Passed && status.ObservedGeneration == dataplane.Generation {
+		if err := r.rollbackPreviewDeployment(ctx, logger, &dataplane); err != nil {
+			cErr := r.ensureRolledOutCondition(ctx, logger, &dataplane, metav1.ConditionFalse, consts.DataPlaneConditionReasonRolloutPromotionFailed, "failed to roll back preview Deployment")
+			return ctrl.Result{}, fmt.Errorf("failed rolling back preview Deployment for DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, errors.Join(cErr, err))
+		}
+		if _, ok := dataplane.Annotations[operatorv1beta1.DataPlanePromoteWhenReadyAnnotationKey]; ok {
+			if err := r.resetPromoteWhenReadyAnnotation(ctx, &dataplane); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		err := r.ensureRolledOutCondition(ctx, logger, &dataplane, metav1.ConditionFalse, consts.DataPlaneConditionReasonRolloutPromotionFailed,
+			fmt.Sprintf("analysis failed: %s", status.Message))
+		return ctrl.Result{}, err
+	}
+
 	// Ensure "preview" Deployment.
-	deployment, res, err := r.ensureDeploymentForDataPlane(ctx, logger, &dataplane, certSecret)
+	deployment, res, err := r.ensureDeploymentForDataPlane(ctx, logger, &dataplane, certSecret, konnectCertSecret)
 	if err != nil {
 		cErr := r.ensureRolledOutCondition(ctx, logger, &dataplane, metav1.ConditionFalse, consts.DataPlaneConditionReasonRolloutFailed, "failed to ensure preview Deployment")
 		return ctrl.Result{}, fmt.Errorf("failed to ensure Deployment for DataPlane: %w", errors.Join(cErr, err))
@@ -209,8 +283,14 @@ func (r *BlueGreenReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, err
 	}
 
-	// TODO: Perform promotion condition checks to verify we can proceed
-	// Ref: https://github.com/Kong/gateway-operator/issues/974
+	// Shift the traffic to the preview Deployment in steps before promoting it.
+	if dataplane.Spec.Deployment.Rollout.Strategy.Canary != nil {
+		if res, done, err := r.ensureCanaryStepsCompleted(ctx, logger, &dataplane); err != nil {
+			return ctrl.Result{}, err
+		} else if !done {
+			return res, nil
+		}
+	}
 
 	if proceedWithPromotion, err := canProceedWithPromotion(dataplane); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed checking if DataPlane %s/%s can be promoted: %w", dataplane.Namespace, dataplane.Name, err)
@@ -222,6 +302,15 @@ func (r *BlueGreenReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, err
 	}
 
+	// Analyze the preview resources before promoting them.
+	if analyzed, requeueAfter, err := r.ensurePreviewAnalyzed(ctx, logger, &dataplane, dataplaneAdminService, previewIngressService); err != nil {
+		return ctrl.Result{}, err
+	} else if analyzed || requeueAfter > 0 {
+		// Status update will trigger reconciliation, the explicit requeue
+		// schedules the next run of the analysis checks.
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
 	// If we've failed to promote previously, don't set the RolledOut reason to
 	// PromotionInProgress as the error can reoccur and the status can start flapping.
 	c, ok = k8sutils.GetCondition(consts.DataPlaneConditionTypeRolledOut, dataplane.Status.RolloutStatus)
@@ -277,6 +366,8 @@ func (r *BlueGreenReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		// reconciliation to create new preview.
 		old := dataplane.DeepCopy()
 		dataplane.Status.RolloutStatus.Deployment.Selector = ""
+		dataplane.Status.RolloutStatus.Canary = nil
+		dataplane.Status.RolloutStatus.Analysis = nil
 		if err := r.Client.Status().Patch(ctx, &dataplane, client.MergeFrom(old)); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed updating DataPlane's RolloutStatus: %w", err)
 		}
@@ -298,7 +389,12 @@ func (r *BlueGreenReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	}
 
 	log.Debug(logger, "BlueGreen reconciliation complete for DataPlane resource", dataplane)
-	return ctrl.Result{}, nil
+	// Requeue in order to renew the certificates before they expire.
+	requeueAfter := secrets.RenewalRequeueAfter(certSecret, r.ClusterCertificateRenewBefore)
+	if konnectCertSecret != nil {
+		requeueAfter = min(requeueAfter, konnectClientCertificateRequeueAfter(konnectCertSecret, r.ClusterCertificateRenewBefore))
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 // ensureDataPlaneLiveReadyStatus ensures that the DataPlane has the Ready status
@@ -484,6 +580,7 @@ func (r *BlueGreenReconciler) ensureDeploymentForDataPlane(
 	logger logr.Logger,
 	dataplane *operatorv1beta1.DataPlane,
 	certSecret *corev1.Secret,
+	konnectCertSecret *corev1.Secret,
 ) (*appsv1.Deployment, op.CreatedUpdatedOrNoop, error) {
 	deploymentOpts := []k8sresources.DeploymentOpt{
 		labelSelectorFromDataPlaneRolloutStatusSelectorDeploymentOpt(dataplane),
@@ -492,10 +589,12 @@ func (r *BlueGreenReconciler) ensureDeploymentForDataPlane(
 	// If we're running the exact same Generation as "live" version is then:
 	// - the rollout resource plan is set to ScaleDownOnPromotionScaleUpOnRollout
 	//   then  scale down the Deployment to 0 replicas.
+	// Otherwise, when using the Canary rollout strategy, scale the Deployment
+	// according to the weight of the current Canary step.
 	cReady, okReady := k8sutils.GetCondition(k8sutils.ReadyType, dataplane)
 	cRolledOut, okRolledOut := k8sutils.GetCondition(consts.DataPlaneConditionTypeRolledOut, dataplane.Status.RolloutStatus)
 	if okReady && okRolledOut && cReady.ObservedGeneration == cRolledOut.ObservedGeneration {
-		dPlan := dataplane.Spec.Deployment.Rollout.Strategy.BlueGreen.Resources.Plan.Deployment
+		dPlan := rolloutResourcePlanDeployment(dataplane)
 		if dPlan == operatorv1beta1.RolloutResourcePlanDeploymentScaleDownOnPromotionScaleUpOnRollout {
 			deploymentOpts = append(deploymentOpts, func(d *appsv1.Deployment) {
 				d.Spec.Replicas = lo.ToPtr(int32(0))
@@ -503,6 +602,11 @@ func (r *BlueGreenReconciler) ensureDeploymentForDataPlane(
 		}
 		// TODO: implemented DeleteOnPromotionRecreateOnRollout
 		// Ref: https://github.com/Kong/gateway-operator/issues/1010
+	} else if dataplane.Spec.Deployment.Rollout.Strategy.Canary != nil {
+		previewReplicas, _ := canaryReplicas(dataplane, canaryWeight(dataplane))
+		deploymentOpts = append(deploymentOpts, func(d *appsv1.Deployment) {
+			d.Spec.Replicas = lo.ToPtr(previewReplicas)
+		})
 	}
 	deploymentLabels := client.MatchingLabels{
 		consts.DataPlaneDeploymentStateLabel: consts.DataPlaneStateLabelValuePreview,
@@ -511,6 +615,7 @@ func (r *BlueGreenReconciler) ensureDeploymentForDataPlane(
 		WithBeforeCallbacks(r.Callbacks.BeforeDeployment).
 		WithAfterCallbacks(r.Callbacks.AfterDeployment).
 		WithClusterCertificate(certSecret.Name).
+		WithKonnectClientCertificate(konnectCertSecret).
 		WithOpts(deploymentOpts...).
 		WithDefaultImage(r.DefaultImage).
 		WithAdditionalLabels(deploymentLabels)
@@ -727,6 +832,11 @@ func rolloutStatusChanged(old, updated *operatorv1beta1.DataPlane) bool {
 // canProceedWithPromotion verifies whether a DataPlane preview resources can be promoted. It assumes that all the
 // preview resources are ready.
 func canProceedWithPromotion(dataplane operatorv1beta1.DataPlane) (bool, error) {
+	if dataplane.Spec.Deployment.Rollout.Strategy.Canary != nil {
+		// Canary rollouts are promoted as soon as all of their steps are completed.
+		return true, nil
+	}
+
 	promotionStrategy := dataplane.Spec.Deployment.Rollout.Strategy.BlueGreen.Promotion.Strategy
 	switch promotionStrategy {
 	case operatorv1beta1.BreakBeforePromotion:
@@ -787,7 +897,7 @@ func (r *BlueGreenReconciler) ensurePreviewIngressService(
 		ctx,
 		logger,
 		r.Client,
-		dataplane,
+		dataPlaneWithoutIngressServiceAddresses(dataplane),
 		additionalServiceLabels,
 		labelSelectorFromDataPlaneRolloutStatusSelectorServiceOpt(dataplane),
 	)
@@ -805,6 +915,24 @@ func (r *BlueGreenReconciler) ensurePreviewIngressService(
 	return res, svc, nil
 }
 
+// dataPlaneWithoutIngressServiceAddresses returns a copy of the provided DataPlane
+// without the load balancer IP, the external IPs and the external-dns hostname
+// requested for its ingress Service. These addresses belong to the "live" ingress
+// Service only: assigning them to the "preview" one as well would make both Services
+// compete for them.
+func dataPlaneWithoutIngressServiceAddresses(dataplane *operatorv1beta1.DataPlane) *operatorv1beta1.DataPlane {
+	if dataplane.Spec.Network.Services == nil || dataplane.Spec.Network.Services.Ingress == nil {
+		return dataplane
+	}
+
+	dataplane = dataplane.DeepCopy()
+	ingress := dataplane.Spec.Network.Services.Ingress
+	ingress.LoadBalancerIP = ""
+	ingress.ExternalIPs = nil
+	delete(ingress.Annotations, consts.ExternalDNSHostnameAnnotation)
+	return dataplane
+}
+
 // ensureDataPlaneRolloutIngressServiceStatus ensures status.rollout.service.ingress
 // contains the name and addresses of "preview" ingress service.
 func (r *BlueGreenReconciler) ensureDataPlaneRolloutIngressServiceStatus(
@@ -946,6 +1074,397 @@ func (r *BlueGreenReconciler) ensurePreviewDeploymentLabeledLive(
 	return true, nil
 }
 
+// ensureCanaryStepsCompleted shifts the traffic to the preview Deployment according
+// to the configured Canary steps. It returns true when all the steps are completed
+// and the preview resources can be promoted.
+// It assumes that the preview Deployment is ready.
+func (r *BlueGreenReconciler) ensureCanaryStepsCompleted(
+	ctx context.Context,
+	logger logr.Logger,
+	dataplane *operatorv1beta1.DataPlane,
+) (res ctrl.Result, done bool, err error) {
+	canary := dataplane.Spec.Deployment.Rollout.Strategy.Canary
+	if len(canary.Steps) == 0 {
+		return ctrl.Result{}, true, nil
+	}
+
+	status := extractRolloutStatusCanary(dataplane)
+	if status == nil {
+		old := dataplane.DeepCopy()
+		dataplane = initDataPlaneStatusRollout(dataplane)
+		dataplane.Status.RolloutStatus.Canary = &operatorv1beta1.DataPlaneRolloutStatusCanary{
+			Step:   0,
+			Weight: canary.Steps[0].Weight,
+		}
+		if _, err := r.patchRolloutStatus(ctx, logger, old, dataplane); err != nil {
+			return ctrl.Result{}, false, fmt.Errorf("failed initializing Canary rollout status: %w", err)
+		}
+		return ctrl.Result{}, false, nil // status update will trigger reconciliation
+	}
+	if int(status.Step) >= len(canary.Steps) {
+		return ctrl.Result{}, true, nil
+	}
+	step := canary.Steps[status.Step]
+
+	// Make the live Services route the traffic to both the live and the preview Deployment.
+	if updated, err := r.ensureLiveServicesSelectPreview(ctx, dataplane); err != nil {
+		cErr := r.ensureRolledOutCondition(ctx, logger, dataplane, metav1.ConditionFalse, consts.DataPlaneConditionReasonRolloutFailed, "failed to update live Services for Canary rollout")
+		return ctrl.Result{}, false, fmt.Errorf("failed updating live Services of DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, errors.Join(cErr, err))
+	} else if updated {
+		log.Debug(logger, "live services select the preview deployment", dataplane)
+		return ctrl.Result{}, false, nil // live services update will trigger reconciliation
+	}
+
+	previewReplicas, liveReplicas := canaryReplicas(dataplane, status.Weight)
+	if updated, err := r.ensureLiveDeploymentReplicas(ctx, dataplane, liveReplicas); err != nil {
+		cErr := r.ensureRolledOutCondition(ctx, logger, dataplane, metav1.ConditionFalse, consts.DataPlaneConditionReasonRolloutFailed, "failed to scale live Deployment for Canary rollout")
+		return ctrl.Result{}, false, fmt.Errorf("failed scaling live Deployment of DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, errors.Join(cErr, err))
+	} else if updated {
+		log.Debug(logger, "live deployment scaled for Canary step", dataplane, "step", status.Step, "replicas", liveReplicas)
+		return ctrl.Result{}, false, nil // live deployment update will trigger reconciliation
+	}
+
+	// The traffic is split proportionally to the number of replicas, which might not
+	// allow representing the configured weight.
+	effectiveWeight := canaryEffectiveWeight(previewReplicas, liveReplicas)
+	if status.StepStartedAt == nil || status.EffectiveWeight != effectiveWeight {
+		old := dataplane.DeepCopy()
+		dataplane.Status.RolloutStatus.Canary.EffectiveWeight = effectiveWeight
+		if status.StepStartedAt == nil {
+			dataplane.Status.RolloutStatus.Canary.StepStartedAt = lo.ToPtr(metav1.Now())
+		}
+		if _, err := r.patchRolloutStatus(ctx, logger, old, dataplane); err != nil {
+			return ctrl.Result{}, false, fmt.Errorf("failed updating Canary rollout status: %w", err)
+		}
+		return ctrl.Result{}, false, nil // status update will trigger reconciliation
+	}
+
+	stepDescription := fmt.Sprintf("Canary step %d/%d at %d%% weight", status.Step+1, len(canary.Steps), status.Weight)
+	if effectiveWeight != status.Weight {
+		stepDescription = fmt.Sprintf("%s (%d%% effective with %d preview and %d live replicas)",
+			stepDescription, effectiveWeight, previewReplicas, liveReplicas)
+	}
+	if step.Pause != nil {
+		if remaining := time.Until(status.StepStartedAt.Add(step.Pause.Duration)); remaining > 0 {
+			log.Trace(logger, "Canary step paused", dataplane, "step", status.Step, "remaining", remaining)
+			err := r.ensureRolledOutCondition(ctx, logger, dataplane, metav1.ConditionFalse, consts.DataPlaneConditionReasonRolloutProgressing,
+				fmt.Sprintf("%s paused for %s", stepDescription, step.Pause.Duration))
+			return ctrl.Result{RequeueAfter: remaining}, false, err
+		}
+	}
+
+	if canary.Advancement == operatorv1beta1.CanaryAdvancementManual {
+		if dataplane.Annotations[operatorv1beta1.DataPlaneAdvanceCanaryStepAnnotationKey] != operatorv1beta1.DataPlaneAdvanceCanaryStepAnnotationTrue {
+			log.Debug(logger, "Canary step awaiting advancement", dataplane, "step", status.Step)
+			err := r.ensureRolledOutCondition(ctx, logger, dataplane, metav1.ConditionFalse, consts.DataPlaneConditionReasonRolloutAwaitingAdvancement,
+				fmt.Sprintf("%s awaiting advancement", stepDescription))
+			return ctrl.Result{}, false, err
+		}
+		// Reset the annotation before advancing so that it cannot advance more than one step.
+		if err := r.resetAdvanceCanaryStepAnnotation(ctx, dataplane); err != nil {
+			return ctrl.Result{}, false, err
+		}
+	}
+
+	old := dataplane.DeepCopy()
+	next := dataplane.Status.RolloutStatus.Canary
+	next.Step++
+	if int(next.Step) < len(canary.Steps) {
+		next.Weight = canary.Steps[next.Step].Weight
+	}
+	next.EffectiveWeight = 0
+	next.StepStartedAt = nil
+	if _, err := r.patchRolloutStatus(ctx, logger, old, dataplane); err != nil {
+		return ctrl.Result{}, false, fmt.Errorf("failed advancing Canary rollout: %w", err)
+	}
+	log.Debug(logger, "Canary rollout advanced", dataplane, "step", next.Step, "weight", next.Weight)
+	return ctrl.Result{}, false, nil // status update will trigger reconciliation
+}
+
+// ensureLiveServicesSelectPreview ensures that the live Ingress Service selects the Pods
+// of both the live and the preview Deployments by dropping the DataPlane's selector
+// from its selector. The live Admin API Service keeps selecting the live Pods only,
+// as the ControlPlane configures the preview Pods through the preview Admin API Service.
+// The DataPlane controller restores the selector once the preview is promoted.
+func (r *BlueGreenReconciler) ensureLiveServicesSelectPreview(
+	ctx context.Context,
+	dataplane *operatorv1beta1.DataPlane,
+) (updated bool, err error) {
+	services, err := k8sutils.ListServicesForOwner(
+		ctx,
+		r.Client,
+		dataplane.Namespace,
+		dataplane.UID,
+		client.MatchingLabels{
+			"app":                                dataplane.Name,
+			consts.DataPlaneServiceStateLabel:    consts.DataPlaneStateLabelValueLive,
+			consts.DataPlaneServiceTypeLabel:     string(consts.DataPlaneIngressServiceLabelValue),
+			consts.GatewayOperatorManagedByLabel: consts.DataPlaneManagedLabelValue,
+		},
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed listing live services for DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, err)
+	}
+
+	for _, svc := range services {
+		svc := svc
+		if _, ok := svc.Spec.Selector[consts.OperatorLabelSelector]; !ok {
+			continue
+		}
+
+		old := svc.DeepCopy()
+		delete(svc.Spec.Selector, consts.OperatorLabelSelector)
+		if err := r.Client.Patch(ctx, &svc, client.MergeFrom(old)); err != nil {
+			return false, fmt.Errorf("failed updating selector of live service %s: %w", svc.Name, err)
+		}
+		updated = true
+	}
+	return updated, nil
+}
+
+// ensureLiveDeploymentReplicas ensures that the live Deployment is scaled to the provided
+// number of replicas.
+func (r *BlueGreenReconciler) ensureLiveDeploymentReplicas(
+	ctx context.Context,
+	dataplane *operatorv1beta1.DataPlane,
+	replicas int32,
+) (updated bool, err error) {
+	deployments, err := k8sutils.ListDeploymentsForOwner(
+		ctx,
+		r.Client,
+		dataplane.Namespace,
+		dataplane.UID,
+		client.MatchingLabels{
+			"app":                                dataplane.Name,
+			consts.DataPlaneDeploymentStateLabel: consts.DataPlaneStateLabelValueLive,
+			consts.OperatorLabelSelector:         dataplane.Status.Selector,
+		},
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed listing live deployments for DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, err)
+	}
+
+	for _, deployment := range deployments {
+		deployment := deployment
+		if deployment.Spec.Replicas != nil && *deployment.Spec.Replicas == replicas {
+			continue
+		}
+
+		old := deployment.DeepCopy()
+		deployment.Spec.Replicas = lo.ToPtr(replicas)
+		if err := r.Client.Patch(ctx, &deployment, client.MergeFrom(old)); err != nil {
+			return false, fmt.Errorf("failed scaling live deployment %s: %w", deployment.Name, err)
+		}
+		updated = true
+	}
+	return updated, nil
+}
+
+// resetAdvanceCanaryStepAnnotation resets advance-canary-step DataPlane annotation.
+// This prevents the Canary rollout from unintentionally advancing more than one step.
+func (r *BlueGreenReconciler) resetAdvanceCanaryStepAnnotation(
+	ctx context.Context,
+	dataplane *operatorv1beta1.DataPlane,
+) error {
+	oldDp := dataplane.DeepCopy()
+	delete(dataplane.Annotations, operatorv1beta1.DataPlaneAdvanceCanaryStepAnnotationKey)
+	if err := r.Client.Patch(ctx, dataplane, client.MergeFrom(oldDp)); err != nil {
+		return fmt.Errorf("failed resetting advance-canary-step annotation: %w", err)
+	}
+	return nil
+}
+
+// resetRolloutProgressStatus removes the Canary rollout progress and the analysis
+// result from DataPlane's rollout status so that the Canary rollout starts over
+// from its first step and the preview resources get analyzed again.
+func (r *BlueGreenReconciler) resetRolloutProgressStatus(
+	ctx context.Context,
+	dataplane *operatorv1beta1.DataPlane,
+) error {
+	if extractRolloutStatusCanary(dataplane) == nil && extractRolloutStatusAnalysis(dataplane) == nil {
+		return nil
+	}
+
+	old := dataplane.DeepCopy()
+	dataplane.Status.RolloutStatus.Canary = nil
+	dataplane.Status.RolloutStatus.Analysis = nil
+	return r.Client.Status().Patch(ctx, dataplane, client.MergeFrom(old))
+}
+
+// ensurePreviewAnalyzed runs the analysis of the preview resources, if configured,
+// and records its progress in DataPlane's rollout status. The analysis checks are
+// run once per call, the configured number of times and interval apart, until the
+// analysis completes. It returns true when the status has been updated along with
+// the duration after which the next run of the checks is due, if any.
+// The analysis runs once per DataPlane's generation.
+func (r *BlueGreenReconciler) ensurePreviewAnalyzed(
+	ctx context.Context,
+	logger logr.Logger,
+	dataplane *operatorv1beta1.DataPlane,
+	adminService *corev1.Service,
+	ingressService *corev1.Service,
+) (updated bool, requeueAfter time.Duration, err error) {
+	blueGreen := dataplane.Spec.Deployment.Rollout.Strategy.BlueGreen
+	if blueGreen == nil || blueGreen.Analysis == nil {
+		return false, 0, nil
+	}
+
+	status := &operatorv1beta1.DataPlaneRolloutStatusAnalysis{
+		ObservedGeneration: dataplane.Generation,
+	}
+	if existing := extractRolloutStatusAnalysis(dataplane); existing != nil && existing.ObservedGeneration == dataplane.Generation {
+		if existing.Completed {
+			return false, 0, nil
+		}
+		if d := analysis.NextRunAfter(*blueGreen.Analysis, existing, time.Now()); d > 0 {
+			return false, d, nil
+		}
+		status = existing.DeepCopy()
+	}
+
+	target := analysis.Target{
+		IngressHost:  serviceHost(ingressService),
+		IngressPort:  consts.DefaultHTTPPort,
+		AdminAPIHost: serviceHost(adminService),
+	}
+	if len(ingressService.Spec.Ports) > 0 {
+		target.IngressPort = ingressService.Spec.Ports[0].Port
+	}
+
+	log.Debug(logger, "analyzing preview resources", dataplane, "run", status.Runs+1)
+	analysisErr := analysis.NewAnalyzer(r.AnalysisHTTPClient).Run(ctx, *blueGreen.Analysis, target)
+	if analysisErr != nil {
+		log.Info(logger, "run of the analysis of preview resources failed", dataplane, "reason", analysisErr.Error())
+	}
+	analysis.Record(*blueGreen.Analysis, status, analysisErr, time.Now())
+	if status.Completed && !status.Passed {
+		log.Info(logger, "analysis of preview resources failed", dataplane, "failed_runs", status.Failures)
+	}
+
+	old := dataplane.DeepCopy()
+	dataplane = initDataPlaneStatusRollout(dataplane)
+	dataplane.Status.RolloutStatus.Analysis = status
+	if _, err := r.patchRolloutStatus(ctx, logger, old, dataplane); err != nil {
+		return false, 0, fmt.Errorf("failed updating rollout status with analysis progress: %w", err)
+	}
+	if !status.Completed {
+		requeueAfter = analysis.NextRunAfter(*blueGreen.Analysis, status, time.Now())
+	}
+	return true, requeueAfter, nil
+}
+
+// rollbackPreviewDeployment rolls the preview Deployment's Pod template back
+// to the live Deployment's one. The preview Pods keep their labels and
+// the DataPlane's certificate.
+func (r *BlueGreenReconciler) rollbackPreviewDeployment(
+	ctx context.Context,
+	logger logr.Logger,
+	dataplane *operatorv1beta1.DataPlane,
+) error {
+	liveDeployments, err := k8sutils.ListDeploymentsForOwner(
+		ctx,
+		r.Client,
+		dataplane.Namespace,
+		dataplane.UID,
+		client.MatchingLabels{
+			"app":                                dataplane.Name,
+			consts.DataPlaneDeploymentStateLabel: consts.DataPlaneStateLabelValueLive,
+			consts.OperatorLabelSelector:         dataplane.Status.Selector,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed listing live deployments for DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, err)
+	}
+	if len(liveDeployments) == 0 {
+		return fmt.Errorf("no live deployments found for DataPlane %s/%s", dataplane.Namespace, dataplane.Name)
+	}
+
+	previewDeployments, err := k8sutils.ListDeploymentsForOwner(
+		ctx,
+		r.Client,
+		dataplane.Namespace,
+		dataplane.UID,
+		client.MatchingLabels{
+			"app":                                dataplane.Name,
+			consts.DataPlaneDeploymentStateLabel: consts.DataPlaneStateLabelValuePreview,
+			consts.OperatorLabelSelector:         getRolloutLabelSelectorFromDataPlane(dataplane),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed listing preview deployments for DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, err)
+	}
+
+	for _, deployment := range previewDeployments {
+		deployment := deployment
+		template := liveDeployments[0].Spec.Template.DeepCopy()
+		template.Labels = deployment.Spec.Template.Labels
+		if certVolume, ok := lo.Find(deployment.Spec.Template.Spec.Volumes, func(v corev1.Volume) bool {
+			return v.Name == consts.ClusterCertificateVolume
+		}); ok {
+			for i := range template.Spec.Volumes {
+				if template.Spec.Volumes[i].Name == consts.ClusterCertificateVolume {
+					template.Spec.Volumes[i] = certVolume
+				}
+			}
+		}
+		if equality.Semantic.DeepEqual(deployment.Spec.Template, *template) {
+			continue
+		}
+
+		old := deployment.DeepCopy()
+		deployment.Spec.Template = *template
+		if err := r.Client.Patch(ctx, &deployment, client.MergeFrom(old)); err != nil {
+			return fmt.Errorf("failed rolling back preview deployment %s: %w", deployment.Name, err)
+		}
+		log.Debug(logger, "preview deployment rolled back to live", dataplane, "deployment", deployment.Name)
+	}
+	return nil
+}
+
+// serviceHost returns the in-cluster DNS name of the provided Service.
+func serviceHost(svc *corev1.Service) string {
+	return fmt.Sprintf("%s.%s.svc", svc.Name, svc.Namespace)
+}
+
+// canaryWeight returns the weight of the Canary step the DataPlane's rollout is at.
+func canaryWeight(dataplane *operatorv1beta1.DataPlane) int32 {
+	if status := extractRolloutStatusCanary(dataplane); status != nil {
+		return status.Weight
+	}
+	if steps := dataplane.Spec.Deployment.Rollout.Strategy.Canary.Steps; len(steps) > 0 {
+		return steps[0].Weight
+	}
+	return 0
+}
+
+// canaryReplicas returns the number of replicas of the preview and the live
+// Deployments for the provided Canary weight.
+// Both Deployments run at least 1 replica until the preview is promoted.
+func canaryReplicas(dataplane *operatorv1beta1.DataPlane, weight int32) (preview int32, live int32) {
+	total := int32(1)
+	if replicas := dataplane.Spec.Deployment.Replicas; replicas != nil && *replicas > 0 {
+		total = *replicas
+	}
+	preview = int32(math.Round(float64(total) * float64(weight) / 100))
+	preview = min(max(preview, 1), total)
+	return preview, max(total-preview, 1)
+}
+
+// canaryEffectiveWeight returns the percentage of traffic handled by the preview
+// Deployment when the provided numbers of preview and live replicas are running.
+func canaryEffectiveWeight(preview, live int32) int32 {
+	return int32(math.Round(float64(preview) * 100 / float64(preview+live)))
+}
+
+// rolloutResourcePlanDeployment returns the Deployment resource plan of the DataPlane's
+// rollout strategy. Canary rollouts always scale the preview Deployment down on promotion.
+func rolloutResourcePlanDeployment(dataplane *operatorv1beta1.DataPlane) operatorv1beta1.RolloutResourcePlanDeployment {
+	if blueGreen := dataplane.Spec.Deployment.Rollout.Strategy.BlueGreen; blueGreen != nil {
+		return blueGreen.Resources.Plan.Deployment
+	}
+	return operatorv1beta1.RolloutResourcePlanDeploymentScaleDownOnPromotionScaleUpOnRollout
+}
+
 // -------------------------------------------------------------------------------
 // utility functions to operate pointer fields in rollout status of dataplane
 // TODO: find a method to automatically generate the extract* and init* functions
@@ -965,6 +1484,20 @@ func extractRolloutStatusServiceIngress(dataplane *operatorv1beta1.DataPlane) *o
 	return dataplane.Status.RolloutStatus.Services.Ingress
 }
 
+func extractRolloutStatusCanary(dataplane *operatorv1beta1.DataPlane) *operatorv1beta1.DataPlaneRolloutStatusCanary {
+	if dataplane.Status.RolloutStatus == nil {
+		return nil
+	}
+	return dataplane.Status.RolloutStatus.Canary
+}
+
+func extractRolloutStatusAnalysis(dataplane *operatorv1beta1.DataPlane) *operatorv1beta1.DataPlaneRolloutStatusAnalysis {
+	if dataplane.Status.RolloutStatus == nil {
+		return nil
+	}
+	return dataplane.Status.RolloutStatus.Analysis
+}
+
 func initDataPlaneStatusRollout(dataplane *operatorv1beta1.DataPlane) *operatorv1beta1.DataPlane {
 	if dataplane.Status.RolloutStatus == nil {
 		dataplane.Status.RolloutStatus = &operatorv1beta1.DataPlaneRolloutStatus{}
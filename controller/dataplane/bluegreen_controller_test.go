@@ -4,8 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
@@ -19,6 +23,7 @@ import (
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -419,6 +424,15 @@ func TestCanProceedWithPromotion(t *testing.T) {
 				Build(),
 			expectedCanProceed: true,
 		},
+		{
+			name: "Canary strategy",
+			dataplane: *builder.NewDataPlaneBuilder().
+				WithCanaryStrategy(operatorv1beta1.CanaryStrategy{
+					Steps: []operatorv1beta1.CanaryStep{{Weight: 50}},
+				}).
+				Build(),
+			expectedCanProceed: true,
+		},
 		{
 			name: "unknown strategy",
 			dataplane: *builder.NewDataPlaneBuilder().
@@ -443,6 +457,492 @@ func TestCanProceedWithPromotion(t *testing.T) {
 	}
 }
 
+func TestCanaryReplicas(t *testing.T) {
+	testCases := []struct {
+		name            string
+		replicas        *int32
+		weight          int32
+		expectedPreview int32
+		expectedLive    int32
+		expectedWeight  int32
+	}{
+		{
+			name:            "replicas unset",
+			weight:          50,
+			expectedPreview: 1,
+			expectedLive:    1,
+			expectedWeight:  50,
+		},
+		{
+			name:            "replicas unset splits the traffic evenly whatever the weight",
+			weight:          10,
+			expectedPreview: 1,
+			expectedLive:    1,
+			expectedWeight:  50,
+		},
+		{
+			name:            "weight split across replicas",
+			replicas:        lo.ToPtr(int32(4)),
+			weight:          25,
+			expectedPreview: 1,
+			expectedLive:    3,
+			expectedWeight:  25,
+		},
+		{
+			name:            "weight rounded to the nearest replica",
+			replicas:        lo.ToPtr(int32(10)),
+			weight:          33,
+			expectedPreview: 3,
+			expectedLive:    7,
+			expectedWeight:  30,
+		},
+		{
+			name:            "preview runs at least 1 replica",
+			replicas:        lo.ToPtr(int32(10)),
+			weight:          1,
+			expectedPreview: 1,
+			expectedLive:    9,
+			expectedWeight:  10,
+		},
+		{
+			name:            "live runs at least 1 replica until promotion",
+			replicas:        lo.ToPtr(int32(4)),
+			weight:          100,
+			expectedPreview: 4,
+			expectedLive:    1,
+			expectedWeight:  80,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dp := builder.NewDataPlaneBuilder().Build()
+			dp.Spec.Deployment.Replicas = tc.replicas
+
+			preview, live := canaryReplicas(dp, tc.weight)
+			require.Equal(t, tc.expectedPreview, preview)
+			require.Equal(t, tc.expectedLive, live)
+			require.Equal(t, tc.expectedWeight, canaryEffectiveWeight(preview, live))
+		})
+	}
+}
+
+func TestEnsureCanaryStepsCompleted(t *testing.T) {
+	const liveSelector = "live-selector"
+	ctx := context.Background()
+
+	dp := builder.NewDataPlaneBuilder().
+		WithObjectMeta(metav1.ObjectMeta{
+			Namespace:  "default",
+			Name:       "dp-canary",
+			UID:        types.UID(uuid.NewString()),
+			Generation: 2,
+		}).
+		WithCanaryStrategy(operatorv1beta1.CanaryStrategy{
+			Steps: []operatorv1beta1.CanaryStep{
+				{Weight: 25, Pause: &metav1.Duration{Duration: time.Hour}},
+				{Weight: 50},
+			},
+			Advancement: operatorv1beta1.CanaryAdvancementManual,
+		}).
+		Build()
+	dp.Spec.Deployment.Replicas = lo.ToPtr(int32(4))
+	dp.Status.Selector = liveSelector
+	dp.Status.RolloutStatus = &operatorv1beta1.DataPlaneRolloutStatus{
+		Deployment: &operatorv1beta1.DataPlaneRolloutStatusDeployment{
+			Selector: "preview-selector",
+		},
+	}
+
+	liveLabels := func(stateLabel string, extra map[string]string) map[string]string {
+		l := map[string]string{
+			"app":                                dp.Name,
+			stateLabel:                           consts.DataPlaneStateLabelValueLive,
+			consts.GatewayOperatorManagedByLabel: consts.DataPlaneManagedLabelValue,
+		}
+		for k, v := range extra {
+			l[k] = v
+		}
+		return l
+	}
+	liveSvcSelector := map[string]string{
+		"app":                        dp.Name,
+		consts.OperatorLabelSelector: liveSelector,
+	}
+	ingressSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: dp.Namespace,
+			Name:      "dataplane-ingress-dp-canary",
+			Labels: liveLabels(consts.DataPlaneServiceStateLabel, map[string]string{
+				consts.DataPlaneServiceTypeLabel: string(consts.DataPlaneIngressServiceLabelValue),
+			}),
+		},
+		Spec: corev1.ServiceSpec{Selector: lo.Assign(liveSvcSelector)},
+	}
+	adminSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: dp.Namespace,
+			Name:      "dataplane-admin-dp-canary",
+			Labels: liveLabels(consts.DataPlaneServiceStateLabel, map[string]string{
+				consts.DataPlaneServiceTypeLabel: string(consts.DataPlaneAdminServiceLabelValue),
+			}),
+		},
+		Spec: corev1.ServiceSpec{Selector: lo.Assign(liveSvcSelector)},
+	}
+	liveDeployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: dp.Namespace,
+			Name:      "dataplane-dp-canary",
+			Labels: liveLabels(consts.DataPlaneDeploymentStateLabel, map[string]string{
+				consts.OperatorLabelSelector: liveSelector,
+			}),
+		},
+		Spec: appsv1.DeploymentSpec{Replicas: lo.ToPtr(int32(4))},
+	}
+	for _, o := range []client.Object{ingressSvc, adminSvc, liveDeployment} {
+		k8sutils.SetOwnerForObject(o, dp)
+	}
+
+	fakeClient := fakectrlruntimeclient.
+		NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(dp, ingressSvc, adminSvc, liveDeployment).
+		WithStatusSubresource(dp).
+		Build()
+	reconciler := BlueGreenReconciler{
+		Client: fakeClient,
+	}
+
+	ensure := func(t *testing.T) (ctrl.Result, bool) {
+		t.Helper()
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(dp), dp))
+		res, done, err := reconciler.ensureCanaryStepsCompleted(ctx, logr.Discard(), dp)
+		require.NoError(t, err)
+		return res, done
+	}
+	requireRolledOutReason := func(t *testing.T, reason k8sutils.ConditionReason) {
+		t.Helper()
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(dp), dp))
+		c, ok := k8sutils.GetCondition(consts.DataPlaneConditionTypeRolledOut, dp.Status.RolloutStatus)
+		require.True(t, ok)
+		require.EqualValues(t, reason, c.Reason)
+	}
+	annotateAdvance := func(t *testing.T) {
+		t.Helper()
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(dp), dp))
+		old := dp.DeepCopy()
+		dp.Annotations = map[string]string{
+			operatorv1beta1.DataPlaneAdvanceCanaryStepAnnotationKey: operatorv1beta1.DataPlaneAdvanceCanaryStepAnnotationTrue,
+		}
+		require.NoError(t, fakeClient.Patch(ctx, dp, client.MergeFrom(old)))
+	}
+
+	t.Log("initializing the Canary rollout status at the first step")
+	_, done := ensure(t)
+	require.False(t, done)
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(dp), dp))
+	require.NotNil(t, dp.Status.RolloutStatus.Canary)
+	require.EqualValues(t, 0, dp.Status.RolloutStatus.Canary.Step)
+	require.EqualValues(t, 25, dp.Status.RolloutStatus.Canary.Weight)
+
+	t.Log("making the live ingress Service select the preview Deployment")
+	_, done = ensure(t)
+	require.False(t, done)
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(ingressSvc), ingressSvc))
+	require.Equal(t, map[string]string{"app": dp.Name}, ingressSvc.Spec.Selector)
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(adminSvc), adminSvc))
+	require.Equal(t, liveSvcSelector, adminSvc.Spec.Selector, "live Admin API Service should keep selecting the live Pods")
+
+	t.Log("scaling the live Deployment down according to the step weight")
+	_, done = ensure(t)
+	require.False(t, done)
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(liveDeployment), liveDeployment))
+	require.EqualValues(t, 3, *liveDeployment.Spec.Replicas)
+
+	t.Log("starting the step's pause")
+	_, done = ensure(t)
+	require.False(t, done)
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(dp), dp))
+	require.NotNil(t, dp.Status.RolloutStatus.Canary.StepStartedAt)
+	require.EqualValues(t, 25, dp.Status.RolloutStatus.Canary.EffectiveWeight)
+
+	res, done := ensure(t)
+	require.False(t, done)
+	require.Greater(t, res.RequeueAfter, time.Duration(0))
+	require.LessOrEqual(t, res.RequeueAfter, time.Hour)
+	requireRolledOutReason(t, consts.DataPlaneConditionReasonRolloutProgressing)
+
+	t.Log("awaiting the manual advancement once the pause elapsed")
+	old := dp.DeepCopy()
+	dp.Status.RolloutStatus.Canary.StepStartedAt = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+	require.NoError(t, fakeClient.Status().Patch(ctx, dp, client.MergeFrom(old)))
+	_, done = ensure(t)
+	require.False(t, done)
+	requireRolledOutReason(t, consts.DataPlaneConditionReasonRolloutAwaitingAdvancement)
+
+	t.Log("advancing to the next step once the DataPlane is annotated")
+	annotateAdvance(t)
+	_, done = ensure(t)
+	require.False(t, done)
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(dp), dp))
+	require.NotContains(t, dp.Annotations, operatorv1beta1.DataPlaneAdvanceCanaryStepAnnotationKey)
+	require.EqualValues(t, 1, dp.Status.RolloutStatus.Canary.Step)
+	require.EqualValues(t, 50, dp.Status.RolloutStatus.Canary.Weight)
+	require.Nil(t, dp.Status.RolloutStatus.Canary.StepStartedAt)
+
+	t.Log("scaling the live Deployment down according to the next step weight")
+	_, done = ensure(t)
+	require.False(t, done)
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(liveDeployment), liveDeployment))
+	require.EqualValues(t, 2, *liveDeployment.Spec.Replicas)
+
+	t.Log("awaiting the manual advancement of the last step which has no pause")
+	_, done = ensure(t)
+	require.False(t, done)
+	_, done = ensure(t)
+	require.False(t, done)
+	requireRolledOutReason(t, consts.DataPlaneConditionReasonRolloutAwaitingAdvancement)
+
+	t.Log("completing the Canary steps")
+	annotateAdvance(t)
+	_, done = ensure(t)
+	require.False(t, done)
+	_, done = ensure(t)
+	require.True(t, done)
+	require.EqualValues(t, 2, dp.Status.RolloutStatus.Canary.Step)
+}
+
+// stubAnalysisHTTPClient responds to the requests of the rollout analysis
+// checks with the configured status code.
+type stubAnalysisHTTPClient struct {
+	statusCode int
+	urls       []string
+}
+
+func (c *stubAnalysisHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	c.urls = append(c.urls, req.URL.String())
+	return &http.Response{StatusCode: c.statusCode, Body: io.NopCloser(strings.NewReader(""))}, nil
+}
+
+func TestPreviewAnalysis(t *testing.T) {
+	const (
+		liveSelector    = "live-selector"
+		previewSelector = "preview-selector"
+	)
+	ctx := context.Background()
+
+	dp := builder.NewDataPlaneBuilder().
+		WithObjectMeta(metav1.ObjectMeta{
+			Namespace:  "default",
+			Name:       "dp-analysis",
+			UID:        types.UID(uuid.NewString()),
+			Generation: 2,
+		}).
+		WithPromotionStrategy(operatorv1beta1.AutomaticPromotion).
+		WithRolloutAnalysis(operatorv1beta1.RolloutAnalysis{
+			Interval:         &metav1.Duration{Duration: time.Minute},
+			Count:            2,
+			FailureThreshold: 2,
+			HTTPChecks: []operatorv1beta1.RolloutHTTPCheck{
+				{Name: "ingress", Path: "/echo"},
+				{Name: "status", Service: operatorv1beta1.RolloutAnalysisServiceAdminAPI, Path: "/status"},
+			},
+		}).
+		Build()
+	dp.Status.Selector = liveSelector
+	dp.Status.RolloutStatus = &operatorv1beta1.DataPlaneRolloutStatus{
+		Deployment: &operatorv1beta1.DataPlaneRolloutStatusDeployment{
+			Selector: previewSelector,
+		},
+	}
+
+	ingressSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: dp.Namespace, Name: "dataplane-ingress-preview"},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{Name: "http", Port: 8080}},
+		},
+	}
+	adminSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: dp.Namespace, Name: "dataplane-admin-preview"},
+	}
+
+	deployment := func(name, state, selector, image, certSecret string) *appsv1.Deployment {
+		d := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: dp.Namespace,
+				Name:      name,
+				Labels: map[string]string{
+					"app":                                dp.Name,
+					consts.DataPlaneDeploymentStateLabel: state,
+					consts.OperatorLabelSelector:         selector,
+				},
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: lo.ToPtr(int32(1)),
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							"app":                        dp.Name,
+							consts.OperatorLabelSelector: selector,
+						},
+					},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: consts.DataPlaneProxyContainerName, Image: image}},
+						Volumes: []corev1.Volume{
+							{
+								Name: consts.ClusterCertificateVolume,
+								VolumeSource: corev1.VolumeSource{
+									Secret: &corev1.SecretVolumeSource{SecretName: certSecret},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		k8sutils.SetOwnerForObject(d, dp)
+		return d
+	}
+
+	setup := func(t *testing.T, statusCode int) (*BlueGreenReconciler, *stubAnalysisHTTPClient) {
+		httpClient := &stubAnalysisHTTPClient{statusCode: statusCode}
+		fakeClient := fakectrlruntimeclient.
+			NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithObjects(
+				dp.DeepCopy(),
+				deployment("dataplane-live", consts.DataPlaneStateLabelValueLive, liveSelector, "kong:3.6", "live-cert"),
+				deployment("dataplane-preview", consts.DataPlaneStateLabelValuePreview, previewSelector, "kong:3.7", "preview-cert"),
+			).
+			WithStatusSubresource(dp).
+			Build()
+		return &BlueGreenReconciler{
+			Client:             fakeClient,
+			AnalysisHTTPClient: httpClient,
+		}, httpClient
+	}
+
+	// elapseInterval moves the last run of the analysis one interval back
+	// so that the next run is due.
+	elapseInterval := func(t *testing.T, reconciler *BlueGreenReconciler, got *operatorv1beta1.DataPlane) {
+		old := got.DeepCopy()
+		got.Status.RolloutStatus.Analysis.LastRunTime = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+		require.NoError(t, reconciler.Status().Patch(ctx, got, client.MergeFrom(old)))
+	}
+
+	t.Run("passing analysis runs count times per generation", func(t *testing.T) {
+		reconciler, httpClient := setup(t, http.StatusOK)
+		got := &operatorv1beta1.DataPlane{}
+		require.NoError(t, reconciler.Get(ctx, client.ObjectKeyFromObject(dp), got))
+
+		updated, requeueAfter, err := reconciler.ensurePreviewAnalyzed(ctx, logr.Discard(), got, adminSvc, ingressSvc)
+		require.NoError(t, err)
+		require.True(t, updated)
+		require.InDelta(t, time.Minute, requeueAfter, float64(time.Second))
+		require.Equal(t, []string{
+			"http://dataplane-ingress-preview.default.svc:8080/echo",
+			"http://dataplane-admin-preview.default.svc:8100/status",
+		}, httpClient.urls)
+
+		require.NoError(t, reconciler.Get(ctx, client.ObjectKeyFromObject(dp), got))
+		status := got.Status.RolloutStatus.Analysis
+		require.NotNil(t, status)
+		require.EqualValues(t, 2, status.ObservedGeneration)
+		require.EqualValues(t, 1, status.Runs)
+		require.NotNil(t, status.LastRunTime)
+		require.False(t, status.Completed)
+		require.False(t, status.Passed)
+
+		t.Log("verifying that the checks are not run again before the interval elapses")
+		updated, requeueAfter, err = reconciler.ensurePreviewAnalyzed(ctx, logr.Discard(), got, adminSvc, ingressSvc)
+		require.NoError(t, err)
+		require.False(t, updated)
+		require.Positive(t, requeueAfter)
+		require.Len(t, httpClient.urls, 2)
+
+		elapseInterval(t, reconciler, got)
+		updated, requeueAfter, err = reconciler.ensurePreviewAnalyzed(ctx, logr.Discard(), got, adminSvc, ingressSvc)
+		require.NoError(t, err)
+		require.True(t, updated)
+		require.Zero(t, requeueAfter)
+		require.Len(t, httpClient.urls, 4)
+
+		require.NoError(t, reconciler.Get(ctx, client.ObjectKeyFromObject(dp), got))
+		status = got.Status.RolloutStatus.Analysis
+		require.EqualValues(t, 2, status.Runs)
+		require.Zero(t, status.Failures)
+		require.True(t, status.Completed)
+		require.True(t, status.Passed)
+
+		updated, requeueAfter, err = reconciler.ensurePreviewAnalyzed(ctx, logr.Discard(), got, adminSvc, ingressSvc)
+		require.NoError(t, err)
+		require.False(t, updated)
+		require.Zero(t, requeueAfter)
+		require.Len(t, httpClient.urls, 4)
+	})
+
+	t.Run("failed runs below the failure threshold do not fail the analysis", func(t *testing.T) {
+		reconciler, httpClient := setup(t, http.StatusInternalServerError)
+		got := &operatorv1beta1.DataPlane{}
+		require.NoError(t, reconciler.Get(ctx, client.ObjectKeyFromObject(dp), got))
+
+		_, _, err := reconciler.ensurePreviewAnalyzed(ctx, logr.Discard(), got, adminSvc, ingressSvc)
+		require.NoError(t, err)
+		require.NoError(t, reconciler.Get(ctx, client.ObjectKeyFromObject(dp), got))
+		require.EqualValues(t, 1, got.Status.RolloutStatus.Analysis.Failures)
+		require.False(t, got.Status.RolloutStatus.Analysis.Completed)
+
+		httpClient.statusCode = http.StatusOK
+		elapseInterval(t, reconciler, got)
+		_, _, err = reconciler.ensurePreviewAnalyzed(ctx, logr.Discard(), got, adminSvc, ingressSvc)
+		require.NoError(t, err)
+		require.NoError(t, reconciler.Get(ctx, client.ObjectKeyFromObject(dp), got))
+		status := got.Status.RolloutStatus.Analysis
+		require.EqualValues(t, 2, status.Runs)
+		require.EqualValues(t, 1, status.Failures)
+		require.True(t, status.Completed)
+		require.True(t, status.Passed)
+	})
+
+	t.Run("failing analysis rolls the preview deployment back", func(t *testing.T) {
+		reconciler, _ := setup(t, http.StatusInternalServerError)
+		got := &operatorv1beta1.DataPlane{}
+		require.NoError(t, reconciler.Get(ctx, client.ObjectKeyFromObject(dp), got))
+
+		for i := 0; i < 2; i++ {
+			updated, _, err := reconciler.ensurePreviewAnalyzed(ctx, logr.Discard(), got, adminSvc, ingressSvc)
+			require.NoError(t, err)
+			require.True(t, updated)
+			require.NoError(t, reconciler.Get(ctx, client.ObjectKeyFromObject(dp), got))
+			if !got.Status.RolloutStatus.Analysis.Completed {
+				elapseInterval(t, reconciler, got)
+			}
+		}
+
+		status := got.Status.RolloutStatus.Analysis
+		require.NotNil(t, status)
+		require.True(t, status.Completed)
+		require.False(t, status.Passed)
+		require.EqualValues(t, 2, status.Failures)
+		require.EqualValues(t, 2, status.ObservedGeneration)
+		require.Contains(t, status.Message, `HTTP check "ingress"`)
+		require.Contains(t, status.Message, `HTTP check "status"`)
+
+		require.NoError(t, reconciler.rollbackPreviewDeployment(ctx, logr.Discard(), got))
+		preview := &appsv1.Deployment{}
+		require.NoError(t, reconciler.Get(ctx, types.NamespacedName{Namespace: dp.Namespace, Name: "dataplane-preview"}, preview))
+		require.Equal(t, "kong:3.6", preview.Spec.Template.Spec.Containers[0].Image)
+		require.Equal(t, previewSelector, preview.Spec.Template.Labels[consts.OperatorLabelSelector])
+		require.Equal(t, "preview-cert", preview.Spec.Template.Spec.Volumes[0].Secret.SecretName)
+
+		t.Log("verifying that the analysis result is reset when the DataPlane's generation changes")
+		require.NoError(t, reconciler.resetRolloutProgressStatus(ctx, got))
+		require.NoError(t, reconciler.Get(ctx, client.ObjectKeyFromObject(dp), got))
+		require.Nil(t, got.Status.RolloutStatus.Analysis)
+	})
+}
+
 func TestEnsurePreviewIngressService(t *testing.T) {
 	testCases := []struct {
 		name                     string
@@ -558,6 +1058,56 @@ func TestEnsurePreviewIngressService(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "existing service has addresses of the live service, should remove them",
+			dataplane: func() *operatorv1beta1.DataPlane {
+				dp := builder.NewDataPlaneBuilder().WithObjectMeta(
+					metav1.ObjectMeta{Namespace: "default", Name: "dp-2"},
+				).WithIngressServiceType(corev1.ServiceTypeLoadBalancer).
+					WithIngressServiceAnnotations(map[string]string{
+						consts.ExternalDNSHostnameAnnotation: "gw.example.com",
+						"foo":                                "bar",
+					}).
+					WithPromotionStrategy(operatorv1beta1.AutomaticPromotion).Build()
+				dp.Spec.Network.Services.Ingress.LoadBalancerIP = "10.0.0.1"
+				dp.Spec.Network.Services.Ingress.ExternalIPs = []string{"10.0.0.2"}
+				return dp
+			}(),
+			existingServiceModifier: func(t *testing.T, ctx context.Context, cl client.Client, svc *corev1.Service) {
+				require.Equal(t, "10.0.0.1", svc.Spec.LoadBalancerIP) //nolint:staticcheck
+				require.Equal(t, []string{"10.0.0.2"}, svc.Spec.ExternalIPs)
+				svc.Annotations = map[string]string{
+					consts.ExternalDNSHostnameAnnotation:    "gw.example.com",
+					"foo":                                   "bar",
+					consts.AnnotationLastAppliedAnnotations: `{"external-dns.alpha.kubernetes.io/hostname":"gw.example.com","foo":"bar"}`,
+				}
+				require.NoError(t, cl.Update(ctx, svc))
+			},
+			expectedCreatedOrUpdated: op.Updated,
+			expectedService: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:    "default",
+					GenerateName: "dataplane-ingress-dp-2-",
+					Labels: map[string]string{
+						"app":                                "dp-2",
+						consts.GatewayOperatorManagedByLabel: consts.DataPlaneManagedLabelValue,
+						consts.GatewayOperatorManagedByLabelLegacy: consts.DataPlaneManagedLabelValue,
+						consts.DataPlaneServiceTypeLabel:           string(consts.DataPlaneIngressServiceLabelValue),
+						consts.DataPlaneServiceStateLabel:          consts.DataPlaneStateLabelValuePreview,
+					},
+					Annotations: map[string]string{
+						"foo":                                   "bar",
+						consts.AnnotationLastAppliedAnnotations: `{"foo":"bar"}`,
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeLoadBalancer,
+					Selector: map[string]string{
+						"app": "dp-2",
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -605,6 +1155,8 @@ func TestEnsurePreviewIngressService(t *testing.T) {
 			assert.Equal(t, tc.expectedService.Annotations, svc.Annotations, "should have expected annotations")
 			assert.Equal(t, tc.expectedService.Spec.Type, svc.Spec.Type, "should have expected service type")
 			assert.Equal(t, tc.expectedService.Spec.Selector, svc.Spec.Selector, "should have expected selectors")
+			assert.Equal(t, tc.expectedService.Spec.LoadBalancerIP, svc.Spec.LoadBalancerIP, "should have expected load balancer IP") //nolint:staticcheck
+			assert.Equal(t, tc.expectedService.Spec.ExternalIPs, svc.Spec.ExternalIPs, "should have expected external IPs")
 		})
 	}
 }
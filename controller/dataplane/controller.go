@@ -2,7 +2,9 @@ package dataplane
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
@@ -18,6 +20,7 @@ import (
 	"github.com/kong/gateway-operator/controller/pkg/ctxinjector"
 	"github.com/kong/gateway-operator/controller/pkg/log"
 	"github.com/kong/gateway-operator/controller/pkg/op"
+	"github.com/kong/gateway-operator/controller/pkg/secrets"
 	"github.com/kong/gateway-operator/pkg/consts"
 	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
 	k8sresources "github.com/kong/gateway-operator/pkg/utils/kubernetes/resources"
@@ -43,13 +46,26 @@ type Reconciler struct {
 	Callbacks                DataPlaneCallbacks
 	ContextInjector          ctxinjector.CtxInjector
 	DefaultImage             string
+
+	// ClusterCertificateRenewBefore is the duration before the expiry of
+	// the DataPlane's certificate at which it gets reissued.
+	ClusterCertificateRenewBefore time.Duration
+	// CertificateIssuer is the issuer of the DataPlane's certificate used
+	// unless the DataPlane configures its own.
+	CertificateIssuer operatorv1beta1.CertificateIssuer
+	// ClusterCertificateKeyType is the type of the private key generated for
+	// the DataPlane's certificate.
+	ClusterCertificateKeyType secrets.KeyType
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.eventRecorder = mgr.GetEventRecorderFor("dataplane")
 
-	return DataPlaneWatchBuilder(mgr).
+	return DataPlaneWatchBuilder(mgr, types.NamespacedName{
+		Namespace: r.ClusterCASecretNamespace,
+		Name:      r.ClusterCASecretName,
+	}).
 		Complete(r)
 }
 
@@ -146,11 +162,19 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 			Namespace: r.ClusterCASecretNamespace,
 			Name:      r.ClusterCASecretName,
 		},
+		r.CertificateIssuer,
 		types.NamespacedName{
 			Namespace: dataplaneAdminService.Namespace,
 			Name:      dataplaneAdminService.Name,
 		},
+		secrets.WithRenewBefore(r.ClusterCertificateRenewBefore),
+		secrets.WithEventRecorder(r.eventRecorder),
+		secrets.WithKeyType(r.ClusterCertificateKeyType),
 	)
+	if errors.Is(err, secrets.ErrCertificatePending) {
+		log.Debug(logger, "waiting for mTLS certificate to be issued", dataplane)
+		return ctrl.Result{RequeueAfter: secrets.CertificatePendingRequeueAfter}, nil
+	}
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -159,6 +183,25 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, nil // requeue will be triggered by the creation or update of the owned object
 	}
 
+	log.Trace(logger, "ensuring Konnect client certificate", dataplane)
+	res, konnectCertSecret, err := ensureDataPlaneKonnectClientCertificate(ctx, r.Client, logger, dataplane,
+		r.ClusterCASecretNamespace,
+		r.ClusterCertificateRenewBefore,
+		r.ClusterCertificateKeyType,
+	)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if res != op.Noop {
+		log.Debug(logger, "Konnect client certificate created/updated", dataplane)
+		// cert-manager Certificates are not watched hence the explicit requeue.
+		return ctrl.Result{RequeueAfter: konnectClientCertificateRetryInterval}, nil
+	}
+	if dataplane.Spec.Network.KonnectCertificateOptions != nil && konnectCertSecret == nil {
+		log.Debug(logger, "waiting for Konnect client certificate to be issued", dataplane)
+		return ctrl.Result{RequeueAfter: konnectClientCertificateRetryInterval}, nil
+	}
+
 	log.Trace(logger, "checking readiness of DataPlane service", dataplaneIngressService)
 	if dataplaneIngressService.Spec.ClusterIP == "" {
 		return ctrl.Result{}, nil // no need to requeue, the update will trigger.
@@ -182,6 +225,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		WithBeforeCallbacks(r.Callbacks.BeforeDeployment).
 		WithAfterCallbacks(r.Callbacks.AfterDeployment).
 		WithClusterCertificate(certSecret.Name).
+		WithKonnectClientCertificate(konnectCertSecret).
 		WithOpts(deploymentOpts...).
 		WithDefaultImage(r.DefaultImage).
 		WithAdditionalLabels(deploymentLabels)
@@ -203,6 +247,14 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, nil
 	}
 
+	res, _, err = ensurePodDisruptionBudgetForDataPlane(ctx, r.Client, logger, dataplane)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if res != op.Noop {
+		return ctrl.Result{}, nil
+	}
+
 	if res, err := ensureDataPlaneReadyStatus(ctx, r.Client, logger, dataplane, dataplane.Generation); err != nil {
 		return ctrl.Result{}, err
 	} else if res.Requeue {
@@ -210,7 +262,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	}
 
 	log.Debug(logger, "reconciliation complete for DataPlane resource", dataplane)
-	return ctrl.Result{}, nil
+	// Requeue in order to renew the certificates before they expire.
+	requeueAfter := secrets.RenewalRequeueAfter(certSecret, r.ClusterCertificateRenewBefore)
+	if konnectCertSecret != nil {
+		requeueAfter = min(requeueAfter, konnectClientCertificateRequeueAfter(konnectCertSecret, r.ClusterCertificateRenewBefore))
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 func (r *Reconciler) initSelectorInStatus(ctx context.Context, logger logr.Logger, dataplane *operatorv1beta1.DataPlane) error {
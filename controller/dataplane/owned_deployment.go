@@ -2,6 +2,7 @@ package dataplane
 
 import (
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"path/filepath"
@@ -29,14 +30,15 @@ import (
 
 // DeploymentBuilder builds a Deployment for a DataPlane.
 type DeploymentBuilder struct {
-	clusterCertificateName string
-	beforeCallbacks        CallbackManager
-	afterCallbacks         CallbackManager
-	logger                 logr.Logger
-	client                 client.Client
-	additionalLabels       client.MatchingLabels
-	defaultImage           string
-	opts                   []k8sresources.DeploymentOpt
+	clusterCertificateName   string
+	konnectClientCertificate *corev1.Secret
+	beforeCallbacks          CallbackManager
+	afterCallbacks           CallbackManager
+	logger                   logr.Logger
+	client                   client.Client
+	additionalLabels         client.MatchingLabels
+	defaultImage             string
+	opts                     []k8sresources.DeploymentOpt
 }
 
 // NewDeploymentBuilder creates a DeploymentBuilder.
@@ -65,6 +67,14 @@ func (d *DeploymentBuilder) WithClusterCertificate(name string) *DeploymentBuild
 	return d
 }
 
+// WithKonnectClientCertificate sets the Secret holding the client certificate the DataPlane
+// uses to authenticate with Konnect. When set, it replaces the cluster certificate
+// in the proxy environment.
+func (d *DeploymentBuilder) WithKonnectClientCertificate(secret *corev1.Secret) *DeploymentBuilder {
+	d.konnectClientCertificate = secret
+	return d
+}
+
 // WithAdditionalLabels configures additional labels for a DeploymentBuilder.
 func (d *DeploymentBuilder) WithAdditionalLabels(labels client.MatchingLabels) *DeploymentBuilder {
 	d.additionalLabels = labels
@@ -118,6 +128,9 @@ func (d *DeploymentBuilder) BuildAndDeploy(
 
 	// Add the cluster certificate to the generated Deployment
 	desiredDeployment = setClusterCertVars(desiredDeployment, d.clusterCertificateName)
+	if d.konnectClientCertificate != nil {
+		desiredDeployment = setKonnectClientCertVars(desiredDeployment, d.konnectClientCertificate)
+	}
 
 	// run any callbacks that patch the initial Deployment struct
 	afterDeploymentCallbacks := NewCallbackRunner(d.client)
@@ -226,6 +239,34 @@ func setClusterCertVars(
 		)
 }
 
+// setKonnectClientCertVars configures the Konnect client certificate in the proxy environment,
+// overriding the cluster certificate. The certificate's hash is set as a pod template annotation
+// so that the DataPlane gets rolled out when the certificate is renewed.
+func setKonnectClientCertVars(
+	deployment *k8sresources.Deployment,
+	secret *corev1.Secret,
+) *k8sresources.Deployment {
+	if deployment.Spec.Template.Annotations == nil {
+		deployment.Spec.Template.Annotations = map[string]string{}
+	}
+	deployment.Spec.Template.Annotations[consts.KonnectClientCertificateHashAnnotation] = fmt.Sprintf("%x", sha256.Sum256(secret.Data["tls.crt"]))
+
+	return deployment.WithVolume(k8sresources.KonnectClientCertificateVolume(secret.Name)).
+		WithVolumeMount(k8sresources.KonnectClientCertificateVolumeMount(), consts.DataPlaneProxyContainerName).
+		WithEnvVar(
+			corev1.EnvVar{
+				Name:  consts.ClusterCertEnvKey,
+				Value: filepath.Join(consts.KonnectClientCertificateVolumeMountPath, "tls.crt"),
+			}, consts.DataPlaneProxyContainerName,
+		).
+		WithEnvVar(
+			corev1.EnvVar{
+				Name:  consts.ClusterCertKeyEnvKey,
+				Value: filepath.Join(consts.KonnectClientCertificateVolumeMountPath, "tls.key"),
+			}, consts.DataPlaneProxyContainerName,
+		)
+}
+
 // listOrReduceDataPlaneDeployments lists existing DataPlane Deployments. If only one is present, it returns it. If
 // multiple are present, it reduces them to one and notifies the caller it reduced, so that the caller can try its
 // operation again once there's only a single Deployment to work with.
@@ -295,6 +336,8 @@ func reconcileDataPlaneDeployment(
 	existing *appsv1.Deployment,
 	desired *appsv1.Deployment,
 ) (res op.CreatedUpdatedOrNoop, deploy *appsv1.Deployment, err error) {
+	k8sresources.InheritInfrastructureMetadata(dataplane, desired, &desired.Spec.Template)
+
 	if existing != nil {
 		var updated bool
 		original := existing.DeepCopy()
@@ -302,7 +345,8 @@ func reconcileDataPlaneDeployment(
 		k8sresources.SetDefaultsPodTemplateSpec(&desired.Spec.Template)
 
 		// ensure that object metadata is up to date
-		updated, existing.ObjectMeta = k8sutils.EnsureObjectMetaIsUpdated(existing.ObjectMeta, desired.ObjectMeta)
+		updated, existing.ObjectMeta = k8sutils.EnsureObjectMetaIsUpdated(existing.ObjectMeta, desired.ObjectMeta,
+			k8sresources.EnsureInfrastructureAnnotationsAreUpdated)
 
 		// some custom comparison rules are needed for some PodTemplateSpec sub-attributes, in particular
 		// resources and affinity.
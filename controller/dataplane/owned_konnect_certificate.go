@@ -0,0 +1,353 @@
+package dataplane
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	dataplanepkg "github.com/kong/gateway-operator/controller/pkg/dataplane"
+	"github.com/kong/gateway-operator/controller/pkg/log"
+	"github.com/kong/gateway-operator/controller/pkg/op"
+	"github.com/kong/gateway-operator/controller/pkg/patch"
+	"github.com/kong/gateway-operator/controller/pkg/secrets"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+	k8sresources "github.com/kong/gateway-operator/pkg/utils/kubernetes/resources"
+)
+
+// konnectClientCertificateRetryInterval is the interval after which the DataPlane
+// is requeued when its Konnect client certificate is being issued.
+const konnectClientCertificateRetryInterval = 10 * time.Second
+
+// konnectClientCertificateUsages are the key usages requested for the DataPlane's
+// Konnect client certificate.
+var konnectClientCertificateUsages = []certificatesv1.KeyUsage{
+	certificatesv1.UsageKeyEncipherment,
+	certificatesv1.UsageDigitalSignature,
+	certificatesv1.UsageClientAuth,
+}
+
+// ensureDataPlaneKonnectClientCertificate ensures that the client certificate the DataPlane
+// uses to authenticate with Konnect is issued and stored in a Secret owned by the DataPlane.
+//
+// The certificate is requested from the cert-manager Issuer (or ClusterIssuer when the issuer's
+// namespace is not set) referenced in DataPlane's spec.network.konnectCertificate.
+// When cert-manager is not installed in the cluster, the issuer reference is expected to point
+// at a Secret holding a CA certificate and key (in the provided clusterResourceNamespace when
+// the namespace is not set) which is then used to sign the certificate by the operator itself.
+//
+// In both cases the certificate is renewed renewBefore its expiry and its private key
+// is of the provided type, like the cluster certificates issued by the operator.
+//
+// When the DataPlane doesn't specify Konnect certificate options, previously created
+// certificates are removed.
+//
+// It returns the Secret holding the certificate, which is nil when the certificate is
+// not (yet) available.
+func ensureDataPlaneKonnectClientCertificate(
+	ctx context.Context,
+	cl client.Client,
+	logger logr.Logger,
+	dataplane *operatorv1beta1.DataPlane,
+	clusterResourceNamespace string,
+	renewBefore time.Duration,
+	keyType secrets.KeyType,
+) (op.CreatedUpdatedOrNoop, *corev1.Secret, error) {
+	certOpts := dataplane.Spec.Network.KonnectCertificateOptions
+	if certOpts == nil {
+		return op.Noop, nil, cleanupDataPlaneKonnectClientCertificate(ctx, cl, dataplane)
+	}
+
+	var (
+		issuerNN = types.NamespacedName{
+			Namespace: certOpts.Issuer.Namespace,
+			Name:      certOpts.Issuer.Name,
+		}
+		issuer     client.Object = &certmanagerv1.Issuer{}
+		issuerKind               = certmanagerv1.IssuerKind
+	)
+	if issuerNN.Namespace == "" {
+		issuer = &certmanagerv1.ClusterIssuer{}
+		issuerKind = certmanagerv1.ClusterIssuerKind
+	}
+
+	err := cl.Get(ctx, issuerNN, issuer)
+	switch {
+	case err == nil:
+		// cert-manager Certificates can only reference Issuers from their own namespace,
+		// and the Secret needs to be in the DataPlane's namespace to be mounted.
+		if issuerNN.Namespace != "" && issuerNN.Namespace != dataplane.Namespace {
+			return op.Noop, nil, fmt.Errorf(
+				"%s %s has to be in the same namespace as DataPlane %s/%s",
+				issuerKind, issuerNN, dataplane.Namespace, dataplane.Name,
+			)
+		}
+		return ensureDataPlaneKonnectCertManagerCertificate(ctx, cl, logger, dataplane, cmmeta.ObjectReference{
+			Name:  issuerNN.Name,
+			Kind:  issuerKind,
+			Group: certmanagerv1.SchemeGroupVersion.Group,
+		}, renewBefore, keyType)
+	case isCertManagerUnavailableError(err):
+		if issuerNN.Namespace == "" {
+			issuerNN.Namespace = clusterResourceNamespace
+		}
+		log.Trace(logger, "cert-manager is not available, signing Konnect client certificate with CA Secret", dataplane, "secret", issuerNN)
+		return ensureDataPlaneKonnectLocallySignedCertificate(ctx, cl, dataplane, issuerNN, renewBefore, keyType)
+	case k8serrors.IsNotFound(err):
+		return op.Noop, nil, fmt.Errorf("%s %s referenced by DataPlane %s/%s not found", issuerKind, issuerNN, dataplane.Namespace, dataplane.Name)
+	default:
+		return op.Noop, nil, fmt.Errorf("failed getting %s %s: %w", issuerKind, issuerNN, err)
+	}
+}
+
+// ensureDataPlaneKonnectCertManagerCertificate ensures that a cert-manager Certificate
+// for the DataPlane's Konnect client certificate exists and that the Secret it produces
+// is owned by the DataPlane. Renewal of the certificate is performed by cert-manager.
+func ensureDataPlaneKonnectCertManagerCertificate(
+	ctx context.Context,
+	cl client.Client,
+	logger logr.Logger,
+	dataplane *operatorv1beta1.DataPlane,
+	issuerRef cmmeta.ObjectReference,
+	renewBefore time.Duration,
+	keyType secrets.KeyType,
+) (op.CreatedUpdatedOrNoop, *corev1.Secret, error) {
+	generated := generateKonnectClientCertificate(dataplane, issuerRef, renewBefore, keyType)
+
+	existing := &certmanagerv1.Certificate{}
+	if err := cl.Get(ctx, client.ObjectKeyFromObject(generated), existing); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return op.Noop, nil, fmt.Errorf("failed getting Certificate %s/%s: %w", generated.Namespace, generated.Name, err)
+		}
+		if err := cl.Create(ctx, generated); err != nil {
+			return op.Noop, nil, fmt.Errorf("failed creating Certificate %s/%s: %w", generated.Namespace, generated.Name, err)
+		}
+		log.Debug(logger, "Konnect client Certificate created", dataplane, "certificate", generated.Name)
+		return op.Created, nil, nil
+	}
+
+	old := existing.DeepCopy()
+	updated, existingMeta := k8sutils.EnsureObjectMetaIsUpdated(existing.ObjectMeta, generated.ObjectMeta)
+	existing.ObjectMeta = existingMeta
+	if !cmp.Equal(existing.Spec, generated.Spec) {
+		existing.Spec = generated.Spec
+		updated = true
+	}
+	if res, _, err := patch.ApplyPatchIfNonEmpty(ctx, cl, logger, existing, old, dataplane, updated); err != nil {
+		return op.Noop, nil, err
+	} else if res != op.Noop {
+		return res, nil, nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := cl.Get(ctx, types.NamespacedName{Namespace: existing.Namespace, Name: existing.Spec.SecretName}, secret); err != nil {
+		if k8serrors.IsNotFound(err) {
+			// The certificate has not been issued yet.
+			return op.Noop, nil, nil
+		}
+		return op.Noop, nil, fmt.Errorf("failed getting Secret %s/%s: %w", existing.Namespace, existing.Spec.SecretName, err)
+	}
+
+	// Make sure the Secret produced by cert-manager is owned by the DataPlane so that
+	// it gets garbage collected together with it. cert-manager might already be set as
+	// its controller (when configured to do so) in which case a non controller
+	// reference is used.
+	if !k8sutils.IsOwnedByRefUID(secret, dataplane.GetUID()) {
+		oldSecret := secret.DeepCopy()
+		ownerRef := k8sutils.GenerateOwnerReferenceForObject(dataplane)
+		if metav1.GetControllerOfNoCopy(secret) != nil {
+			ownerRef.Controller = nil
+		}
+		secret.OwnerReferences = append(secret.OwnerReferences, ownerRef)
+		if err := cl.Patch(ctx, secret, client.MergeFrom(oldSecret)); err != nil {
+			return op.Noop, nil, fmt.Errorf("failed setting DataPlane as owner of Secret %s/%s: %w", secret.Namespace, secret.Name, err)
+		}
+		return op.Updated, secret, nil
+	}
+
+	return op.Noop, secret, nil
+}
+
+// ensureDataPlaneKonnectLocallySignedCertificate ensures that the DataPlane's Konnect client
+// certificate, signed by the CA from the provided Secret, exists. The certificate is reissued
+// following the same rules as the cluster certificates, e.g. when it expires in less than
+// renewBefore or when its private key is not of the provided type.
+func ensureDataPlaneKonnectLocallySignedCertificate(
+	ctx context.Context,
+	cl client.Client,
+	dataplane *operatorv1beta1.DataPlane,
+	caSecretNN types.NamespacedName,
+	renewBefore time.Duration,
+	keyType secrets.KeyType,
+) (op.CreatedUpdatedOrNoop, *corev1.Secret, error) {
+	return secrets.EnsureCertificate(ctx,
+		dataplane,
+		konnectClientCertificateSubject(dataplane),
+		caSecretNN,
+		konnectClientCertificateUsages,
+		cl,
+		client.MatchingLabels{
+			consts.KonnectClientCertificateLabel: consts.KonnectClientCertificateLabelValue,
+		},
+		secrets.WithRenewBefore(renewBefore),
+		secrets.WithKeyType(keyType),
+	)
+}
+
+// cleanupDataPlaneKonnectClientCertificate removes the Konnect client certificates
+// (both the Secrets and the cert-manager Certificates) created for the DataPlane.
+//
+// The cert-manager Certificate is only looked up when there are Secrets left to remove
+// so that DataPlanes which never used Konnect certificates do not require cert-manager
+// types to be watched.
+func cleanupDataPlaneKonnectClientCertificate(
+	ctx context.Context,
+	cl client.Client,
+	dataplane *operatorv1beta1.DataPlane,
+) error {
+	secrets, err := k8sutils.ListSecretsForOwner(ctx, cl, dataplane.GetUID(),
+		client.InNamespace(dataplane.Namespace),
+		client.MatchingLabels{
+			consts.KonnectClientCertificateLabel: consts.KonnectClientCertificateLabelValue,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed listing Konnect client certificate Secrets for DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, err)
+	}
+	if len(secrets) == 0 {
+		return nil
+	}
+
+	// Remove the Certificate first so that cert-manager doesn't reissue the Secret.
+	cert := &certmanagerv1.Certificate{}
+	err = cl.Get(ctx, types.NamespacedName{Namespace: dataplane.Namespace, Name: konnectClientCertificateName(dataplane)}, cert)
+	switch {
+	case err == nil:
+		if k8sutils.IsOwnedByRefUID(cert, dataplane.GetUID()) {
+			if err := cl.Delete(ctx, cert); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("failed deleting Certificate %s/%s: %w", cert.Namespace, cert.Name, err)
+			}
+		}
+	case k8serrors.IsNotFound(err), isCertManagerUnavailableError(err):
+	default:
+		return fmt.Errorf("failed getting Certificate for DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, err)
+	}
+
+	for i := range secrets {
+		if err := dataplanepkg.OwnedObjectPreDeleteHook(ctx, cl, &secrets[i]); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		if err := cl.Delete(ctx, &secrets[i]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed deleting Secret %s/%s: %w", secrets[i].Namespace, secrets[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// generateKonnectClientCertificate generates the cert-manager Certificate for the DataPlane's
+// Konnect client certificate. The Secret it produces is named after the Certificate.
+// When renewBefore is not set, cert-manager's default renewal time is used.
+func generateKonnectClientCertificate(
+	dataplane *operatorv1beta1.DataPlane,
+	issuerRef cmmeta.ObjectReference,
+	renewBefore time.Duration,
+	keyType secrets.KeyType,
+) *certmanagerv1.Certificate {
+	labels := k8sresources.GetManagedLabelForOwner(dataplane)
+	labels[consts.KonnectClientCertificateLabel] = consts.KonnectClientCertificateLabelValue
+
+	name := konnectClientCertificateName(dataplane)
+	cert := &certmanagerv1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: dataplane.Namespace,
+			Name:      name,
+			Labels:    labels,
+		},
+		Spec: certmanagerv1.CertificateSpec{
+			CommonName: konnectClientCertificateSubject(dataplane),
+			SecretName: name,
+			SecretTemplate: &certmanagerv1.CertificateSecretTemplate{
+				Labels: labels,
+			},
+			IssuerRef: issuerRef,
+			Usages: []certmanagerv1.KeyUsage{
+				certmanagerv1.UsageKeyEncipherment,
+				certmanagerv1.UsageDigitalSignature,
+				certmanagerv1.UsageClientAuth,
+			},
+			PrivateKey: certManagerPrivateKey(keyType),
+		},
+	}
+	if renewBefore > 0 {
+		cert.Spec.RenewBefore = &metav1.Duration{Duration: renewBefore}
+	}
+	k8sutils.SetOwnerForObject(cert, dataplane)
+	return cert
+}
+
+// certManagerPrivateKey returns the cert-manager private key settings generating
+// keys of the provided type. An empty type stands for the secrets.DefaultKeyType.
+func certManagerPrivateKey(keyType secrets.KeyType) *certmanagerv1.CertificatePrivateKey {
+	privateKey := &certmanagerv1.CertificatePrivateKey{
+		RotationPolicy: certmanagerv1.RotationPolicyAlways,
+	}
+	switch keyType {
+	case secrets.KeyTypeRSA2048:
+		privateKey.Algorithm, privateKey.Size = certmanagerv1.RSAKeyAlgorithm, 2048
+	case secrets.KeyTypeRSA4096:
+		privateKey.Algorithm, privateKey.Size = certmanagerv1.RSAKeyAlgorithm, 4096
+	case secrets.KeyTypeECDSAP384:
+		privateKey.Algorithm, privateKey.Size = certmanagerv1.ECDSAKeyAlgorithm, 384
+	case secrets.KeyTypeEd25519:
+		privateKey.Algorithm = certmanagerv1.Ed25519KeyAlgorithm
+	default:
+		privateKey.Algorithm, privateKey.Size = certmanagerv1.ECDSAKeyAlgorithm, 256
+	}
+	return privateKey
+}
+
+// konnectClientCertificateName returns the name of the cert-manager Certificate
+// (and the Secret it produces) for the DataPlane's Konnect client certificate.
+func konnectClientCertificateName(dataplane *operatorv1beta1.DataPlane) string {
+	return fmt.Sprintf("%s-%s-konnect-client", consts.DataPlanePrefix, dataplane.Name)
+}
+
+// konnectClientCertificateSubject returns the subject used in the DataPlane's
+// Konnect client certificate.
+func konnectClientCertificateSubject(dataplane *operatorv1beta1.DataPlane) string {
+	return fmt.Sprintf("%s.%s", dataplane.Name, dataplane.Namespace)
+}
+
+// konnectClientCertificateRequeueAfter returns the duration after which the DataPlane
+// should be reconciled again in order to pick up (or perform) the renewal of the
+// Konnect client certificate held in the provided Secret.
+func konnectClientCertificateRequeueAfter(secret *corev1.Secret, renewBefore time.Duration) time.Duration {
+	if secret == nil {
+		return konnectClientCertificateRetryInterval
+	}
+	if d := secrets.RenewalRequeueAfter(secret, renewBefore); d > 0 {
+		return d
+	}
+	return konnectClientCertificateRetryInterval
+}
+
+// isCertManagerUnavailableError returns true when the provided error indicates that
+// cert-manager types are not available, either because cert-manager CRDs are not installed
+// in the cluster or because its types are not registered in the client's scheme.
+func isCertManagerUnavailableError(err error) bool {
+	return meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err)
+}
@@ -0,0 +1,248 @@
+package dataplane
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+	"time"
+
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/controller/pkg/op"
+	"github.com/kong/gateway-operator/controller/pkg/secrets"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+	k8sresources "github.com/kong/gateway-operator/pkg/utils/kubernetes/resources"
+	"github.com/kong/gateway-operator/test/helpers"
+)
+
+func TestEnsureDataPlaneKonnectClientCertificate(t *testing.T) {
+	const (
+		namespace   = "test-namespace"
+		renewBefore = 24 * time.Hour
+	)
+
+	newDataPlane := func(issuer *operatorv1beta1.NamespacedName) *operatorv1beta1.DataPlane {
+		dp := &operatorv1beta1.DataPlane{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "gateway-operator.konghq.com/v1beta1",
+				Kind:       "DataPlane",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dp",
+				Namespace: namespace,
+				UID:       types.UID("1234"),
+			},
+		}
+		if issuer != nil {
+			dp.Spec.Network.KonnectCertificateOptions = &operatorv1beta1.KonnectCertificateOptions{
+				Issuer: *issuer,
+			}
+		}
+		return dp
+	}
+
+	ca := helpers.CreateCA(t)
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "konnect-ca",
+			Namespace: namespace,
+		},
+		Data: map[string][]byte{
+			"tls.crt": ca.CertPEM.Bytes(),
+			"tls.key": ca.KeyPEM.Bytes(),
+		},
+	}
+
+	t.Run("without cert-manager the certificate is signed using the CA Secret", func(t *testing.T) {
+		ctx := context.Background()
+		dp := newDataPlane(&operatorv1beta1.NamespacedName{Name: caSecret.Name})
+		keyType := secrets.KeyTypeECDSAP384
+		cl := fakectrlruntimeclient.NewClientBuilder().
+			WithScheme(clientgoscheme.Scheme).
+			WithObjects(dp, caSecret.DeepCopy()).
+			WithStatusSubresource(dp).
+			Build()
+
+		res, secret, err := ensureDataPlaneKonnectClientCertificate(ctx, cl, logr.Discard(), dp, namespace, renewBefore, keyType)
+		require.NoError(t, err)
+		require.Equal(t, op.Created, res)
+		require.NotNil(t, secret)
+		require.Equal(t, consts.KonnectClientCertificateLabelValue, secret.Labels[consts.KonnectClientCertificateLabel])
+
+		res, secondSecret, err := ensureDataPlaneKonnectClientCertificate(ctx, cl, logr.Discard(), dp, namespace, renewBefore, keyType)
+		require.NoError(t, err)
+		require.Equal(t, op.Noop, res)
+		require.Equal(t, secret.Name, secondSecret.Name)
+
+		priv, err := secrets.ParsePrivateKey(secondSecret.Data["tls.key"])
+		require.NoError(t, err)
+		actualKeyType, err := secrets.KeyTypeOf(priv)
+		require.NoError(t, err)
+		require.Equal(t, keyType, actualKeyType)
+		require.Equal(t,
+			secrets.RenewalRequeueAfter(secondSecret, renewBefore).Round(time.Minute),
+			konnectClientCertificateRequeueAfter(secondSecret, renewBefore).Round(time.Minute),
+		)
+
+		t.Log("changing the key type and verifying the certificate is reissued")
+		keyType = secrets.KeyTypeEd25519
+		res, reissuedSecret, err := ensureDataPlaneKonnectClientCertificate(ctx, cl, logr.Discard(), dp, namespace, renewBefore, keyType)
+		require.NoError(t, err)
+		require.Equal(t, op.Created, res)
+		priv, err = secrets.ParsePrivateKey(reissuedSecret.Data["tls.key"])
+		require.NoError(t, err)
+		actualKeyType, err = secrets.KeyTypeOf(priv)
+		require.NoError(t, err)
+		require.Equal(t, keyType, actualKeyType)
+		secondSecret = reissuedSecret
+
+		t.Log("removing Konnect certificate options and verifying the Secret is removed")
+		dp.Spec.Network.KonnectCertificateOptions = nil
+		res, secret, err = ensureDataPlaneKonnectClientCertificate(ctx, cl, logr.Discard(), dp, namespace, renewBefore, keyType)
+		require.NoError(t, err)
+		require.Equal(t, op.Noop, res)
+		require.Nil(t, secret)
+		err = cl.Get(ctx, client.ObjectKeyFromObject(secondSecret), &corev1.Secret{})
+		require.True(t, k8serrors.IsNotFound(err))
+	})
+
+	t.Run("with cert-manager a Certificate is requested from the Issuer", func(t *testing.T) {
+		ctx := context.Background()
+		scheme := runtime.NewScheme()
+		require.NoError(t, clientgoscheme.AddToScheme(scheme))
+		require.NoError(t, operatorv1beta1.AddToScheme(scheme))
+		require.NoError(t, certmanagerv1.AddToScheme(scheme))
+
+		dp := newDataPlane(&operatorv1beta1.NamespacedName{Name: "issuer", Namespace: namespace})
+		keyType := secrets.KeyTypeRSA4096
+		issuer := &certmanagerv1.Issuer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "issuer",
+				Namespace: namespace,
+			},
+		}
+		cl := fakectrlruntimeclient.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(dp, issuer).
+			Build()
+
+		res, secret, err := ensureDataPlaneKonnectClientCertificate(ctx, cl, logr.Discard(), dp, namespace, renewBefore, keyType)
+		require.NoError(t, err)
+		require.Equal(t, op.Created, res)
+		require.Nil(t, secret)
+
+		cert := &certmanagerv1.Certificate{}
+		require.NoError(t, cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: konnectClientCertificateName(dp)}, cert))
+		require.Equal(t, certmanagerv1.IssuerKind, cert.Spec.IssuerRef.Kind)
+		require.Equal(t, "issuer", cert.Spec.IssuerRef.Name)
+		require.Equal(t, "dp.test-namespace", cert.Spec.CommonName)
+		require.Equal(t, &metav1.Duration{Duration: renewBefore}, cert.Spec.RenewBefore)
+		require.Equal(t, certmanagerv1.RSAKeyAlgorithm, cert.Spec.PrivateKey.Algorithm)
+		require.Equal(t, 4096, cert.Spec.PrivateKey.Size)
+		require.True(t, k8sutils.IsOwnedByRefUID(cert, dp.UID))
+
+		t.Log("verifying that nothing is returned until the certificate is issued")
+		res, secret, err = ensureDataPlaneKonnectClientCertificate(ctx, cl, logr.Discard(), dp, namespace, renewBefore, keyType)
+		require.NoError(t, err)
+		require.Equal(t, op.Noop, res)
+		require.Nil(t, secret)
+
+		t.Log("issuing the certificate and verifying the Secret gets owned by the DataPlane")
+		require.NoError(t, cl.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cert.Spec.SecretName,
+				Namespace: namespace,
+			},
+			Data: map[string][]byte{
+				"tls.crt": ca.CertPEM.Bytes(),
+				"tls.key": ca.KeyPEM.Bytes(),
+			},
+		}))
+		res, secret, err = ensureDataPlaneKonnectClientCertificate(ctx, cl, logr.Discard(), dp, namespace, renewBefore, keyType)
+		require.NoError(t, err)
+		require.Equal(t, op.Updated, res)
+		require.NotNil(t, secret)
+		require.True(t, k8sutils.IsOwnedByRefUID(secret, dp.UID))
+
+		res, secret, err = ensureDataPlaneKonnectClientCertificate(ctx, cl, logr.Discard(), dp, namespace, renewBefore, keyType)
+		require.NoError(t, err)
+		require.Equal(t, op.Noop, res)
+		require.NotNil(t, secret)
+	})
+
+	t.Run("Issuer from another namespace is rejected", func(t *testing.T) {
+		ctx := context.Background()
+		scheme := runtime.NewScheme()
+		require.NoError(t, clientgoscheme.AddToScheme(scheme))
+		require.NoError(t, operatorv1beta1.AddToScheme(scheme))
+		require.NoError(t, certmanagerv1.AddToScheme(scheme))
+
+		dp := newDataPlane(&operatorv1beta1.NamespacedName{Name: "issuer", Namespace: "other"})
+		keyType := secrets.DefaultKeyType
+		issuer := &certmanagerv1.Issuer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "issuer",
+				Namespace: "other",
+			},
+		}
+		cl := fakectrlruntimeclient.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(dp, issuer).
+			Build()
+
+		_, _, err := ensureDataPlaneKonnectClientCertificate(ctx, cl, logr.Discard(), dp, namespace, renewBefore, keyType)
+		require.Error(t, err)
+	})
+}
+
+func TestSetKonnectClientCertVars(t *testing.T) {
+	dp := &operatorv1beta1.DataPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dp",
+			Namespace: "default",
+		},
+	}
+	deployment, err := k8sresources.GenerateNewDeploymentForDataPlane(dp, consts.DefaultDataPlaneImage)
+	require.NoError(t, err)
+	deployment = setClusterCertVars(deployment, "cluster-cert")
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "konnect-cert",
+		},
+		Data: map[string][]byte{
+			"tls.crt": []byte("cert"),
+		},
+	}
+	deployment = setKonnectClientCertVars(deployment, secret)
+
+	require.Equal(t,
+		fmt.Sprintf("%x", sha256.Sum256([]byte("cert"))),
+		deployment.Spec.Template.Annotations[consts.KonnectClientCertificateHashAnnotation],
+	)
+	container := k8sutils.GetPodContainerByName(&deployment.Spec.Template.Spec, consts.DataPlaneProxyContainerName)
+	require.NotNil(t, container)
+	require.Contains(t, container.Env, corev1.EnvVar{
+		Name:  consts.ClusterCertEnvKey,
+		Value: "/var/konnect-client-certificate/tls.crt",
+	})
+	require.Contains(t, container.Env, corev1.EnvVar{
+		Name:  consts.ClusterCertKeyEnvKey,
+		Value: "/var/konnect-client-certificate/tls.key",
+	})
+	require.Contains(t, container.VolumeMounts, k8sresources.KonnectClientCertificateVolumeMount())
+	require.Contains(t, deployment.Spec.Template.Spec.Volumes, k8sresources.KonnectClientCertificateVolume("konnect-cert"))
+}
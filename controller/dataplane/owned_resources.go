@@ -7,10 +7,13 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/samber/lo"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	certificatesv1 "k8s.io/api/certificates/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
@@ -35,12 +38,18 @@ func ensureDataPlaneCertificate(
 	cl client.Client,
 	dataplane *operatorv1beta1.DataPlane,
 	clusterCASecretNN types.NamespacedName,
+	defaultIssuer operatorv1beta1.CertificateIssuer,
 	adminServiceNN types.NamespacedName,
+	opts ...secrets.EnsureCertificateOpt,
 ) (op.CreatedUpdatedOrNoop, *corev1.Secret, error) {
 	usages := []certificatesv1.KeyUsage{
 		certificatesv1.UsageKeyEncipherment,
 		certificatesv1.UsageDigitalSignature, certificatesv1.UsageServerAuth,
 	}
+	issuer, err := secrets.NewIssuer(lo.FromPtrOr(dataplane.Spec.CertificateIssuer, defaultIssuer), clusterCASecretNN)
+	if err != nil {
+		return op.Noop, nil, err
+	}
 	return secrets.EnsureCertificate(ctx,
 		dataplane,
 		fmt.Sprintf("*.%s.%s.svc", adminServiceNN.Name, adminServiceNN.Namespace),
@@ -48,6 +57,7 @@ func ensureDataPlaneCertificate(
 		usages,
 		cl,
 		secrets.GetManagedLabelForServiceSecret(adminServiceNN),
+		append(opts, secrets.WithIssuer(issuer))...,
 	)
 }
 
@@ -88,6 +98,7 @@ func ensureHPAForDataPlane(
 	if err != nil {
 		return op.Noop, nil, err
 	}
+	k8sresources.InheritInfrastructureMetadata(dataplane, generatedHPA)
 
 	if len(hpas) == 1 {
 		var updated bool
@@ -95,7 +106,8 @@ func ensureHPAForDataPlane(
 		oldExistingHPA := existingHPA.DeepCopy()
 
 		// ensure that object metadata is up to date
-		updated, existingHPA.ObjectMeta = k8sutils.EnsureObjectMetaIsUpdated(existingHPA.ObjectMeta, generatedHPA.ObjectMeta)
+		updated, existingHPA.ObjectMeta = k8sutils.EnsureObjectMetaIsUpdated(existingHPA.ObjectMeta, generatedHPA.ObjectMeta,
+			k8sresources.EnsureInfrastructureAnnotationsAreUpdated)
 
 		// ensure that rollout strategy is up to date
 		if !cmp.Equal(existingHPA.Spec, generatedHPA.Spec) {
@@ -113,6 +125,69 @@ func ensureHPAForDataPlane(
 	return op.Created, nil, nil
 }
 
+func ensurePodDisruptionBudgetForDataPlane(
+	ctx context.Context,
+	cl client.Client,
+	log logr.Logger,
+	dataplane *operatorv1beta1.DataPlane,
+) (res op.CreatedUpdatedOrNoop, pdb *policyv1.PodDisruptionBudget, err error) {
+	matchingLabels := k8sresources.GetManagedLabelForOwner(dataplane)
+	pdbs, err := k8sutils.ListPodDisruptionBudgetsForOwner(
+		ctx,
+		cl,
+		dataplane.Namespace,
+		dataplane.UID,
+		matchingLabels,
+	)
+	if err != nil {
+		return op.Noop, nil, fmt.Errorf("failed listing PodDisruptionBudgets for DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, err)
+	}
+
+	if dataplane.Spec.Deployment.DeploymentOptions.PodDisruptionBudget == nil {
+		if err := k8sreduce.ReducePodDisruptionBudgets(ctx, cl, pdbs, k8sreduce.FilterNone); err != nil {
+			return op.Noop, nil, fmt.Errorf("failed reducing PodDisruptionBudgets for DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, err)
+		}
+		return op.Noop, nil, nil
+	}
+
+	if len(pdbs) > 1 {
+		if err := k8sreduce.ReducePodDisruptionBudgets(ctx, cl, pdbs, k8sreduce.FilterPodDisruptionBudgets); err != nil {
+			return op.Noop, nil, fmt.Errorf("failed reducing PodDisruptionBudgets for DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, err)
+		}
+		return op.Noop, nil, nil
+	}
+
+	generatedPDB, err := k8sresources.GeneratePodDisruptionBudgetForDataPlane(dataplane)
+	if err != nil {
+		return op.Noop, nil, err
+	}
+	k8sresources.InheritInfrastructureMetadata(dataplane, generatedPDB)
+
+	if len(pdbs) == 1 {
+		var updated bool
+		existingPDB := &pdbs[0]
+		oldExistingPDB := existingPDB.DeepCopy()
+
+		// ensure that object metadata is up to date
+		updated, existingPDB.ObjectMeta = k8sutils.EnsureObjectMetaIsUpdated(existingPDB.ObjectMeta, generatedPDB.ObjectMeta,
+			k8sresources.EnsureInfrastructureAnnotationsAreUpdated)
+
+		// ensure that the disruption budget is up to date
+		if !cmp.Equal(existingPDB.Spec, generatedPDB.Spec) {
+			existingPDB.Spec = generatedPDB.Spec
+			updated = true
+		}
+
+		return patch.ApplyPatchIfNonEmpty(ctx, cl, log, existingPDB, oldExistingPDB, dataplane, updated)
+	}
+
+	if err = cl.Create(ctx, generatedPDB); err != nil {
+		return op.Noop, nil, fmt.Errorf("failed creating PodDisruptionBudget for DataPlane %s: %w", dataplane.Name, err)
+	}
+
+	return op.Created, generatedPDB, nil
+}
+
 func matchingLabelsToServiceOpt(ml client.MatchingLabels) k8sresources.ServiceOpt {
 	return func(s *corev1.Service) {
 		if s.Labels == nil {
@@ -208,11 +283,13 @@ func ensureAdminServiceForDataPlane(
 	if err != nil {
 		return op.Noop, nil, err
 	}
+	k8sresources.InheritInfrastructureMetadata(dataPlane, generatedService)
 
 	if count == 1 {
 		var updated bool
 		existingService := &services[0]
-		updated, existingService.ObjectMeta = k8sutils.EnsureObjectMetaIsUpdated(existingService.ObjectMeta, generatedService.ObjectMeta)
+		updated, existingService.ObjectMeta = k8sutils.EnsureObjectMetaIsUpdated(existingService.ObjectMeta, generatedService.ObjectMeta,
+			k8sresources.EnsureInfrastructureAnnotationsAreUpdated)
 
 		if existingService.Spec.Type != generatedService.Spec.Type {
 			existingService.Spec.Type = generatedService.Spec.Type
@@ -320,6 +397,7 @@ func ensureIngressServiceForDataPlane(
 		return op.Noop, nil, err
 	}
 	addAnnotationsForDataPlaneIngressService(generatedService, *dataPlane)
+	k8sresources.InheritInfrastructureMetadata(dataPlane, generatedService)
 	k8sutils.SetOwnerForObject(generatedService, dataPlane)
 
 	if count == 1 {
@@ -339,7 +417,8 @@ func ensureIngressServiceForDataPlane(
 				}
 				existingMeta.Annotations = updatedAnnotations
 				return metaToUpdate, existingMeta
-			})
+			},
+			k8sresources.EnsureInfrastructureAnnotationsAreUpdated)
 
 		if existingService.Spec.Type != generatedService.Spec.Type {
 			existingService.Spec.Type = generatedService.Spec.Type
@@ -357,6 +436,14 @@ func ensureIngressServiceForDataPlane(
 			existingService.Spec.Ports = generatedService.Spec.Ports
 			updated = true
 		}
+		if existingService.Spec.LoadBalancerIP != generatedService.Spec.LoadBalancerIP { //nolint:staticcheck
+			existingService.Spec.LoadBalancerIP = generatedService.Spec.LoadBalancerIP //nolint:staticcheck
+			updated = true
+		}
+		if !cmp.Equal(existingService.Spec.ExternalIPs, generatedService.Spec.ExternalIPs, cmpopts.EquateEmpty()) {
+			existingService.Spec.ExternalIPs = generatedService.Spec.ExternalIPs
+			updated = true
+		}
 
 		if updated {
 			if err := cl.Update(ctx, existingService); err != nil {
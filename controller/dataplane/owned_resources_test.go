@@ -2,9 +2,11 @@ package dataplane
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/go-logr/logr"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -192,3 +194,120 @@ func TestEnsureIngressServiceForDataPlane(t *testing.T) {
 		})
 	}
 }
+
+func TestEnsurePodDisruptionBudgetForDataPlane(t *testing.T) {
+	newDataPlane := func(pdb *operatorv1beta1.PodDisruptionBudget) *operatorv1beta1.DataPlane {
+		dp := builder.NewDataPlaneBuilder().WithObjectMeta(metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "dp-1",
+			UID:       "dp-uid",
+		}).Build()
+		dp.Spec.Deployment.PodDisruptionBudget = pdb
+		return dp
+	}
+
+	testCases := []struct {
+		name                   string
+		dataplane              *operatorv1beta1.DataPlane
+		existingPDBs           []*operatorv1beta1.PodDisruptionBudget
+		expectedResult         op.CreatedUpdatedOrNoop
+		expectedPDBCount       int
+		expectedMinAvailable   *intstr.IntOrString
+		expectedMaxUnavailable *intstr.IntOrString
+	}{
+		{
+			name:             "no PodDisruptionBudget is created when it's not configured",
+			dataplane:        newDataPlane(nil),
+			expectedResult:   op.Noop,
+			expectedPDBCount: 0,
+		},
+		{
+			name:                   "PodDisruptionBudget is created with the default disruption budget",
+			dataplane:              newDataPlane(&operatorv1beta1.PodDisruptionBudget{}),
+			expectedResult:         op.Created,
+			expectedPDBCount:       1,
+			expectedMaxUnavailable: lo.ToPtr(intstr.FromInt32(1)),
+		},
+		{
+			name: "existing PodDisruptionBudget is updated",
+			dataplane: newDataPlane(&operatorv1beta1.PodDisruptionBudget{
+				MinAvailable: lo.ToPtr(intstr.FromString("50%")),
+			}),
+			existingPDBs: []*operatorv1beta1.PodDisruptionBudget{
+				{},
+			},
+			expectedResult:       op.Updated,
+			expectedPDBCount:     1,
+			expectedMinAvailable: lo.ToPtr(intstr.FromString("50%")),
+		},
+		{
+			name: "up to date PodDisruptionBudget is left as is",
+			dataplane: newDataPlane(&operatorv1beta1.PodDisruptionBudget{
+				MaxUnavailable: lo.ToPtr(intstr.FromInt32(2)),
+			}),
+			existingPDBs: []*operatorv1beta1.PodDisruptionBudget{
+				{MaxUnavailable: lo.ToPtr(intstr.FromInt32(2))},
+			},
+			expectedResult:         op.Noop,
+			expectedPDBCount:       1,
+			expectedMaxUnavailable: lo.ToPtr(intstr.FromInt32(2)),
+		},
+		{
+			name:      "PodDisruptionBudget is deleted when it's no longer configured",
+			dataplane: newDataPlane(nil),
+			existingPDBs: []*operatorv1beta1.PodDisruptionBudget{
+				{},
+			},
+			expectedResult:   op.Noop,
+			expectedPDBCount: 0,
+		},
+		{
+			name:      "redundant PodDisruptionBudgets are reduced",
+			dataplane: newDataPlane(&operatorv1beta1.PodDisruptionBudget{}),
+			existingPDBs: []*operatorv1beta1.PodDisruptionBudget{
+				{},
+				{},
+			},
+			expectedResult:         op.Noop,
+			expectedPDBCount:       1,
+			expectedMaxUnavailable: lo.ToPtr(intstr.FromInt32(1)),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fakeClient := fakectrlruntimeclient.
+				NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				Build()
+
+			for i, spec := range tc.existingPDBs {
+				dp := newDataPlane(spec)
+				existingPDB, err := k8sresources.GeneratePodDisruptionBudgetForDataPlane(dp)
+				require.NoError(t, err)
+				existingPDB.Name = fmt.Sprintf("%s%d", existingPDB.GenerateName, i)
+				require.NoError(t, fakeClient.Create(ctx, existingPDB))
+			}
+
+			res, _, err := ensurePodDisruptionBudgetForDataPlane(ctx, fakeClient, logr.Discard(), tc.dataplane)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedResult, res)
+
+			pdbs, err := k8sutils.ListPodDisruptionBudgetsForOwner(ctx, fakeClient, tc.dataplane.Namespace, tc.dataplane.UID)
+			require.NoError(t, err)
+			require.Len(t, pdbs, tc.expectedPDBCount)
+			if tc.expectedPDBCount == 0 {
+				return
+			}
+			pdb := pdbs[0]
+			require.Equal(t, map[string]string{
+				"app":                                tc.dataplane.Name,
+				consts.GatewayOperatorManagedByLabel: consts.DataPlaneManagedLabelValue,
+			}, pdb.Spec.Selector.MatchLabels)
+			require.Equal(t, tc.expectedMinAvailable, pdb.Spec.MinAvailable)
+			require.Equal(t, tc.expectedMaxUnavailable, pdb.Spec.MaxUnavailable)
+		})
+	}
+}
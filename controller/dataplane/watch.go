@@ -1,19 +1,29 @@
 package dataplane
 
 import (
+	"context"
+
+	"github.com/samber/lo"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	k8slog "sigs.k8s.io/controller-runtime/pkg/log"
 
 	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/controller/pkg/secrets"
 )
 
 // DataPlaneWatchBuilder creates a controller builder pre-configured with
 // the necessary watches for DataPlane resources that are managed by
-// the operator.
-func DataPlaneWatchBuilder(mgr ctrl.Manager) *builder.Builder {
+// the operator. The DataPlanes are also reconciled when the cluster CA Secret
+// identified by clusterCASecretNN changes.
+func DataPlaneWatchBuilder(mgr ctrl.Manager, clusterCASecretNN types.NamespacedName) *builder.Builder {
 	return ctrl.NewControllerManagedBy(mgr).
 		// watch DataPlane objects
 		For(&operatorv1beta1.DataPlane{}).
@@ -24,5 +34,26 @@ func DataPlaneWatchBuilder(mgr ctrl.Manager) *builder.Builder {
 		// watch for changes in Deployments created by the dataplane controller
 		Owns(&appsv1.Deployment{}).
 		// watch for changes in HPA created by the dataplane controller
-		Owns(&autoscalingv2.HorizontalPodAutoscaler{})
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
+		// watch for changes in PodDisruptionBudgets created by the dataplane controller
+		Owns(&policyv1.PodDisruptionBudget{}).
+		// watch for changes in the cluster CA Secret in order to reissue the
+		// DataPlanes' certificates as soon as the CA is rolled over
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(requestsForDataPlanes(mgr.GetClient())),
+			builder.WithPredicates(secrets.ClusterCASecretPredicate(clusterCASecretNN)),
+		)
+}
+
+// requestsForDataPlanes returns a handler.MapFunc returning requests for all the DataPlanes.
+func requestsForDataPlanes(cl client.Client) handler.MapFunc {
+	return func(ctx context.Context, _ client.Object) []ctrl.Request {
+		dataplanes := &operatorv1beta1.DataPlaneList{}
+		if err := cl.List(ctx, dataplanes); err != nil {
+			k8slog.FromContext(ctx).Error(err, "failed to list dataplanes in map func")
+			return nil
+		}
+		return objectsListToRequests(lo.ToSlicePtr(dataplanes.Items))
+	}
 }
@@ -0,0 +1,46 @@
+package dataplane
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/controller/pkg/secrets"
+)
+
+func TestRequestsForDataPlanesOnClusterCAChange(t *testing.T) {
+	caSecretNN := types.NamespacedName{Namespace: "kong-system", Name: "kong-operator-ca"}
+	predicate := secrets.ClusterCASecretPredicate(caSecretNN)
+
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: caSecretNN.Namespace, Name: caSecretNN.Name},
+	}
+	otherSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: caSecretNN.Namespace, Name: "other"},
+	}
+	require.True(t, predicate.Update(event.UpdateEvent{ObjectOld: caSecret, ObjectNew: caSecret}))
+	require.False(t, predicate.Update(event.UpdateEvent{ObjectOld: otherSecret, ObjectNew: otherSecret}))
+
+	cl := fakectrlruntimeclient.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(
+			&operatorv1beta1.DataPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "dp-1"}},
+			&operatorv1beta1.DataPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-2", Name: "dp-2"}},
+		).
+		Build()
+
+	requests := requestsForDataPlanes(cl)(context.Background(), caSecret)
+	require.ElementsMatch(t, []ctrl.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "ns-1", Name: "dp-1"}},
+		{NamespacedName: types.NamespacedName{Namespace: "ns-2", Name: "dp-2"}},
+	}, requests)
+}
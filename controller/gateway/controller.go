@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -22,6 +23,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
@@ -59,7 +61,7 @@ const provisionDataPlaneFailRetryAfter = 5 * time.Second
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		// watch Gateway objects, filtering out any Gateways which are not configured with
 		// a supported GatewayClass controller name.
 		For(&gwtypes.Gateway{},
@@ -98,8 +100,32 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 		// This is required to properly support Gateway's listeners.allowedRoutes.namespaces.selector.
 		Watches(
 			&corev1.Namespace{},
-			handler.EnqueueRequestsFromMapFunc(r.listManagedGatewaysInNamespace)).
-		Complete(r)
+			handler.EnqueueRequestsFromMapFunc(r.listManagedGatewaysInNamespace))
+
+	// L4 routes are part of Gateway API's experimental channel hence they are only
+	// watched (so that Gateway listener status can be updated) when their CRDs are installed.
+	checker := k8sutils.CRDChecker{Client: mgr.GetClient()}
+	for _, route := range []struct {
+		resource string
+		obj      client.Object
+	}{
+		{resource: "tcproutes", obj: &gatewayv1alpha2.TCPRoute{}},
+		{resource: "udproutes", obj: &gatewayv1alpha2.UDPRoute{}},
+		{resource: "tlsroutes", obj: &gatewayv1alpha2.TLSRoute{}},
+	} {
+		ok, err := checker.CRDExists(gatewayv1alpha2.SchemeGroupVersion.WithResource(route.resource))
+		if err != nil {
+			return fmt.Errorf("failed checking if %s CRD exists: %w", route.resource, err)
+		}
+		if !ok {
+			continue
+		}
+		b = b.Watches(
+			route.obj,
+			handler.EnqueueRequestsFromMapFunc(r.listGatewaysAttachedByL4Route))
+	}
+
+	return b.Complete(r)
 }
 
 // Reconcile moves the current state of an object to the intended state.
@@ -190,7 +216,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	}
 
 	log.Trace(logger, "determining configuration", gateway)
-	gatewayConfig, err := r.getOrCreateGatewayConfiguration(ctx, gwc.GatewayClass)
+	gatewayConfig, err := r.getOrCreateGatewayConfiguration(ctx, gwc.GatewayClass, &gateway)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -360,6 +386,19 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	}
 
 	gwConditionAware.setProgrammed()
+	if notAssigned := gatewayAddressesNotAssigned(&gateway, ingressServices[0]); len(notAssigned) > 0 {
+		log.Debug(logger, "requested addresses not assigned", gateway, "addresses", notAssigned)
+		k8sutils.SetCondition(
+			k8sutils.NewConditionWithGeneration(
+				k8sutils.ConditionType(gatewayv1.GatewayConditionProgrammed),
+				metav1.ConditionFalse,
+				k8sutils.ConditionReason(gatewayv1.GatewayReasonAddressNotAssigned),
+				strings.Join(notAssigned, "; "),
+				gateway.Generation,
+			),
+			gwConditionAware,
+		)
+	}
 	res, err := patch.ApplyGatewayStatusPatchIfNotEmpty(ctx, r.Client, logger, &gateway, oldGateway)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -438,7 +477,7 @@ func (r *Reconciler) provisionDataPlane(
 	// if not configured in gatewayconfiguration, compare deployment option of dataplane with an empty one.
 	expectedDataPlaneOptions := &operatorv1beta1.DataPlaneOptions{}
 	if gatewayConfig.Spec.DataPlaneOptions != nil {
-		expectedDataPlaneOptions = gatewayConfigDataPlaneOptionsToDataPlaneOptions(*gatewayConfig.Spec.DataPlaneOptions)
+		expectedDataPlaneOptions = gatewayutils.GatewayConfigDataPlaneOptionsToDataPlaneOptions(*gatewayConfig.Spec.DataPlaneOptions)
 	}
 	// Don't require setting defaults for DataPlane when using Gateway CRD.
 	setDataPlaneOptionsDefaults(expectedDataPlaneOptions, r.DefaultDataPlaneImage)
@@ -451,11 +490,17 @@ func (r *Reconciler) provisionDataPlane(
 		)
 		return nil, errWrap
 	}
+	setDataPlaneStreamListen(expectedDataPlaneOptions, gateway.Spec.Listeners)
+	setDataPlaneIngressServiceAddresses(expectedDataPlaneOptions, gateway.Spec.Addresses)
 
-	if !dataplaneSpecDeepEqual(&dataplane.Spec.DataPlaneOptions, expectedDataPlaneOptions) {
+	oldDataPlane := dataplane.DeepCopy()
+	metadataUpdated := setGatewayInfrastructureMetadata(dataplane, gateway)
+	if metadataUpdated ||
+		!dataplaneSpecDeepEqual(&dataplane.Spec.DataPlaneOptions, expectedDataPlaneOptions) ||
+		!reflect.DeepEqual(dataplane.Spec.CertificateIssuer, gatewayConfig.Spec.CertificateIssuer) {
 		log.Trace(logger, "dataplane config is out of date, updating", gateway)
-		oldDataPlane := dataplane.DeepCopy()
 		dataplane.Spec.DataPlaneOptions = *expectedDataPlaneOptions
+		dataplane.Spec.CertificateIssuer = gatewayConfig.Spec.CertificateIssuer.DeepCopy()
 
 		if err = r.Client.Patch(ctx, dataplane, client.MergeFrom(oldDataPlane)); err != nil {
 			k8sutils.SetCondition(
@@ -554,10 +599,14 @@ func (r *Reconciler) provisionControlPlane(
 	// Don't require setting defaults for ControlPlane when using Gateway CRD.
 	setControlPlaneOptionsDefaults(expectedControlPlaneOptions)
 
-	if !controlplanecontroller.SpecDeepEqual(&controlPlane.Spec.ControlPlaneOptions, expectedControlPlaneOptions) {
+	controlplaneOld := controlPlane.DeepCopy()
+	metadataUpdated := setGatewayInfrastructureMetadata(controlPlane, gateway)
+	if metadataUpdated ||
+		!controlplanecontroller.SpecDeepEqual(&controlPlane.Spec.ControlPlaneOptions, expectedControlPlaneOptions) ||
+		!reflect.DeepEqual(controlPlane.Spec.CertificateIssuer, gatewayConfig.Spec.CertificateIssuer) {
 		log.Trace(logger, "controlplane config is out of date, updating", gateway)
-		controlplaneOld := controlPlane.DeepCopy()
 		controlPlane.Spec.ControlPlaneOptions = *expectedControlPlaneOptions
+		controlPlane.Spec.CertificateIssuer = gatewayConfig.Spec.CertificateIssuer.DeepCopy()
 		if err := r.Client.Patch(ctx, controlPlane, client.MergeFrom(controlplaneOld)); err != nil {
 			k8sutils.SetCondition(
 				createControlPlaneCondition(metav1.ConditionFalse, k8sutils.UnableToProvisionReason, err.Error(), gateway.Generation),
@@ -612,7 +661,8 @@ func setControlPlaneOptionsDefaults(opts *operatorv1beta1.ControlPlaneOptions) {
 		})
 	}
 
-	if opts.Deployment.Replicas == nil {
+	// If no replicas are set, set it to default 1, but only if Scaling is not set as well.
+	if opts.Deployment.Replicas == nil && opts.Deployment.Scaling == nil {
 		opts.Deployment.Replicas = lo.ToPtr(int32(1))
 	}
 }
@@ -686,6 +736,10 @@ func deploymentOptionsDeepEqual(o1, o2 *operatorv1beta1.DeploymentOptions, envVa
 		return false
 	}
 
+	if !reflect.DeepEqual(o1.PodDisruptionBudget, o2.PodDisruptionBudget) {
+		return false
+	}
+
 	opts := []cmp.Option{
 		cmp.Comparer(k8sresources.ResourceRequirementsEqual),
 		cmp.Comparer(func(a, b []corev1.EnvVar) bool {
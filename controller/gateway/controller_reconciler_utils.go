@@ -2,10 +2,10 @@ package gateway
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
-	"net/http"
 	"strconv"
 	"strings"
 
@@ -15,10 +15,12 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
@@ -26,6 +28,7 @@ import (
 	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
 	operatorerrors "github.com/kong/gateway-operator/internal/errors"
 	gwtypes "github.com/kong/gateway-operator/internal/types"
+	dputils "github.com/kong/gateway-operator/internal/utils/dataplane"
 	"github.com/kong/gateway-operator/internal/utils/gatewayclass"
 	"github.com/kong/gateway-operator/pkg/consts"
 	gatewayutils "github.com/kong/gateway-operator/pkg/utils/gateway"
@@ -50,14 +53,18 @@ func (r *Reconciler) createDataPlane(ctx context.Context,
 		},
 	}
 	if gatewayConfig.Spec.DataPlaneOptions != nil {
-		dataplane.Spec.DataPlaneOptions = *gatewayConfigDataPlaneOptionsToDataPlaneOptions(*gatewayConfig.Spec.DataPlaneOptions)
+		dataplane.Spec.DataPlaneOptions = *gatewayutils.GatewayConfigDataPlaneOptionsToDataPlaneOptions(*gatewayConfig.Spec.DataPlaneOptions)
 	}
 	setDataPlaneOptionsDefaults(&dataplane.Spec.DataPlaneOptions, r.DefaultDataPlaneImage)
 	if err := setDataPlaneIngressServicePorts(&dataplane.Spec.DataPlaneOptions, gateway.Spec.Listeners); err != nil {
 		return nil, err
 	}
+	setDataPlaneStreamListen(&dataplane.Spec.DataPlaneOptions, gateway.Spec.Listeners)
+	setDataPlaneIngressServiceAddresses(&dataplane.Spec.DataPlaneOptions, gateway.Spec.Addresses)
+	dataplane.Spec.CertificateIssuer = gatewayConfig.Spec.CertificateIssuer.DeepCopy()
 	k8sutils.SetOwnerForObject(dataplane, gateway)
 	gatewayutils.LabelObjectAsGatewayManaged(dataplane)
+	setGatewayInfrastructureMetadata(dataplane, gateway)
 	err := r.Client.Create(ctx, dataplane)
 	if err != nil {
 		return nil, err
@@ -78,7 +85,8 @@ func (r *Reconciler) createControlPlane(
 			GenerateName: k8sutils.TrimGenerateName(fmt.Sprintf("%s-", gateway.Name)),
 		},
 		Spec: operatorv1beta1.ControlPlaneSpec{
-			GatewayClass: (*gatewayv1.ObjectName)(&gatewayClass.Name),
+			GatewayClass:      (*gatewayv1.ObjectName)(&gatewayClass.Name),
+			CertificateIssuer: gatewayConfig.Spec.CertificateIssuer.DeepCopy(),
 		},
 	}
 	if gatewayConfig.Spec.ControlPlaneOptions != nil {
@@ -91,9 +99,28 @@ func (r *Reconciler) createControlPlane(
 	setControlPlaneOptionsDefaults(&controlplane.Spec.ControlPlaneOptions)
 	k8sutils.SetOwnerForObject(controlplane, gateway)
 	gatewayutils.LabelObjectAsGatewayManaged(controlplane)
+	setGatewayInfrastructureMetadata(controlplane, gateway)
 	return r.Client.Create(ctx, controlplane)
 }
 
+// setGatewayInfrastructureMetadata applies the labels and annotations from the
+// Gateway's spec.infrastructure to the provided object generated for the Gateway.
+// It returns true when the object's labels or annotations were changed.
+func setGatewayInfrastructureMetadata(obj metav1.Object, gateway *gwtypes.Gateway) bool {
+	var labels, annotations map[string]string
+	if infra := gateway.Spec.Infrastructure; infra != nil {
+		labels = make(map[string]string, len(infra.Labels))
+		for k, v := range infra.Labels {
+			labels[string(k)] = string(v)
+		}
+		annotations = make(map[string]string, len(infra.Annotations))
+		for k, v := range infra.Annotations {
+			annotations[string(k)] = string(v)
+		}
+	}
+	return k8sresources.SetInfrastructureMetadata(obj, labels, annotations)
+}
+
 func (r *Reconciler) getGatewayAddresses(
 	ctx context.Context,
 	dataplane *operatorv1beta1.DataPlane,
@@ -125,28 +152,6 @@ func (r *Reconciler) getGatewayAddresses(
 	return gatewayAddressesFromService(services[0])
 }
 
-func gatewayConfigDataPlaneOptionsToDataPlaneOptions(opts operatorv1beta1.GatewayConfigDataPlaneOptions) *operatorv1beta1.DataPlaneOptions {
-	dataPlaneOptions := &operatorv1beta1.DataPlaneOptions{
-		Deployment: opts.Deployment,
-	}
-
-	if opts.Network.Services != nil && opts.Network.Services.Ingress != nil {
-		dataPlaneOptions.Network = operatorv1beta1.DataPlaneNetworkOptions{
-			Services: &operatorv1beta1.DataPlaneServices{
-				Ingress: &operatorv1beta1.DataPlaneServiceOptions{
-					ServiceOptions: operatorv1beta1.ServiceOptions{
-						Type:                  opts.Network.Services.Ingress.Type,
-						Annotations:           opts.Network.Services.Ingress.Annotations,
-						ExternalTrafficPolicy: opts.Network.Services.Ingress.ExternalTrafficPolicy,
-					},
-				},
-			},
-		}
-	}
-
-	return dataPlaneOptions
-}
-
 func gatewayAddressesFromService(svc corev1.Service) ([]gwtypes.GatewayStatusAddress, error) {
 	addresses := make([]gwtypes.GatewayStatusAddress, 0, len(svc.Status.LoadBalancer.Ingress))
 
@@ -178,6 +183,14 @@ func gatewayAddressesFromService(svc corev1.Service) ([]gwtypes.GatewayStatusAdd
 		})
 	}
 
+	// External IPs are routed to the Service by the cluster nodes, regardless of its type.
+	for _, ip := range svc.Spec.ExternalIPs {
+		addresses = append(addresses, gwtypes.GatewayStatusAddress{
+			Value: ip,
+			Type:  lo.ToPtr(gatewayv1.IPAddressType),
+		})
+	}
+
 	return addresses, nil
 }
 
@@ -198,53 +211,63 @@ func (r *Reconciler) verifyGatewayClassSupport(ctx context.Context, gateway *gwt
 	return gwc, nil
 }
 
-func (r *Reconciler) getOrCreateGatewayConfiguration(ctx context.Context, gatewayClass *gatewayv1.GatewayClass) (*operatorv1beta1.GatewayConfiguration, error) {
-	gatewayConfig, err := r.getGatewayConfigForGatewayClass(ctx, gatewayClass)
+// getOrCreateGatewayConfiguration returns the GatewayConfiguration which applies
+// to the provided Gateway. The GatewayConfiguration referenced by the Gateway's
+// infrastructure parametersRef is layered over the one referenced by its
+// GatewayClass so that Gateways can override the class-level options.
+func (r *Reconciler) getOrCreateGatewayConfiguration(
+	ctx context.Context,
+	gatewayClass *gatewayv1.GatewayClass,
+	gateway *gwtypes.Gateway,
+) (*operatorv1beta1.GatewayConfiguration, error) {
+	gatewayConfig, err := gatewayutils.GetGatewayConfigurationForGatewayClass(ctx, r.Client, gatewayClass)
+	if err != nil {
+		if !errors.Is(err, operatorerrors.ErrObjectMissingParametersRef) {
+			return nil, err
+		}
+		gatewayConfig = new(operatorv1beta1.GatewayConfiguration)
+	}
+
+	gatewayLevelConfig, err := gatewayutils.GetGatewayConfigurationForGateway(ctx, r.Client, gateway)
 	if err != nil {
 		if errors.Is(err, operatorerrors.ErrObjectMissingParametersRef) {
-			return new(operatorv1beta1.GatewayConfiguration), nil
+			return gatewayConfig, nil
 		}
-		return nil, err
+		return nil, fmt.Errorf("failed getting GatewayConfiguration for Gateway %s: %w", client.ObjectKeyFromObject(gateway), err)
 	}
 
-	return gatewayConfig, nil
+	return mergeGatewayConfigurations(gatewayConfig, gatewayLevelConfig)
 }
 
-func (r *Reconciler) getGatewayConfigForGatewayClass(ctx context.Context, gatewayClass *gatewayv1.GatewayClass) (*operatorv1beta1.GatewayConfiguration, error) {
-	if gatewayClass.Spec.ParametersRef == nil {
-		return nil, fmt.Errorf("%w, gatewayClass = %s", operatorerrors.ErrObjectMissingParametersRef, gatewayClass.Name)
-	}
-
-	if string(gatewayClass.Spec.ParametersRef.Group) != operatorv1beta1.SchemeGroupVersion.Group ||
-		string(gatewayClass.Spec.ParametersRef.Kind) != "GatewayConfiguration" {
-		return nil, &k8serrors.StatusError{
-			ErrStatus: metav1.Status{
-				Status: metav1.StatusFailure,
-				Code:   http.StatusBadRequest,
-				Reason: metav1.StatusReasonInvalid,
-				Details: &metav1.StatusDetails{
-					Kind: string(gatewayClass.Spec.ParametersRef.Kind),
-					Causes: []metav1.StatusCause{{
-						Type: metav1.CauseTypeFieldValueNotSupported,
-						Message: fmt.Sprintf("controller only supports %s %s resources for GatewayClass parametersRef",
-							operatorv1beta1.SchemeGroupVersion.Group, "GatewayConfiguration"),
-					}},
-				},
-			},
-		}
+// mergeGatewayConfigurations layers the override GatewayConfiguration over the
+// base one using a strategic merge patch of their specs: fields set in the
+// override replace the ones from the base while lists like the pod template's
+// containers are merged by their keys.
+func mergeGatewayConfigurations(
+	base, override *operatorv1beta1.GatewayConfiguration,
+) (*operatorv1beta1.GatewayConfiguration, error) {
+	baseBytes, err := json.Marshal(base.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal GatewayConfiguration %s: %w", client.ObjectKeyFromObject(base), err)
+	}
+	overrideBytes, err := json.Marshal(override.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal GatewayConfiguration %s: %w", client.ObjectKeyFromObject(override), err)
+	}
+
+	mergedBytes, err := strategicpatch.StrategicMergePatch(baseBytes, overrideBytes, &operatorv1beta1.GatewayConfigurationSpec{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge GatewayConfiguration %s into %s: %w",
+			client.ObjectKeyFromObject(override), client.ObjectKeyFromObject(base), err)
 	}
 
-	if gatewayClass.Spec.ParametersRef.Namespace == nil ||
-		*gatewayClass.Spec.ParametersRef.Namespace == "" ||
-		gatewayClass.Spec.ParametersRef.Name == "" {
-		return nil, fmt.Errorf("GatewayClass %s has invalid ParametersRef: both namespace and name must be provided", gatewayClass.Name)
+	merged := override.DeepCopy()
+	merged.Spec = operatorv1beta1.GatewayConfigurationSpec{}
+	if err := json.Unmarshal(mergedBytes, &merged.Spec); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal merged GatewayConfiguration %s: %w", client.ObjectKeyFromObject(override), err)
 	}
 
-	gatewayConfig := new(operatorv1beta1.GatewayConfiguration)
-	return gatewayConfig, r.Client.Get(ctx, client.ObjectKey{
-		Namespace: string(*gatewayClass.Spec.ParametersRef.Namespace),
-		Name:      gatewayClass.Spec.ParametersRef.Name,
-	}, gatewayConfig)
+	return merged, nil
 }
 
 func (r *Reconciler) ensureDataPlaneHasNetworkPolicy(
@@ -272,6 +295,7 @@ func (r *Reconciler) ensureDataPlaneHasNetworkPolicy(
 	}
 	k8sutils.SetOwnerForObject(generatedPolicy, gateway)
 	gatewayutils.LabelObjectAsGatewayManaged(generatedPolicy)
+	setGatewayInfrastructureMetadata(generatedPolicy, gateway)
 
 	if count == 1 {
 		var (
@@ -279,7 +303,8 @@ func (r *Reconciler) ensureDataPlaneHasNetworkPolicy(
 			existingPolicy = &networkPolicies[0]
 			old            = existingPolicy.DeepCopy()
 		)
-		metaUpdated, existingPolicy.ObjectMeta = k8sutils.EnsureObjectMetaIsUpdated(existingPolicy.ObjectMeta, generatedPolicy.ObjectMeta)
+		metaUpdated, existingPolicy.ObjectMeta = k8sutils.EnsureObjectMetaIsUpdated(existingPolicy.ObjectMeta, generatedPolicy.ObjectMeta,
+			k8sresources.EnsureInfrastructureAnnotationsAreUpdated)
 
 		if k8sresources.EnsureNetworkPolicyIsUpdated(existingPolicy, generatedPolicy) || metaUpdated {
 			if err := r.Client.Patch(ctx, existingPolicy, client.MergeFrom(old)); err != nil {
@@ -529,11 +554,9 @@ func supportedRoutesByProtocol() map[gatewayv1.ProtocolType]map[gatewayv1.Kind]s
 	return map[gatewayv1.ProtocolType]map[gatewayv1.Kind]struct{}{
 		gatewayv1.HTTPProtocolType:  {"HTTPRoute": {}},
 		gatewayv1.HTTPSProtocolType: {"HTTPRoute": {}},
-
-		// L4 routes not supported yet
-		// gatewayv1.TLSProtocolType:   {"TLSRoute": {}},
-		// gatewayv1.TCPProtocolType:   {"TCPRoute": {}},
-		// gatewayv1.UDPProtocolType:   {"UDPRoute": {}},
+		gatewayv1.TLSProtocolType:   {"TLSRoute": {}},
+		gatewayv1.TCPProtocolType:   {"TCPRoute": {}},
+		gatewayv1.UDPProtocolType:   {"UDPRoute": {}},
 	}
 }
 
@@ -686,44 +709,60 @@ func countAttachedRoutesForGatewayListener(ctx context.Context, g *gwtypes.Gatew
 	}
 
 	kindsForProtocol, protocolSupported := supportedRoutesByProtocol()[listener.Protocol]
-	switch len(allowedRoutes.Kinds) {
-	case 0:
-		if protocolSupported {
-			for k := range kindsForProtocol {
-				// NOTE: Count other types of routes when they are supported.
-
-				switch k {
-				case "HTTPRoute":
-					httpRoutes, err := gatewayutils.ListHTTPRoutesForGateway(ctx, cl, g, opts...)
-					if err != nil {
-						return 0, fmt.Errorf(
-							"failed to list HTTPRoutes for Gateway %s when counting AttachedRoutes: %w",
-							client.ObjectKeyFromObject(g), err,
-						)
-					}
-					count += int32(len(httpRoutes))
-				default:
-					return 0, fmt.Errorf("unsupported route kind: %T", k)
-				}
-			}
+	if !protocolSupported {
+		return 0, nil
+	}
+
+	// Count only the route kinds that are both supported by the listener's
+	// protocol and allowed by the listener.
+	kinds := lo.Keys(kindsForProtocol)
+	if len(allowedRoutes.Kinds) > 0 {
+		kinds = lo.Filter(kinds, func(k gatewayv1.Kind, _ int) bool {
+			return lo.ContainsBy(allowedRoutes.Kinds, func(gvk gatewayv1.RouteGroupKind) bool {
+				return gvk.Kind == k &&
+					gvk.Group != nil && *gvk.Group == gatewayv1.Group(gatewayv1.GroupVersion.Group)
+			})
+		})
+	}
+
+	for _, k := range kinds {
+		var (
+			routesCount int
+			err         error
+		)
+		switch k {
+		case "HTTPRoute":
+			var routes []gwtypes.HTTPRoute
+			routes, err = gatewayutils.ListHTTPRoutesForGateway(ctx, cl, g, opts...)
+			routesCount = len(routes)
+		case "TCPRoute":
+			var routes []gwtypes.TCPRoute
+			routes, err = gatewayutils.ListTCPRoutesForGateway(ctx, cl, g, opts...)
+			routesCount = len(routes)
+		case "UDPRoute":
+			var routes []gwtypes.UDPRoute
+			routes, err = gatewayutils.ListUDPRoutesForGateway(ctx, cl, g, opts...)
+			routesCount = len(routes)
+		case "TLSRoute":
+			var routes []gwtypes.TLSRoute
+			routes, err = gatewayutils.ListTLSRoutesForGateway(ctx, cl, g, opts...)
+			routesCount = len(routes)
+		default:
+			return 0, fmt.Errorf("unsupported route kind: %s", k)
 		}
-	default:
-		if lo.ContainsBy(allowedRoutes.Kinds, func(gvk gatewayv1.RouteGroupKind) bool {
-			if _, ok := kindsForProtocol[gvk.Kind]; !ok {
-				return false
-			}
-			return gvk.Group != nil && *gvk.Group == gatewayv1.Group(gatewayv1.GroupVersion.Group)
-		}) {
-			httpRoutes, err := gatewayutils.ListHTTPRoutesForGateway(ctx, cl, g, opts...)
-			if err != nil {
-				return 0, fmt.Errorf(
-					"failed to list HTTPRoutes for Gateway %s when counting AttachedRoutes: %w",
-					client.ObjectKeyFromObject(g), err,
-				)
+		if err != nil {
+			// L4 routes are part of Gateway API's experimental channel so their
+			// CRDs might not be installed in the cluster. In that case there
+			// are no routes to count.
+			if meta.IsNoMatchError(err) {
+				continue
 			}
-
-			count += int32(len(httpRoutes))
+			return 0, fmt.Errorf(
+				"failed to list %ss for Gateway %s when counting AttachedRoutes: %w",
+				k, client.ObjectKeyFromObject(g), err,
+			)
 		}
+		count += int32(routesCount)
 	}
 
 	return count, nil
@@ -804,7 +843,12 @@ func setDataPlaneIngressServicePorts(opts *operatorv1beta1.DataPlaneOptions, lis
 		}
 	}
 
-	var errs error
+	var (
+		errs error
+		// streamTargetPorts maps the ports of the DataPlane's stream listeners
+		// to the ports of the Gateway listeners they serve.
+		streamTargetPorts = make(map[int]gatewayv1.PortNumber)
+	)
 	for i, l := range listeners {
 		var name string
 		// If the listener name is set, use it. Otherwise, we need to be sure the
@@ -823,6 +867,21 @@ func setDataPlaneIngressServicePorts(opts *operatorv1beta1.DataPlaneOptions, lis
 			port.TargetPort = intstr.FromInt(consts.DataPlaneProxySSLPort)
 		case gatewayv1.HTTPProtocolType:
 			port.TargetPort = intstr.FromInt(consts.DataPlaneProxyPort)
+		case gatewayv1.TCPProtocolType, gatewayv1.TLSProtocolType, gatewayv1.UDPProtocolType:
+			targetPort := dataPlaneStreamListenPort(l.Port)
+			if isDataPlaneReservedPort(targetPort) {
+				errs = errors.Join(errs, fmt.Errorf("listener %d uses port %d which is reserved by the DataPlane", i, l.Port))
+				continue
+			}
+			if listenerPort, ok := streamTargetPorts[targetPort]; ok && listenerPort != l.Port {
+				errs = errors.Join(errs, fmt.Errorf("listener %d uses port %d which is served by the DataPlane on the same port as port %d", i, l.Port, listenerPort))
+				continue
+			}
+			streamTargetPorts[targetPort] = l.Port
+			port.TargetPort = intstr.FromInt(targetPort)
+			if l.Protocol == gatewayv1.UDPProtocolType {
+				port.Protocol = corev1.ProtocolUDP
+			}
 		default:
 			errs = errors.Join(errs, fmt.Errorf("listener %d uses unsupported protocol %s", i, l.Protocol))
 			continue
@@ -832,6 +891,270 @@ func setDataPlaneIngressServicePorts(opts *operatorv1beta1.DataPlaneOptions, lis
 	return errs
 }
 
+// isDataPlaneReservedPort returns true if the provided port is used by the DataPlane
+// for purposes other than proxying L4 traffic.
+func isDataPlaneReservedPort(port int) bool {
+	return lo.Contains([]int{
+		consts.DataPlaneProxyPort,
+		consts.DataPlaneProxySSLPort,
+		consts.DataPlaneAdminAPIPort,
+		consts.DataPlaneStatusPort,
+		consts.DataPlaneMetricsPort,
+	}, port)
+}
+
+// dataPlaneStreamListenPortOffset is the offset between the ports of Gateway's L4
+// listeners using privileged ports (below 1024) and the ports of the DataPlane's
+// stream listeners serving them. This way Kong doesn't need to run as root (or with
+// the NET_BIND_SERVICE capability) to serve such listeners.
+const dataPlaneStreamListenPortOffset = 10000
+
+// dataPlaneStreamListenPort returns the port of the DataPlane's stream listener which
+// serves the Gateway's L4 listener with the provided port.
+func dataPlaneStreamListenPort(port gatewayv1.PortNumber) int {
+	if port < 1024 {
+		return int(port) + dataPlaneStreamListenPortOffset
+	}
+	return int(port)
+}
+
+// setDataPlaneStreamListen configures the KONG_STREAM_LISTEN environment variable of the
+// DataPlane proxy container so that Kong listens on the ports serving the Gateway's TCP,
+// UDP and TLS listeners. Any KONG_STREAM_LISTEN value already set is overridden when the
+// Gateway has such listeners.
+//
+// Kong matches L4 routes by the port on which the connection was received, hence when
+// some of these listeners are served on a different port than the listener's one,
+// KONG_PORT_MAPS is extended with the mapping of the Gateway's listener ports to the
+// ports Kong listens on, so that routes keep being matched by the listener ports.
+func setDataPlaneStreamListen(opts *operatorv1beta1.DataPlaneOptions, listeners []gatewayv1.Listener) {
+	streamListen := generateKongStreamListen(listeners)
+	if streamListen == "" || opts.Deployment.PodTemplateSpec == nil {
+		return
+	}
+	container := k8sutils.GetPodContainerByName(&opts.Deployment.PodTemplateSpec.Spec, consts.DataPlaneProxyContainerName)
+	if container == nil {
+		return
+	}
+	container.Env = k8sutils.UpdateEnv(container.Env, consts.EnvVarKongStreamListen, streamListen)
+
+	portMaps := dputils.KongDefaults[consts.EnvVarKongPortMaps]
+	if env, found := lo.Find(container.Env, func(e corev1.EnvVar) bool { return e.Name == consts.EnvVarKongPortMaps }); found {
+		if env.ValueFrom != nil {
+			// The port maps are not managed by the operator when they are sourced
+			// from another object.
+			return
+		}
+		portMaps = env.Value
+	}
+	if portMaps = generateKongPortMaps(portMaps, listeners); portMaps != "" {
+		container.Env = k8sutils.UpdateEnv(container.Env, consts.EnvVarKongPortMaps, portMaps)
+	}
+}
+
+// generateKongPortMaps extends the provided port_maps Kong configuration with the mapping
+// of the ports of the provided listeners to the ports Kong listens on for them. Mappings
+// already present for the listener ports are preserved. It returns an empty string when
+// all L4 listeners are served on their own ports, as no mapping is needed then.
+func generateKongPortMaps(portMaps string, listeners []gatewayv1.Listener) string {
+	if !lo.ContainsBy(listeners, func(l gatewayv1.Listener) bool {
+		return isStreamListener(l) && dataPlaneStreamListenPort(l.Port) != int(l.Port)
+	}) {
+		return ""
+	}
+
+	var (
+		entries = make([]string, 0)
+		mapped  = make(map[string]struct{})
+	)
+	for _, entry := range strings.Split(portMaps, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		hostPort, _, _ := strings.Cut(entry, ":")
+		mapped[hostPort] = struct{}{}
+		entries = append(entries, entry)
+	}
+	for _, l := range listeners {
+		var targetPort int
+		switch {
+		case l.Protocol == gatewayv1.HTTPProtocolType:
+			targetPort = consts.DataPlaneProxyPort
+		case l.Protocol == gatewayv1.HTTPSProtocolType:
+			targetPort = consts.DataPlaneProxySSLPort
+		case isStreamListener(l):
+			targetPort = dataPlaneStreamListenPort(l.Port)
+		default:
+			continue
+		}
+		hostPort := strconv.Itoa(int(l.Port))
+		if _, ok := mapped[hostPort]; ok {
+			continue
+		}
+		mapped[hostPort] = struct{}{}
+		entries = append(entries, fmt.Sprintf("%s:%d", hostPort, targetPort))
+	}
+	return strings.Join(entries, ", ")
+}
+
+// isStreamListener returns true if the provided listener is served by the DataPlane's
+// stream listeners.
+func isStreamListener(l gatewayv1.Listener) bool {
+	return l.Protocol == gatewayv1.TCPProtocolType ||
+		l.Protocol == gatewayv1.TLSProtocolType ||
+		l.Protocol == gatewayv1.UDPProtocolType
+}
+
+// generateKongStreamListen generates the stream_listen Kong configuration for the
+// provided listeners. It returns an empty string when there are no L4 listeners.
+//
+// TLS listeners in Terminate mode have the TLS connection terminated by Kong, while
+// in Passthrough mode the connection is proxied as is, routed by its SNI.
+func generateKongStreamListen(listeners []gatewayv1.Listener) string {
+	var (
+		entries = make([]string, 0)
+		seen    = make(map[string]struct{})
+	)
+	for _, l := range listeners {
+		var entry string
+		switch l.Protocol {
+		case gatewayv1.TCPProtocolType:
+			entry = fmt.Sprintf("0.0.0.0:%d reuseport backlog=16384", dataPlaneStreamListenPort(l.Port))
+		case gatewayv1.UDPProtocolType:
+			entry = fmt.Sprintf("0.0.0.0:%d udp reuseport", dataPlaneStreamListenPort(l.Port))
+		case gatewayv1.TLSProtocolType:
+			if l.TLS != nil && l.TLS.Mode != nil && *l.TLS.Mode == gatewayv1.TLSModeTerminate {
+				entry = fmt.Sprintf("0.0.0.0:%d ssl reuseport backlog=16384", dataPlaneStreamListenPort(l.Port))
+			} else {
+				entry = fmt.Sprintf("0.0.0.0:%d reuseport backlog=16384", dataPlaneStreamListenPort(l.Port))
+			}
+		default:
+			continue
+		}
+		if _, ok := seen[entry]; ok {
+			continue
+		}
+		seen[entry] = struct{}{}
+		entries = append(entries, entry)
+	}
+	return strings.Join(entries, ", ")
+}
+
+// gatewayServiceAddresses holds the Gateway's requested addresses sorted by the way
+// they are carried into the DataPlane's ingress Service.
+type gatewayServiceAddresses struct {
+	// loadBalancerIP is the IP address requested as the Service's load balancer IP.
+	loadBalancerIP string
+	// externalIPs are the IP addresses requested as the Service's external IPs.
+	externalIPs []string
+	// hostnames are the hostnames published through the external-dns annotation.
+	hostnames []string
+	// unassignable are the addresses which cannot be assigned to the Service
+	// along with the reason why.
+	unassignable []string
+}
+
+// classifyGatewayAddresses sorts the addresses requested in Gateway's spec.addresses
+// depending on the type of the DataPlane's ingress Service:
+//   - for LoadBalancer Services the first IP address is requested as the load balancer IP
+//     and hostnames are published through the external-dns hostname annotation,
+//   - for other Service types IP addresses are requested as external IPs.
+//
+// All other addresses cannot be assigned to the Service.
+func classifyGatewayAddresses(addresses []gwtypes.GatewayAddress, serviceType corev1.ServiceType) gatewayServiceAddresses {
+	if serviceType == "" {
+		serviceType = k8sresources.DefaultDataPlaneIngressServiceType
+	}
+
+	var res gatewayServiceAddresses
+	for _, a := range addresses {
+		addressType := gatewayv1.IPAddressType
+		if a.Type != nil {
+			addressType = *a.Type
+		}
+
+		switch addressType {
+		case gatewayv1.IPAddressType:
+			if net.ParseIP(a.Value) == nil {
+				res.unassignable = append(res.unassignable, fmt.Sprintf("%s is not a valid IP address", a.Value))
+				continue
+			}
+			switch {
+			case serviceType != corev1.ServiceTypeLoadBalancer:
+				res.externalIPs = append(res.externalIPs, a.Value)
+			case res.loadBalancerIP == "":
+				res.loadBalancerIP = a.Value
+			default:
+				res.unassignable = append(res.unassignable,
+					fmt.Sprintf("%s cannot be assigned, only one IP address can be requested for a %s Service", a.Value, serviceType))
+			}
+		case gatewayv1.HostnameAddressType:
+			if serviceType != corev1.ServiceTypeLoadBalancer {
+				res.unassignable = append(res.unassignable,
+					fmt.Sprintf("hostname %s cannot be assigned to a %s Service", a.Value, serviceType))
+				continue
+			}
+			res.hostnames = append(res.hostnames, a.Value)
+		default:
+			res.unassignable = append(res.unassignable, fmt.Sprintf("address type %s of %s is not supported", addressType, a.Value))
+		}
+	}
+	return res
+}
+
+// setDataPlaneIngressServiceAddresses carries the addresses requested in Gateway's
+// spec.addresses into the DataPlane's ingress Service options.
+func setDataPlaneIngressServiceAddresses(opts *operatorv1beta1.DataPlaneOptions, addresses []gwtypes.GatewayAddress) {
+	if len(addresses) == 0 {
+		return
+	}
+
+	if opts.Network.Services == nil {
+		opts.Network.Services = &operatorv1beta1.DataPlaneServices{}
+	}
+	if opts.Network.Services.Ingress == nil {
+		opts.Network.Services.Ingress = &operatorv1beta1.DataPlaneServiceOptions{}
+	}
+	ingress := opts.Network.Services.Ingress
+
+	serviceAddresses := classifyGatewayAddresses(addresses, ingress.Type)
+	ingress.LoadBalancerIP = serviceAddresses.loadBalancerIP
+	ingress.ExternalIPs = serviceAddresses.externalIPs
+	if len(serviceAddresses.hostnames) > 0 {
+		// The annotations can be shared with the GatewayConfiguration, hence the copy.
+		annotations := make(map[string]string, len(ingress.Annotations)+1)
+		for k, v := range ingress.Annotations {
+			annotations[k] = v
+		}
+		annotations[consts.ExternalDNSHostnameAnnotation] = strings.Join(serviceAddresses.hostnames, ",")
+		ingress.Annotations = annotations
+	}
+}
+
+// gatewayAddressesNotAssigned returns the reasons why the addresses requested in
+// Gateway's spec.addresses are not assigned to the Gateway. IP addresses are
+// considered assigned once the ingress Service reports them, while hostnames are
+// considered assigned once they are published through the external-dns annotation.
+func gatewayAddressesNotAssigned(gateway *gwtypes.Gateway, svc corev1.Service) []string {
+	serviceAddresses := classifyGatewayAddresses(gateway.Spec.Addresses, svc.Spec.Type)
+	notAssigned := serviceAddresses.unassignable
+
+	requestedIPs := serviceAddresses.externalIPs
+	if serviceAddresses.loadBalancerIP != "" {
+		requestedIPs = append([]string{serviceAddresses.loadBalancerIP}, requestedIPs...)
+	}
+	for _, ip := range requestedIPs {
+		assigned := lo.ContainsBy(gateway.Status.Addresses, func(a gwtypes.GatewayStatusAddress) bool {
+			return (a.Type == nil || *a.Type == gatewayv1.IPAddressType) && a.Value == ip
+		})
+		if !assigned {
+			notAssigned = append(notAssigned, fmt.Sprintf("%s has not been assigned to Service %s yet", ip, svc.Name))
+		}
+	}
+	return notAssigned
+}
+
 // getSupportedKindsWithResolvedRefsCondition returns all the route kinds supported by the listener, along with the resolvedRefs
 // condition, that is based on the presence of errors in such a field.
 func getSupportedKindsWithResolvedRefsCondition(ctx context.Context, c client.Client, gatewayNamespace string, generation int64, listener gatewayv1.Listener) (supportedKinds []gatewayv1.RouteGroupKind, resolvedRefsCondition metav1.Condition, err error) {
@@ -846,13 +1169,17 @@ func getSupportedKindsWithResolvedRefsCondition(ctx context.Context, c client.Cl
 	}
 
 	message := ""
+	isTLSPassthrough := listener.TLS != nil && listener.TLS.Mode != nil && *listener.TLS.Mode == gatewayv1.TLSModePassthrough
 	if listener.TLS != nil {
-		// We currently do not support TLSRoutes, hence only TLS termination supported.
-		if *listener.TLS.Mode != gatewayv1.TLSModeTerminate {
+		// TLS passthrough is only supported for TLS listeners (used with TLSRoutes),
+		// HTTPS listeners always terminate TLS.
+		if isTLSPassthrough && listener.Protocol != gatewayv1.TLSProtocolType {
 			resolvedRefsCondition.Status = metav1.ConditionFalse
 			resolvedRefsCondition.Reason = string(gatewayv1.ListenerReasonInvalidCertificateRef)
 			message = conditionMessage(message, "Only Terminate mode is supported")
 		}
+	}
+	if listener.TLS != nil && !isTLSPassthrough {
 		// We currently do not support more that one listener certificate.
 		if len(listener.TLS.CertificateRefs) != 1 {
 			resolvedRefsCondition.Reason = string(ListenerReasonTooManyTLSSecrets)
@@ -3,6 +3,7 @@ package gateway
 import (
 	"context"
 	"errors"
+	"maps"
 	"testing"
 
 	"github.com/samber/lo"
@@ -99,6 +100,27 @@ func TestGatewayAddressesFromService(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "ClusterIP Service with external IPs",
+			svc: corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Type:        "ClusterIP",
+					ClusterIP:   "198.51.100.1",
+					ExternalIPs: []string{"203.0.113.10"},
+				},
+			},
+			addresses: []gwtypes.GatewayStatusAddress{
+				{
+					Value: "198.51.100.1",
+					Type:  lo.ToPtr(gatewayv1.IPAddressType),
+				},
+				{
+					Value: "203.0.113.10",
+					Type:  lo.ToPtr(gatewayv1.IPAddressType),
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "ClusterIP Service without ClusterIP",
 			svc: corev1.Service{
@@ -549,6 +571,94 @@ func TestSetDataPlaneIngressServicePorts(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "L4 listeners",
+			listeners: []gwtypes.Listener{
+				{
+					Name:     "tcp",
+					Protocol: gatewayv1.TCPProtocolType,
+					Port:     gatewayv1.PortNumber(5432),
+				},
+				{
+					Name:     "udp",
+					Protocol: gatewayv1.UDPProtocolType,
+					Port:     gatewayv1.PortNumber(8899),
+				},
+				{
+					Name:     "tls",
+					Protocol: gatewayv1.TLSProtocolType,
+					Port:     gatewayv1.PortNumber(9443),
+				},
+			},
+			expectedPorts: []operatorv1beta1.DataPlaneServicePort{
+				{
+					Name:       "tcp",
+					Port:       5432,
+					TargetPort: intstr.FromInt(5432),
+				},
+				{
+					Name:       "udp",
+					Port:       8899,
+					TargetPort: intstr.FromInt(8899),
+					Protocol:   corev1.ProtocolUDP,
+				},
+				{
+					Name:       "tls",
+					Port:       9443,
+					TargetPort: intstr.FromInt(9443),
+				},
+			},
+		},
+		{
+			name: "L4 listeners on privileged ports are served on unprivileged ports",
+			listeners: []gwtypes.Listener{
+				{
+					Name:     "tcp",
+					Protocol: gatewayv1.TCPProtocolType,
+					Port:     gatewayv1.PortNumber(22),
+				},
+				{
+					Name:     "tls",
+					Protocol: gatewayv1.TLSProtocolType,
+					Port:     gatewayv1.PortNumber(443),
+				},
+			},
+			expectedPorts: []operatorv1beta1.DataPlaneServicePort{
+				{
+					Name:       "tcp",
+					Port:       22,
+					TargetPort: intstr.FromInt(10022),
+				},
+				{
+					Name:       "tls",
+					Port:       443,
+					TargetPort: intstr.FromInt(10443),
+				},
+			},
+		},
+		{
+			name: "L4 listeners served on the same port",
+			listeners: []gwtypes.Listener{
+				{
+					Name:     "tcp",
+					Protocol: gatewayv1.TCPProtocolType,
+					Port:     gatewayv1.PortNumber(22),
+				},
+				{
+					Name:     "tcp-unprivileged",
+					Protocol: gatewayv1.TCPProtocolType,
+					Port:     gatewayv1.PortNumber(10022),
+				},
+			},
+			expectedPorts: []operatorv1beta1.DataPlaneServicePort{
+				{
+					Name:       "tcp",
+					Port:       22,
+					TargetPort: intstr.FromInt(10022),
+				},
+			},
+			expectedError: errors.New("listener 1 uses port 10022 which is served by the DataPlane on the same port as port 22"),
+		},
 		{
 			name: "some invalid listeners",
 			listeners: []gwtypes.Listener{
@@ -558,8 +668,13 @@ func TestSetDataPlaneIngressServicePorts(t *testing.T) {
 					Port:     gatewayv1.PortNumber(80),
 				},
 				{
-					Name:     "udp",
-					Protocol: gatewayv1.UDPProtocolType,
+					Name:     "tcp",
+					Protocol: gatewayv1.TCPProtocolType,
+					Port:     gatewayv1.PortNumber(consts.DataPlaneProxyPort),
+				},
+				{
+					Name:     "unknown",
+					Protocol: gatewayv1.ProtocolType("example.com/unknown"),
 					Port:     gatewayv1.PortNumber(8899),
 				},
 			},
@@ -570,19 +685,342 @@ func TestSetDataPlaneIngressServicePorts(t *testing.T) {
 					TargetPort: intstr.FromInt(consts.DataPlaneProxyPort),
 				},
 			},
-			expectedError: errors.New("listener 1 uses unsupported protocol UDP"),
+			expectedError: errors.New("listener 1 uses port 8000 which is reserved by the DataPlane\nlistener 2 uses unsupported protocol example.com/unknown"),
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			tc := tc
-			err := setDataPlaneIngressServicePorts(&operatorv1beta1.DataPlaneOptions{}, tc.listeners)
+			opts := &operatorv1beta1.DataPlaneOptions{}
+			err := setDataPlaneIngressServicePorts(opts, tc.listeners)
 			if tc.expectedError == nil {
 				require.NoError(t, err)
 			} else {
 				require.EqualError(t, err, tc.expectedError.Error())
 			}
+			if tc.expectedPorts != nil {
+				require.Equal(t, tc.expectedPorts, opts.Network.Services.Ingress.Ports)
+			}
+		})
+	}
+}
+
+func TestSetDataPlaneStreamListen(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		listeners            []gwtypes.Listener
+		existingEnv          []corev1.EnvVar
+		expectedStreamListen string
+		expectedPortMaps     string
+	}{
+		{
+			name: "no L4 listeners",
+			listeners: []gwtypes.Listener{
+				{
+					Name:     "http",
+					Protocol: gwtypes.HTTPProtocolType,
+					Port:     gatewayv1.PortNumber(80),
+				},
+			},
+		},
+		{
+			name: "no L4 listeners, existing stream listen is kept",
+			listeners: []gwtypes.Listener{
+				{
+					Name:     "http",
+					Protocol: gwtypes.HTTPProtocolType,
+					Port:     gatewayv1.PortNumber(80),
+				},
+			},
+			existingEnv: []corev1.EnvVar{
+				{Name: consts.EnvVarKongStreamListen, Value: "0.0.0.0:9999"},
+			},
+			expectedStreamListen: "0.0.0.0:9999",
+		},
+		{
+			name: "TCP, UDP and TLS listeners",
+			listeners: []gwtypes.Listener{
+				{
+					Name:     "http",
+					Protocol: gwtypes.HTTPProtocolType,
+					Port:     gatewayv1.PortNumber(80),
+				},
+				{
+					Name:     "tcp",
+					Protocol: gatewayv1.TCPProtocolType,
+					Port:     gatewayv1.PortNumber(5432),
+				},
+				{
+					Name:     "udp",
+					Protocol: gatewayv1.UDPProtocolType,
+					Port:     gatewayv1.PortNumber(8899),
+				},
+				{
+					Name:     "tls-passthrough",
+					Protocol: gatewayv1.TLSProtocolType,
+					Port:     gatewayv1.PortNumber(9443),
+					TLS: &gatewayv1.GatewayTLSConfig{
+						Mode: lo.ToPtr(gatewayv1.TLSModePassthrough),
+					},
+				},
+				{
+					Name:     "tls-passthrough-other-host",
+					Protocol: gatewayv1.TLSProtocolType,
+					Port:     gatewayv1.PortNumber(9443),
+					TLS: &gatewayv1.GatewayTLSConfig{
+						Mode: lo.ToPtr(gatewayv1.TLSModePassthrough),
+					},
+				},
+				{
+					Name:     "tls-terminate",
+					Protocol: gatewayv1.TLSProtocolType,
+					Port:     gatewayv1.PortNumber(9444),
+					TLS: &gatewayv1.GatewayTLSConfig{
+						Mode: lo.ToPtr(gatewayv1.TLSModeTerminate),
+					},
+				},
+			},
+			existingEnv: []corev1.EnvVar{
+				{Name: consts.EnvVarKongStreamListen, Value: "0.0.0.0:9999"},
+			},
+			expectedStreamListen: "0.0.0.0:5432 reuseport backlog=16384, " +
+				"0.0.0.0:8899 udp reuseport, " +
+				"0.0.0.0:9443 reuseport backlog=16384, " +
+				"0.0.0.0:9444 ssl reuseport backlog=16384",
+		},
+		{
+			name: "L4 listeners on privileged ports",
+			listeners: []gwtypes.Listener{
+				{
+					Name:     "http",
+					Protocol: gwtypes.HTTPProtocolType,
+					Port:     gatewayv1.PortNumber(8080),
+				},
+				{
+					Name:     "tcp",
+					Protocol: gatewayv1.TCPProtocolType,
+					Port:     gatewayv1.PortNumber(22),
+				},
+				{
+					Name:     "udp",
+					Protocol: gatewayv1.UDPProtocolType,
+					Port:     gatewayv1.PortNumber(53),
+				},
+				{
+					Name:     "tcp-unprivileged",
+					Protocol: gatewayv1.TCPProtocolType,
+					Port:     gatewayv1.PortNumber(5432),
+				},
+			},
+			expectedStreamListen: "0.0.0.0:10022 reuseport backlog=16384, " +
+				"0.0.0.0:10053 udp reuseport, " +
+				"0.0.0.0:5432 reuseport backlog=16384",
+			expectedPortMaps: "80:8000, 443:8443, 8080:8000, 22:10022, 53:10053, 5432:5432",
+		},
+		{
+			name: "L4 listeners on privileged ports, existing port maps are kept",
+			listeners: []gwtypes.Listener{
+				{
+					Name:     "tcp",
+					Protocol: gatewayv1.TCPProtocolType,
+					Port:     gatewayv1.PortNumber(22),
+				},
+				{
+					Name:     "tls",
+					Protocol: gatewayv1.TLSProtocolType,
+					Port:     gatewayv1.PortNumber(443),
+				},
+			},
+			existingEnv: []corev1.EnvVar{
+				{Name: consts.EnvVarKongPortMaps, Value: "443:8443"},
+			},
+			expectedStreamListen: "0.0.0.0:10022 reuseport backlog=16384, " +
+				"0.0.0.0:10443 reuseport backlog=16384",
+			expectedPortMaps: "443:8443, 22:10022",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := &operatorv1beta1.DataPlaneOptions{
+				Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+					DeploymentOptions: operatorv1beta1.DeploymentOptions{
+						PodTemplateSpec: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name: consts.DataPlaneProxyContainerName,
+										Env:  tc.existingEnv,
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			setDataPlaneStreamListen(opts, tc.listeners)
+			container := k8sutils.GetPodContainerByName(&opts.Deployment.PodTemplateSpec.Spec, consts.DataPlaneProxyContainerName)
+			require.NotNil(t, container)
+			require.Equal(t, tc.expectedStreamListen, k8sutils.EnvValueByName(container.Env, consts.EnvVarKongStreamListen))
+			require.Equal(t, tc.expectedPortMaps, k8sutils.EnvValueByName(container.Env, consts.EnvVarKongPortMaps))
+		})
+	}
+}
+
+func TestSetDataPlaneIngressServiceAddresses(t *testing.T) {
+	testCases := []struct {
+		name            string
+		serviceType     corev1.ServiceType
+		annotations     map[string]string
+		addresses       []gwtypes.GatewayAddress
+		expectedIngress *operatorv1beta1.DataPlaneServiceOptions
+	}{
+		{
+			name:            "no addresses",
+			expectedIngress: nil,
+		},
+		{
+			name: "LoadBalancer Service gets the first IP as load balancer IP and hostnames as external-dns annotation",
+			annotations: map[string]string{
+				"foo": "bar",
+			},
+			addresses: []gwtypes.GatewayAddress{
+				{Value: "203.0.113.10"},
+				{Type: lo.ToPtr(gatewayv1.IPAddressType), Value: "203.0.113.11"},
+				{Type: lo.ToPtr(gatewayv1.HostnameAddressType), Value: "a.example.com"},
+				{Type: lo.ToPtr(gatewayv1.HostnameAddressType), Value: "b.example.com"},
+			},
+			expectedIngress: &operatorv1beta1.DataPlaneServiceOptions{
+				LoadBalancerIP: "203.0.113.10",
+				ServiceOptions: operatorv1beta1.ServiceOptions{
+					Annotations: map[string]string{
+						"foo":                                "bar",
+						consts.ExternalDNSHostnameAnnotation: "a.example.com,b.example.com",
+					},
+				},
+			},
+		},
+		{
+			name:        "ClusterIP Service gets all IPs as external IPs",
+			serviceType: corev1.ServiceTypeClusterIP,
+			addresses: []gwtypes.GatewayAddress{
+				{Value: "203.0.113.10"},
+				{Value: "2001:db8::1"},
+				{Type: lo.ToPtr(gatewayv1.HostnameAddressType), Value: "a.example.com"},
+			},
+			expectedIngress: &operatorv1beta1.DataPlaneServiceOptions{
+				ExternalIPs: []string{"203.0.113.10", "2001:db8::1"},
+				ServiceOptions: operatorv1beta1.ServiceOptions{
+					Type: corev1.ServiceTypeClusterIP,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := &operatorv1beta1.DataPlaneOptions{}
+			if tc.serviceType != "" || tc.annotations != nil {
+				opts.Network.Services = &operatorv1beta1.DataPlaneServices{
+					Ingress: &operatorv1beta1.DataPlaneServiceOptions{
+						ServiceOptions: operatorv1beta1.ServiceOptions{
+							Type:        tc.serviceType,
+							Annotations: tc.annotations,
+						},
+					},
+				}
+			}
+			annotationsBefore := maps.Clone(tc.annotations)
+
+			setDataPlaneIngressServiceAddresses(opts, tc.addresses)
+
+			if tc.expectedIngress == nil {
+				require.Nil(t, opts.Network.Services)
+				return
+			}
+			require.NotNil(t, opts.Network.Services)
+			require.Equal(t, tc.expectedIngress, opts.Network.Services.Ingress)
+			// The annotations shared with the GatewayConfiguration must not be modified.
+			require.Equal(t, annotationsBefore, tc.annotations)
+		})
+	}
+}
+
+func TestGatewayAddressesNotAssigned(t *testing.T) {
+	testCases := []struct {
+		name                string
+		serviceType         corev1.ServiceType
+		addresses           []gwtypes.GatewayAddress
+		statusAddresses     []gwtypes.GatewayStatusAddress
+		expectedNotAssigned []string
+	}{
+		{
+			name:        "no addresses requested",
+			serviceType: corev1.ServiceTypeLoadBalancer,
+		},
+		{
+			name:        "requested load balancer IP and hostname are assigned",
+			serviceType: corev1.ServiceTypeLoadBalancer,
+			addresses: []gwtypes.GatewayAddress{
+				{Value: "203.0.113.10"},
+				{Type: lo.ToPtr(gatewayv1.HostnameAddressType), Value: "a.example.com"},
+			},
+			statusAddresses: []gwtypes.GatewayStatusAddress{
+				{Type: lo.ToPtr(gatewayv1.IPAddressType), Value: "203.0.113.10"},
+			},
+		},
+		{
+			name:        "requested load balancer IP not assigned yet and additional IP cannot be assigned",
+			serviceType: corev1.ServiceTypeLoadBalancer,
+			addresses: []gwtypes.GatewayAddress{
+				{Value: "203.0.113.10"},
+				{Value: "203.0.113.11"},
+			},
+			statusAddresses: []gwtypes.GatewayStatusAddress{
+				{Type: lo.ToPtr(gatewayv1.IPAddressType), Value: "198.51.100.1"},
+			},
+			expectedNotAssigned: []string{
+				"203.0.113.11 cannot be assigned, only one IP address can be requested for a LoadBalancer Service",
+				"203.0.113.10 has not been assigned to Service svc yet",
+			},
+		},
+		{
+			name:        "hostname and named addresses cannot be assigned to a ClusterIP Service",
+			serviceType: corev1.ServiceTypeClusterIP,
+			addresses: []gwtypes.GatewayAddress{
+				{Value: "203.0.113.10"},
+				{Type: lo.ToPtr(gatewayv1.HostnameAddressType), Value: "a.example.com"},
+				{Type: lo.ToPtr(gatewayv1.NamedAddressType), Value: "my-address"},
+			},
+			statusAddresses: []gwtypes.GatewayStatusAddress{
+				{Type: lo.ToPtr(gatewayv1.IPAddressType), Value: "10.0.0.1"},
+				{Type: lo.ToPtr(gatewayv1.IPAddressType), Value: "203.0.113.10"},
+			},
+			expectedNotAssigned: []string{
+				"hostname a.example.com cannot be assigned to a ClusterIP Service",
+				"address type NamedAddress of my-address is not supported",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gateway := &gwtypes.Gateway{
+				Spec: gwtypes.GatewaySpec{
+					Addresses: tc.addresses,
+				},
+				Status: gatewayv1.GatewayStatus{
+					Addresses: tc.statusAddresses,
+				},
+			}
+			svc := corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "svc"},
+				Spec: corev1.ServiceSpec{
+					Type: tc.serviceType,
+				},
+			}
+			require.Equal(t, tc.expectedNotAssigned, gatewayAddressesNotAssigned(gateway, svc))
 		})
 	}
 }
@@ -890,7 +1328,65 @@ func TestGetSupportedKindsWithResolvedRefsCondition(t *testing.T) {
 			listener: gwtypes.Listener{
 				Protocol: gatewayv1.UDPProtocolType,
 			},
-			expectedSupportedKinds: []gwtypes.RouteGroupKind{},
+			expectedSupportedKinds: []gwtypes.RouteGroupKind{
+				{
+					Group: (*gwtypes.Group)(&gatewayv1.GroupVersion.Group),
+					Kind:  "UDPRoute",
+				},
+			},
+			expectedResolvedRefsCondition: metav1.Condition{
+				Type:               string(gatewayv1.ListenerConditionResolvedRefs),
+				Status:             metav1.ConditionTrue,
+				Reason:             string(gatewayv1.ListenerReasonResolvedRefs),
+				Message:            "Listeners' references are accepted.",
+				ObservedGeneration: generation,
+			},
+		},
+		{
+			name: "no tls, TCP protocol, TCP and HTTP routes",
+			listener: gwtypes.Listener{
+				Protocol: gatewayv1.TCPProtocolType,
+				AllowedRoutes: &gwtypes.AllowedRoutes{
+					Kinds: []gwtypes.RouteGroupKind{
+						{
+							Group: (*gwtypes.Group)(&gatewayv1.GroupVersion.Group),
+							Kind:  "TCPRoute",
+						},
+						{
+							Group: (*gwtypes.Group)(&gatewayv1.GroupVersion.Group),
+							Kind:  "HTTPRoute",
+						},
+					},
+				},
+			},
+			expectedSupportedKinds: []gwtypes.RouteGroupKind{
+				{
+					Group: (*gwtypes.Group)(&gatewayv1.GroupVersion.Group),
+					Kind:  "TCPRoute",
+				},
+			},
+			expectedResolvedRefsCondition: metav1.Condition{
+				Type:               string(gatewayv1.ListenerConditionResolvedRefs),
+				Status:             metav1.ConditionFalse,
+				Reason:             string(gatewayv1.ListenerReasonInvalidRouteKinds),
+				Message:            "Route HTTPRoute not supported.",
+				ObservedGeneration: generation,
+			},
+		},
+		{
+			name: "tls with passthrough, TLS protocol, no allowed routes",
+			listener: gwtypes.Listener{
+				Protocol: gatewayv1.TLSProtocolType,
+				TLS: &gatewayv1.GatewayTLSConfig{
+					Mode: lo.ToPtr(gatewayv1.TLSModePassthrough),
+				},
+			},
+			expectedSupportedKinds: []gwtypes.RouteGroupKind{
+				{
+					Group: (*gwtypes.Group)(&gatewayv1.GroupVersion.Group),
+					Kind:  "TLSRoute",
+				},
+			},
 			expectedResolvedRefsCondition: metav1.Condition{
 				Type:               string(gatewayv1.ListenerConditionResolvedRefs),
 				Status:             metav1.ConditionTrue,
@@ -1651,3 +2147,154 @@ func TestCountAttachedRoutesForGatewayListener(t *testing.T) {
 		})
 	}
 }
+
+func TestGetOrCreateGatewayConfiguration(t *testing.T) {
+	classConfig := &operatorv1beta1.GatewayConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "infra",
+			Name:      "class-config",
+		},
+		Spec: operatorv1beta1.GatewayConfigurationSpec{
+			DataPlaneOptions: &operatorv1beta1.GatewayConfigDataPlaneOptions{
+				Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+					DeploymentOptions: operatorv1beta1.DeploymentOptions{
+						Replicas: lo.ToPtr(int32(2)),
+						PodTemplateSpec: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name:  consts.DataPlaneProxyContainerName,
+										Image: "kong/kong-gateway:3.7",
+										Env: []corev1.EnvVar{
+											{Name: "KONG_LOG_LEVEL", Value: "debug"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			CertificateIssuer: &operatorv1beta1.CertificateIssuer{
+				Type: operatorv1beta1.CertificateIssuerTypeLocalCA,
+			},
+		},
+	}
+	gatewayConfig := &operatorv1beta1.GatewayConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "gateway-config",
+		},
+		Spec: operatorv1beta1.GatewayConfigurationSpec{
+			DataPlaneOptions: &operatorv1beta1.GatewayConfigDataPlaneOptions{
+				Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+					DeploymentOptions: operatorv1beta1.DeploymentOptions{
+						PodTemplateSpec: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name: consts.DataPlaneProxyContainerName,
+										Env: []corev1.EnvVar{
+											{Name: "KONG_NGINX_WORKER_PROCESSES", Value: "4"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ControlPlaneOptions: &operatorv1beta1.ControlPlaneOptions{
+				Deployment: operatorv1beta1.ControlPlaneDeploymentOptions{
+					Replicas: lo.ToPtr(int32(3)),
+				},
+			},
+		},
+	}
+	gatewayClass := &gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "kong",
+		},
+		Spec: gatewayv1.GatewayClassSpec{
+			ParametersRef: &gatewayv1.ParametersReference{
+				Group:     gatewayv1.Group(operatorv1beta1.SchemeGroupVersion.Group),
+				Kind:      gatewayv1.Kind("GatewayConfiguration"),
+				Namespace: lo.ToPtr(gatewayv1.Namespace("infra")),
+				Name:      "class-config",
+			},
+		},
+	}
+	gatewayWithParametersRef := func(name string) *gwtypes.Gateway {
+		return &gwtypes.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "gw",
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "kong",
+				Infrastructure: &gatewayv1.GatewayInfrastructure{
+					ParametersRef: &gatewayv1.LocalParametersReference{
+						Group: gatewayv1.Group(operatorv1beta1.SchemeGroupVersion.Group),
+						Kind:  gatewayv1.Kind("GatewayConfiguration"),
+						Name:  name,
+					},
+				},
+			},
+		}
+	}
+
+	cl := fakectrlruntimeclient.NewClientBuilder().
+		WithScheme(scheme.Get()).
+		WithObjects(classConfig, gatewayConfig).
+		Build()
+	r := Reconciler{Client: cl}
+	ctx := context.Background()
+
+	t.Run("class-level configuration only", func(t *testing.T) {
+		gateway := &gwtypes.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gw"},
+		}
+		got, err := r.getOrCreateGatewayConfiguration(ctx, gatewayClass, gateway)
+		require.NoError(t, err)
+		require.Equal(t, classConfig.Spec, got.Spec)
+	})
+
+	t.Run("gateway-level configuration without class-level one", func(t *testing.T) {
+		got, err := r.getOrCreateGatewayConfiguration(ctx, &gatewayv1.GatewayClass{}, gatewayWithParametersRef("gateway-config"))
+		require.NoError(t, err)
+		require.Equal(t, gatewayConfig.Spec, got.Spec)
+	})
+
+	t.Run("gateway-level configuration is layered over the class-level one", func(t *testing.T) {
+		got, err := r.getOrCreateGatewayConfiguration(ctx, gatewayClass, gatewayWithParametersRef("gateway-config"))
+		require.NoError(t, err)
+
+		require.Equal(t, "gateway-config", got.Name)
+		require.Equal(t, classConfig.Spec.CertificateIssuer, got.Spec.CertificateIssuer)
+		require.Equal(t, gatewayConfig.Spec.ControlPlaneOptions, got.Spec.ControlPlaneOptions)
+
+		dpOpts := got.Spec.DataPlaneOptions
+		require.NotNil(t, dpOpts)
+		require.Equal(t, lo.ToPtr(int32(2)), dpOpts.Deployment.Replicas)
+		require.NotNil(t, dpOpts.Deployment.PodTemplateSpec)
+		container := k8sutils.GetPodContainerByName(&dpOpts.Deployment.PodTemplateSpec.Spec, consts.DataPlaneProxyContainerName)
+		require.NotNil(t, container)
+		require.Equal(t, "kong/kong-gateway:3.7", container.Image)
+		require.ElementsMatch(t, []corev1.EnvVar{
+			{Name: "KONG_LOG_LEVEL", Value: "debug"},
+			{Name: "KONG_NGINX_WORKER_PROCESSES", Value: "4"},
+		}, container.Env)
+	})
+
+	t.Run("missing gateway-level configuration", func(t *testing.T) {
+		_, err := r.getOrCreateGatewayConfiguration(ctx, gatewayClass, gatewayWithParametersRef("typo"))
+		require.Error(t, err)
+	})
+
+	t.Run("unsupported gateway-level parametersRef kind", func(t *testing.T) {
+		gateway := gatewayWithParametersRef("gateway-config")
+		gateway.Spec.Infrastructure.ParametersRef.Kind = "ConfigMap"
+		_, err := r.getOrCreateGatewayConfiguration(ctx, gatewayClass, gateway)
+		require.Error(t, err)
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
@@ -20,6 +21,7 @@ import (
 	operatorerrors "github.com/kong/gateway-operator/internal/errors"
 	gwtypes "github.com/kong/gateway-operator/internal/types"
 	"github.com/kong/gateway-operator/pkg/consts"
+	gatewayutils "github.com/kong/gateway-operator/pkg/utils/gateway"
 	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
 	"github.com/kong/gateway-operator/pkg/utils/kubernetes/resources"
 	"github.com/kong/gateway-operator/pkg/vars"
@@ -141,28 +143,8 @@ func (r *Reconciler) listGatewaysForGatewayConfig(ctx context.Context, obj clien
 		return
 	}
 
-	gatewayClassList := new(gatewayv1.GatewayClassList)
-	if err := r.Client.List(ctx, gatewayClassList); err != nil {
-		log.FromContext(ctx).Error(
-			fmt.Errorf("unexpected error occurred while listing GatewayClass resources"),
-			"failed to run map funcs",
-			"error", err.Error(),
-		)
-		return
-	}
-
-	matchingGatewayClasses := make(map[string]struct{})
-	for _, gatewayClass := range gatewayClassList.Items {
-		if gatewayClass.Spec.ParametersRef != nil &&
-			string(gatewayClass.Spec.ParametersRef.Group) == operatorv1beta1.SchemeGroupVersion.Group &&
-			string(gatewayClass.Spec.ParametersRef.Kind) == "GatewayConfiguration" &&
-			gatewayClass.Spec.ParametersRef.Name == gatewayConfig.Name {
-			matchingGatewayClasses[gatewayClass.Name] = struct{}{}
-		}
-	}
-
-	gatewayList := new(gatewayv1.GatewayList)
-	if err := r.Client.List(ctx, gatewayList); err != nil {
+	gateways, err := gatewayutils.ListGatewaysForGatewayConfiguration(ctx, r.Client, gatewayConfig)
+	if err != nil {
 		log.FromContext(ctx).Error(
 			fmt.Errorf("unexpected error occurred while listing Gateway resources"),
 			"failed to run map funcs",
@@ -171,15 +153,13 @@ func (r *Reconciler) listGatewaysForGatewayConfig(ctx context.Context, obj clien
 		return
 	}
 
-	for _, gateway := range gatewayList.Items {
-		if _, ok := matchingGatewayClasses[string(gateway.Spec.GatewayClassName)]; ok {
-			recs = append(recs, reconcile.Request{
-				NamespacedName: types.NamespacedName{
-					Namespace: gateway.Namespace,
-					Name:      gateway.Name,
-				},
-			})
-		}
+	for _, gateway := range gateways {
+		recs = append(recs, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: gateway.Namespace,
+				Name:      gateway.Name,
+			},
+		})
 	}
 
 	return
@@ -305,6 +285,48 @@ func (r *Reconciler) listGatewaysAttachedByHTTPRoute(ctx context.Context, obj cl
 	return recs
 }
 
+// listGatewaysAttachedByL4Route is a watch predicate which finds all Gateways mentioned
+// in TCPRoutes', UDPRoutes' or TLSRoutes' Parents field.
+func (r *Reconciler) listGatewaysAttachedByL4Route(ctx context.Context, obj client.Object) []reconcile.Request {
+	logger := log.FromContext(ctx)
+
+	var parentRefs []gatewayv1.ParentReference
+	switch route := obj.(type) {
+	case *gatewayv1alpha2.TCPRoute:
+		parentRefs = route.Spec.ParentRefs
+	case *gatewayv1alpha2.UDPRoute:
+		parentRefs = route.Spec.ParentRefs
+	case *gatewayv1alpha2.TLSRoute:
+		parentRefs = route.Spec.ParentRefs
+	default:
+		logger.Error(
+			operatorerrors.ErrUnexpectedObject,
+			"failed to run map funcs",
+			"expected", "TCPRoute, UDPRoute or TLSRoute", "found", reflect.TypeOf(obj),
+		)
+		return nil
+	}
+
+	var recs []reconcile.Request
+	for _, parentRef := range parentRefs {
+		if parentRef.Group != nil && string(*parentRef.Group) != gatewayv1.GroupName ||
+			parentRef.Kind != nil && string(*parentRef.Kind) != "Gateway" {
+			continue
+		}
+		namespace := obj.GetNamespace()
+		if parentRef.Namespace != nil && *parentRef.Namespace != "" {
+			namespace = string(*parentRef.Namespace)
+		}
+		recs = append(recs, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: namespace,
+				Name:      string(parentRef.Name),
+			},
+		})
+	}
+	return recs
+}
+
 // -----------------------------------------------------------------------------
 // GatewayReconciler - Config Defaults
 // -----------------------------------------------------------------------------
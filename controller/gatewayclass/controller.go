@@ -2,19 +2,26 @@ package gatewayclass
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 
-	"k8s.io/apimachinery/pkg/api/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
 	"github.com/kong/gateway-operator/controller/pkg/log"
+	operatorerrors "github.com/kong/gateway-operator/internal/errors"
 	"github.com/kong/gateway-operator/internal/utils/gatewayclass"
+	gatewayutils "github.com/kong/gateway-operator/pkg/utils/gateway"
 	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
 )
 
@@ -27,6 +34,11 @@ type Reconciler struct {
 	client.Client
 	Scheme          *runtime.Scheme
 	DevelopmentMode bool
+	// WatchNamespaces are the namespaces the operator is limited to. When set,
+	// GatewayConfigurations referenced from other namespaces can't be read
+	// from the cache and the GatewayClass is reported as having invalid
+	// parameters.
+	WatchNamespaces []string
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -34,6 +46,16 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&gatewayv1.GatewayClass{},
 			builder.WithPredicates(predicate.NewPredicateFuncs(r.gatewayClassMatches))).
+		// watch for GatewayConfigurations being created or deleted so that
+		// the GatewayClasses referencing them in their parametersRef get
+		// their Accepted condition updated.
+		Watches(
+			&operatorv1beta1.GatewayConfiguration{},
+			handler.EnqueueRequestsFromMapFunc(r.listGatewayClassesForGatewayConfig),
+			builder.WithPredicates(predicate.Funcs{
+				UpdateFunc:  func(event.UpdateEvent) bool { return false },
+				GenericFunc: func(event.GenericEvent) bool { return false },
+			})).
 		Complete(r)
 }
 
@@ -45,7 +67,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 
 	gwc := gatewayclass.NewDecorator()
 	if err := r.Client.Get(ctx, req.NamespacedName, gwc.GatewayClass); err != nil {
-		if errors.IsNotFound(err) {
+		if k8serrors.IsNotFound(err) {
 			log.Debug(logger, "object enqueued no longer exists, skipping", req)
 			return ctrl.Result{}, nil
 		}
@@ -53,23 +75,75 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	}
 	log.Debug(logger, "processing gatewayclass", gwc)
 
-	if gwc.IsControlled() {
-		if !gwc.IsAccepted() {
-			acceptedCondition := metav1.Condition{
-				Type:               string(gatewayv1.GatewayClassConditionStatusAccepted),
-				Status:             metav1.ConditionTrue,
-				ObservedGeneration: gwc.Generation,
-				LastTransitionTime: metav1.Now(),
-				Reason:             string(gatewayv1.GatewayClassReasonAccepted),
-				Message:            "the gatewayclass has been accepted by the operator",
-			}
-			k8sutils.SetCondition(acceptedCondition, gwc)
-			if err := r.Status().Update(ctx, gwc.GatewayClass); err != nil {
-				return ctrl.Result{}, fmt.Errorf("failed updating GatewayClass: %w", err)
-			}
-			return ctrl.Result{}, nil
-		}
+	if !gwc.IsControlled() {
+		return ctrl.Result{}, nil
+	}
+
+	acceptedCondition, err := r.acceptedCondition(ctx, gwc.GatewayClass)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	oldGwc := gatewayclass.DecorateGatewayClass(gwc.GatewayClass.DeepCopy())
+	k8sutils.SetCondition(acceptedCondition, gwc)
+	if !k8sutils.NeedsUpdate(oldGwc, gwc) {
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.Status().Update(ctx, gwc.GatewayClass); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed updating GatewayClass: %w", err)
+	}
+	if acceptedCondition.Status == metav1.ConditionTrue {
+		log.Debug(logger, "gatewayclass accepted", gwc)
+	} else {
+		log.Debug(logger, "gatewayclass not accepted", gwc, "reason", acceptedCondition.Message)
 	}
 
 	return ctrl.Result{}, nil
 }
+
+// acceptedCondition resolves the parametersRef of the GatewayClass and returns
+// the Accepted condition reflecting whether it references an existing
+// GatewayConfiguration. GatewayClasses without a parametersRef are accepted.
+func (r *Reconciler) acceptedCondition(ctx context.Context, gwc *gatewayv1.GatewayClass) (metav1.Condition, error) {
+	if ref := gwc.Spec.ParametersRef; ref != nil && ref.Namespace != nil && *ref.Namespace != "" &&
+		len(r.WatchNamespaces) > 0 && !slices.Contains(r.WatchNamespaces, string(*ref.Namespace)) {
+		return invalidParametersCondition(gwc, fmt.Sprintf("referenced GatewayConfiguration %s/%s is in a namespace not watched by the operator",
+			*ref.Namespace, ref.Name)), nil
+	}
+
+	_, err := gatewayutils.GetGatewayConfigurationForGatewayClass(ctx, r.Client, gwc)
+	switch {
+	case err == nil, errors.Is(err, operatorerrors.ErrObjectMissingParametersRef):
+		return metav1.Condition{
+			Type:               string(gatewayv1.GatewayClassConditionStatusAccepted),
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: gwc.Generation,
+			LastTransitionTime: metav1.Now(),
+			Reason:             string(gatewayv1.GatewayClassReasonAccepted),
+			Message:            "the gatewayclass has been accepted by the operator",
+		}, nil
+	case k8serrors.IsNotFound(err):
+		return invalidParametersCondition(gwc, fmt.Sprintf("referenced GatewayConfiguration %s/%s not found",
+			*gwc.Spec.ParametersRef.Namespace, gwc.Spec.ParametersRef.Name)), nil
+	case k8serrors.IsInvalid(err):
+		return invalidParametersCondition(gwc, fmt.Sprintf("unsupported parametersRef kind %s/%s, expected %s/%s",
+			gwc.Spec.ParametersRef.Group, gwc.Spec.ParametersRef.Kind,
+			operatorv1beta1.SchemeGroupVersion.Group, "GatewayConfiguration")), nil
+	case errors.Is(err, operatorerrors.ErrInvalidParametersRef):
+		return invalidParametersCondition(gwc, err.Error()), nil
+	default:
+		return metav1.Condition{}, fmt.Errorf("failed resolving GatewayClass parametersRef: %w", err)
+	}
+}
+
+func invalidParametersCondition(gwc *gatewayv1.GatewayClass, msg string) metav1.Condition {
+	return metav1.Condition{
+		Type:               string(gatewayv1.GatewayClassConditionStatusAccepted),
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: gwc.Generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             string(gatewayv1.GatewayClassReasonInvalidParameters),
+		Message:            msg,
+	}
+}
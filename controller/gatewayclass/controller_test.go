@@ -6,6 +6,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -18,6 +19,7 @@ import (
 	operatorv1alpha1 "github.com/kong/gateway-operator/api/v1alpha1"
 	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
 	"github.com/kong/gateway-operator/internal/utils/gatewayclass"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
 	"github.com/kong/gateway-operator/pkg/vars"
 )
 
@@ -118,3 +120,128 @@ func TestGatewayClassReconciler_Reconcile(t *testing.T) {
 		})
 	}
 }
+
+func TestGatewayClassReconciler_ReconcileParametersRef(t *testing.T) {
+	gatewayConfig := &operatorv1beta1.GatewayConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-gatewayconfiguration",
+		},
+	}
+
+	testCases := []struct {
+		name            string
+		parametersRef   *gatewayv1.ParametersReference
+		watchNamespaces []string
+		expectedStatus  metav1.ConditionStatus
+		expectedReason  gatewayv1.GatewayClassConditionReason
+	}{
+		{
+			name: "existing gatewayconfiguration",
+			parametersRef: &gatewayv1.ParametersReference{
+				Group:     gatewayv1.Group(operatorv1beta1.SchemeGroupVersion.Group),
+				Kind:      gatewayv1.Kind("GatewayConfiguration"),
+				Namespace: lo.ToPtr(gatewayv1.Namespace("default")),
+				Name:      "test-gatewayconfiguration",
+			},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: gatewayv1.GatewayClassReasonAccepted,
+		},
+		{
+			name: "missing gatewayconfiguration",
+			parametersRef: &gatewayv1.ParametersReference{
+				Group:     gatewayv1.Group(operatorv1beta1.SchemeGroupVersion.Group),
+				Kind:      gatewayv1.Kind("GatewayConfiguration"),
+				Namespace: lo.ToPtr(gatewayv1.Namespace("default")),
+				Name:      "typo",
+			},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: gatewayv1.GatewayClassReasonInvalidParameters,
+		},
+		{
+			name: "unsupported kind",
+			parametersRef: &gatewayv1.ParametersReference{
+				Group:     gatewayv1.Group(operatorv1beta1.SchemeGroupVersion.Group),
+				Kind:      gatewayv1.Kind("DataPlane"),
+				Namespace: lo.ToPtr(gatewayv1.Namespace("default")),
+				Name:      "test-gatewayconfiguration",
+			},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: gatewayv1.GatewayClassReasonInvalidParameters,
+		},
+		{
+			name: "gatewayconfiguration in a watched namespace",
+			parametersRef: &gatewayv1.ParametersReference{
+				Group:     gatewayv1.Group(operatorv1beta1.SchemeGroupVersion.Group),
+				Kind:      gatewayv1.Kind("GatewayConfiguration"),
+				Namespace: lo.ToPtr(gatewayv1.Namespace("default")),
+				Name:      "test-gatewayconfiguration",
+			},
+			watchNamespaces: []string{"default"},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  gatewayv1.GatewayClassReasonAccepted,
+		},
+		{
+			name: "gatewayconfiguration in a namespace not watched",
+			parametersRef: &gatewayv1.ParametersReference{
+				Group:     gatewayv1.Group(operatorv1beta1.SchemeGroupVersion.Group),
+				Kind:      gatewayv1.Kind("GatewayConfiguration"),
+				Namespace: lo.ToPtr(gatewayv1.Namespace("default")),
+				Name:      "test-gatewayconfiguration",
+			},
+			watchNamespaces: []string{"other"},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  gatewayv1.GatewayClassReasonInvalidParameters,
+		},
+		{
+			name: "missing namespace",
+			parametersRef: &gatewayv1.ParametersReference{
+				Group: gatewayv1.Group(operatorv1beta1.SchemeGroupVersion.Group),
+				Kind:  gatewayv1.Kind("GatewayConfiguration"),
+				Name:  "test-gatewayconfiguration",
+			},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: gatewayv1.GatewayClassReasonInvalidParameters,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			gatewayClass := &gatewayv1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-gatewayclass",
+				},
+				Spec: gatewayv1.GatewayClassSpec{
+					ControllerName: gatewayv1.GatewayController(vars.ControllerName()),
+					ParametersRef:  tc.parametersRef,
+				},
+			}
+
+			fakeClient := fakectrlruntimeclient.
+				NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(gatewayClass, gatewayConfig).
+				WithStatusSubresource(gatewayClass).
+				Build()
+
+			reconciler := Reconciler{
+				Client:          fakeClient,
+				WatchNamespaces: tc.watchNamespaces,
+			}
+
+			ctx := context.Background()
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: gatewayClass.Name}}
+			_, err := reconciler.Reconcile(ctx, req)
+			require.NoError(t, err)
+
+			gwc := gatewayclass.NewDecorator()
+			require.NoError(t, reconciler.Client.Get(ctx, req.NamespacedName, gwc.GatewayClass))
+			cond, ok := k8sutils.GetCondition(k8sutils.ConditionType(gatewayv1.GatewayClassConditionStatusAccepted), gwc)
+			require.True(t, ok)
+			require.Equal(t, tc.expectedStatus, cond.Status)
+			require.Equal(t, string(tc.expectedReason), cond.Reason)
+		})
+	}
+}
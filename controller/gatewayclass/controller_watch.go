@@ -6,10 +6,13 @@ import (
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
 	operatorerrors "github.com/kong/gateway-operator/internal/errors"
 	"github.com/kong/gateway-operator/internal/utils/gatewayclass"
+	gatewayutils "github.com/kong/gateway-operator/pkg/utils/gateway"
 )
 
 // -----------------------------------------------------------------------------
@@ -30,3 +33,33 @@ func (r *Reconciler) gatewayClassMatches(obj client.Object) bool {
 
 	return gatewayclass.DecorateGatewayClass(gwc).IsControlled()
 }
+
+// -----------------------------------------------------------------------------
+// GatewayClassReconciler - Watch Map Funcs
+// -----------------------------------------------------------------------------
+
+func (r *Reconciler) listGatewayClassesForGatewayConfig(ctx context.Context, obj client.Object) (recs []reconcile.Request) {
+	gatewayConfig, ok := obj.(*operatorv1beta1.GatewayConfiguration)
+	if !ok {
+		log.FromContext(ctx).Error(
+			operatorerrors.ErrUnexpectedObject,
+			"failed to run map funcs",
+			"expected", "GatewayConfiguration", "found", reflect.TypeOf(obj),
+		)
+		return
+	}
+
+	gatewayClasses, err := gatewayutils.ListGatewayClassesForGatewayConfiguration(ctx, r.Client, gatewayConfig)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "could not list gatewayclasses in map func")
+		return
+	}
+
+	for _, gatewayClass := range gatewayClasses {
+		recs = append(recs, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&gatewayClass),
+		})
+	}
+
+	return
+}
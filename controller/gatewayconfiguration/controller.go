@@ -0,0 +1,232 @@
+package gatewayconfiguration
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/controller/gateway"
+	"github.com/kong/gateway-operator/controller/pkg/log"
+	"github.com/kong/gateway-operator/controller/pkg/secrets"
+	gwtypes "github.com/kong/gateway-operator/internal/types"
+	"github.com/kong/gateway-operator/internal/validation/gatewayconfiguration"
+	gatewayutils "github.com/kong/gateway-operator/pkg/utils/gateway"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+)
+
+// -----------------------------------------------------------------------------
+// GatewayConfigurationReconciler
+// -----------------------------------------------------------------------------
+
+// Reconciler reconciles a GatewayConfiguration object.
+// It doesn't manage any resources: it reports in the GatewayConfiguration's
+// status whether it is valid, which GatewayClasses and Gateways use it and
+// whether provisioning the DataPlanes and ControlPlanes for them failed.
+type Reconciler struct {
+	client.Client
+	Scheme          *runtime.Scheme
+	DevelopmentMode bool
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&operatorv1beta1.GatewayConfiguration{}).
+		// watch GatewayClasses so that the list of GatewayClasses referencing
+		// a GatewayConfiguration is kept up to date.
+		Watches(
+			&gatewayv1.GatewayClass{},
+			handler.EnqueueRequestsFromMapFunc(r.listGatewayConfigurationsForGatewayClass)).
+		// watch Gateways so that the list of Gateways using a GatewayConfiguration
+		// and the provisioning errors reported in their status are kept up to date.
+		Watches(
+			&gatewayv1.Gateway{},
+			handler.EnqueueRequestsFromMapFunc(r.listGatewayConfigurationsForGateway)).
+		// watch Secrets so that references to certificate issuer Secrets get
+		// resolved once the Secrets are created.
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.listGatewayConfigurationsForSecret)).
+		Complete(r)
+}
+
+// Reconcile moves the current state of an object to the intended state.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.GetLogger(ctx, "gatewayconfiguration", r.DevelopmentMode)
+
+	log.Trace(logger, "reconciling gatewayconfiguration resource", req)
+	var gatewayConfig operatorv1beta1.GatewayConfiguration
+	if err := r.Client.Get(ctx, req.NamespacedName, &gatewayConfig); err != nil {
+		if k8serrors.IsNotFound(err) {
+			log.Debug(logger, "object enqueued no longer exists, skipping", req)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	oldGatewayConfig := gatewayConfig.DeepCopy()
+
+	gatewayClasses, err := gatewayutils.ListGatewayClassesForGatewayConfiguration(ctx, r.Client, &gatewayConfig)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed listing GatewayClasses referencing the GatewayConfiguration: %w", err)
+	}
+	gateways, err := gatewayutils.ListGatewaysForGatewayConfiguration(ctx, r.Client, &gatewayConfig)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed listing Gateways using the GatewayConfiguration: %w", err)
+	}
+	gatewayConfig.Status.GatewayClasses = gatewayClassNames(gatewayClasses)
+	gatewayConfig.Status.Gateways = gatewayNames(gateways)
+
+	k8sutils.SetCondition(r.acceptedCondition(&gatewayConfig), &gatewayConfig)
+
+	resolvedRefsCondition, err := r.resolvedRefsCondition(ctx, &gatewayConfig, gateways)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	k8sutils.SetCondition(resolvedRefsCondition, &gatewayConfig)
+
+	k8sutils.SetCondition(provisionedCondition(&gatewayConfig, gateways), &gatewayConfig)
+
+	if !k8sutils.NeedsUpdate(oldGatewayConfig, &gatewayConfig) &&
+		slices.Equal(oldGatewayConfig.Status.GatewayClasses, gatewayConfig.Status.GatewayClasses) &&
+		slices.Equal(oldGatewayConfig.Status.Gateways, gatewayConfig.Status.Gateways) {
+		log.Debug(logger, "gatewayconfiguration status up to date", &gatewayConfig)
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.Client.Status().Patch(ctx, &gatewayConfig, client.MergeFrom(oldGatewayConfig)); err != nil {
+		if k8serrors.IsConflict(err) {
+			log.Debug(logger, "conflict found when updating gatewayconfiguration, retrying", &gatewayConfig)
+			return ctrl.Result{Requeue: true}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed patching GatewayConfiguration status: %w", err)
+	}
+	log.Debug(logger, "gatewayconfiguration status updated", &gatewayConfig)
+
+	return ctrl.Result{}, nil
+}
+
+// acceptedCondition validates the GatewayConfiguration options the same way
+// the admission webhook does and returns the resulting Accepted condition.
+// Unlike the admission webhook, it is aware of the development mode and hence
+// rejects images with tags which are not versions outside of it.
+func (r *Reconciler) acceptedCondition(gatewayConfig *operatorv1beta1.GatewayConfiguration) metav1.Condition {
+	if err := gatewayconfiguration.NewValidator(r.Client, gatewayconfiguration.WithDevelopmentMode(r.DevelopmentMode)).Validate(gatewayConfig); err != nil {
+		return k8sutils.NewConditionWithGeneration(
+			AcceptedType, metav1.ConditionFalse, InvalidReason, err.Error(), gatewayConfig.Generation,
+		)
+	}
+	return k8sutils.NewConditionWithGeneration(
+		AcceptedType, metav1.ConditionTrue, AcceptedReason, "GatewayConfiguration is valid", gatewayConfig.Generation,
+	)
+}
+
+// resolvedRefsCondition checks that the objects referenced by the GatewayConfiguration
+// exist and returns the resulting ResolvedRefs condition.
+// The certificate issuer Secret has to exist in the namespace of every Gateway
+// using the GatewayConfiguration as that's where its DataPlane and ControlPlane live.
+func (r *Reconciler) resolvedRefsCondition(
+	ctx context.Context,
+	gatewayConfig *operatorv1beta1.GatewayConfiguration,
+	gateways []gwtypes.Gateway,
+) (metav1.Condition, error) {
+	var missing []string
+	if issuer := gatewayConfig.Spec.CertificateIssuer; issuer != nil {
+		if _, err := secrets.NewIssuer(*issuer, types.NamespacedName{}); err != nil {
+			return k8sutils.NewConditionWithGeneration(
+				ResolvedRefsType, metav1.ConditionFalse, InvalidReason, err.Error(), gatewayConfig.Generation,
+			), nil
+		}
+
+		if issuer.Type == operatorv1beta1.CertificateIssuerTypeSecret {
+			namespaces := lo.Uniq(lo.Map(gateways, func(gw gwtypes.Gateway, _ int) string {
+				return gw.Namespace
+			}))
+			sort.Strings(namespaces)
+			for _, namespace := range namespaces {
+				nn := types.NamespacedName{Namespace: namespace, Name: issuer.Secret.Name}
+				if err := r.Client.Get(ctx, nn, &corev1.Secret{}); err != nil {
+					if k8serrors.IsNotFound(err) {
+						missing = append(missing, nn.String())
+						continue
+					}
+					return metav1.Condition{}, fmt.Errorf("failed getting certificate issuer Secret %s: %w", nn, err)
+				}
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		return k8sutils.NewConditionWithGeneration(
+			ResolvedRefsType, metav1.ConditionFalse, RefNotFoundReason,
+			fmt.Sprintf("certificate issuer Secrets not found: %s", strings.Join(missing, ", ")),
+			gatewayConfig.Generation,
+		), nil
+	}
+	return k8sutils.NewConditionWithGeneration(
+		ResolvedRefsType, metav1.ConditionTrue, ResolvedRefsReason, "all references resolved", gatewayConfig.Generation,
+	), nil
+}
+
+// provisionedCondition returns the Provisioned condition which surfaces the
+// DataPlane and ControlPlane provisioning errors reported by the Gateways
+// using the GatewayConfiguration.
+func provisionedCondition(gatewayConfig *operatorv1beta1.GatewayConfiguration, gateways []gwtypes.Gateway) metav1.Condition {
+	var failures []string
+	for _, gw := range gateways {
+		for _, cond := range gw.Status.Conditions {
+			if cond.Type != string(gateway.DataPlaneReadyType) && cond.Type != string(gateway.ControlPlaneReadyType) {
+				continue
+			}
+			if cond.Status == metav1.ConditionFalse && cond.Reason == string(k8sutils.UnableToProvisionReason) {
+				failures = append(failures, fmt.Sprintf("Gateway %s/%s: %s: %s", gw.Namespace, gw.Name, cond.Type, cond.Message))
+			}
+		}
+	}
+
+	if len(failures) > 0 {
+		sort.Strings(failures)
+		return k8sutils.NewConditionWithGeneration(
+			ProvisionedType, metav1.ConditionFalse, k8sutils.UnableToProvisionReason,
+			strings.Join(failures, "; "), gatewayConfig.Generation,
+		)
+	}
+	return k8sutils.NewConditionWithGeneration(
+		ProvisionedType, metav1.ConditionTrue, ProvisionedReason,
+		"no provisioning errors reported by the Gateways", gatewayConfig.Generation,
+	)
+}
+
+func gatewayClassNames(gatewayClasses []gatewayv1.GatewayClass) []string {
+	names := lo.Map(gatewayClasses, func(gwc gatewayv1.GatewayClass, _ int) string {
+		return gwc.Name
+	})
+	sort.Strings(names)
+	return names
+}
+
+func gatewayNames(gateways []gwtypes.Gateway) []operatorv1beta1.NamespacedName {
+	names := lo.Map(gateways, func(gw gwtypes.Gateway, _ int) operatorv1beta1.NamespacedName {
+		return operatorv1beta1.NamespacedName{Namespace: gw.Namespace, Name: gw.Name}
+	})
+	sort.Slice(names, func(i, j int) bool {
+		if names[i].Namespace != names[j].Namespace {
+			return names[i].Namespace < names[j].Namespace
+		}
+		return names[i].Name < names[j].Name
+	})
+	return names
+}
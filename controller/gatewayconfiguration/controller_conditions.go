@@ -0,0 +1,45 @@
+package gatewayconfiguration
+
+import (
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+)
+
+// -----------------------------------------------------------------------------
+// GatewayConfiguration - Status Condition Types
+// -----------------------------------------------------------------------------
+
+const (
+	// AcceptedType indicates whether the GatewayConfiguration options are valid.
+	AcceptedType k8sutils.ConditionType = "Accepted"
+
+	// ResolvedRefsType indicates whether all the objects referenced by the
+	// GatewayConfiguration exist.
+	ResolvedRefsType k8sutils.ConditionType = "ResolvedRefs"
+
+	// ProvisionedType indicates whether the DataPlanes and ControlPlanes of the
+	// Gateways using the GatewayConfiguration have been provisioned.
+	ProvisionedType k8sutils.ConditionType = "Provisioned"
+)
+
+// -----------------------------------------------------------------------------
+// GatewayConfiguration - Status Condition Reasons
+// -----------------------------------------------------------------------------
+
+const (
+	// AcceptedReason must be used with the Accepted condition set to true.
+	AcceptedReason k8sutils.ConditionReason = "Accepted"
+
+	// InvalidReason must be used with the Accepted condition set to false
+	// to express that the GatewayConfiguration options are invalid.
+	InvalidReason k8sutils.ConditionReason = "Invalid"
+
+	// ResolvedRefsReason must be used with the ResolvedRefs condition set to true.
+	ResolvedRefsReason k8sutils.ConditionReason = "ResolvedRefs"
+
+	// RefNotFoundReason must be used with the ResolvedRefs condition set to false
+	// to express that an object referenced by the GatewayConfiguration doesn't exist.
+	RefNotFoundReason k8sutils.ConditionReason = "RefNotFound"
+
+	// ProvisionedReason must be used with the Provisioned condition set to true.
+	ProvisionedReason k8sutils.ConditionReason = "Provisioned"
+)
@@ -0,0 +1,312 @@
+package gatewayconfiguration
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	controllerruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/controller/gateway"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+	"github.com/kong/gateway-operator/pkg/vars"
+)
+
+func init() {
+	if err := gatewayv1.Install(scheme.Scheme); err != nil {
+		fmt.Println("error while adding gatewayv1 scheme")
+		os.Exit(1)
+	}
+	if err := operatorv1beta1.AddToScheme(scheme.Scheme); err != nil {
+		fmt.Println("error while adding operatorv1beta1 scheme")
+		os.Exit(1)
+	}
+}
+
+func TestGatewayConfigurationReconciler_Reconcile(t *testing.T) {
+	gatewayConfigReq := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: "default",
+			Name:      "test-gatewayconfiguration",
+		},
+	}
+
+	gatewayClass := func(name string) *gatewayv1.GatewayClass {
+		return &gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: gatewayv1.GatewayClassSpec{
+				ControllerName: gatewayv1.GatewayController(vars.ControllerName()),
+				ParametersRef: &gatewayv1.ParametersReference{
+					Group:     gatewayv1.Group(operatorv1beta1.SchemeGroupVersion.Group),
+					Kind:      gatewayv1.Kind("GatewayConfiguration"),
+					Namespace: lo.ToPtr(gatewayv1.Namespace("default")),
+					Name:      "test-gatewayconfiguration",
+				},
+			},
+		}
+	}
+	testGateway := func(namespace, name, gatewayClassName string, conditions ...metav1.Condition) *gatewayv1.Gateway {
+		return &gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: gatewayv1.ObjectName(gatewayClassName),
+			},
+			Status: gatewayv1.GatewayStatus{
+				Conditions: conditions,
+			},
+		}
+	}
+
+	testCases := []struct {
+		name                   string
+		gatewayConfig          *operatorv1beta1.GatewayConfiguration
+		objects                []controllerruntimeclient.Object
+		expectedGatewayClasses []string
+		expectedGateways       []operatorv1beta1.NamespacedName
+		expectedConditions     map[k8sutils.ConditionType]metav1.ConditionStatus
+		expectedMessages       map[k8sutils.ConditionType]string
+	}{
+		{
+			name: "unused gatewayconfiguration is accepted",
+			gatewayConfig: &operatorv1beta1.GatewayConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "test-gatewayconfiguration",
+				},
+			},
+			expectedConditions: map[k8sutils.ConditionType]metav1.ConditionStatus{
+				AcceptedType:     metav1.ConditionTrue,
+				ResolvedRefsType: metav1.ConditionTrue,
+				ProvisionedType:  metav1.ConditionTrue,
+			},
+		},
+		{
+			name: "referencing gatewayclasses and gateways are listed",
+			gatewayConfig: &operatorv1beta1.GatewayConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "test-gatewayconfiguration",
+				},
+			},
+			objects: []controllerruntimeclient.Object{
+				gatewayClass("b"),
+				gatewayClass("a"),
+				&gatewayv1.GatewayClass{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "unrelated",
+					},
+				},
+				testGateway("ns-2", "gw", "b"),
+				testGateway("ns-1", "gw", "a"),
+				testGateway("ns-1", "unrelated", "unrelated"),
+			},
+			expectedGatewayClasses: []string{"a", "b"},
+			expectedGateways: []operatorv1beta1.NamespacedName{
+				{Namespace: "ns-1", Name: "gw"},
+				{Namespace: "ns-2", Name: "gw"},
+			},
+			expectedConditions: map[k8sutils.ConditionType]metav1.ConditionStatus{
+				AcceptedType:     metav1.ConditionTrue,
+				ResolvedRefsType: metav1.ConditionTrue,
+				ProvisionedType:  metav1.ConditionTrue,
+			},
+		},
+		{
+			name: "gateways referencing the gatewayconfiguration through their infrastructure are listed",
+			gatewayConfig: &operatorv1beta1.GatewayConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "test-gatewayconfiguration",
+				},
+			},
+			objects: []controllerruntimeclient.Object{
+				&gatewayv1.Gateway{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "gw",
+					},
+					Spec: gatewayv1.GatewaySpec{
+						GatewayClassName: "unrelated",
+						Infrastructure: &gatewayv1.GatewayInfrastructure{
+							ParametersRef: &gatewayv1.LocalParametersReference{
+								Group: gatewayv1.Group(operatorv1beta1.SchemeGroupVersion.Group),
+								Kind:  gatewayv1.Kind("GatewayConfiguration"),
+								Name:  "test-gatewayconfiguration",
+							},
+						},
+					},
+				},
+			},
+			expectedGateways: []operatorv1beta1.NamespacedName{
+				{Namespace: "default", Name: "gw"},
+			},
+			expectedConditions: map[k8sutils.ConditionType]metav1.ConditionStatus{
+				AcceptedType:     metav1.ConditionTrue,
+				ResolvedRefsType: metav1.ConditionTrue,
+				ProvisionedType:  metav1.ConditionTrue,
+			},
+		},
+		{
+			name: "invalid options are not accepted",
+			gatewayConfig: &operatorv1beta1.GatewayConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "test-gatewayconfiguration",
+				},
+				Spec: operatorv1beta1.GatewayConfigurationSpec{
+					DataPlaneOptions: &operatorv1beta1.GatewayConfigDataPlaneOptions{
+						Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+							DeploymentOptions: operatorv1beta1.DeploymentOptions{
+								PodTemplateSpec: &corev1.PodTemplateSpec{
+									Spec: corev1.PodSpec{
+										Containers: []corev1.Container{
+											{
+												Name:  "proxy",
+												Image: "kong/kong-gateway:2.0",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedConditions: map[k8sutils.ConditionType]metav1.ConditionStatus{
+				AcceptedType:     metav1.ConditionFalse,
+				ResolvedRefsType: metav1.ConditionTrue,
+				ProvisionedType:  metav1.ConditionTrue,
+			},
+			expectedMessages: map[k8sutils.ConditionType]string{
+				AcceptedType: "invalid dataPlaneOptions: unsupported image kong/kong-gateway:2.0",
+			},
+		},
+		{
+			name: "missing certificate issuer secret is reported",
+			gatewayConfig: &operatorv1beta1.GatewayConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "test-gatewayconfiguration",
+				},
+				Spec: operatorv1beta1.GatewayConfigurationSpec{
+					CertificateIssuer: &operatorv1beta1.CertificateIssuer{
+						Type: operatorv1beta1.CertificateIssuerTypeSecret,
+						Secret: &operatorv1beta1.SecretIssuer{
+							Name: "mtls",
+						},
+					},
+				},
+			},
+			objects: []controllerruntimeclient.Object{
+				gatewayClass("a"),
+				testGateway("ns-1", "gw", "a"),
+				testGateway("ns-2", "gw", "a"),
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-1",
+						Name:      "mtls",
+					},
+				},
+			},
+			expectedGatewayClasses: []string{"a"},
+			expectedGateways: []operatorv1beta1.NamespacedName{
+				{Namespace: "ns-1", Name: "gw"},
+				{Namespace: "ns-2", Name: "gw"},
+			},
+			expectedConditions: map[k8sutils.ConditionType]metav1.ConditionStatus{
+				AcceptedType:     metav1.ConditionTrue,
+				ResolvedRefsType: metav1.ConditionFalse,
+				ProvisionedType:  metav1.ConditionTrue,
+			},
+			expectedMessages: map[k8sutils.ConditionType]string{
+				ResolvedRefsType: "certificate issuer Secrets not found: ns-2/mtls",
+			},
+		},
+		{
+			name: "provisioning errors reported by gateways are surfaced",
+			gatewayConfig: &operatorv1beta1.GatewayConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "test-gatewayconfiguration",
+				},
+			},
+			objects: []controllerruntimeclient.Object{
+				gatewayClass("a"),
+				testGateway("ns-1", "gw", "a",
+					k8sutils.NewCondition(gateway.DataPlaneReadyType, metav1.ConditionFalse, k8sutils.UnableToProvisionReason, "dataplane creation failed"),
+					k8sutils.NewCondition(gateway.ControlPlaneReadyType, metav1.ConditionFalse, k8sutils.WaitingToBecomeReadyReason, "waiting"),
+				),
+			},
+			expectedGatewayClasses: []string{"a"},
+			expectedGateways: []operatorv1beta1.NamespacedName{
+				{Namespace: "ns-1", Name: "gw"},
+			},
+			expectedConditions: map[k8sutils.ConditionType]metav1.ConditionStatus{
+				AcceptedType:     metav1.ConditionTrue,
+				ResolvedRefsType: metav1.ConditionTrue,
+				ProvisionedType:  metav1.ConditionFalse,
+			},
+			expectedMessages: map[k8sutils.ConditionType]string{
+				ProvisionedType: "Gateway ns-1/gw: DataPlaneReady: dataplane creation failed",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fakectrlruntimeclient.
+				NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(append(tc.objects, tc.gatewayConfig)...).
+				WithStatusSubresource(tc.gatewayConfig).
+				Build()
+
+			reconciler := Reconciler{
+				Client: fakeClient,
+			}
+
+			ctx := context.Background()
+			_, err := reconciler.Reconcile(ctx, gatewayConfigReq)
+			require.NoError(t, err)
+
+			var gatewayConfig operatorv1beta1.GatewayConfiguration
+			require.NoError(t, fakeClient.Get(ctx, gatewayConfigReq.NamespacedName, &gatewayConfig))
+			require.Equal(t, tc.expectedGatewayClasses, gatewayConfig.Status.GatewayClasses)
+			require.Equal(t, tc.expectedGateways, gatewayConfig.Status.Gateways)
+			require.Len(t, gatewayConfig.Status.Conditions, len(tc.expectedConditions))
+			for conditionType, status := range tc.expectedConditions {
+				condition, ok := k8sutils.GetCondition(conditionType, &gatewayConfig)
+				require.True(t, ok, "condition %s not found", conditionType)
+				require.Equal(t, status, condition.Status, "unexpected status of condition %s", conditionType)
+				if msg, ok := tc.expectedMessages[conditionType]; ok {
+					require.Equal(t, msg, condition.Message)
+				}
+			}
+
+			// Reconciling again without changes must not update the status.
+			resourceVersion := gatewayConfig.ResourceVersion
+			_, err = reconciler.Reconcile(ctx, gatewayConfigReq)
+			require.NoError(t, err)
+			require.NoError(t, fakeClient.Get(ctx, gatewayConfigReq.NamespacedName, &gatewayConfig))
+			require.Equal(t, resourceVersion, gatewayConfig.ResourceVersion)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package gatewayconfiguration
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	operatorerrors "github.com/kong/gateway-operator/internal/errors"
+	gatewayutils "github.com/kong/gateway-operator/pkg/utils/gateway"
+)
+
+// -----------------------------------------------------------------------------
+// GatewayConfigurationReconciler - Watch Map Funcs
+// -----------------------------------------------------------------------------
+
+// listGatewayConfigurationsForGatewayClass enqueues the GatewayConfiguration
+// referenced by the GatewayClass along with the GatewayConfigurations which
+// list the GatewayClass in their status, so that they are updated when the
+// GatewayClass stops referencing them.
+func (r *Reconciler) listGatewayConfigurationsForGatewayClass(ctx context.Context, obj client.Object) []reconcile.Request {
+	gatewayClass, ok := obj.(*gatewayv1.GatewayClass)
+	if !ok {
+		log.FromContext(ctx).Error(
+			operatorerrors.ErrUnexpectedObject,
+			"failed to run map funcs",
+			"expected", "GatewayClass", "found", reflect.TypeOf(obj),
+		)
+		return nil
+	}
+
+	return r.listGatewayConfigurations(ctx, gatewayClass, func(gatewayConfig operatorv1beta1.GatewayConfiguration) bool {
+		return lo.Contains(gatewayConfig.Status.GatewayClasses, gatewayClass.Name)
+	})
+}
+
+// listGatewayConfigurationsForGateway enqueues the GatewayConfigurations
+// referenced by the Gateway and by its GatewayClass along with the
+// GatewayConfigurations which list the Gateway in their status.
+func (r *Reconciler) listGatewayConfigurationsForGateway(ctx context.Context, obj client.Object) []reconcile.Request {
+	gateway, ok := obj.(*gatewayv1.Gateway)
+	if !ok {
+		log.FromContext(ctx).Error(
+			operatorerrors.ErrUnexpectedObject,
+			"failed to run map funcs",
+			"expected", "Gateway", "found", reflect.TypeOf(obj),
+		)
+		return nil
+	}
+
+	var gatewayClass *gatewayv1.GatewayClass
+	gwc := &gatewayv1.GatewayClass{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: string(gateway.Spec.GatewayClassName)}, gwc); err == nil {
+		gatewayClass = gwc
+	}
+
+	nn := operatorv1beta1.NamespacedName{Namespace: gateway.Namespace, Name: gateway.Name}
+	return r.listGatewayConfigurations(ctx, gatewayClass, func(gatewayConfig operatorv1beta1.GatewayConfiguration) bool {
+		return gatewayutils.IsGatewayConfigurationReferencedByGateway(&gatewayConfig, gateway) ||
+			lo.Contains(gatewayConfig.Status.Gateways, nn)
+	})
+}
+
+// listGatewayConfigurationsForSecret enqueues the GatewayConfigurations which
+// use the Secret as their certificate issuer.
+func (r *Reconciler) listGatewayConfigurationsForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		log.FromContext(ctx).Error(
+			operatorerrors.ErrUnexpectedObject,
+			"failed to run map funcs",
+			"expected", "Secret", "found", reflect.TypeOf(obj),
+		)
+		return nil
+	}
+
+	return r.listGatewayConfigurations(ctx, nil, func(gatewayConfig operatorv1beta1.GatewayConfiguration) bool {
+		issuer := gatewayConfig.Spec.CertificateIssuer
+		return issuer != nil &&
+			issuer.Type == operatorv1beta1.CertificateIssuerTypeSecret &&
+			issuer.Secret != nil && issuer.Secret.Name == secret.Name
+	})
+}
+
+// listGatewayConfigurations returns the requests for the GatewayConfiguration
+// referenced by the provided GatewayClass, if any, and for the GatewayConfigurations
+// matching the provided predicate.
+func (r *Reconciler) listGatewayConfigurations(
+	ctx context.Context,
+	gatewayClass *gatewayv1.GatewayClass,
+	matches func(operatorv1beta1.GatewayConfiguration) bool,
+) []reconcile.Request {
+	gatewayConfigs := &operatorv1beta1.GatewayConfigurationList{}
+	if err := r.Client.List(ctx, gatewayConfigs); err != nil {
+		log.FromContext(ctx).Error(err, "could not list gatewayconfigurations in map func")
+		return nil
+	}
+
+	var recs []reconcile.Request
+	for _, gatewayConfig := range gatewayConfigs.Items {
+		if (gatewayClass != nil && gatewayutils.IsGatewayConfigurationReferencedByGatewayClass(&gatewayConfig, gatewayClass)) ||
+			matches(gatewayConfig) {
+			recs = append(recs, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: gatewayConfig.Namespace,
+					Name:      gatewayConfig.Name,
+				},
+			})
+		}
+	}
+	return recs
+}
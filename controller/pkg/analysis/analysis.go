@@ -0,0 +1,337 @@
+package analysis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/samber/lo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/pkg/consts"
+)
+
+const (
+	// DefaultHTTPCheckTimeout is the timeout of the HTTP checks which do not
+	// configure their own.
+	DefaultHTTPCheckTimeout = 5 * time.Second
+
+	// DefaultInterval is the time between two runs of the checks of the analyses
+	// which do not configure their own.
+	DefaultInterval = 10 * time.Second
+
+	// DefaultCount is the number of runs of the checks of the analyses which
+	// do not configure their own.
+	DefaultCount = 3
+
+	// DefaultFailureThreshold is the number of failed runs after which the analyses
+	// which do not configure their own threshold fail.
+	DefaultFailureThreshold = 1
+
+	// metricsPath is the path under which Kong exposes its metrics on the status API.
+	metricsPath = "/metrics"
+)
+
+// HTTPClient sends the requests of the analysis checks.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Target contains the addresses of the preview Services the analysis checks
+// are run against.
+type Target struct {
+	// IngressHost is the host of the preview ingress Service.
+	IngressHost string
+	// IngressPort is the port of the preview ingress Service used by the
+	// HTTP checks which do not configure their own.
+	IngressPort int32
+	// AdminAPIHost is the host of the preview Admin API Service.
+	AdminAPIHost string
+}
+
+// Analyzer runs the analysis checks of a DataPlane rollout.
+type Analyzer struct {
+	client HTTPClient
+}
+
+// NewAnalyzer returns a new Analyzer sending its requests using the provided
+// client. http.DefaultClient is used when the client is nil.
+func NewAnalyzer(client HTTPClient) *Analyzer {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Analyzer{
+		client: client,
+	}
+}
+
+// Run runs all the checks of the provided analysis against the target once.
+// It returns an error describing all the failed checks when any of them fails.
+func (a *Analyzer) Run(ctx context.Context, analysis operatorv1beta1.RolloutAnalysis, target Target) error {
+	var failures []string
+	for _, check := range analysis.HTTPChecks {
+		if err := a.runHTTPCheck(ctx, check, target); err != nil {
+			failures = append(failures, fmt.Sprintf("HTTP check %q: %v", check.Name, err))
+		}
+	}
+
+	if len(analysis.MetricChecks) > 0 {
+		families, err := a.scrapeMetrics(ctx, target)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("failed scraping metrics: %v", err))
+		} else {
+			for _, check := range analysis.MetricChecks {
+				if err := runMetricCheck(check, families); err != nil {
+					failures = append(failures, fmt.Sprintf("metric check %q: %v", check.Name, err))
+				}
+			}
+		}
+	}
+
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// Record records the result of a run of the analysis checks in the provided status.
+// The status is marked as completed once the failure threshold has been reached
+// or all the runs of the analysis completed.
+func Record(
+	analysis operatorv1beta1.RolloutAnalysis,
+	status *operatorv1beta1.DataPlaneRolloutStatusAnalysis,
+	runErr error,
+	now time.Time,
+) {
+	status.Runs++
+	status.LastRunTime = &metav1.Time{Time: now}
+	if runErr != nil {
+		status.Failures++
+		status.Message = runErr.Error()
+	}
+
+	switch {
+	case status.Failures >= failureThreshold(analysis):
+		status.Completed, status.Passed = true, false
+	case status.Runs >= count(analysis):
+		status.Completed, status.Passed = true, true
+	}
+}
+
+// NextRunAfter returns the duration after which the next run of the analysis
+// checks is due. It returns 0 when the checks can be run right away.
+func NextRunAfter(
+	analysis operatorv1beta1.RolloutAnalysis,
+	status *operatorv1beta1.DataPlaneRolloutStatusAnalysis,
+	now time.Time,
+) time.Duration {
+	if status == nil || status.LastRunTime == nil {
+		return 0
+	}
+	return max(status.LastRunTime.Add(interval(analysis)).Sub(now), 0)
+}
+
+// interval returns the time between two runs of the analysis checks.
+func interval(analysis operatorv1beta1.RolloutAnalysis) time.Duration {
+	if analysis.Interval != nil && analysis.Interval.Duration > 0 {
+		return analysis.Interval.Duration
+	}
+	return DefaultInterval
+}
+
+// count returns the number of runs of the analysis checks.
+func count(analysis operatorv1beta1.RolloutAnalysis) int32 {
+	if analysis.Count > 0 {
+		return analysis.Count
+	}
+	return DefaultCount
+}
+
+// failureThreshold returns the number of failed runs after which the analysis fails.
+// It cannot exceed the number of runs.
+func failureThreshold(analysis operatorv1beta1.RolloutAnalysis) int32 {
+	if analysis.FailureThreshold > 0 {
+		return min(analysis.FailureThreshold, count(analysis))
+	}
+	return DefaultFailureThreshold
+}
+
+// runHTTPCheck sends the request of the provided check and verifies the response's status code.
+func (a *Analyzer) runHTTPCheck(ctx context.Context, check operatorv1beta1.RolloutHTTPCheck, target Target) error {
+	host, port := target.IngressHost, target.IngressPort
+	if check.Service == operatorv1beta1.RolloutAnalysisServiceAdminAPI {
+		// The Admin API requires mTLS hence the status API is used instead.
+		host, port = target.AdminAPIHost, consts.DataPlaneStatusPort
+	}
+	if check.Port != nil {
+		port = *check.Port
+	}
+	path := check.Path
+	if path == "" {
+		path = "/"
+	}
+	method := check.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	timeout := DefaultHTTPCheckTimeout
+	if check.Timeout != nil && check.Timeout.Duration > 0 {
+		timeout = check.Timeout.Duration
+	}
+	expectedStatusCodes := check.ExpectedStatusCodes
+	if len(expectedStatusCodes) == 0 {
+		expectedStatusCodes = []int32{http.StatusOK}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var body io.Reader
+	if check.Body != "" {
+		body = strings.NewReader(check.Body)
+	}
+	url := "http://" + net.JoinHostPort(host, strconv.Itoa(int(port))) + path
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return fmt.Errorf("failed creating request: %w", err)
+	}
+	for k, v := range check.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if !lo.Contains(expectedStatusCodes, int32(resp.StatusCode)) {
+		return fmt.Errorf("%s %s returned unexpected status code %d", method, url, resp.StatusCode)
+	}
+	return nil
+}
+
+// scrapeMetrics scrapes the metrics exposed by the status API of the preview Deployment.
+func (a *Analyzer) scrapeMetrics(ctx context.Context, target Target) (map[string]*dto.MetricFamily, error) {
+	ctx, cancel := context.WithTimeout(ctx, DefaultHTTPCheckTimeout)
+	defer cancel()
+
+	url := "http://" + net.JoinHostPort(target.AdminAPIHost, strconv.Itoa(consts.DataPlaneMetricsPort)) + metricsPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating request: %w", err)
+	}
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s returned unexpected status code %d", url, resp.StatusCode)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing metrics: %w", err)
+	}
+	return families, nil
+}
+
+// runMetricCheck verifies that the sum of the values of the metric's series
+// selected by the check does not exceed the check's threshold.
+// Metrics which are not exposed, e.g. counters of requests which never
+// happened, are considered to be 0.
+func runMetricCheck(check operatorv1beta1.RolloutMetricCheck, families map[string]*dto.MetricFamily) error {
+	threshold, err := strconv.ParseFloat(check.Max, 64)
+	if err != nil {
+		return fmt.Errorf("invalid threshold %q: %w", check.Max, err)
+	}
+
+	value, err := metricValue(check, families)
+	if err != nil {
+		return err
+	}
+	if value > threshold {
+		return fmt.Errorf("value %s exceeds the threshold %s", strconv.FormatFloat(value, 'f', -1, 64), check.Max)
+	}
+	return nil
+}
+
+// metricValue returns the sum of the values of the metric's series selected by the check.
+// The `_count` and `_sum` series of histograms and summaries are supported.
+func metricValue(check operatorv1beta1.RolloutMetricCheck, families map[string]*dto.MetricFamily) (float64, error) {
+	name, suffix := check.Name, ""
+	family, ok := families[name]
+	if !ok {
+		for _, s := range []string{"_count", "_sum"} {
+			if base, found := strings.CutSuffix(check.Name, s); found {
+				if family, ok = families[base]; ok {
+					name, suffix = base, s
+					break
+				}
+			}
+		}
+	}
+	if !ok {
+		return 0, nil
+	}
+
+	var sum float64
+	for _, m := range family.GetMetric() {
+		if !labelsMatch(m.GetLabel(), check.Labels) {
+			continue
+		}
+		switch family.GetType() {
+		case dto.MetricType_COUNTER:
+			sum += m.GetCounter().GetValue()
+		case dto.MetricType_GAUGE:
+			sum += m.GetGauge().GetValue()
+		case dto.MetricType_UNTYPED:
+			sum += m.GetUntyped().GetValue()
+		case dto.MetricType_HISTOGRAM:
+			switch suffix {
+			case "_count":
+				sum += float64(m.GetHistogram().GetSampleCount())
+			case "_sum":
+				sum += m.GetHistogram().GetSampleSum()
+			default:
+				return 0, fmt.Errorf("histogram %s has to be checked using its _count or _sum series", name)
+			}
+		case dto.MetricType_SUMMARY:
+			switch suffix {
+			case "_count":
+				sum += float64(m.GetSummary().GetSampleCount())
+			case "_sum":
+				sum += m.GetSummary().GetSampleSum()
+			default:
+				return 0, fmt.Errorf("summary %s has to be checked using its _count or _sum series", name)
+			}
+		default:
+			return 0, fmt.Errorf("unsupported type %s of metric %s", family.GetType(), name)
+		}
+	}
+	return sum, nil
+}
+
+// labelsMatch returns true when the provided labels contain all the expected ones.
+func labelsMatch(labels []*dto.LabelPair, expected map[string]string) bool {
+	for k, v := range expected {
+		if !lo.ContainsBy(labels, func(l *dto.LabelPair) bool {
+			return l.GetName() == k && l.GetValue() == v
+		}) {
+			return false
+		}
+	}
+	return true
+}
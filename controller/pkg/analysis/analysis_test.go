@@ -0,0 +1,294 @@
+package analysis
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+)
+
+// stubHTTPClient responds to the requests using the responses registered
+// for their URLs and records the received requests.
+type stubHTTPClient struct {
+	responses map[string]stubResponse
+	requests  []*http.Request
+}
+
+type stubResponse struct {
+	statusCode int
+	body       string
+	err        error
+}
+
+func (c *stubHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	c.requests = append(c.requests, req)
+	resp, ok := c.responses[req.Method+" "+req.URL.String()]
+	if !ok {
+		return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(""))}, nil
+	}
+	if resp.err != nil {
+		return nil, resp.err
+	}
+	return &http.Response{StatusCode: resp.statusCode, Body: io.NopCloser(strings.NewReader(resp.body))}, nil
+}
+
+const metrics = `# HELP kong_http_requests_total HTTP status codes per consumer/service/route in Kong
+# TYPE kong_http_requests_total counter
+kong_http_requests_total{service="echo",route="echo",code="200",source="service",consumer=""} 97
+kong_http_requests_total{service="echo",route="echo",code="500",source="service",consumer=""} 2
+kong_http_requests_total{service="echo",route="echo",code="500",source="kong",consumer=""} 1
+# HELP kong_nginx_connections_total Number of connections by subsystem
+# TYPE kong_nginx_connections_total gauge
+kong_nginx_connections_total{node_id="1",subsystem="http",state="active"} 4
+# HELP kong_request_latency_ms Total latency incurred during requests for each service/route in Kong
+# TYPE kong_request_latency_ms histogram
+kong_request_latency_ms_bucket{service="echo",route="echo",le="25"} 90
+kong_request_latency_ms_bucket{service="echo",route="echo",le="+Inf"} 100
+kong_request_latency_ms_sum{service="echo",route="echo"} 1500
+kong_request_latency_ms_count{service="echo",route="echo"} 100
+`
+
+func TestAnalyzerRun(t *testing.T) {
+	target := Target{
+		IngressHost:  "preview-ingress.ns.svc",
+		IngressPort:  80,
+		AdminAPIHost: "preview-admin.ns.svc",
+	}
+	responses := map[string]stubResponse{
+		"GET http://preview-ingress.ns.svc:80/":         {statusCode: http.StatusOK},
+		"GET http://preview-ingress.ns.svc:80/teapot":   {statusCode: http.StatusTeapot},
+		"POST http://preview-ingress.ns.svc:8000/echo":  {statusCode: http.StatusCreated},
+		"GET http://preview-ingress.ns.svc:80/down":     {err: errors.New("connection refused")},
+		"GET http://preview-admin.ns.svc:8100/status":   {statusCode: http.StatusOK},
+		"GET http://preview-admin.ns.svc:8100/metrics":  {statusCode: http.StatusOK, body: metrics},
+		"HEAD http://preview-ingress.ns.svc:80/missing": {statusCode: http.StatusNotFound},
+	}
+
+	testCases := []struct {
+		name          string
+		analysis      operatorv1beta1.RolloutAnalysis
+		expectedError []string
+	}{
+		{
+			name: "empty analysis passes",
+		},
+		{
+			name: "passing HTTP checks",
+			analysis: operatorv1beta1.RolloutAnalysis{
+				HTTPChecks: []operatorv1beta1.RolloutHTTPCheck{
+					{Name: "defaults"},
+					{Name: "status", Service: operatorv1beta1.RolloutAnalysisServiceAdminAPI, Path: "/status"},
+					{
+						Name:                "custom",
+						Port:                lo.ToPtr(int32(8000)),
+						Path:                "/echo",
+						Method:              http.MethodPost,
+						Headers:             map[string]string{"Content-Type": "application/json"},
+						Body:                `{"hello":"world"}`,
+						ExpectedStatusCodes: []int32{http.StatusOK, http.StatusCreated},
+					},
+					{Name: "not found", Method: http.MethodHead, Path: "/missing", ExpectedStatusCodes: []int32{http.StatusNotFound}},
+				},
+			},
+		},
+		{
+			name: "failing HTTP checks",
+			analysis: operatorv1beta1.RolloutAnalysis{
+				HTTPChecks: []operatorv1beta1.RolloutHTTPCheck{
+					{Name: "defaults"},
+					{Name: "teapot", Path: "/teapot"},
+					{Name: "down", Path: "/down"},
+				},
+			},
+			expectedError: []string{
+				`HTTP check "teapot": GET http://preview-ingress.ns.svc:80/teapot returned unexpected status code 418`,
+				`HTTP check "down": request failed: connection refused`,
+			},
+		},
+		{
+			name: "passing metric checks",
+			analysis: operatorv1beta1.RolloutAnalysis{
+				MetricChecks: []operatorv1beta1.RolloutMetricCheck{
+					{Name: "kong_http_requests_total", Labels: map[string]string{"code": "500"}, Max: "3"},
+					{Name: "kong_http_requests_total", Labels: map[string]string{"code": "500", "source": "kong"}, Max: "1"},
+					{Name: "kong_nginx_connections_total", Max: "4.5"},
+					{Name: "kong_request_latency_ms_count", Max: "100"},
+					{Name: "kong_upstream_target_health", Max: "0"},
+				},
+			},
+		},
+		{
+			name: "failing metric checks",
+			analysis: operatorv1beta1.RolloutAnalysis{
+				MetricChecks: []operatorv1beta1.RolloutMetricCheck{
+					{Name: "kong_http_requests_total", Labels: map[string]string{"code": "500"}, Max: "2"},
+					{Name: "kong_http_requests_total", Max: "99"},
+					{Name: "kong_request_latency_ms_sum", Max: "1000"},
+					{Name: "kong_request_latency_ms", Max: "1000"},
+				},
+			},
+			expectedError: []string{
+				`metric check "kong_http_requests_total": value 3 exceeds the threshold 2`,
+				`metric check "kong_http_requests_total": value 100 exceeds the threshold 99`,
+				`metric check "kong_request_latency_ms_sum": value 1500 exceeds the threshold 1000`,
+				`metric check "kong_request_latency_ms": histogram kong_request_latency_ms has to be checked using its _count or _sum series`,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &stubHTTPClient{responses: responses}
+			err := NewAnalyzer(client).Run(context.Background(), tc.analysis, target)
+			if len(tc.expectedError) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, expected := range tc.expectedError {
+				require.ErrorContains(t, err, expected)
+			}
+		})
+	}
+
+	t.Run("request of an HTTP check", func(t *testing.T) {
+		client := &stubHTTPClient{responses: responses}
+		err := NewAnalyzer(client).Run(context.Background(), operatorv1beta1.RolloutAnalysis{
+			HTTPChecks: []operatorv1beta1.RolloutHTTPCheck{
+				{
+					Name:    "custom",
+					Port:    lo.ToPtr(int32(8000)),
+					Path:    "/echo",
+					Method:  http.MethodPost,
+					Headers: map[string]string{"Content-Type": "application/json"},
+					Body:    `{"hello":"world"}`,
+					ExpectedStatusCodes: []int32{
+						http.StatusCreated,
+					},
+				},
+			},
+		}, target)
+		require.NoError(t, err)
+		require.Len(t, client.requests, 1)
+		req := client.requests[0]
+		require.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		require.Equal(t, `{"hello":"world"}`, string(body))
+	})
+
+	t.Run("metrics cannot be scraped", func(t *testing.T) {
+		client := &stubHTTPClient{}
+		err := NewAnalyzer(client).Run(context.Background(), operatorv1beta1.RolloutAnalysis{
+			MetricChecks: []operatorv1beta1.RolloutMetricCheck{
+				{Name: "kong_http_requests_total", Max: "0"},
+			},
+		}, target)
+		require.ErrorContains(t, err, "failed scraping metrics: GET http://preview-admin.ns.svc:8100/metrics returned unexpected status code 404")
+	})
+}
+
+func TestRecord(t *testing.T) {
+	now := time.Now()
+	runErr := errors.New("HTTP check \"ingress\": request failed")
+
+	testCases := []struct {
+		name           string
+		analysis       operatorv1beta1.RolloutAnalysis
+		results        []error
+		expectedStatus operatorv1beta1.DataPlaneRolloutStatusAnalysis
+	}{
+		{
+			name:     "defaults: passes after 3 successful runs",
+			analysis: operatorv1beta1.RolloutAnalysis{},
+			results:  []error{nil, nil, nil},
+			expectedStatus: operatorv1beta1.DataPlaneRolloutStatusAnalysis{
+				Runs:      3,
+				Completed: true,
+				Passed:    true,
+			},
+		},
+		{
+			name:     "defaults: in progress after 2 successful runs",
+			analysis: operatorv1beta1.RolloutAnalysis{},
+			results:  []error{nil, nil},
+			expectedStatus: operatorv1beta1.DataPlaneRolloutStatusAnalysis{
+				Runs: 2,
+			},
+		},
+		{
+			name:     "defaults: fails on the first failed run",
+			analysis: operatorv1beta1.RolloutAnalysis{},
+			results:  []error{nil, runErr},
+			expectedStatus: operatorv1beta1.DataPlaneRolloutStatusAnalysis{
+				Runs:      2,
+				Failures:  1,
+				Completed: true,
+				Message:   runErr.Error(),
+			},
+		},
+		{
+			name:     "failed runs below the threshold",
+			analysis: operatorv1beta1.RolloutAnalysis{Count: 3, FailureThreshold: 2},
+			results:  []error{runErr, nil, nil},
+			expectedStatus: operatorv1beta1.DataPlaneRolloutStatusAnalysis{
+				Runs:      3,
+				Failures:  1,
+				Completed: true,
+				Passed:    true,
+				Message:   runErr.Error(),
+			},
+		},
+		{
+			name:     "threshold greater than count is capped",
+			analysis: operatorv1beta1.RolloutAnalysis{Count: 2, FailureThreshold: 5},
+			results:  []error{runErr, runErr},
+			expectedStatus: operatorv1beta1.DataPlaneRolloutStatusAnalysis{
+				Runs:      2,
+				Failures:  2,
+				Completed: true,
+				Message:   runErr.Error(),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			status := &operatorv1beta1.DataPlaneRolloutStatusAnalysis{}
+			for _, result := range tc.results {
+				Record(tc.analysis, status, result, now)
+			}
+			tc.expectedStatus.LastRunTime = &metav1.Time{Time: now}
+			require.Equal(t, tc.expectedStatus, *status)
+		})
+	}
+}
+
+func TestNextRunAfter(t *testing.T) {
+	now := time.Now()
+	status := func(lastRun time.Time) *operatorv1beta1.DataPlaneRolloutStatusAnalysis {
+		return &operatorv1beta1.DataPlaneRolloutStatusAnalysis{
+			Runs:        1,
+			LastRunTime: &metav1.Time{Time: lastRun},
+		}
+	}
+
+	require.Zero(t, NextRunAfter(operatorv1beta1.RolloutAnalysis{}, nil, now))
+	require.Zero(t, NextRunAfter(operatorv1beta1.RolloutAnalysis{}, &operatorv1beta1.DataPlaneRolloutStatusAnalysis{}, now))
+	require.Equal(t, DefaultInterval-time.Second,
+		NextRunAfter(operatorv1beta1.RolloutAnalysis{}, status(now.Add(-time.Second)), now),
+	)
+	require.Equal(t, 30*time.Second,
+		NextRunAfter(operatorv1beta1.RolloutAnalysis{Interval: &metav1.Duration{Duration: time.Minute}}, status(now.Add(-30*time.Second)), now),
+	)
+	require.Zero(t, NextRunAfter(operatorv1beta1.RolloutAnalysis{}, status(now.Add(-time.Hour)), now))
+}
@@ -86,3 +86,19 @@ func (b *testDataPlaneBuilder) WithPromotionStrategy(promotionStrategy operatorv
 	b.dataplane.Spec.Deployment.Rollout.Strategy.BlueGreen.Promotion.Strategy = promotionStrategy
 	return b
 }
+
+// WithRolloutAnalysis sets the analysis of the DataPlane's BlueGreen rollout.
+func (b *testDataPlaneBuilder) WithRolloutAnalysis(analysis operatorv1beta1.RolloutAnalysis) *testDataPlaneBuilder {
+	b.initDeploymentRolloutBlueGreen()
+	b.dataplane.Spec.Deployment.Rollout.Strategy.BlueGreen.Analysis = &analysis
+	return b
+}
+
+// WithCanaryStrategy sets the Canary rollout strategy of the DataPlane object.
+func (b *testDataPlaneBuilder) WithCanaryStrategy(canary operatorv1beta1.CanaryStrategy) *testDataPlaneBuilder {
+	if b.dataplane.Spec.Deployment.Rollout == nil {
+		b.dataplane.Spec.Deployment.Rollout = &operatorv1beta1.Rollout{}
+	}
+	b.dataplane.Spec.Deployment.Rollout.Strategy.Canary = &canary
+	return b
+}
@@ -2,8 +2,7 @@ package secrets
 
 import (
 	"context"
-	"crypto/ecdsa"
-	"crypto/elliptic"
+	"crypto"
 	"crypto/rand"
 	"crypto/x509"
 	"crypto/x509/pkix"
@@ -22,7 +21,7 @@ import (
 	"github.com/go-logr/logr"
 	certificatesv1 "k8s.io/api/certificates/v1"
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -96,13 +95,9 @@ limitations under the License.
 // signCertificate takes a CertificateSigningRequest and a TLS Secret and returns a PEM x.509 certificate
 // signed by the certificate in the Secret.
 func signCertificate(csr certificatesv1.CertificateSigningRequest, ca *corev1.Secret) ([]byte, error) {
-	caKeyBlock, _ := pem.Decode(ca.Data["tls.key"])
-	if caKeyBlock == nil {
-		return nil, fmt.Errorf("failed decoding 'tls.key' data from secret %s", ca.Name)
-	}
-	priv, err := x509.ParseECPrivateKey(caKeyBlock.Bytes)
+	priv, err := ParsePrivateKey(ca.Data["tls.key"])
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed parsing 'tls.key' data from secret %s: %w", ca.Name, err)
 	}
 
 	caCertBlock, _ := pem.Decode(ca.Data["tls.crt"])
@@ -135,7 +130,7 @@ func signCertificate(csr certificatesv1.CertificateSigningRequest, ca *corev1.Se
 			ExpiryString: certExpiryDuration.String(),
 		},
 	}
-	cfs, err := local.NewSigner(priv, caCert, x509.ECDSAWithSHA256, policy)
+	cfs, err := local.NewSigner(priv, caCert, SignatureAlgorithm(priv), policy)
 	if err != nil {
 		return nil, err
 	}
@@ -151,6 +146,17 @@ func signCertificate(csr certificatesv1.CertificateSigningRequest, ca *corev1.Se
 // mtlsCASecretNamespace/mtlsCASecretName Secret, or does nothing if a namespace/name Secret is
 // already present. It returns a boolean indicating if it created a Secret and an error indicating
 // any failures it encountered.
+//
+// The certificate is issued by the Issuer configured with WithIssuer, which defaults to
+// the local CA stored in the mtlsCASecretNamespace/mtlsCASecretName Secret. When the issuer
+// has not issued the certificate yet, ErrCertificatePending is returned and the request
+// is checked again on subsequent calls.
+//
+// An existing Secret is replaced with a newly issued certificate when its certificate
+// is invalid, was issued for a different subject or by a different CA, or expires within
+// the renewal window configured with WithRenewBefore. Whenever that happens the
+// CertificateRotated condition is set on the owner and, if configured with WithEventRecorder,
+// an Event is emitted.
 func EnsureCertificate[
 	T interface {
 		*operatorv1beta1.ControlPlane | *operatorv1beta1.DataPlane
@@ -164,9 +170,19 @@ func EnsureCertificate[
 	usages []certificatesv1.KeyUsage,
 	cl client.Client,
 	additionalMatchingLabels client.MatchingLabels,
+	opts ...EnsureCertificateOpt,
 ) (op.CreatedUpdatedOrNoop, *corev1.Secret, error) {
 	setCALogger(ctrlruntimelog.Log)
 
+	var options ensureCertificateOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	issuer := options.issuer
+	if issuer == nil {
+		issuer = NewLocalCAIssuer(mtlsCASecretNN)
+	}
+
 	// TODO: https://github.com/Kong/gateway-operator/pull/1101.
 	// Use only new labels after several minor version of soak time.
 
@@ -216,34 +232,60 @@ func EnsureCertificate[
 
 	// If there are no secrets yet, then create one.
 	if count == 0 {
-		return generateTLSDataSecret(ctx, generatedSecret, owner, subject, mtlsCASecretNN, usages, cl)
+		return generateTLSDataSecret(ctx, generatedSecret, owner, subject, issuer, options.keyType, usages, cl)
 	}
 
 	// Otherwise there is already 1 certificate matching specified selectors.
 	existingSecret := &secrets[0]
-
-	block, _ := pem.Decode(existingSecret.Data["tls.crt"])
-	if block == nil {
-		// The existing secret has a broken certificate, delete it and recreate it.
-		if err := cl.Delete(ctx, existingSecret); err != nil {
-			return op.Noop, nil, err
-		}
-
-		return generateTLSDataSecret(ctx, generatedSecret, owner, subject, mtlsCASecretNN, usages, cl)
+	req := CertificateRequest{
+		Owner:   owner,
+		Subject: subject,
+		Usages:  usages,
 	}
 
-	// Check if existing certificate is for a different subject.
+	// Check if the existing certificate has to be reissued.
 	// If that's the case, delete the old certificate and create a new one.
-	cert, err := x509.ParseCertificate(block.Bytes)
-	if err != nil {
-		return op.Noop, nil, err
+	var (
+		reason        k8sutils.ConditionReason
+		reasonMessage string
+	)
+	if len(existingSecret.Data[consts.TLSCRT]) == 0 {
+		// Certificates which have been requested but not issued yet are stored
+		// with an empty tls.crt, along with the private key used for the request.
+		priv, err := ParsePrivateKey(existingSecret.Data[consts.TLSKey])
+		if err == nil {
+			return issuePendingCertificate(ctx, cl, issuer, req, priv, existingSecret)
+		}
+		reason, reasonMessage = consts.CertificateRotatedReasonInvalid, fmt.Sprintf("the private key could not be parsed: %v", err)
+	} else {
+		reason, reasonMessage, err = issuer.ReissueReason(ctx, cl, req, existingSecret, options.renewBefore)
+		if err != nil {
+			return op.Noop, nil, err
+		}
+		// Keys of certificates copied from pre-provisioned Secrets are not
+		// generated by the operator so their type cannot be enforced.
+		if _, ok := issuer.(*secretIssuer); reason == "" && !ok {
+			reason, reasonMessage = privateKeyReissueReason(existingSecret, options.keyType)
+		}
 	}
-	if cert.Subject.CommonName != subject {
-		if err := cl.Delete(ctx, existingSecret); err != nil {
+	if reason != "" {
+		for _, hook := range getPreDeleteHooks(owner) {
+			if err := hook(ctx, cl, existingSecret); client.IgnoreNotFound(err) != nil {
+				return op.Noop, nil, err
+			}
+		}
+		if err := cl.Delete(ctx, existingSecret); client.IgnoreNotFound(err) != nil {
 			return op.Noop, nil, err
 		}
 
-		return generateTLSDataSecret(ctx, generatedSecret, owner, subject, mtlsCASecretNN, usages, cl)
+		res, secret, issueErr := generateTLSDataSecret(ctx, generatedSecret, owner, subject, issuer, options.keyType, usages, cl)
+		if issueErr != nil && !errors.Is(issueErr, ErrCertificatePending) {
+			return res, secret, issueErr
+		}
+		if err := notifyCertificateReissued(ctx, cl, owner, secret, reason, reasonMessage, options.eventRecorder); err != nil {
+			return res, secret, err
+		}
+		return res, secret, issueErr
 	}
 
 	var updated bool
@@ -301,95 +343,113 @@ func getSecretOpts[T interface {
 	}
 }
 
-// generateTLSDataSecret generates a TLS certificate data, fills the provided secret with
-// that data and creates it using the k8s client.
+// generateTLSDataSecret generates a private key of the provided type, requests a certificate for it from
+// the provided issuer, fills the provided secret with that data and creates it using
+// the k8s client.
 // It returns a boolean indicating whether the secret has been created, the secret
-// itself and an error.
+// itself and an error. When the certificate has not been issued yet, the secret is
+// created with the private key and an empty certificate and ErrCertificatePending
+// is returned.
 func generateTLSDataSecret(
 	ctx context.Context,
 	generatedSecret *corev1.Secret,
 	owner client.Object,
 	subject string,
-	mtlsCASecret types.NamespacedName,
+	issuer Issuer,
+	keyType KeyType,
 	usages []certificatesv1.KeyUsage,
 	k8sClient client.Client,
 ) (op.CreatedUpdatedOrNoop, *corev1.Secret, error) {
-	template := x509.CertificateRequest{
-		Subject: pkix.Name{
-			CommonName:   subject,
-			Organization: []string{"Kong, Inc."},
-			Country:      []string{"US"},
-		},
-		SignatureAlgorithm: x509.ECDSAWithSHA256,
-		DNSNames:           []string{subject},
+	priv, err := GeneratePrivateKey(keyType)
+	if err != nil {
+		return op.Noop, nil, err
 	}
-
-	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	request, err := newCertificateRequest(subject, priv)
 	if err != nil {
 		return op.Noop, nil, err
 	}
-
-	der, err := x509.CreateCertificateRequest(rand.Reader, &template, priv)
+	privPEM, err := EncodePrivateKey(priv)
 	if err != nil {
 		return op.Noop, nil, err
 	}
 
-	// This is effectively a placeholder so long as we handle signing internally. When actually creating CSR resources,
-	// this string is used by signers to filter which resources they pay attention to
-	signerName := "gateway-operator.konghq.com/mtls"
-	// TODO This creates certificates that last for 10 years as an arbitrarily long period for the alpha. A production-
-	// ready implementation should use a shorter lifetime and rotate certificates. Rotation requires some mechanism to
-	// recognize that certificates have expired (ideally without permissions to read Secrets across the cluster) and
-	// to get Deployments to acknowledge them. For Kong, this requires a restart, as there's no way to force a reload
-	// of updated files on disk.
-	expiration := int32(315400000)
-
-	csr := certificatesv1.CertificateSigningRequest{
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: owner.GetNamespace(),
-			Name:      owner.GetName(),
-		},
-		Spec: certificatesv1.CertificateSigningRequestSpec{
-			Request: pem.EncodeToMemory(&pem.Block{
-				Type:  "CERTIFICATE REQUEST",
-				Bytes: der,
-			}),
-			SignerName:        signerName,
-			ExpirationSeconds: &expiration,
-			Usages:            usages,
-		},
+	generatedSecret.Data = map[string][]byte{
+		consts.TLSKey: privPEM,
+	}
+	req := CertificateRequest{
+		Owner:   owner,
+		Subject: subject,
+		Usages:  usages,
+		Request: request,
+	}
+	issueErr := issuer.Issue(ctx, k8sClient, req, generatedSecret)
+	if issueErr != nil && !errors.Is(issueErr, ErrCertificatePending) {
+		return op.Noop, nil, issueErr
 	}
 
-	ca := &corev1.Secret{}
-	err = k8sClient.Get(ctx, mtlsCASecret, ca)
+	err = k8sClient.Create(ctx, generatedSecret)
 	if err != nil {
 		return op.Noop, nil, err
 	}
 
-	signed, err := signCertificate(csr, ca)
+	return op.Created, generatedSecret, issueErr
+}
+
+// issuePendingCertificate checks with the provided issuer whether the certificate
+// requested for the provided Secret has been issued and, if that's the case, stores
+// it in the Secret.
+func issuePendingCertificate(
+	ctx context.Context,
+	cl client.Client,
+	issuer Issuer,
+	req CertificateRequest,
+	priv crypto.Signer,
+	secret *corev1.Secret,
+) (op.CreatedUpdatedOrNoop, *corev1.Secret, error) {
+	request, err := newCertificateRequest(req.Subject, priv)
 	if err != nil {
 		return op.Noop, nil, err
 	}
-	privDer, err := x509.MarshalECPrivateKey(priv)
-	if err != nil {
-		return op.Noop, nil, err
+	req.Request = request
+
+	old := secret.DeepCopy()
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+	issueErr := issuer.Issue(ctx, cl, req, secret)
+	if issueErr != nil && !errors.Is(issueErr, ErrCertificatePending) {
+		return op.Noop, nil, issueErr
+	}
+	if equality.Semantic.DeepEqual(old, secret) {
+		return op.Noop, secret, issueErr
 	}
+	if err := cl.Patch(ctx, secret, client.MergeFrom(old)); err != nil {
+		return op.Noop, secret, fmt.Errorf("failed patching secret %s: %w", secret.Name, err)
+	}
+	return op.Updated, secret, issueErr
+}
 
-	generatedSecret.Data = map[string][]byte{
-		"ca.crt":  ca.Data["tls.crt"],
-		"tls.crt": signed,
-		"tls.key": pem.EncodeToMemory(&pem.Block{
-			Type:  "EC PRIVATE KEY",
-			Bytes: privDer,
-		}),
+// newCertificateRequest returns a PEM encoded x509 certificate request for the
+// provided subject signed with the provided private key.
+func newCertificateRequest(subject string, priv crypto.Signer) ([]byte, error) {
+	template := x509.CertificateRequest{
+		Subject: pkix.Name{
+			CommonName:   subject,
+			Organization: []string{"Kong, Inc."},
+			Country:      []string{"US"},
+		},
+		SignatureAlgorithm: SignatureAlgorithm(priv),
+		DNSNames:           []string{subject},
 	}
 
-	err = k8sClient.Create(ctx, generatedSecret)
+	der, err := x509.CreateCertificateRequest(rand.Reader, &template, priv)
 	if err != nil {
-		return op.Noop, nil, err
+		return nil, err
 	}
-
-	return op.Created, generatedSecret, nil
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE REQUEST",
+		Bytes: der,
+	}), nil
 }
 
 // GetManagedLabelForServiceSecret returns a label selector for the ServiceSecret.
@@ -21,9 +21,11 @@ import (
 	"github.com/stretchr/testify/require"
 	certificatesv1 "k8s.io/api/certificates/v1"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	ctrlruntimelog "sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -32,6 +34,8 @@ import (
 	"github.com/kong/gateway-operator/controller/pkg/log"
 	"github.com/kong/gateway-operator/controller/pkg/op"
 	gwtypes "github.com/kong/gateway-operator/internal/types"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
 	k8sresources "github.com/kong/gateway-operator/pkg/utils/kubernetes/resources"
 )
 
@@ -396,6 +400,158 @@ func TestMaybeCreateCertificateSecret(t *testing.T) {
 	}
 }
 
+func TestEnsureCertificateRotation(t *testing.T) {
+	const (
+		namespace = "ns"
+		subject   = "test-subject"
+	)
+	caNN := types.NamespacedName{Name: "test-mtls-secret", Namespace: namespace}
+	usages := []certificatesv1.KeyUsage{certificatesv1.UsageServerAuth}
+
+	setup := func(t *testing.T) (client.Client, *operatorv1beta1.DataPlane, *corev1.Secret) {
+		scheme := runtime.NewScheme()
+		require.NoError(t, corev1.AddToScheme(scheme))
+		require.NoError(t, operatorv1beta1.AddToScheme(scheme))
+
+		dp := &operatorv1beta1.DataPlane{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: operatorv1beta1.SchemeGroupVersion.String(),
+				Kind:       "DataPlane",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dp-1",
+				Namespace: namespace,
+				UID:       types.UID("1234"),
+			},
+		}
+		caSecret, err := generateCACert(caNN)
+		require.NoError(t, err)
+		cl := fakectrlruntimeclient.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(dp, caSecret).
+			WithStatusSubresource(dp).
+			Build()
+		return cl, dp, caSecret
+	}
+
+	t.Run("certificate which is not expiring is kept", func(t *testing.T) {
+		ctx := context.Background()
+		cl, dp, _ := setup(t)
+
+		res, secret, err := EnsureCertificate(ctx, dp, subject, caNN, usages, cl, nil, WithRenewBefore(time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, op.Created, res)
+
+		res, secondSecret, err := EnsureCertificate(ctx, dp, subject, caNN, usages, cl, nil, WithRenewBefore(time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, op.Noop, res)
+		require.Equal(t, secret.Name, secondSecret.Name)
+		require.Greater(t, RenewalRequeueAfter(secondSecret, time.Hour), 24*time.Hour)
+	})
+
+	t.Run("expiring certificate is reissued", func(t *testing.T) {
+		ctx := context.Background()
+		cl, dp, caSecret := setup(t)
+		recorder := record.NewFakeRecorder(1)
+
+		existing := generateLeafSecret(t, dp, caSecret, subject, time.Hour)
+		require.NoError(t, cl.Create(ctx, existing))
+
+		res, secret, err := EnsureCertificate(ctx, dp, subject, caNN, usages, cl, nil,
+			WithRenewBefore(2*time.Hour), WithEventRecorder(recorder),
+		)
+		require.NoError(t, err)
+		require.Equal(t, op.Created, res)
+		require.NotEqual(t, existing.Name, secret.Name)
+		require.True(t, k8serrors.IsNotFound(cl.Get(ctx, client.ObjectKeyFromObject(existing), &corev1.Secret{})))
+
+		require.Len(t, recorder.Events, 1)
+		require.Contains(t, <-recorder.Events, "CertificateReissued")
+
+		require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(dp), dp))
+		c, ok := k8sutils.GetCondition(consts.CertificateRotatedType, dp)
+		require.True(t, ok)
+		require.Equal(t, metav1.ConditionTrue, c.Status)
+		require.Equal(t, string(consts.CertificateRotatedReasonExpiring), c.Reason)
+	})
+
+	t.Run("certificate issued by a previous CA is reissued with the CA trust bundle", func(t *testing.T) {
+		ctx := context.Background()
+		cl, dp, caSecret := setup(t)
+
+		res, secret, err := EnsureCertificate(ctx, dp, subject, caNN, usages, cl, nil)
+		require.NoError(t, err)
+		require.Equal(t, op.Created, res)
+
+		t.Log("rolling over the CA")
+		newCASecret, err := generateCACert(caNN)
+		require.NoError(t, err)
+		caSecret.Data[consts.CACRT] = append(bytes.Clone(newCASecret.Data[consts.TLSCRT]), caSecret.Data[consts.TLSCRT]...)
+		caSecret.Data[consts.TLSCRT] = newCASecret.Data[consts.TLSCRT]
+		caSecret.Data[consts.TLSKey] = newCASecret.Data[consts.TLSKey]
+		require.NoError(t, cl.Update(ctx, caSecret))
+
+		res, newSecret, err := EnsureCertificate(ctx, dp, subject, caNN, usages, cl, nil)
+		require.NoError(t, err)
+		require.Equal(t, op.Created, res)
+		require.NotEqual(t, secret.Name, newSecret.Name)
+		require.Equal(t, caSecret.Data[consts.CACRT], newSecret.Data[consts.CACRT])
+
+		require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(dp), dp))
+		c, ok := k8sutils.GetCondition(consts.CertificateRotatedType, dp)
+		require.True(t, ok)
+		require.Equal(t, string(consts.CertificateRotatedReasonCAChanged), c.Reason)
+
+		res, _, err = EnsureCertificate(ctx, dp, subject, caNN, usages, cl, nil)
+		require.NoError(t, err)
+		require.Equal(t, op.Noop, res)
+	})
+}
+
+// generateLeafSecret generates a certificate Secret for the provided DataPlane
+// signed by the CA from caSecret and valid for the provided duration.
+func generateLeafSecret(
+	t *testing.T,
+	dp *operatorv1beta1.DataPlane,
+	caSecret *corev1.Secret,
+	subject string,
+	validity time.Duration,
+) *corev1.Secret {
+	caCerts, err := ParsePEMCertificates(caSecret.Data[consts.TLSCRT])
+	require.NoError(t, err)
+	require.Len(t, caCerts, 1)
+	caKeyBlock, _ := pem.Decode(caSecret.Data[consts.TLSKey])
+	require.NotNil(t, caKeyBlock)
+	caKey, err := x509.ParseECPrivateKey(caKeyBlock.Bytes)
+	require.NoError(t, err)
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
+	require.NoError(t, err)
+	template := x509.Certificate{
+		Subject: pkix.Name{
+			CommonName: subject,
+		},
+		SerialNumber: serial,
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(validity),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, caCerts[0], priv.Public(), caKey)
+	require.NoError(t, err)
+	privDer, err := x509.MarshalECPrivateKey(priv)
+	require.NoError(t, err)
+
+	secret := k8sresources.GenerateNewTLSSecret(dp)
+	secret.Name = "existing-secret"
+	secret.Data = map[string][]byte{
+		consts.CACRT:  caSecret.Data[consts.TLSCRT],
+		consts.TLSCRT: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		consts.TLSKey: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privDer}),
+	}
+	return secret
+}
+
 func generateCACert(nn types.NamespacedName) (*corev1.Secret, error) {
 	serial, err := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
 	if err != nil {
@@ -0,0 +1,486 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+	k8sresources "github.com/kong/gateway-operator/pkg/utils/kubernetes/resources"
+)
+
+// ErrCertificatePending is returned when a certificate has been requested but
+// has not been issued yet. Callers should retry later.
+var ErrCertificatePending = errors.New("certificate has not been issued yet")
+
+// CertificatePendingRequeueAfter is the interval after which callers should check
+// again whether a pending certificate has been issued.
+const CertificatePendingRequeueAfter = 10 * time.Second
+
+// certificateExpirationSeconds is the requested lifetime of issued certificates.
+// This requests certificates that last for 10 years. The actual lifetime is capped
+// by the issuer (e.g. by the expiry of the CA which signs them) and EnsureCertificate
+// reissues them ahead of their expiry. Reissued certificates are stored in new Secrets
+// so that Deployments using them get rolled out, as Kong requires a restart to pick up
+// updated files on disk.
+const certificateExpirationSeconds = int32(315400000)
+
+// CertificateRequest describes a certificate to be issued.
+type CertificateRequest struct {
+	// Owner is the object which the certificate is issued for.
+	Owner client.Object
+	// Subject is the common name of the certificate.
+	Subject string
+	// Usages are the key usages requested for the certificate.
+	Usages []certificatesv1.KeyUsage
+	// Request is the PEM encoded x509 certificate request.
+	Request []byte
+}
+
+// Issuer issues the certificates used for mTLS between ControlPlanes and DataPlanes.
+type Issuer interface {
+	// Issue fills the provided Secret with the certificate issued for the provided
+	// request (tls.crt) and the CA trust bundle (ca.crt). The Secret already holds
+	// the private key (tls.key) matching the request, which issuers may replace.
+	//
+	// When the certificate has been requested but is not available yet, Issue
+	// returns ErrCertificatePending. The Secret is then stored with an empty
+	// tls.crt, as kubernetes.io/tls Secrets have to hold that key, and passed to
+	// Issue again on subsequent attempts.
+	Issue(ctx context.Context, cl client.Client, req CertificateRequest, secret *corev1.Secret) error
+
+	// ReissueReason checks whether the certificate stored in the provided Secret
+	// has to be reissued. It returns the reason for the reissue along with a human
+	// readable message or an empty reason when the certificate can be kept.
+	ReissueReason(
+		ctx context.Context,
+		cl client.Client,
+		req CertificateRequest,
+		secret *corev1.Secret,
+		renewBefore time.Duration,
+	) (k8sutils.ConditionReason, string, error)
+}
+
+// NewIssuer returns the Issuer configured with the provided CertificateIssuer.
+// The cluster CA Secret is used for signing by the LocalCA issuer and as the
+// source of the trust bundle by the CertificateSigningRequest issuer.
+// An empty issuer type yields the LocalCA issuer.
+func NewIssuer(spec operatorv1beta1.CertificateIssuer, clusterCASecretNN types.NamespacedName) (Issuer, error) {
+	switch spec.Type {
+	case "", operatorv1beta1.CertificateIssuerTypeLocalCA:
+		return NewLocalCAIssuer(clusterCASecretNN), nil
+	case operatorv1beta1.CertificateIssuerTypeCertificateSigningRequest:
+		if spec.CertificateSigningRequest == nil || spec.CertificateSigningRequest.SignerName == "" {
+			return nil, fmt.Errorf("certificate issuer %s requires a signer name", spec.Type)
+		}
+		return NewCertificateSigningRequestIssuer(spec.CertificateSigningRequest.SignerName, clusterCASecretNN), nil
+	case operatorv1beta1.CertificateIssuerTypeSecret:
+		if spec.Secret == nil || spec.Secret.Name == "" {
+			return nil, fmt.Errorf("certificate issuer %s requires a Secret name", spec.Type)
+		}
+		return NewSecretIssuer(spec.Secret.Name), nil
+	default:
+		return nil, fmt.Errorf("unsupported certificate issuer type %q", spec.Type)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Issuer - Local CA
+// -----------------------------------------------------------------------------
+
+type localCAIssuer struct {
+	caSecretNN types.NamespacedName
+}
+
+// NewLocalCAIssuer returns an Issuer which signs certificates in-process using
+// the CA stored in the provided Secret. This is the default issuer.
+func NewLocalCAIssuer(caSecretNN types.NamespacedName) Issuer {
+	return &localCAIssuer{caSecretNN: caSecretNN}
+}
+
+// Issue implements Issuer.
+func (i *localCAIssuer) Issue(ctx context.Context, cl client.Client, req CertificateRequest, secret *corev1.Secret) error {
+	ca := &corev1.Secret{}
+	if err := cl.Get(ctx, i.caSecretNN, ca); err != nil {
+		return err
+	}
+
+	expiration := certificateExpirationSeconds
+	csr := certificatesv1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: req.Owner.GetNamespace(),
+			Name:      req.Owner.GetName(),
+		},
+		Spec: certificatesv1.CertificateSigningRequestSpec{
+			Request:           req.Request,
+			SignerName:        "gateway-operator.konghq.com/mtls",
+			ExpirationSeconds: &expiration,
+			Usages:            req.Usages,
+		},
+	}
+	signed, err := signCertificate(csr, ca)
+	if err != nil {
+		return err
+	}
+
+	secret.Data[consts.TLSCRT] = signed
+	secret.Data[consts.CACRT] = CATrustBundle(ca)
+	return nil
+}
+
+// ReissueReason implements Issuer.
+func (i *localCAIssuer) ReissueReason(
+	ctx context.Context,
+	cl client.Client,
+	req CertificateRequest,
+	secret *corev1.Secret,
+	renewBefore time.Duration,
+) (k8sutils.ConditionReason, string, error) {
+	ca := &corev1.Secret{}
+	if err := cl.Get(ctx, i.caSecretNN, ca); err != nil {
+		return "", "", err
+	}
+	return certificateReissueReason(secret, ca, req.Subject, renewBefore)
+}
+
+// -----------------------------------------------------------------------------
+// Issuer - CertificateSigningRequest API
+// -----------------------------------------------------------------------------
+
+type certificateSigningRequestIssuer struct {
+	signerName string
+	caSecretNN types.NamespacedName
+}
+
+// NewCertificateSigningRequestIssuer returns an Issuer which requests certificates
+// through the certificates.k8s.io CertificateSigningRequest API from the provided
+// signer. CertificateSigningRequests have to be approved and signed by an external
+// party, until then certificates are pending.
+// The CA trust bundle is read from the provided Secret, which has to hold the CA
+// certificates of the signer in ca.crt or tls.crt.
+func NewCertificateSigningRequestIssuer(signerName string, caSecretNN types.NamespacedName) Issuer {
+	return &certificateSigningRequestIssuer{
+		signerName: signerName,
+		caSecretNN: caSecretNN,
+	}
+}
+
+// Issue implements Issuer.
+func (i *certificateSigningRequestIssuer) Issue(ctx context.Context, cl client.Client, req CertificateRequest, secret *corev1.Secret) error {
+	csrName, ok := secret.Annotations[consts.CertificateSigningRequestAnnotation]
+	if !ok {
+		return i.requestCertificate(ctx, cl, req, secret)
+	}
+
+	csr := &certificatesv1.CertificateSigningRequest{}
+	if err := cl.Get(ctx, types.NamespacedName{Name: csrName}, csr); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		// The CertificateSigningRequest has been removed, e.g. garbage collected
+		// by the cluster, hence request the certificate again.
+		return i.requestCertificate(ctx, cl, req, secret)
+	}
+
+	for _, cond := range csr.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		if cond.Type == certificatesv1.CertificateDenied || cond.Type == certificatesv1.CertificateFailed {
+			// Deleting the CertificateSigningRequest makes the next attempt request
+			// the certificate again.
+			if err := cl.Delete(ctx, csr); client.IgnoreNotFound(err) != nil {
+				return err
+			}
+			return fmt.Errorf("CertificateSigningRequest %s has been %s: %s", csr.Name, cond.Type, cond.Message)
+		}
+	}
+	if len(csr.Status.Certificate) == 0 {
+		return ErrCertificatePending
+	}
+
+	bundle, err := i.trustBundle(ctx, cl)
+	if err != nil {
+		return err
+	}
+	if err := verifyCertificateChain(csr.Status.Certificate, bundle); err != nil {
+		// The CertificateSigningRequest is kept so that the certificate does not
+		// get requested over and over again until the trust bundle is fixed.
+		return fmt.Errorf("certificate issued for CertificateSigningRequest %s is not trusted by the CA in Secret %s: %w",
+			csr.Name, i.caSecretNN, err,
+		)
+	}
+
+	secret.Data[consts.TLSCRT] = csr.Status.Certificate
+	secret.Data[consts.CACRT] = bundle
+	delete(secret.Annotations, consts.CertificateSigningRequestAnnotation)
+
+	if err := cl.Delete(ctx, csr); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *certificateSigningRequestIssuer) requestCertificate(
+	ctx context.Context,
+	cl client.Client,
+	req CertificateRequest,
+	secret *corev1.Secret,
+) error {
+	// CertificateSigningRequests are cluster scoped hence they cannot be owned
+	// by the owner of the certificate. The labels link them to it instead.
+	labels := k8sresources.GetManagedLabelForOwner(req.Owner)
+	labels[consts.GatewayOperatorManagedByNamespaceLabel] = req.Owner.GetNamespace()
+	labels[consts.GatewayOperatorManagedByNameLabel] = req.Owner.GetName()
+
+	expiration := certificateExpirationSeconds
+	csr := &certificatesv1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("%s-%s-", req.Owner.GetNamespace(), req.Owner.GetName()),
+			Labels:       labels,
+		},
+		Spec: certificatesv1.CertificateSigningRequestSpec{
+			Request:           req.Request,
+			SignerName:        i.signerName,
+			ExpirationSeconds: &expiration,
+			Usages:            req.Usages,
+		},
+	}
+	if err := cl.Create(ctx, csr); err != nil {
+		return fmt.Errorf("failed creating CertificateSigningRequest for %s: %w", req.Subject, err)
+	}
+
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string)
+	}
+	secret.Annotations[consts.CertificateSigningRequestAnnotation] = csr.Name
+	// kubernetes.io/tls Secrets are rejected by the API server without tls.crt,
+	// which is left empty until the certificate gets issued.
+	secret.Data[consts.TLSCRT] = []byte{}
+	return ErrCertificatePending
+}
+
+// ReissueReason implements Issuer.
+func (i *certificateSigningRequestIssuer) ReissueReason(
+	ctx context.Context,
+	cl client.Client,
+	req CertificateRequest,
+	secret *corev1.Secret,
+	renewBefore time.Duration,
+) (k8sutils.ConditionReason, string, error) {
+	certs, err := ParsePEMCertificates(secret.Data[consts.TLSCRT])
+	if err != nil {
+		return consts.CertificateRotatedReasonInvalid, fmt.Sprintf("the certificate could not be parsed: %v", err), nil
+	}
+	if len(certs) == 0 {
+		return consts.CertificateRotatedReasonInvalid, "the certificate could not be decoded", nil
+	}
+	cert := certs[0]
+
+	if cert.Subject.CommonName != req.Subject {
+		return consts.CertificateRotatedReasonSubjectChanged,
+			fmt.Sprintf("the certificate was issued for %q instead of %q", cert.Subject.CommonName, req.Subject), nil
+	}
+
+	bundle, err := i.trustBundle(ctx, cl)
+	if err != nil {
+		return "", "", err
+	}
+	if err := verifyCertificateChain(secret.Data[consts.TLSCRT], bundle); err != nil {
+		return consts.CertificateRotatedReasonCAChanged, "the certificate is not trusted by the CA trust bundle", nil
+	}
+	if !bytes.Equal(secret.Data[consts.CACRT], bundle) {
+		return consts.CertificateRotatedReasonCAChanged, "the CA trust bundle has changed", nil
+	}
+
+	// External signers may cap the lifetime of certificates below the renewal
+	// window, in which case they are renewed after 2/3 of their lifetime so that
+	// they do not get reissued right away.
+	renewBefore = min(renewBefore, cert.NotAfter.Sub(cert.NotBefore)/3)
+	if time.Until(cert.NotAfter) < renewBefore {
+		return consts.CertificateRotatedReasonExpiring,
+			fmt.Sprintf("the certificate expires at %s", cert.NotAfter.Format(time.RFC3339)), nil
+	}
+
+	return "", "", nil
+}
+
+func (i *certificateSigningRequestIssuer) trustBundle(ctx context.Context, cl client.Client) ([]byte, error) {
+	ca := &corev1.Secret{}
+	if err := cl.Get(ctx, i.caSecretNN, ca); err != nil {
+		return nil, fmt.Errorf("failed getting CA trust bundle: %w", err)
+	}
+	bundle := CATrustBundle(ca)
+	if len(bundle) == 0 {
+		return nil, fmt.Errorf("no CA trust bundle found in Secret %s", i.caSecretNN)
+	}
+	return bundle, nil
+}
+
+// verifyCertificateChain verifies that the first of the provided PEM encoded
+// certificates is trusted by the provided bundle. The remaining certificates
+// are used as intermediates.
+func verifyCertificateChain(chain []byte, bundle []byte) error {
+	certs, err := ParsePEMCertificates(chain)
+	if err != nil {
+		return err
+	}
+	if len(certs) == 0 {
+		return errors.New("no certificate found")
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(bundle) {
+		return errors.New("no CA certificate found in the trust bundle")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err = certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	return err
+}
+
+// -----------------------------------------------------------------------------
+// Issuer - Pre-provisioned Secret
+// -----------------------------------------------------------------------------
+
+type secretIssuer struct {
+	name string
+}
+
+// NewSecretIssuer returns an Issuer which reads certificates from pre-provisioned
+// Secrets, e.g. ones managed by a corporate PKI or cert-manager. As ControlPlanes and
+// DataPlanes need certificates for different subjects and usages, a Secret is read
+// per purpose. Its name is the provided name suffixed with the kind of the owner of
+// the certificate and with "server" or "client", e.g. <name>-dataplane-server for
+// the DataPlane Admin API certificate, <name>-controlplane-client for the certificate
+// ControlPlanes use to authenticate against it and <name>-controlplane-server for
+// the ControlPlane admission webhook certificate.
+// The Secrets have to reside in the namespace of the owner of the certificate and hold
+// the tls.crt, tls.key and ca.crt keys. Server certificates have to be issued for the
+// requested subject, either as their common name or as a DNS SAN, and certificates
+// carrying extended key usages have to allow the requested ones. Renewal of the
+// certificates is up to the provisioner of the Secrets; changes are picked up on
+// subsequent reconciliations.
+func NewSecretIssuer(name string) Issuer {
+	return &secretIssuer{name: name}
+}
+
+// Issue implements Issuer.
+func (i *secretIssuer) Issue(ctx context.Context, cl client.Client, req CertificateRequest, secret *corev1.Secret) error {
+	source, err := i.source(ctx, cl, req)
+	if err != nil {
+		return err
+	}
+	for _, key := range []string{consts.TLSCRT, consts.TLSKey, consts.CACRT} {
+		secret.Data[key] = source.Data[key]
+	}
+	return nil
+}
+
+// ReissueReason implements Issuer.
+func (i *secretIssuer) ReissueReason(
+	ctx context.Context,
+	cl client.Client,
+	req CertificateRequest,
+	secret *corev1.Secret,
+	_ time.Duration,
+) (k8sutils.ConditionReason, string, error) {
+	if err := verifyCertificateMatchesRequest(secret.Data[consts.TLSCRT], req); err != nil {
+		return consts.CertificateRotatedReasonSubjectChanged, err.Error(), nil
+	}
+	source, err := i.source(ctx, cl, req)
+	if err != nil {
+		return "", "", err
+	}
+	for _, key := range []string{consts.TLSCRT, consts.TLSKey, consts.CACRT} {
+		if !bytes.Equal(secret.Data[key], source.Data[key]) {
+			return consts.CertificateRotatedReasonSourceChanged,
+				fmt.Sprintf("the certificate in Secret %s has changed", source.Name), nil
+		}
+	}
+	return "", "", nil
+}
+
+// source returns the pre-provisioned Secret holding the certificate for the
+// purpose of the provided request and verifies that the certificate matches it.
+func (i *secretIssuer) source(ctx context.Context, cl client.Client, req CertificateRequest) (*corev1.Secret, error) {
+	source := &corev1.Secret{}
+	nn := types.NamespacedName{Namespace: req.Owner.GetNamespace(), Name: i.sourceName(req)}
+	if err := cl.Get(ctx, nn, source); err != nil {
+		return nil, fmt.Errorf("failed getting certificate Secret %s: %w", nn, err)
+	}
+	for _, key := range []string{consts.TLSCRT, consts.TLSKey, consts.CACRT} {
+		if len(source.Data[key]) == 0 {
+			return nil, fmt.Errorf("certificate Secret %s is missing the %s key", nn, key)
+		}
+	}
+	if err := verifyCertificateMatchesRequest(source.Data[consts.TLSCRT], req); err != nil {
+		return nil, fmt.Errorf("certificate Secret %s does not match the request: %w", nn, err)
+	}
+	return source, nil
+}
+
+// sourceName returns the name of the pre-provisioned Secret holding the certificate
+// for the purpose of the provided request.
+func (i *secretIssuer) sourceName(req CertificateRequest) string {
+	kind := "dataplane"
+	if _, ok := req.Owner.(*operatorv1beta1.ControlPlane); ok {
+		kind = "controlplane"
+	}
+	purpose := "client"
+	if lo.Contains(req.Usages, certificatesv1.UsageServerAuth) {
+		purpose = "server"
+	}
+	return fmt.Sprintf("%s-%s-%s", i.name, kind, purpose)
+}
+
+// verifyCertificateMatchesRequest verifies that the first certificate in the provided
+// PEM data can be used for the provided request. Server certificates have to be issued
+// for the requested subject, while the subjects of client certificates are not
+// checked by their peers and can be arbitrary. Extended key usages, when present, have
+// to allow the requested usages.
+func verifyCertificateMatchesRequest(certPEM []byte, req CertificateRequest) error {
+	certs, err := ParsePEMCertificates(certPEM)
+	if err != nil {
+		return fmt.Errorf("the certificate could not be parsed: %w", err)
+	}
+	if len(certs) == 0 {
+		return errors.New("the certificate could not be decoded")
+	}
+	cert := certs[0]
+
+	if lo.Contains(req.Usages, certificatesv1.UsageServerAuth) &&
+		cert.Subject.CommonName != req.Subject && !lo.Contains(cert.DNSNames, req.Subject) {
+		return fmt.Errorf("the certificate was issued for %q instead of %q", cert.Subject.CommonName, req.Subject)
+	}
+
+	if len(cert.ExtKeyUsage) == 0 || lo.Contains(cert.ExtKeyUsage, x509.ExtKeyUsageAny) {
+		return nil
+	}
+	for usage, extUsage := range map[certificatesv1.KeyUsage]x509.ExtKeyUsage{
+		certificatesv1.UsageServerAuth: x509.ExtKeyUsageServerAuth,
+		certificatesv1.UsageClientAuth: x509.ExtKeyUsageClientAuth,
+	} {
+		if lo.Contains(req.Usages, usage) && !lo.Contains(cert.ExtKeyUsage, extUsage) {
+			return fmt.Errorf("the certificate does not allow the %q usage", usage)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,295 @@
+package secrets
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/controller/pkg/op"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+)
+
+func TestNewIssuer(t *testing.T) {
+	caNN := types.NamespacedName{Name: "ca", Namespace: "ns"}
+
+	testCases := []struct {
+		name          string
+		spec          operatorv1beta1.CertificateIssuer
+		expected      Issuer
+		expectedError bool
+	}{
+		{
+			name:     "empty type defaults to the local CA",
+			expected: NewLocalCAIssuer(caNN),
+		},
+		{
+			name: "local CA",
+			spec: operatorv1beta1.CertificateIssuer{
+				Type: operatorv1beta1.CertificateIssuerTypeLocalCA,
+			},
+			expected: NewLocalCAIssuer(caNN),
+		},
+		{
+			name: "CertificateSigningRequest API",
+			spec: operatorv1beta1.CertificateIssuer{
+				Type: operatorv1beta1.CertificateIssuerTypeCertificateSigningRequest,
+				CertificateSigningRequest: &operatorv1beta1.CertificateSigningRequestIssuer{
+					SignerName: "example.com/mtls",
+				},
+			},
+			expected: NewCertificateSigningRequestIssuer("example.com/mtls", caNN),
+		},
+		{
+			name: "CertificateSigningRequest API without a signer name",
+			spec: operatorv1beta1.CertificateIssuer{
+				Type: operatorv1beta1.CertificateIssuerTypeCertificateSigningRequest,
+			},
+			expectedError: true,
+		},
+		{
+			name: "pre-provisioned Secret",
+			spec: operatorv1beta1.CertificateIssuer{
+				Type: operatorv1beta1.CertificateIssuerTypeSecret,
+				Secret: &operatorv1beta1.SecretIssuer{
+					Name: "certificate",
+				},
+			},
+			expected: NewSecretIssuer("certificate"),
+		},
+		{
+			name: "unknown type",
+			spec: operatorv1beta1.CertificateIssuer{
+				Type: "Vault",
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			issuer, err := NewIssuer(tc.spec, caNN)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, issuer)
+		})
+	}
+}
+
+func TestCertificateSigningRequestIssuer(t *testing.T) {
+	const (
+		namespace  = "ns"
+		subject    = "test-subject"
+		signerName = "example.com/mtls"
+	)
+	caNN := types.NamespacedName{Name: "test-mtls-secret", Namespace: namespace}
+	usages := []certificatesv1.KeyUsage{certificatesv1.UsageServerAuth}
+	issuer := NewCertificateSigningRequestIssuer(signerName, caNN)
+
+	setup := func(t *testing.T) (client.Client, *operatorv1beta1.DataPlane, *corev1.Secret) {
+		scheme := runtime.NewScheme()
+		require.NoError(t, corev1.AddToScheme(scheme))
+		require.NoError(t, certificatesv1.AddToScheme(scheme))
+		require.NoError(t, operatorv1beta1.AddToScheme(scheme))
+
+		dp := &operatorv1beta1.DataPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dp-1",
+				Namespace: namespace,
+				UID:       types.UID("1234"),
+			},
+		}
+		// The local CA acts as the external signer.
+		caSecret, err := generateCACert(caNN)
+		require.NoError(t, err)
+		cl := fakectrlruntimeclient.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(dp, caSecret).
+			WithStatusSubresource(dp, &certificatesv1.CertificateSigningRequest{}).
+			Build()
+		return cl, dp, caSecret
+	}
+
+	getCSR := func(t *testing.T, cl client.Client, secret *corev1.Secret) *certificatesv1.CertificateSigningRequest {
+		csrName := secret.Annotations[consts.CertificateSigningRequestAnnotation]
+		require.NotEmpty(t, csrName)
+		csr := &certificatesv1.CertificateSigningRequest{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Name: csrName}, csr))
+		return csr
+	}
+
+	t.Run("certificate is issued once the CertificateSigningRequest is signed", func(t *testing.T) {
+		ctx := context.Background()
+		cl, dp, caSecret := setup(t)
+
+		res, secret, err := EnsureCertificate(ctx, dp, subject, caNN, usages, cl, nil, WithIssuer(issuer))
+		require.ErrorIs(t, err, ErrCertificatePending)
+		require.Equal(t, op.Created, res)
+		require.Empty(t, secret.Data[consts.TLSCRT])
+		require.NotEmpty(t, secret.Data[consts.TLSKey])
+
+		t.Log("verifying that the pending Secret holds both keys required for kubernetes.io/tls Secrets")
+		stored := &corev1.Secret{}
+		require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(secret), stored))
+		require.Equal(t, corev1.SecretTypeTLS, stored.Type)
+		require.Contains(t, stored.Data, corev1.TLSCertKey)
+		require.Contains(t, stored.Data, corev1.TLSPrivateKeyKey)
+
+		csr := getCSR(t, cl, secret)
+		require.Equal(t, signerName, csr.Spec.SignerName)
+		require.Equal(t, usages, csr.Spec.Usages)
+
+		t.Log("verifying that the certificate is pending until the CertificateSigningRequest is signed")
+		res, _, err = EnsureCertificate(ctx, dp, subject, caNN, usages, cl, nil, WithIssuer(issuer))
+		require.ErrorIs(t, err, ErrCertificatePending)
+		require.Equal(t, op.Noop, res)
+
+		t.Log("signing the CertificateSigningRequest")
+		signed, err := signCertificate(*csr, caSecret)
+		require.NoError(t, err)
+		csr.Status.Certificate = signed
+		require.NoError(t, cl.Status().Update(ctx, csr))
+
+		res, issued, err := EnsureCertificate(ctx, dp, subject, caNN, usages, cl, nil, WithIssuer(issuer))
+		require.NoError(t, err)
+		require.Equal(t, op.Updated, res)
+		require.Equal(t, secret.Name, issued.Name)
+		require.Equal(t, signed, issued.Data[consts.TLSCRT])
+		require.Equal(t, secret.Data[consts.TLSKey], issued.Data[consts.TLSKey])
+		require.Equal(t, caSecret.Data[consts.TLSCRT], issued.Data[consts.CACRT])
+		require.NotContains(t, issued.Annotations, consts.CertificateSigningRequestAnnotation)
+		require.True(t, k8serrors.IsNotFound(cl.Get(ctx, client.ObjectKeyFromObject(csr), csr)))
+
+		res, _, err = EnsureCertificate(ctx, dp, subject, caNN, usages, cl, nil, WithIssuer(issuer))
+		require.NoError(t, err)
+		require.Equal(t, op.Noop, res)
+	})
+
+	t.Run("denied CertificateSigningRequest is requested again", func(t *testing.T) {
+		ctx := context.Background()
+		cl, dp, _ := setup(t)
+
+		_, secret, err := EnsureCertificate(ctx, dp, subject, caNN, usages, cl, nil, WithIssuer(issuer))
+		require.ErrorIs(t, err, ErrCertificatePending)
+
+		csr := getCSR(t, cl, secret)
+		csr.Status.Conditions = []certificatesv1.CertificateSigningRequestCondition{
+			{
+				Type:    certificatesv1.CertificateDenied,
+				Status:  corev1.ConditionTrue,
+				Message: "not allowed",
+			},
+		}
+		require.NoError(t, cl.Status().Update(ctx, csr))
+
+		_, _, err = EnsureCertificate(ctx, dp, subject, caNN, usages, cl, nil, WithIssuer(issuer))
+		require.ErrorContains(t, err, "not allowed")
+		require.True(t, k8serrors.IsNotFound(cl.Get(ctx, client.ObjectKeyFromObject(csr), csr)))
+
+		_, secret, err = EnsureCertificate(ctx, dp, subject, caNN, usages, cl, nil, WithIssuer(issuer))
+		require.ErrorIs(t, err, ErrCertificatePending)
+		require.NotEqual(t, csr.Name, getCSR(t, cl, secret).Name)
+	})
+
+	t.Run("certificate not trusted by the CA trust bundle is not stored", func(t *testing.T) {
+		ctx := context.Background()
+		cl, dp, _ := setup(t)
+
+		_, secret, err := EnsureCertificate(ctx, dp, subject, caNN, usages, cl, nil, WithIssuer(issuer))
+		require.ErrorIs(t, err, ErrCertificatePending)
+
+		otherCASecret, err := generateCACert(caNN)
+		require.NoError(t, err)
+		csr := getCSR(t, cl, secret)
+		signed, err := signCertificate(*csr, otherCASecret)
+		require.NoError(t, err)
+		csr.Status.Certificate = signed
+		require.NoError(t, cl.Status().Update(ctx, csr))
+
+		_, _, err = EnsureCertificate(ctx, dp, subject, caNN, usages, cl, nil, WithIssuer(issuer))
+		require.ErrorContains(t, err, "is not trusted")
+		require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(csr), csr))
+	})
+}
+
+func TestSecretIssuer(t *testing.T) {
+	const (
+		namespace = "ns"
+		subject   = "test-subject"
+	)
+	caNN := types.NamespacedName{Name: "test-mtls-secret", Namespace: namespace}
+	usages := []certificatesv1.KeyUsage{certificatesv1.UsageServerAuth}
+	issuer := NewSecretIssuer("provisioned")
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, operatorv1beta1.AddToScheme(scheme))
+
+	dp := &operatorv1beta1.DataPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dp-1",
+			Namespace: namespace,
+			UID:       types.UID("1234"),
+		},
+	}
+	caSecret, err := generateCACert(caNN)
+	require.NoError(t, err)
+	source := generateLeafSecret(t, dp, caSecret, subject, 24*time.Hour)
+	source.Name = "provisioned-dataplane-server"
+	source.OwnerReferences = nil
+	source.Labels = nil
+	cl := fakectrlruntimeclient.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(dp, source).
+		WithStatusSubresource(dp).
+		Build()
+	ctx := context.Background()
+
+	res, secret, err := EnsureCertificate(ctx, dp, subject, caNN, usages, cl, nil, WithIssuer(issuer))
+	require.NoError(t, err)
+	require.Equal(t, op.Created, res)
+	require.Equal(t, source.Data, secret.Data)
+
+	res, _, err = EnsureCertificate(ctx, dp, subject, caNN, usages, cl, nil, WithIssuer(issuer))
+	require.NoError(t, err)
+	require.Equal(t, op.Noop, res)
+
+	t.Log("renewing the certificate in the pre-provisioned Secret")
+	renewed := generateLeafSecret(t, dp, caSecret, subject, 48*time.Hour)
+	source.Data = renewed.Data
+	require.NoError(t, cl.Update(ctx, source))
+
+	res, newSecret, err := EnsureCertificate(ctx, dp, subject, caNN, usages, cl, nil, WithIssuer(issuer))
+	require.NoError(t, err)
+	require.Equal(t, op.Created, res)
+	require.NotEqual(t, secret.Name, newSecret.Name)
+	require.Equal(t, renewed.Data, newSecret.Data)
+
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(dp), dp))
+	c, ok := k8sutils.GetCondition(consts.CertificateRotatedType, dp)
+	require.True(t, ok)
+	require.Equal(t, string(consts.CertificateRotatedReasonSourceChanged), c.Reason)
+
+	t.Log("requesting a certificate for a subject the pre-provisioned certificate was not issued for")
+	_, _, err = EnsureCertificate(ctx, dp, "other-subject", caNN, usages, cl, nil, WithIssuer(issuer))
+	require.ErrorContains(t, err, `the certificate was issued for "test-subject" instead of "other-subject"`)
+
+	t.Log("requesting a client certificate which is read from a Secret of its own")
+	clientUsages := []certificatesv1.KeyUsage{certificatesv1.UsageClientAuth}
+	_, _, err = EnsureCertificate(ctx, dp, "client", caNN, clientUsages, cl, nil, WithIssuer(issuer))
+	require.ErrorContains(t, err, "provisioned-dataplane-client")
+}
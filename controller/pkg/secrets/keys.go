@@ -0,0 +1,178 @@
+package secrets
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/cloudflare/cfssl/signer"
+)
+
+// -----------------------------------------------------------------------------
+// Private keys - Types
+// -----------------------------------------------------------------------------
+
+// KeyType is the type of the private keys generated by the operator.
+type KeyType string
+
+const (
+	// KeyTypeRSA2048 is a 2048 bit RSA key.
+	KeyTypeRSA2048 KeyType = "rsa-2048"
+	// KeyTypeRSA4096 is a 4096 bit RSA key.
+	KeyTypeRSA4096 KeyType = "rsa-4096"
+	// KeyTypeECDSAP256 is an ECDSA key using the P-256 curve.
+	KeyTypeECDSAP256 KeyType = "ecdsa-p256"
+	// KeyTypeECDSAP384 is an ECDSA key using the P-384 curve.
+	KeyTypeECDSAP384 KeyType = "ecdsa-p384"
+	// KeyTypeEd25519 is an Ed25519 key.
+	KeyTypeEd25519 KeyType = "ed25519"
+
+	// DefaultKeyType is the type of the keys generated when none is configured.
+	DefaultKeyType = KeyTypeECDSAP256
+)
+
+// KeyTypes returns all the supported key types.
+func KeyTypes() []KeyType {
+	return []KeyType{
+		KeyTypeRSA2048,
+		KeyTypeRSA4096,
+		KeyTypeECDSAP256,
+		KeyTypeECDSAP384,
+		KeyTypeEd25519,
+	}
+}
+
+// ParseKeyType parses the provided key type, returning an error when it is not supported.
+func ParseKeyType(s string) (KeyType, error) {
+	for _, kt := range KeyTypes() {
+		if string(kt) == s {
+			return kt, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported key type %q", s)
+}
+
+// KeyTypeOf returns the KeyType of the provided private key.
+func KeyTypeOf(priv crypto.Signer) (KeyType, error) {
+	switch pub := priv.Public().(type) {
+	case *rsa.PublicKey:
+		switch pub.N.BitLen() {
+		case 2048:
+			return KeyTypeRSA2048, nil
+		case 4096:
+			return KeyTypeRSA4096, nil
+		}
+		return "", fmt.Errorf("unsupported RSA key size %d", pub.N.BitLen())
+	case *ecdsa.PublicKey:
+		switch pub.Curve {
+		case elliptic.P256():
+			return KeyTypeECDSAP256, nil
+		case elliptic.P384():
+			return KeyTypeECDSAP384, nil
+		}
+		return "", fmt.Errorf("unsupported ECDSA curve %s", pub.Curve.Params().Name)
+	case ed25519.PublicKey:
+		return KeyTypeEd25519, nil
+	default:
+		return "", fmt.Errorf("unsupported private key type %T", priv)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Private keys - Generation and encoding
+// -----------------------------------------------------------------------------
+
+// GeneratePrivateKey generates a new private key of the provided type.
+// An empty type generates a key of the DefaultKeyType.
+func GeneratePrivateKey(keyType KeyType) (crypto.Signer, error) {
+	switch keyType {
+	case KeyTypeRSA2048:
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case KeyTypeRSA4096:
+		return rsa.GenerateKey(rand.Reader, 4096)
+	case "", KeyTypeECDSAP256:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case KeyTypeECDSAP384:
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case KeyTypeEd25519:
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		return priv, err
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", keyType)
+	}
+}
+
+// EncodePrivateKey returns the PEM encoded private key.
+// RSA keys are encoded using PKCS#1, ECDSA keys using SEC 1 and Ed25519 keys,
+// which have no dedicated encoding, using PKCS#8.
+func EncodePrivateKey(priv crypto.Signer) ([]byte, error) {
+	var block *pem.Block
+	switch priv := priv.(type) {
+	case *rsa.PrivateKey:
+		block = &pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(priv),
+		}
+	case *ecdsa.PrivateKey:
+		der, err := x509.MarshalECPrivateKey(priv)
+		if err != nil {
+			return nil, err
+		}
+		block = &pem.Block{
+			Type:  "EC PRIVATE KEY",
+			Bytes: der,
+		}
+	case ed25519.PrivateKey:
+		der, err := x509.MarshalPKCS8PrivateKey(priv)
+		if err != nil {
+			return nil, err
+		}
+		block = &pem.Block{
+			Type:  "PRIVATE KEY",
+			Bytes: der,
+		}
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", priv)
+	}
+	return pem.EncodeToMemory(block), nil
+}
+
+// ParsePrivateKey parses a PEM encoded RSA, ECDSA or Ed25519 private key
+// encoded using PKCS#1, PKCS#8 or SEC 1.
+func ParsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("failed decoding private key")
+	}
+
+	// The PEM block type is not relied upon because user provided keys are not
+	// always labeled accurately, e.g. PKCS#8 keys stored as "RSA PRIVATE KEY".
+	if priv, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return priv, nil
+	}
+	if priv, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return priv, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing %q private key: not a PKCS#1, PKCS#8 or SEC 1 encoded key", block.Type)
+	}
+	priv, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	return priv, nil
+}
+
+// SignatureAlgorithm returns the x509 signature algorithm used to sign with
+// the provided private key.
+func SignatureAlgorithm(priv crypto.Signer) x509.SignatureAlgorithm {
+	return signer.DefaultSigAlgo(priv)
+}
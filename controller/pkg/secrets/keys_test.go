@@ -0,0 +1,203 @@
+package secrets
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/controller/pkg/op"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+)
+
+func TestParsePrivateKey(t *testing.T) {
+	pkcs8 := func(t *testing.T, priv crypto.Signer) []byte {
+		der, err := x509.MarshalPKCS8PrivateKey(priv)
+		require.NoError(t, err)
+		return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	}
+
+	for _, keyType := range KeyTypes() {
+		t.Run(string(keyType), func(t *testing.T) {
+			priv, err := GeneratePrivateKey(keyType)
+			require.NoError(t, err)
+
+			encoded, err := EncodePrivateKey(priv)
+			require.NoError(t, err)
+			encodings := map[string][]byte{
+				"default": encoded,
+				"PKCS#8":  pkcs8(t, priv),
+			}
+			switch priv := priv.(type) {
+			case *rsa.PrivateKey:
+				encodings["PKCS#1"] = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(priv)})
+			case *ecdsa.PrivateKey:
+				der, err := x509.MarshalECPrivateKey(priv)
+				require.NoError(t, err)
+				encodings["SEC 1"] = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+			}
+
+			for encoding, data := range encodings {
+				parsed, err := ParsePrivateKey(data)
+				require.NoError(t, err, encoding)
+				parsedType, err := KeyTypeOf(parsed)
+				require.NoError(t, err, encoding)
+				require.Equal(t, keyType, parsedType, encoding)
+			}
+		})
+	}
+
+	t.Run("invalid data", func(t *testing.T) {
+		_, err := ParsePrivateKey([]byte("not a key"))
+		require.Error(t, err)
+		_, err = ParsePrivateKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("not a key")}))
+		require.Error(t, err)
+	})
+}
+
+func TestParseKeyType(t *testing.T) {
+	for _, keyType := range KeyTypes() {
+		parsed, err := ParseKeyType(string(keyType))
+		require.NoError(t, err)
+		require.Equal(t, keyType, parsed)
+	}
+	_, err := ParseKeyType("dsa-1024")
+	require.Error(t, err)
+}
+
+func TestEnsureCertificateKeyTypes(t *testing.T) {
+	const (
+		namespace = "ns"
+		subject   = "test-subject"
+	)
+	caNN := types.NamespacedName{Name: "test-mtls-secret", Namespace: namespace}
+	usages := []certificatesv1.KeyUsage{certificatesv1.UsageServerAuth}
+
+	setup := func(t *testing.T, caKeyType KeyType) (client.Client, *operatorv1beta1.DataPlane, *corev1.Secret) {
+		scheme := runtime.NewScheme()
+		require.NoError(t, corev1.AddToScheme(scheme))
+		require.NoError(t, operatorv1beta1.AddToScheme(scheme))
+
+		dp := &operatorv1beta1.DataPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dp-1",
+				Namespace: namespace,
+				UID:       types.UID("1234"),
+			},
+		}
+		caSecret := generateCACertWithKeyType(t, caNN, caKeyType)
+		cl := fakectrlruntimeclient.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(dp, caSecret).
+			WithStatusSubresource(dp).
+			Build()
+		return cl, dp, caSecret
+	}
+
+	testCases := []struct {
+		caKeyType   KeyType
+		certKeyType KeyType
+	}{
+		{caKeyType: KeyTypeRSA2048, certKeyType: KeyTypeECDSAP256},
+		{caKeyType: KeyTypeRSA4096, certKeyType: KeyTypeRSA2048},
+		{caKeyType: KeyTypeECDSAP256, certKeyType: KeyTypeEd25519},
+		{caKeyType: KeyTypeECDSAP384, certKeyType: KeyTypeECDSAP384},
+		{caKeyType: KeyTypeEd25519, certKeyType: KeyTypeRSA2048},
+	}
+	for _, tc := range testCases {
+		t.Run(string(tc.caKeyType)+" CA issuing "+string(tc.certKeyType)+" certificate", func(t *testing.T) {
+			ctx := context.Background()
+			cl, dp, caSecret := setup(t, tc.caKeyType)
+
+			res, secret, err := EnsureCertificate(ctx, dp, subject, caNN, usages, cl, nil, WithKeyType(tc.certKeyType))
+			require.NoError(t, err)
+			require.Equal(t, op.Created, res)
+
+			priv, err := ParsePrivateKey(secret.Data[consts.TLSKey])
+			require.NoError(t, err)
+			keyType, err := KeyTypeOf(priv)
+			require.NoError(t, err)
+			require.Equal(t, tc.certKeyType, keyType)
+			require.NoError(t, verifyCertificateChain(secret.Data[consts.TLSCRT], caSecret.Data[consts.TLSCRT]))
+
+			res, _, err = EnsureCertificate(ctx, dp, subject, caNN, usages, cl, nil, WithKeyType(tc.certKeyType))
+			require.NoError(t, err)
+			require.Equal(t, op.Noop, res)
+		})
+	}
+
+	t.Run("certificate is reissued when the key type changes", func(t *testing.T) {
+		ctx := context.Background()
+		cl, dp, _ := setup(t, KeyTypeECDSAP256)
+
+		res, secret, err := EnsureCertificate(ctx, dp, subject, caNN, usages, cl, nil)
+		require.NoError(t, err)
+		require.Equal(t, op.Created, res)
+
+		res, newSecret, err := EnsureCertificate(ctx, dp, subject, caNN, usages, cl, nil, WithKeyType(KeyTypeEd25519))
+		require.NoError(t, err)
+		require.Equal(t, op.Created, res)
+		require.NotEqual(t, secret.Name, newSecret.Name)
+
+		require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(dp), dp))
+		c, ok := k8sutils.GetCondition(consts.CertificateRotatedType, dp)
+		require.True(t, ok)
+		require.Equal(t, string(consts.CertificateRotatedReasonKeyTypeChanged), c.Reason)
+	})
+}
+
+// generateCACertWithKeyType generates a CA certificate Secret with a private key of the provided type.
+func generateCACertWithKeyType(t *testing.T, nn types.NamespacedName, keyType KeyType) *corev1.Secret {
+	priv, err := GeneratePrivateKey(keyType)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
+	require.NoError(t, err)
+
+	template := x509.Certificate{
+		Subject: pkix.Name{
+			CommonName: "Kong Gateway Operator CA",
+		},
+		SerialNumber:          serial,
+		SignatureAlgorithm:    SignatureAlgorithm(priv),
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign + x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, priv.Public(), priv)
+	require.NoError(t, err)
+	keyPEM, err := EncodePrivateKey(priv)
+	require.NoError(t, err)
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: nn.Namespace,
+			Name:      nn.Name,
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			consts.TLSCRT: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			consts.TLSKey: keyPEM,
+		},
+	}
+}
@@ -0,0 +1,244 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+)
+
+// -----------------------------------------------------------------------------
+// Certificate rotation - Options
+// -----------------------------------------------------------------------------
+
+// EnsureCertificateOpt is an option for EnsureCertificate.
+type EnsureCertificateOpt func(*ensureCertificateOptions)
+
+type ensureCertificateOptions struct {
+	renewBefore   time.Duration
+	eventRecorder record.EventRecorder
+	issuer        Issuer
+	keyType       KeyType
+}
+
+// WithRenewBefore configures EnsureCertificate to reissue the certificate when
+// it expires in less than the provided duration.
+// When not set, certificates are only reissued once they have expired.
+func WithRenewBefore(renewBefore time.Duration) EnsureCertificateOpt {
+	return func(o *ensureCertificateOptions) {
+		o.renewBefore = renewBefore
+	}
+}
+
+// WithEventRecorder configures EnsureCertificate to emit an Event on the owner
+// whenever its certificate is reissued.
+func WithEventRecorder(recorder record.EventRecorder) EnsureCertificateOpt {
+	return func(o *ensureCertificateOptions) {
+		o.eventRecorder = recorder
+	}
+}
+
+// WithIssuer configures EnsureCertificate to issue the certificate using the
+// provided Issuer instead of the local CA.
+func WithIssuer(issuer Issuer) EnsureCertificateOpt {
+	return func(o *ensureCertificateOptions) {
+		o.issuer = issuer
+	}
+}
+
+// WithKeyType configures EnsureCertificate to generate private keys of the
+// provided type. Certificates whose private key is of a different type are
+// reissued. When not set, DefaultKeyType is used.
+func WithKeyType(keyType KeyType) EnsureCertificateOpt {
+	return func(o *ensureCertificateOptions) {
+		o.keyType = keyType
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Certificate rotation - CA trust bundle
+// -----------------------------------------------------------------------------
+
+// CATrustBundle returns the PEM encoded CA certificates that should be trusted
+// for the cluster CA stored in the provided Secret.
+// During a CA rollover the bundle contains both the current and the previous CA
+// certificates so that certificates issued by either of them are accepted.
+// Secrets without a bundle (e.g. created by older versions of the operator) fall
+// back to the CA certificate itself.
+func CATrustBundle(ca *corev1.Secret) []byte {
+	if bundle := ca.Data[consts.CACRT]; len(bundle) > 0 {
+		return bundle
+	}
+	return ca.Data[consts.TLSCRT]
+}
+
+// ParsePEMCertificates parses all the PEM encoded certificates from the provided data.
+func ParsePEMCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return certs, nil
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+}
+
+// ClusterCASecretPredicate returns a predicate matching the cluster CA Secret.
+// Controllers owning certificates signed by the cluster CA watch it in order to
+// have them reissued as soon as the CA is rolled over, instead of at their next
+// scheduled renewal.
+func ClusterCASecretPredicate(caSecretNN types.NamespacedName) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetNamespace() == caSecretNN.Namespace && obj.GetName() == caSecretNN.Name
+	})
+}
+
+// -----------------------------------------------------------------------------
+// Certificate rotation - Reissue
+// -----------------------------------------------------------------------------
+
+// certificateReissueReason checks whether the certificate stored in the provided
+// Secret has to be reissued. It returns the reason for the reissue along with
+// a human readable message or an empty reason when the certificate can be kept.
+func certificateReissueReason(
+	secret *corev1.Secret,
+	ca *corev1.Secret,
+	subject string,
+	renewBefore time.Duration,
+) (k8sutils.ConditionReason, string, error) {
+	block, _ := pem.Decode(secret.Data[consts.TLSCRT])
+	if block == nil {
+		return consts.CertificateRotatedReasonInvalid, "the certificate could not be decoded", nil
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return consts.CertificateRotatedReasonInvalid, fmt.Sprintf("the certificate could not be parsed: %v", err), nil
+	}
+
+	if cert.Subject.CommonName != subject {
+		return consts.CertificateRotatedReasonSubjectChanged,
+			fmt.Sprintf("the certificate was issued for %q instead of %q", cert.Subject.CommonName, subject), nil
+	}
+
+	caCertBlock, _ := pem.Decode(ca.Data[consts.TLSCRT])
+	if caCertBlock == nil {
+		return "", "", fmt.Errorf("failed decoding '%s' data from secret %s", consts.TLSCRT, ca.Name)
+	}
+	caCert, err := x509.ParseCertificate(caCertBlock.Bytes)
+	if err != nil {
+		return "", "", err
+	}
+	if err := cert.CheckSignatureFrom(caCert); err != nil {
+		return consts.CertificateRotatedReasonCAChanged, "the certificate was not signed by the current cluster CA", nil
+	}
+	if !bytes.Equal(secret.Data[consts.CACRT], CATrustBundle(ca)) {
+		return consts.CertificateRotatedReasonCAChanged, "the cluster CA trust bundle has changed", nil
+	}
+
+	// Certificates cannot outlive the CA that signs them so there is no point
+	// in reissuing a certificate that expires together with its CA: it would
+	// yield the very same expiry. Such certificates get reissued once the CA
+	// has been rolled over.
+	if time.Until(cert.NotAfter) < renewBefore && caCert.NotAfter.After(cert.NotAfter) {
+		return consts.CertificateRotatedReasonExpiring,
+			fmt.Sprintf("the certificate expires at %s", cert.NotAfter.Format(time.RFC3339)), nil
+	}
+
+	return "", "", nil
+}
+
+// privateKeyReissueReason checks whether the private key stored in the provided
+// Secret is of the provided type. It returns the reason for the reissue along with
+// a human readable message or an empty reason when the private key can be kept.
+func privateKeyReissueReason(secret *corev1.Secret, keyType KeyType) (k8sutils.ConditionReason, string) {
+	if keyType == "" {
+		keyType = DefaultKeyType
+	}
+	priv, err := ParsePrivateKey(secret.Data[consts.TLSKey])
+	if err != nil {
+		return consts.CertificateRotatedReasonInvalid, fmt.Sprintf("the private key could not be parsed: %v", err)
+	}
+	actual, err := KeyTypeOf(priv)
+	if err != nil || actual != keyType {
+		return consts.CertificateRotatedReasonKeyTypeChanged,
+			fmt.Sprintf("the private key is not of the configured type %s", keyType)
+	}
+	return "", ""
+}
+
+// notifyCertificateReissued emits an Event and sets the CertificateRotated
+// condition on the owner of a reissued certificate.
+func notifyCertificateReissued(
+	ctx context.Context,
+	cl client.Client,
+	owner client.Object,
+	secret *corev1.Secret,
+	reason k8sutils.ConditionReason,
+	reasonMessage string,
+	recorder record.EventRecorder,
+) error {
+	msg := fmt.Sprintf("Certificate in Secret %s has been reissued: %s", secret.Name, reasonMessage)
+	if recorder != nil {
+		recorder.Event(owner, corev1.EventTypeNormal, "CertificateReissued", msg)
+	}
+
+	conditionsAware, ok := owner.(k8sutils.ConditionsAware)
+	if !ok {
+		return nil
+	}
+	old, ok := owner.DeepCopyObject().(client.Object)
+	if !ok {
+		return fmt.Errorf("failed to copy %T %s/%s", owner, owner.GetNamespace(), owner.GetName())
+	}
+	k8sutils.SetCondition(
+		k8sutils.NewConditionWithGeneration(consts.CertificateRotatedType, metav1.ConditionTrue, reason, msg, owner.GetGeneration()),
+		conditionsAware,
+	)
+	if err := cl.Status().Patch(ctx, owner, client.MergeFrom(old)); err != nil {
+		return fmt.Errorf("failed setting %s condition on %T %s/%s: %w",
+			consts.CertificateRotatedType, owner, owner.GetNamespace(), owner.GetName(), err,
+		)
+	}
+	return nil
+}
+
+// RenewalRequeueAfter returns the duration after which the certificate stored in
+// the provided Secret should be checked again, so that it gets reissued before
+// it expires. It returns 0 when the certificate cannot be parsed.
+func RenewalRequeueAfter(secret *corev1.Secret, renewBefore time.Duration) time.Duration {
+	// certificateRenewalMinRequeueAfter prevents requeueing in a tight loop when
+	// the certificate cannot be renewed yet, e.g. because it expires together
+	// with a cluster CA that has not been rolled over.
+	const certificateRenewalMinRequeueAfter = time.Hour
+
+	block, _ := pem.Decode(secret.Data[consts.TLSCRT])
+	if block == nil {
+		return 0
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return 0
+	}
+	return max(time.Until(cert.NotAfter.Add(-renewBefore)), certificateRenewalMinRequeueAfter)
+}
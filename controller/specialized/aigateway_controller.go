@@ -10,6 +10,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -46,7 +47,15 @@ func (r *AIGatewayReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			handler.EnqueueRequestsFromMapFunc(r.listAIGatewaysForGatewayClass),
 			builder.WithPredicates(predicate.NewPredicateFuncs(watch.GatewayClassMatchesController)),
 		).
-		// TODO watch on Gateways, KongPlugins, e.t.c.
+		// watch AIModelCatalogs to roll out changes of their models to the
+		// AIGateways referencing them.
+		Watches(
+			&v1alpha1.AIModelCatalog{},
+			handler.EnqueueRequestsFromMapFunc(r.listAIGatewaysForAIModelCatalog),
+		).
+		// watch the Gateways owned by AIGateways to keep their status up to date.
+		Owns(&gatewayv1.Gateway{}).
+		// TODO watch on KongPlugins, e.t.c.
 		//
 		// See: https://github.com/Kong/gateway-operator/issues/1368
 		Complete(r)
@@ -64,6 +73,14 @@ func (r *AIGatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, err
 	}
 
+	log.Trace(logger, "handling any necessary aigateway cleanup", aigateway)
+	// cleanup is handled before verifying the GatewayClass so that the owned
+	// resources are removed even if the GatewayClass no longer exists.
+	cleanupUnderway, result, err := r.cleanup(ctx, logger, &aigateway)
+	if cleanupUnderway {
+		return result, err
+	}
+
 	log.Trace(logger, "verifying gatewayclass for aigateway", aigateway)
 	// we verify the GatewayClass in the watch predicates as well, but the watch
 	// predicates are known to be lossy, so they are considered only an optimization
@@ -83,14 +100,17 @@ func (r *AIGatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, nil
 	}
 
-	log.Trace(logger, "handling any necessary aigateway cleanup", aigateway)
-	if aigateway.GetDeletionTimestamp() != nil {
-		log.Debug(logger, "aigateway is being deleted, ignoring", aigateway)
-		return ctrl.Result{}, nil
+	log.Trace(logger, "ensuring the cleanup finalizer is set on aigateway", aigateway)
+	oldAIGateway := aigateway.DeepCopy()
+	if controllerutil.AddFinalizer(&aigateway, string(AIGatewayCleanupFinalizer)) {
+		if err := r.Client.Patch(ctx, &aigateway, client.MergeFrom(oldAIGateway)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to set finalizer on aigateway: %w", err)
+		}
+		log.Debug(logger, "cleanup finalizer set on aigateway", aigateway)
+		return ctrl.Result{}, nil // update will re-queue
 	}
 
 	log.Trace(logger, "marking aigateway as accepted", aigateway)
-	oldAIGateway := aigateway.DeepCopy()
 	k8sutils.SetCondition(newAIGatewayAcceptedCondition(&aigateway), &aigateway)
 	if k8sutils.NeedsUpdate(oldAIGateway, &aigateway) {
 		if err := r.Client.Status().Patch(ctx, &aigateway, client.MergeFrom(oldAIGateway)); err != nil {
@@ -103,6 +123,9 @@ func (r *AIGatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	log.Info(logger, "managing gateway resources for aigateway", aigateway)
 	gatewayResourcesChanged, err := r.manageGateway(ctx, logger, &aigateway)
 	if err != nil {
+		if _, statusErr := r.updateStatus(ctx, &aigateway, err); statusErr != nil {
+			return ctrl.Result{}, errors.Join(err, statusErr)
+		}
 		return ctrl.Result{}, err
 	}
 	if gatewayResourcesChanged {
@@ -112,15 +135,24 @@ func (r *AIGatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	log.Info(logger, "configuring plugin and route resources for aigateway", aigateway)
 	pluginResourcesChanged, err := r.configurePlugins(ctx, logger, &aigateway)
 	if err != nil {
+		if _, statusErr := r.updateStatus(ctx, &aigateway, err); statusErr != nil {
+			return ctrl.Result{}, errors.Join(err, statusErr)
+		}
 		return ctrl.Result{}, err
 	}
 	if pluginResourcesChanged {
 		return ctrl.Result{Requeue: true}, err
 	}
 
-	// TODO: manage status updates
-	//
-	// See: https://github.com/Kong/gateway-operator/issues/1368
+	log.Trace(logger, "updating status for aigateway", aigateway)
+	statusUpdated, err := r.updateStatus(ctx, &aigateway, nil)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if statusUpdated {
+		log.Debug(logger, "aigateway status updated", aigateway)
+		return ctrl.Result{}, nil // update will re-queue
+	}
 
 	log.Info(logger, "reconciliation complete for aigateway resource", aigateway)
 	return ctrl.Result{}, nil
@@ -0,0 +1,128 @@
+package specialized
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kong/gateway-operator/api/v1alpha1"
+	"github.com/kong/gateway-operator/controller/pkg/log"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+)
+
+// ----------------------------------------------------------------------------
+// AIGatewayReconciler - Cleanup
+// ----------------------------------------------------------------------------
+
+// aiGatewayCleanupRequeueAfter is the time after which the cleanup of an
+// AIGateway is retried while its owned resources are being deleted, as not
+// all of them are watched by the controller.
+const aiGatewayCleanupRequeueAfter = time.Second
+
+// cleanup determines whether cleanup is needed/underway for an AIGateway and
+// performs all necessary cleanup steps. Namely, it deletes the resources
+// managed on behalf of the AIGateway, in order: the Gateway, the HTTPRoutes,
+// the KongConsumers, the KongPlugins, the Secrets and finally the Services. Once all of them are gone the
+// cleanup finalizer is removed so that the garbage collector can remove the
+// resource.
+func (r *AIGatewayReconciler) cleanup(
+	ctx context.Context,
+	logger logr.Logger,
+	aigateway *v1alpha1.AIGateway,
+) (
+	bool, // whether or not cleanup is being performed
+	ctrl.Result,
+	error,
+) {
+	if aigateway.DeletionTimestamp.IsZero() {
+		log.Trace(logger, "no cleanup required for aigateway", aigateway)
+		return false, ctrl.Result{}, nil
+	}
+
+	if !controllerutil.ContainsFinalizer(aigateway, string(AIGatewayCleanupFinalizer)) {
+		log.Debug(logger, "aigateway is being deleted and cleanup has already been performed", aigateway)
+		return true, ctrl.Result{}, nil
+	}
+
+	for _, owned := range []struct {
+		kind string
+		list client.ObjectList
+	}{
+		{kind: "gateways", list: &gatewayv1.GatewayList{}},
+		{kind: "httproutes", list: &gatewayv1.HTTPRouteList{}},
+		{kind: "kongconsumers", list: &configurationv1.KongConsumerList{}},
+		{kind: "kongplugins", list: &configurationv1.KongPluginList{}},
+		{kind: "secrets", list: &corev1.SecretList{}},
+		{kind: "services", list: &corev1.ServiceList{}},
+	} {
+		pending, err := r.deleteOwnedResources(ctx, aigateway, owned.list, nil)
+		if err != nil {
+			return true, ctrl.Result{}, fmt.Errorf("failed to delete owned %s for aigateway: %w", owned.kind, err)
+		}
+		if pending {
+			log.Debug(logger, fmt.Sprintf("waiting for owned %s to be deleted", owned.kind), aigateway)
+			return true, ctrl.Result{RequeueAfter: aiGatewayCleanupRequeueAfter}, nil
+		}
+	}
+
+	oldAIGateway := aigateway.DeepCopy()
+	controllerutil.RemoveFinalizer(aigateway, string(AIGatewayCleanupFinalizer))
+	if err := r.Client.Patch(ctx, aigateway, client.MergeFrom(oldAIGateway)); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return true, ctrl.Result{}, nil
+		}
+		return true, ctrl.Result{}, fmt.Errorf("failed to remove finalizer from aigateway: %w", err)
+	}
+	log.Debug(logger, "owned resources cleanup completed, finalizer removed", aigateway)
+
+	return true, ctrl.Result{}, nil
+}
+
+// deleteOwnedResources deletes the objects of the provided list type which are
+// owned by the AIGateway and which keep (if provided) does not return true for.
+// It returns whether any of those objects still exist, including the ones which
+// were just requested to be deleted.
+func (r *AIGatewayReconciler) deleteOwnedResources(
+	ctx context.Context,
+	aigateway *v1alpha1.AIGateway,
+	list client.ObjectList,
+	keep func(client.Object) bool,
+) (bool, error) {
+	if err := r.Client.List(ctx, list, client.InNamespace(aigateway.Namespace)); err != nil {
+		return false, err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return false, err
+	}
+
+	var pending bool
+	for _, item := range items {
+		obj, ok := item.(client.Object)
+		if !ok || !k8sutils.IsOwnedByRefUID(obj, aigateway.UID) {
+			continue
+		}
+		if keep != nil && keep(obj) {
+			continue
+		}
+
+		pending = true
+		if !obj.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		if err := r.Client.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			return pending, err
+		}
+	}
+	return pending, nil
+}
@@ -0,0 +1,131 @@
+package specialized
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kong/gateway-operator/api/v1alpha1"
+	"github.com/kong/gateway-operator/modules/manager/scheme"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+)
+
+func TestAIGatewayCleanup(t *testing.T) {
+	ctx := context.Background()
+	aigateway := &v1alpha1.AIGateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "aigateway",
+			Namespace:         "default",
+			UID:               "aigateway-uid",
+			DeletionTimestamp: &metav1.Time{Time: metav1.Now().Add(-1)},
+			Finalizers:        []string{string(AIGatewayCleanupFinalizer)},
+		},
+	}
+	owned := func(obj client.Object, name string) client.Object {
+		obj.SetName(name)
+		obj.SetNamespace(aigateway.Namespace)
+		k8sutils.SetOwnerForObject(obj, aigateway)
+		return obj
+	}
+	gateway := owned(&gatewayv1.Gateway{}, "aigateway")
+	httpRoute := owned(&gatewayv1.HTTPRoute{}, "gpt-egress")
+	kongConsumer := owned(&configurationv1.KongConsumer{}, "aigateway-devteam")
+	kongPlugin := owned(&configurationv1.KongPlugin{}, "gpt-ai-proxy")
+	secret := owned(&corev1.Secret{}, "aigateway-devteam-credentials")
+	service := owned(&corev1.Service{}, "aigateway-ai-sink")
+	unownedService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "unowned",
+			Namespace: aigateway.Namespace,
+		},
+	}
+
+	cl := fakectrlruntimeclient.NewClientBuilder().
+		WithScheme(scheme.Get()).
+		WithObjects(aigateway, gateway, httpRoute, kongConsumer, kongPlugin, secret, service, unownedService).
+		Build()
+	r := &AIGatewayReconciler{Client: cl}
+
+	exists := func(obj client.Object) bool {
+		err := cl.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+		if k8serrors.IsNotFound(err) {
+			return false
+		}
+		require.NoError(t, err)
+		return true
+	}
+
+	// Owned resources are deleted one kind at a time, in order.
+	for i, deleted := range []client.Object{gateway, httpRoute, kongConsumer, kongPlugin, secret, service} {
+		current := &v1alpha1.AIGateway{}
+		require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(aigateway), current))
+		underway, res, err := r.cleanup(ctx, logr.Discard(), current)
+		require.NoError(t, err)
+		require.True(t, underway)
+		require.Equal(t, aiGatewayCleanupRequeueAfter, res.RequeueAfter)
+		require.False(t, exists(deleted), "step %d", i)
+		require.True(t, exists(unownedService))
+	}
+
+	current := &v1alpha1.AIGateway{}
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(aigateway), current))
+	underway, res, err := r.cleanup(ctx, logr.Discard(), current)
+	require.NoError(t, err)
+	require.True(t, underway)
+	require.Zero(t, res.RequeueAfter)
+	require.False(t, exists(&v1alpha1.AIGateway{ObjectMeta: aigateway.ObjectMeta}),
+		"aigateway should be removed once the finalizer is gone")
+	require.True(t, exists(unownedService))
+}
+
+func TestAIGatewayDeleteOwnedResourcesKeep(t *testing.T) {
+	ctx := context.Background()
+	aigateway := &v1alpha1.AIGateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "aigateway",
+			Namespace: "default",
+			UID:       "aigateway-uid",
+		},
+	}
+	route := func(name string) *gatewayv1.HTTPRoute {
+		r := &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: aigateway.Namespace,
+			},
+		}
+		k8sutils.SetOwnerForObject(r, aigateway)
+		return r
+	}
+	kept, removed := route("gpt-egress"), route("mistral-egress")
+
+	cl := fakectrlruntimeclient.NewClientBuilder().
+		WithScheme(scheme.Get()).
+		WithObjects(aigateway, kept, removed).
+		Build()
+	r := &AIGatewayReconciler{Client: cl}
+
+	pruned, err := r.deleteOwnedResources(ctx, aigateway, &gatewayv1.HTTPRouteList{},
+		keepByName(map[string]struct{}{kept.Name: {}}))
+	require.NoError(t, err)
+	require.True(t, pruned)
+
+	routes := &gatewayv1.HTTPRouteList{}
+	require.NoError(t, cl.List(ctx, routes))
+	require.Len(t, routes.Items, 1)
+	require.Equal(t, kept.Name, routes.Items[0].Name)
+
+	pruned, err = r.deleteOwnedResources(ctx, aigateway, &gatewayv1.HTTPRouteList{},
+		keepByName(map[string]struct{}{kept.Name: {}}))
+	require.NoError(t, err)
+	require.False(t, pruned)
+}
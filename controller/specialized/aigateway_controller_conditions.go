@@ -1,6 +1,8 @@
 package specialized
 
 import (
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -24,3 +26,61 @@ func newAIGatewayAcceptedCondition(obj client.Object) metav1.Condition {
 		LastTransitionTime: metav1.Now(),
 	}
 }
+
+// newAIGatewayProvisioningCondition returns a new Provisioning condition for
+// the AIGateway resource to indicate whether the controller is still working
+// on provisioning the resources the AIGateway needs.
+func newAIGatewayProvisioningCondition(
+	obj client.Object,
+	status metav1.ConditionStatus,
+	reason string,
+	message string,
+) metav1.Condition {
+	return metav1.Condition{
+		Type:               v1alpha1.AIGatewayConditionTypeProvisioning,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: obj.GetGeneration(),
+		LastTransitionTime: metav1.Now(),
+	}
+}
+
+// newAIGatewayEndpointReadyCondition returns a new EndpointReady condition for
+// the AIGateway resource to indicate whether its endpoints are ready for
+// inference.
+func newAIGatewayEndpointReadyCondition(
+	obj client.Object,
+	status metav1.ConditionStatus,
+	reason string,
+	message string,
+) metav1.Condition {
+	return metav1.Condition{
+		Type:               v1alpha1.AIGatewayConditionTypeEndpointReady,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: obj.GetGeneration(),
+		LastTransitionTime: metav1.Now(),
+	}
+}
+
+// newAIGatewayEnterpriseRequiredCondition returns a new EnterpriseRequired
+// condition for the AIGateway resource to indicate to the user whether it
+// uses features provided by plugins only available in Kong Gateway
+// Enterprise, which the default DataPlane image does not include.
+func newAIGatewayEnterpriseRequiredCondition(aigateway *v1alpha1.AIGateway) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               v1alpha1.AIGatewayConditionTypeEnterpriseRequired,
+		Status:             metav1.ConditionFalse,
+		Reason:             v1alpha1.AIGatewayConditionReasonNoEnterprisePluginsConfigured,
+		ObservedGeneration: aigateway.GetGeneration(),
+		LastTransitionTime: metav1.Now(),
+	}
+	if features := aiGatewayEnterpriseFeatures(aigateway); len(features) != 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = v1alpha1.AIGatewayConditionReasonEnterprisePluginsConfigured
+		condition.Message = "DataPlanes have to run a Kong Gateway Enterprise image for: " + strings.Join(features, "; ")
+	}
+	return condition
+}
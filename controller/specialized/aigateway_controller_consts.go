@@ -18,4 +18,17 @@ const (
 	// that can be attached to all Services, HTTPRoutes, and other managed
 	// resources in an AIGateway.
 	AIGatewayEgressServicePort int = 80
+
+	// AIGatewayAnthropicVersion is the version of the Anthropic API which is
+	// configured for the LLMs served by Anthropic.
+	AIGatewayAnthropicVersion = "2023-06-01"
+
+	// AIGatewayConsumerIngressClass is the ingress class set on the
+	// KongConsumers of an AIGateway's consumers, so that they are picked up
+	// by the ControlPlane of the AIGateway's Gateway.
+	AIGatewayConsumerIngressClass = "kong"
+
+	// AIGatewayConsumerKeyName is the name of the header (or query parameter)
+	// the consumers of an AIGateway provide their API key in.
+	AIGatewayConsumerKeyName = "apikey"
 )
@@ -30,6 +30,29 @@ type AICloudProviderLLMConfig struct {
 	Model     *AICloudProviderModelConfig   `json:"model,omitempty"`
 }
 
+// AIProxyAdvancedConfig is a Golang-conversion of the 'AI Proxy Advanced'
+// plugin configuration, from the AI family of Kong plugins, used to spread the
+// requests for an LLM across multiple cloud provider and model combinations.
+type AIProxyAdvancedConfig struct {
+	Balancer *AIProxyAdvancedBalancerConfig `json:"balancer,omitempty"`
+	Targets  []AIProxyAdvancedTargetConfig  `json:"targets"`
+}
+
+// AIProxyAdvancedBalancerConfig is a Golang-conversion of the 'Balancer'
+// configuration of the 'AI Proxy Advanced' plugin.
+type AIProxyAdvancedBalancerConfig struct {
+	Algorithm        string   `json:"algorithm"`
+	Retries          *int     `json:"retries,omitempty"`
+	FailoverCriteria []string `json:"failover_criteria,omitempty"`
+}
+
+// AIProxyAdvancedTargetConfig is a Golang-conversion of the 'Targets'
+// configuration of the 'AI Proxy Advanced' plugin.
+type AIProxyAdvancedTargetConfig struct {
+	AICloudProviderLLMConfig
+	Weight int `json:"weight"`
+}
+
 // AICloudProviderAuthConfig is a Golang-conversion of the 'Auth' configuration
 // for the AI family of Kong plugins.
 type AICloudProviderAuthConfig struct {
@@ -55,6 +78,45 @@ type AICloudProviderModelConfig struct {
 // AICloudProviderOptionsConfig is a Golang-conversion of the 'Options' configuration
 // for the AI family of Kong plugins.
 type AICloudProviderOptionsConfig struct {
-	MaxTokens   *int    `json:"max_tokens,omitempty"`
-	Temperature *string `json:"temperature,omitempty"`
+	MaxTokens        *int    `json:"max_tokens,omitempty"`
+	Temperature      *string `json:"temperature,omitempty"`
+	TopK             *int    `json:"top_k,omitempty"`
+	TopP             *string `json:"top_p,omitempty"`
+	AnthropicVersion *string `json:"anthropic_version,omitempty"`
+	Llama2Format     *string `json:"llama2_format,omitempty"`
+	UpstreamURL      *string `json:"upstream_url,omitempty"`
+}
+
+// KeyAuthConfig is a Golang-conversion of the 'Key Authentication' plugin
+// configuration, used to authenticate the consumers of an AIGateway.
+type KeyAuthConfig struct {
+	KeyNames        []string `json:"key_names,omitempty"`
+	HideCredentials bool     `json:"hide_credentials"`
+}
+
+// RateLimitingConfig is a Golang-conversion of the 'Rate Limiting' plugin
+// configuration, used to enforce the request quotas of an AIGateway's consumers.
+type RateLimitingConfig struct {
+	Minute  *int   `json:"minute,omitempty"`
+	Hour    *int   `json:"hour,omitempty"`
+	Day     *int   `json:"day,omitempty"`
+	LimitBy string `json:"limit_by"`
+	Policy  string `json:"policy"`
+}
+
+// AIRateLimitingAdvancedConfig is a Golang-conversion of the 'AI Rate Limiting
+// Advanced' plugin configuration, from the AI family of Kong plugins, used to
+// enforce the token quotas of an AIGateway's consumers.
+type AIRateLimitingAdvancedConfig struct {
+	Identifier   string                                `json:"identifier"`
+	Strategy     string                                `json:"strategy"`
+	LLMProviders []AIRateLimitingAdvancedProviderLimit `json:"llm_providers"`
+}
+
+// AIRateLimitingAdvancedProviderLimit is a Golang-conversion of the 'LLM
+// Providers' configuration of the 'AI Rate Limiting Advanced' plugin.
+type AIRateLimitingAdvancedProviderLimit struct {
+	Name       string `json:"name"`
+	Limit      []int  `json:"limit"`
+	WindowSize []int  `json:"window_size"`
 }
@@ -2,13 +2,19 @@ package specialized
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"maps"
+	"reflect"
+	"slices"
 
 	"github.com/go-logr/logr"
 	configurationv1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1"
+	"github.com/samber/lo"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kong/gateway-operator/api/v1alpha1"
@@ -43,11 +49,29 @@ func (r *AIGatewayReconciler) createOrUpdateHttpRoute(
 		return false, err
 	}
 
-	// TODO - implement patching
+	// The plugins attached to the route change as consumers, quotas and
+	// prompts are added and removed, so its annotations have to be kept up
+	// to date.
+	//
+	// TODO - implement patching of the spec
 	//
 	// See: https://github.com/Kong/gateway-operator/issues/1368
-
-	return false, nil
+	old := found.DeepCopy()
+	updated := false
+	for k, v := range httpRoute.Annotations {
+		if found.Annotations[k] != v {
+			if found.Annotations == nil {
+				found.Annotations = make(map[string]string, len(httpRoute.Annotations))
+			}
+			found.Annotations[k] = v
+			updated = true
+		}
+	}
+	if !updated {
+		return false, nil
+	}
+	log.Info(logger, "updating httproute for aigateway", aiGateway)
+	return true, r.Client.Patch(ctx, found, client.MergeFrom(old))
 }
 
 func (r *AIGatewayReconciler) createOrUpdatePlugin(
@@ -74,10 +98,103 @@ func (r *AIGatewayReconciler) createOrUpdatePlugin(
 		return false, err
 	}
 
-	// TODO - implement patching
-	//
-	// See: https://github.com/Kong/gateway-operator/issues/1368
+	// The plugin field of a KongPlugin is immutable, so plugins switching
+	// between ai-proxy and ai-proxy-advanced (e.g. when backends are added to
+	// an LLM) have to be recreated.
+	if found.PluginName != kongPlugin.PluginName {
+		log.Info(logger, "recreating plugin for aigateway", aiGateway)
+		if err := r.Client.Delete(ctx, found); err != nil && !k8serrors.IsNotFound(err) {
+			return false, err
+		}
+		return true, r.Client.Create(ctx, kongPlugin)
+	}
+
+	equal, err := kongPluginConfigEqual(found.Config.Raw, kongPlugin.Config.Raw)
+	if err != nil {
+		return false, err
+	}
+	if equal {
+		return false, nil
+	}
+	old := found.DeepCopy()
+	found.Config = kongPlugin.Config
+	log.Info(logger, "updating plugin for aigateway", aiGateway)
+	return true, r.Client.Patch(ctx, found, client.MergeFrom(old))
+}
+
+// kongPluginConfigEqual compares two raw KongPlugin configurations
+// semantically, as the API server does not preserve their formatting.
+func kongPluginConfigEqual(a, b []byte) (bool, error) {
+	var aConfig, bConfig any
+	if len(a) != 0 {
+		if err := json.Unmarshal(a, &aConfig); err != nil {
+			return false, fmt.Errorf("failed to unmarshal plugin configuration: %w", err)
+		}
+	}
+	if len(b) != 0 {
+		if err := json.Unmarshal(b, &bConfig); err != nil {
+			return false, fmt.Errorf("failed to unmarshal plugin configuration: %w", err)
+		}
+	}
+	return reflect.DeepEqual(aConfig, bConfig), nil
+}
+
+func (r *AIGatewayReconciler) createOrUpdateConsumer(
+	ctx context.Context,
+	logger logr.Logger,
+	aiGateway *v1alpha1.AIGateway,
+	kongConsumer *configurationv1.KongConsumer,
+) (bool, error) {
+	log.Trace(logger, "checking for any existing kongconsumer for aigateway", aiGateway)
+
+	found := &configurationv1.KongConsumer{}
+	err := r.Client.Get(ctx, types.NamespacedName{
+		Name:      kongConsumer.Name,
+		Namespace: kongConsumer.Namespace,
+	}, found)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			log.Info(logger, "creating kongconsumer for aigateway", aiGateway)
+			return true, r.Client.Create(ctx, kongConsumer)
+		}
+		return false, err
+	}
+
+	// The plugins enforcing the consumer's quotas change as quotas are added
+	// and removed, so they have to be kept up to date.
+	if maps.Equal(found.Annotations, kongConsumer.Annotations) &&
+		slices.Equal(found.Credentials, kongConsumer.Credentials) {
+		return false, nil
+	}
+	old := found.DeepCopy()
+	found.Annotations = kongConsumer.Annotations
+	found.Credentials = kongConsumer.Credentials
+	log.Info(logger, "updating kongconsumer for aigateway", aiGateway)
+	return true, r.Client.Patch(ctx, found, client.MergeFrom(old))
+}
+
+func (r *AIGatewayReconciler) createOrUpdateSecret(
+	ctx context.Context,
+	logger logr.Logger,
+	aiGateway *v1alpha1.AIGateway,
+	secret *corev1.Secret,
+) (bool, error) {
+	log.Trace(logger, "checking for any existing secret for aigateway", aiGateway)
+
+	found := &corev1.Secret{}
+	err := r.Client.Get(ctx, types.NamespacedName{
+		Name:      secret.Name,
+		Namespace: secret.Namespace,
+	}, found)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			log.Info(logger, "creating secret for aigateway", aiGateway)
+			return true, r.Client.Create(ctx, secret)
+		}
+		return false, err
+	}
 
+	// The credentials are generated once and never rotated by the controller.
 	return false, nil
 }
 
@@ -188,74 +305,343 @@ func (r *AIGatewayReconciler) configurePlugins(
 		return changes, err
 	}
 
-	log.Trace(logger, "retrieving the cloud provider credentials secret for aigateway", aiGateway)
-	if aiGateway.Spec.CloudProviderCredentials == nil {
-		return changes, fmt.Errorf("ai gateway '%s' requires secret reference for Cloud Provider API keys", aiGateway.Name)
+	var (
+		httpRouteNames  = make(map[string]struct{})
+		kongPluginNames = make(map[string]struct{})
+	)
+
+	log.Trace(logger, "generating routes and plugins for self hosted models of aigateway", aiGateway)
+	for _, v := range aiGateway.Spec.LargeLanguageModels.SelfHosted {
+		selfHostedLLM := v
+
+		log.Trace(logger, "configuring the base aiproxy plugin for aigateway", aiGateway)
+		aiProxyPlugin, err := aiSelfHostedToKongPlugin(&selfHostedLLM, aiGateway)
+		if err != nil {
+			return changes, err
+		}
+		changed, err := r.configureLLM(
+			ctx, logger, aiGateway, aiGatewaySinkService, aiProxyPlugin,
+			selfHostedLLM.Identifier, []string{aiSelfHostedProviderName(selfHostedLLM.Format)}, selfHostedLLM.DefaultPrompts, selfHostedLLM.AppendedPrompts,
+			httpRouteNames, kongPluginNames,
+		)
+		if changed {
+			changes = true
+		}
+		if err != nil {
+			return changes, err
+		}
 	}
-	credentialSecretName := aiGateway.Spec.CloudProviderCredentials.Name
-	credentialSecretNamespace := aiGateway.Namespace
-	if aiGateway.Spec.CloudProviderCredentials.Namespace != nil {
-		credentialSecretNamespace = *aiGateway.Spec.CloudProviderCredentials.Namespace
+
+	if len(aiGateway.Spec.LargeLanguageModels.CloudHosted) != 0 {
+		if aiGateway.Spec.CloudProviderCredentials == nil {
+			return changes, fmt.Errorf("ai gateway '%s' requires secret reference for Cloud Provider API keys", aiGateway.Name)
+		}
+
+		log.Trace(logger, "retrieving the cloud provider credentials secret for aigateway", aiGateway)
+		credentialSecret, err := r.getCloudProviderCredentials(ctx, aiGateway, aiGateway.Spec.CloudProviderCredentials, aiGateway.Namespace)
+		if err != nil || credentialSecret == nil {
+			return changes, err
+		}
+
+		log.Trace(logger, "generating routes and plugins for cloud hosted models of aigateway", aiGateway)
+		for _, v := range aiGateway.Spec.LargeLanguageModels.CloudHosted {
+			cloudHostedLLM := v
+
+			changed, err := r.configureCloudHostedLLM(
+				ctx, logger, aiGateway, aiGatewaySinkService, &cloudHostedLLM, credentialSecret,
+				httpRouteNames, kongPluginNames,
+			)
+			if changed {
+				changes = true
+			}
+			if err != nil {
+				return changes, err
+			}
+		}
+	}
+
+	if len(aiGateway.Spec.LargeLanguageModels.Catalog) != 0 {
+		log.Trace(logger, "generating routes and plugins for catalog models of aigateway", aiGateway)
+		credentialSecrets := make(map[string]*corev1.Secret)
+		for _, ref := range aiGateway.Spec.LargeLanguageModels.Catalog {
+			catalog, err := r.getAIModelCatalog(ctx, aiGateway, ref.CatalogName)
+			if err != nil {
+				return changes, err
+			}
+			entry, ok := lo.Find(catalog.Spec.Models, func(m v1alpha1.AIModelCatalogEntry) bool {
+				return m.Name == ref.ModelName
+			})
+			if !ok {
+				return changes, fmt.Errorf(
+					"ai gateway '%s' references model '%s' of aimodelcatalog '%s' but the catalog has no such model",
+					aiGateway.Name, ref.ModelName, ref.CatalogName,
+				)
+			}
+
+			credentialSecret, ok := credentialSecrets[catalog.Name]
+			if !ok {
+				log.Trace(logger, "retrieving the cloud provider credentials secret of aimodelcatalog for aigateway", aiGateway)
+				credentialSecret, err = r.getCloudProviderCredentials(ctx, aiGateway, &catalog.Spec.CloudProviderCredentials, catalog.Namespace)
+				if err != nil || credentialSecret == nil {
+					return changes, err
+				}
+				credentialSecrets[catalog.Name] = credentialSecret
+			}
+
+			catalogLLM := aiModelCatalogEntryToLLM(ref, &entry)
+			changed, err := r.configureCloudHostedLLM(
+				ctx, logger, aiGateway, aiGatewaySinkService, &catalogLLM, credentialSecret,
+				httpRouteNames, kongPluginNames,
+			)
+			if changed {
+				changes = true
+			}
+			if err != nil {
+				return changes, err
+			}
+		}
+	}
+
+	changed, err = r.manageConsumers(ctx, logger, aiGateway)
+	if changed {
+		changes = true
+	}
+	if err != nil {
+		return changes, err
+	}
+
+	log.Trace(logger, "pruning routes and plugins of models removed from aigateway", aiGateway)
+	pruned, err := r.deleteOwnedResources(ctx, aiGateway, &gatewayv1.HTTPRouteList{}, keepByName(httpRouteNames))
+	if err != nil {
+		return changes, fmt.Errorf("failed to prune httproutes for aigateway: %w", err)
+	}
+	if pruned {
+		log.Info(logger, "pruned httproutes of models removed from aigateway", aiGateway)
+		changes = true
+	}
+	pruned, err = r.deleteOwnedResources(ctx, aiGateway, &configurationv1.KongPluginList{}, keepByName(kongPluginNames))
+	if err != nil {
+		return changes, fmt.Errorf("failed to prune kongplugins for aigateway: %w", err)
+	}
+	if pruned {
+		log.Info(logger, "pruned kongplugins of models removed from aigateway", aiGateway)
+		changes = true
+	}
+
+	return changes, nil
+}
+
+// getCloudProviderCredentials returns the Secret holding the cloud provider
+// credentials referenced by the AIGateway or one of its AIModelCatalogs, or
+// nil if it doesn't exist (yet). The namespace is used when the reference
+// doesn't specify one.
+func (r *AIGatewayReconciler) getCloudProviderCredentials(
+	ctx context.Context,
+	aiGateway *v1alpha1.AIGateway,
+	ref *v1alpha1.AICloudProviderAPITokenRef,
+	namespace string,
+) (*corev1.Secret, error) {
+	credentialSecretName := ref.Name
+	credentialSecretNamespace := namespace
+	if ref.Namespace != nil {
+		credentialSecretNamespace = *ref.Namespace
 	}
 	credentialSecret := &corev1.Secret{}
 	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: credentialSecretNamespace, Name: credentialSecretName}, credentialSecret); err != nil {
 		if k8serrors.IsNotFound(err) {
-			return changes, nil
+			return nil, nil
 		}
-		return changes, fmt.Errorf(
+		return nil, fmt.Errorf(
 			"ai gateway '%s' references secret '%s/%s' but it could not be read, %w",
 			aiGateway.Name, credentialSecretNamespace, credentialSecretName, err,
 		)
 	}
 
-	log.Trace(logger, "generating routes and plugins for aigateway", aiGateway)
-	for _, v := range aiGateway.Spec.LargeLanguageModels.CloudHosted {
-		cloudHostedLLM := v
+	return credentialSecret, nil
+}
 
-		log.Trace(logger, "determining whether we have API keys configured for cloud provider", aiGateway)
-		credentialData, ok := credentialSecret.Data[string(cloudHostedLLM.AICloudProvider.Name)]
-		if !ok {
-			return changes, fmt.Errorf(
-				"ai gateway '%s' references provider '%s' but it has no API key stored in the credentials secret",
-				aiGateway.Name, string(cloudHostedLLM.AICloudProvider.Name),
-			)
+// getAIModelCatalog returns the AIModelCatalog with the provided name in the
+// namespace of the AIGateway.
+func (r *AIGatewayReconciler) getAIModelCatalog(
+	ctx context.Context,
+	aiGateway *v1alpha1.AIGateway,
+	name string,
+) (*v1alpha1.AIModelCatalog, error) {
+	catalog := &v1alpha1.AIModelCatalog{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: aiGateway.Namespace, Name: name}, catalog); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, fmt.Errorf("ai gateway '%s' references aimodelcatalog '%s' but it does not exist", aiGateway.Name, name)
 		}
+		return nil, fmt.Errorf("ai gateway '%s' references aimodelcatalog '%s' but it could not be read, %w", aiGateway.Name, name, err)
+	}
+	return catalog, nil
+}
 
+// configureCloudHostedLLM configures the resources serving a single cloud
+// hosted LLM of the AIGateway, authenticated with the API keys stored in the
+// provided credentials Secret.
+func (r *AIGatewayReconciler) configureCloudHostedLLM(
+	ctx context.Context,
+	logger logr.Logger,
+	aiGateway *v1alpha1.AIGateway,
+	sinkService *corev1.Service,
+	cloudHostedLLM *v1alpha1.CloudHostedLargeLanguageModel,
+	credentialSecret *corev1.Secret,
+	httpRouteNames map[string]struct{},
+	kongPluginNames map[string]struct{},
+) (
+	bool, // whether any changes were made
+	error,
+) {
+	log.Trace(logger, "determining whether we have API keys configured for cloud provider", aiGateway)
+	credentialData, ok := credentialSecret.Data[string(cloudHostedLLM.AICloudProvider.Name)]
+	if !ok {
+		return false, fmt.Errorf(
+			"ai gateway '%s' references provider '%s' but it has no API key stored in the credentials secret",
+			aiGateway.Name, string(cloudHostedLLM.AICloudProvider.Name),
+		)
+	}
+
+	var (
+		aiProxyPlugin *configurationv1.KongPlugin
+		err           error
+	)
+	if len(cloudHostedLLM.Backends) == 0 {
 		log.Trace(logger, "configuring the base aiproxy plugin for aigateway", aiGateway)
-		aiProxyPlugin, err := aiCloudGatewayToKongPlugin(&cloudHostedLLM, aiGateway, &credentialData)
+		aiProxyPlugin, err = aiCloudGatewayToKongPlugin(cloudHostedLLM, aiGateway, &credentialData)
+	} else {
+		log.Trace(logger, "configuring the advanced aiproxy plugin for aigateway", aiGateway)
+		aiProxyPlugin, err = aiCloudGatewayToKongAdvancedPlugin(cloudHostedLLM, aiGateway, credentialSecret.Data)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return r.configureLLM(
+		ctx, logger, aiGateway, sinkService, aiProxyPlugin,
+		cloudHostedLLM.Identifier, aiCloudGatewayProviderNames(cloudHostedLLM), cloudHostedLLM.DefaultPrompts, cloudHostedLLM.AppendedPrompts,
+		httpRouteNames, kongPluginNames,
+	)
+}
+
+// configureLLM creates the ai-proxy plugin, the optional ai-prompt-decorator
+// plugin, the plugins authenticating the consumers and enforcing their quotas
+// and the HTTPRoute serving a single LLM of the AIGateway. The names of the
+// configured objects are recorded in httpRouteNames and kongPluginNames.
+func (r *AIGatewayReconciler) configureLLM(
+	ctx context.Context,
+	logger logr.Logger,
+	aiGateway *v1alpha1.AIGateway,
+	sinkService *corev1.Service,
+	aiProxyPlugin *configurationv1.KongPlugin,
+	identifier string,
+	providerNames []string,
+	defaultPrompts []v1alpha1.LLMPrompt,
+	appendedPrompts []v1alpha1.LLMPrompt,
+	httpRouteNames map[string]struct{},
+	kongPluginNames map[string]struct{},
+) (
+	bool, // whether any changes were made
+	error,
+) {
+	changes := false
+
+	plugins := []*configurationv1.KongPlugin{aiProxyPlugin}
+	if len(aiGateway.Spec.Consumers) != 0 {
+		log.Trace(logger, "configuring the consumer authentication and quota plugins for aigateway", aiGateway)
+		keyAuthPlugin, err := aiGatewayToKeyAuthPlugin(aiGateway)
+		if err != nil {
+			return changes, err
+		}
+		quotaPlugins, err := aiGatewayToQuotaPlugins(aiGateway, identifier, providerNames)
+		if err != nil {
+			return changes, err
+		}
+		plugins = append(plugins, keyAuthPlugin)
+		plugins = append(plugins, quotaPlugins...)
+	}
+	for _, plugin := range plugins {
+		kongPluginNames[plugin.Name] = struct{}{}
+		changed, err := r.createOrUpdatePlugin(ctx, logger, aiGateway, plugin)
+		if changed {
+			changes = true
+		}
 		if err != nil {
 			return changes, err
 		}
-		changed, err := r.createOrUpdatePlugin(ctx, logger, aiGateway, aiProxyPlugin)
+	}
+
+	log.Trace(logger, "configuring the ai prompt decorator plugin for aigateway", aiGateway)
+	decoratorPlugin, err := aiCloudGatewayToKongPromptDecoratorPlugin(identifier, defaultPrompts, appendedPrompts, aiGateway)
+	if err != nil {
+		return changes, err
+	}
+	if decoratorPlugin != nil {
+		kongPluginNames[decoratorPlugin.Name] = struct{}{}
+		changed, err := r.createOrUpdatePlugin(ctx, logger, aiGateway, decoratorPlugin)
 		if changed {
 			changes = true
 		}
 		if err != nil {
 			return changes, err
 		}
+	}
 
-		log.Trace(logger, "configuring the ai prompt decorator plugin for aigateway", aiGateway)
-		decoratorPlugin, err := aiCloudGatewayToKongPromptDecoratorPlugin(&cloudHostedLLM, aiGateway)
+	log.Trace(logger, "configuring an httproute for aigateway", aiGateway)
+	pluginNames := make([]string, 0, len(plugins)+1)
+	for _, plugin := range plugins {
+		pluginNames = append(pluginNames, plugin.Name)
+	}
+	if decoratorPlugin != nil {
+		pluginNames = append(pluginNames, decoratorPlugin.Name)
+	}
+	httpRoute := aiCloudGatewayToHTTPRoute(identifier, aiGateway, sinkService, pluginNames)
+	httpRouteNames[httpRoute.Name] = struct{}{}
+	changed, err := r.createOrUpdateHttpRoute(ctx, logger, aiGateway, httpRoute)
+	if changed {
+		changes = true
+	}
+
+	return changes, err
+}
+
+// manageConsumers creates the KongConsumers and the credential Secrets of the
+// AIGateway's consumers and prunes the ones of removed consumers.
+func (r *AIGatewayReconciler) manageConsumers(
+	ctx context.Context,
+	logger logr.Logger,
+	aiGateway *v1alpha1.AIGateway,
+) (
+	bool, // whether any changes were made
+	error,
+) {
+	changes := false
+
+	var (
+		kongConsumerNames = make(map[string]struct{}, len(aiGateway.Spec.Consumers))
+		secretNames       = make(map[string]struct{}, len(aiGateway.Spec.Consumers))
+	)
+	for _, v := range aiGateway.Spec.Consumers {
+		consumer := v
+
+		log.Trace(logger, "configuring the credentials of a consumer for aigateway", aiGateway)
+		secret, err := aiGatewayConsumerToCredentialSecret(aiGateway, &consumer)
 		if err != nil {
 			return changes, err
 		}
-		if decoratorPlugin != nil {
-			changed, err := r.createOrUpdatePlugin(ctx, logger, aiGateway, decoratorPlugin)
-			if changed {
-				changes = true
-			}
-			if err != nil {
-				return changes, err
-			}
+		secretNames[secret.Name] = struct{}{}
+		changed, err := r.createOrUpdateSecret(ctx, logger, aiGateway, secret)
+		if changed {
+			changes = true
 		}
-
-		log.Trace(logger, "configuring an httproute for aigateway", aiGateway)
-		plugins := []string{aiProxyPlugin.Name}
-		if decoratorPlugin != nil {
-			plugins = append(plugins, decoratorPlugin.Name)
+		if err != nil {
+			return changes, err
 		}
-		httpRoute := aiCloudGatewayToHTTPRoute(&cloudHostedLLM, aiGateway, aiGatewaySinkService, plugins)
-		changed, err = r.createOrUpdateHttpRoute(ctx, logger, aiGateway, httpRoute)
+
+		log.Trace(logger, "configuring a kongconsumer for aigateway", aiGateway)
+		kongConsumer := aiGatewayConsumerToKongConsumer(aiGateway, &consumer)
+		kongConsumerNames[kongConsumer.Name] = struct{}{}
+		changed, err = r.createOrUpdateConsumer(ctx, logger, aiGateway, kongConsumer)
 		if changed {
 			changes = true
 		}
@@ -264,5 +650,32 @@ func (r *AIGatewayReconciler) configurePlugins(
 		}
 	}
 
+	log.Trace(logger, "pruning consumers removed from aigateway", aiGateway)
+	pruned, err := r.deleteOwnedResources(ctx, aiGateway, &configurationv1.KongConsumerList{}, keepByName(kongConsumerNames))
+	if err != nil {
+		return changes, fmt.Errorf("failed to prune kongconsumers for aigateway: %w", err)
+	}
+	if pruned {
+		log.Info(logger, "pruned kongconsumers of consumers removed from aigateway", aiGateway)
+		changes = true
+	}
+	pruned, err = r.deleteOwnedResources(ctx, aiGateway, &corev1.SecretList{}, keepByName(secretNames))
+	if err != nil {
+		return changes, fmt.Errorf("failed to prune secrets for aigateway: %w", err)
+	}
+	if pruned {
+		log.Info(logger, "pruned secrets of consumers removed from aigateway", aiGateway)
+		changes = true
+	}
+
 	return changes, nil
 }
+
+// keepByName returns a function which reports whether the name of the provided
+// object is one of the names.
+func keepByName(names map[string]struct{}) func(client.Object) bool {
+	return func(obj client.Object) bool {
+		_, ok := names[obj.GetName()]
+		return ok
+	}
+}
@@ -0,0 +1,152 @@
+package specialized
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kong/gateway-operator/api/v1alpha1"
+	"github.com/kong/gateway-operator/modules/manager/scheme"
+)
+
+func TestAIGatewayConfigurePluginsUpdatesHTTPRoutePlugins(t *testing.T) {
+	ctx := context.Background()
+	aigateway := &v1alpha1.AIGateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "aigateway",
+			Namespace: "default",
+			UID:       "aigateway-uid",
+		},
+		Spec: v1alpha1.AIGatewaySpec{
+			LargeLanguageModels: &v1alpha1.LargeLanguageModels{
+				SelfHosted: []v1alpha1.SelfHostedLargeLanguageModel{
+					{
+						Identifier: "mistral",
+						ServiceRef: v1alpha1.SelfHostedLLMServiceRef{Name: "mistral", Port: 8000},
+					},
+				},
+			},
+		},
+	}
+
+	cl := fakectrlruntimeclient.NewClientBuilder().
+		WithScheme(scheme.Get()).
+		WithObjects(aigateway).
+		Build()
+	r := &AIGatewayReconciler{Client: cl}
+
+	routePlugins := func(t *testing.T) []string {
+		httpRoute := &gatewayv1.HTTPRoute{}
+		require.NoError(t, cl.Get(ctx, client.ObjectKey{Namespace: aigateway.Namespace, Name: "mistral-egress"}, httpRoute))
+		return strings.Split(httpRoute.Annotations["konghq.com/plugins"], ",")
+	}
+
+	t.Log("configuring an aigateway without consumers")
+	changed, err := r.configurePlugins(ctx, logr.Discard(), aigateway)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Len(t, routePlugins(t), 1)
+	keyAuthPluginName := aiGatewayKeyAuthPluginName(aigateway)
+	require.NotContains(t, routePlugins(t), keyAuthPluginName)
+
+	t.Log("adding consumers with quotas to the existing aigateway")
+	aigateway.Spec.Consumers = []v1alpha1.AIGatewayConsumer{
+		{
+			Name: "devteam",
+			Quotas: []v1alpha1.AIGatewayConsumerQuota{
+				{Model: "mistral", Requests: lo.ToPtr(100)},
+			},
+		},
+	}
+	changed, err = r.configurePlugins(ctx, logr.Discard(), aigateway)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Contains(t, routePlugins(t), keyAuthPluginName)
+	require.Contains(t, routePlugins(t), "mistral-devteam-rate-limiting")
+
+	t.Log("configuring the aigateway again without changes")
+	changed, err = r.configurePlugins(ctx, logr.Discard(), aigateway)
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	t.Log("removing the consumers from the aigateway")
+	aigateway.Spec.Consumers = nil
+	changed, err = r.configurePlugins(ctx, logr.Discard(), aigateway)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Len(t, routePlugins(t), 1)
+	require.NotContains(t, routePlugins(t), keyAuthPluginName)
+}
+
+func TestAIGatewayConfigurePluginsAddsPromptDecoratorToExistingHTTPRoute(t *testing.T) {
+	ctx := context.Background()
+	aigateway := &v1alpha1.AIGateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "aigateway",
+			Namespace: "default",
+			UID:       "aigateway-uid",
+		},
+		Spec: v1alpha1.AIGatewaySpec{
+			LargeLanguageModels: &v1alpha1.LargeLanguageModels{
+				SelfHosted: []v1alpha1.SelfHostedLargeLanguageModel{
+					{
+						Identifier: "mistral",
+						ServiceRef: v1alpha1.SelfHostedLLMServiceRef{Name: "mistral", Port: 8000},
+					},
+				},
+			},
+		},
+	}
+
+	cl := fakectrlruntimeclient.NewClientBuilder().
+		WithScheme(scheme.Get()).
+		WithObjects(aigateway).
+		Build()
+	r := &AIGatewayReconciler{Client: cl}
+
+	routePlugins := func(t *testing.T) []string {
+		httpRoute := &gatewayv1.HTTPRoute{}
+		require.NoError(t, cl.Get(ctx, client.ObjectKey{Namespace: aigateway.Namespace, Name: "mistral-egress"}, httpRoute))
+		return strings.Split(httpRoute.Annotations["konghq.com/plugins"], ",")
+	}
+	const decoratorPluginName = "mistral-ai-prompt-decorator"
+
+	t.Log("configuring an aigateway without prompts")
+	changed, err := r.configurePlugins(ctx, logr.Discard(), aigateway)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.NotContains(t, routePlugins(t), decoratorPluginName)
+
+	t.Log("adding appended prompts to the existing model")
+	aigateway.Spec.LargeLanguageModels.SelfHosted[0].AppendedPrompts = []v1alpha1.LLMPrompt{
+		{Role: lo.ToPtr(v1alpha1.LLMPromptRoleUser), Content: "Answer in less than 50 words."},
+	}
+	changed, err = r.configurePlugins(ctx, logr.Discard(), aigateway)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Contains(t, routePlugins(t), decoratorPluginName)
+
+	t.Log("replacing the appended prompts with default prompts")
+	aigateway.Spec.LargeLanguageModels.SelfHosted[0].AppendedPrompts = nil
+	aigateway.Spec.LargeLanguageModels.SelfHosted[0].DefaultPrompts = []v1alpha1.LLMPrompt{
+		{Role: lo.ToPtr(v1alpha1.LLMPromptRoleSystem), Content: "You are a helpful assistant."},
+	}
+	_, err = r.configurePlugins(ctx, logr.Discard(), aigateway)
+	require.NoError(t, err)
+	require.Contains(t, routePlugins(t), decoratorPluginName)
+
+	t.Log("removing the prompts from the model")
+	aigateway.Spec.LargeLanguageModels.SelfHosted[0].DefaultPrompts = nil
+	changed, err = r.configurePlugins(ctx, logr.Discard(), aigateway)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.NotContains(t, routePlugins(t), decoratorPluginName)
+}
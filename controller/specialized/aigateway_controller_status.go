@@ -0,0 +1,185 @@
+package specialized
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/samber/lo"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kong/gateway-operator/api/v1alpha1"
+	gatewayutils "github.com/kong/gateway-operator/pkg/utils/gateway"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+)
+
+// -----------------------------------------------------------------------------
+// AIGatewayReconciler - Status Management
+// -----------------------------------------------------------------------------
+
+// updateStatus sets the Provisioning and EndpointReady conditions and the
+// endpoints of the AIGateway based on the state of its Gateway and patches the
+// AIGateway's status if anything changed. provisionErr is the error which
+// occurred while provisioning the AIGateway's resources, if any.
+func (r *AIGatewayReconciler) updateStatus(
+	ctx context.Context,
+	aigateway *v1alpha1.AIGateway,
+	provisionErr error,
+) (
+	bool, // whether the status was updated
+	error,
+) {
+	var gateway *gatewayv1.Gateway
+	if provisionErr == nil {
+		gateway = &gatewayv1.Gateway{}
+		if err := r.Client.Get(ctx, types.NamespacedName{
+			Namespace: aigateway.Namespace,
+			Name:      aigateway.Name,
+		}, gateway); err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return false, fmt.Errorf("failed to get gateway for aigateway: %w", err)
+			}
+			gateway = nil
+		}
+	}
+
+	oldAIGateway := aigateway.DeepCopy()
+	setAIGatewayStatus(aigateway, gateway, provisionErr)
+	if !k8sutils.NeedsUpdate(oldAIGateway, aigateway) &&
+		cmp.Equal(oldAIGateway.Status.Endpoints, aigateway.Status.Endpoints,
+			cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime"),
+			cmpopts.EquateEmpty(),
+		) {
+		return false, nil
+	}
+
+	if err := r.Client.Status().Patch(ctx, aigateway, client.MergeFrom(oldAIGateway)); err != nil {
+		return false, fmt.Errorf("failed to patch status for aigateway: %w", err)
+	}
+	return true, nil
+}
+
+// setAIGatewayStatus sets the Provisioning, EndpointReady and EnterpriseRequired
+// conditions and the endpoints of the AIGateway. The Provisioning and
+// EndpointReady conditions follow the Programmed condition of the provided
+// Gateway, which is nil when it has not been created yet. When provisionErr is
+// not nil both of them are set to Failed.
+func setAIGatewayStatus(
+	aigateway *v1alpha1.AIGateway,
+	gateway *gatewayv1.Gateway,
+	provisionErr error,
+) {
+	var (
+		provisioning  metav1.Condition
+		endpointReady metav1.Condition
+	)
+	switch {
+	case provisionErr != nil:
+		provisioning = newAIGatewayProvisioningCondition(aigateway,
+			metav1.ConditionFalse, v1alpha1.AIGatewayConditionReasonFailed, provisionErr.Error())
+		endpointReady = newAIGatewayEndpointReadyCondition(aigateway,
+			metav1.ConditionFalse, v1alpha1.AIGatewayConditionReasonFailed, provisionErr.Error())
+	case gateway == nil:
+		msg := "waiting for the gateway to be created"
+		provisioning = newAIGatewayProvisioningCondition(aigateway,
+			metav1.ConditionTrue, v1alpha1.AIGatewayConditionReasonDeploying, msg)
+		endpointReady = newAIGatewayEndpointReadyCondition(aigateway,
+			metav1.ConditionFalse, v1alpha1.AIGatewayConditionReasonDeploying, msg)
+	case !gatewayutils.IsProgrammed(gateway):
+		msg := fmt.Sprintf("waiting for gateway %s to be programmed", gateway.Name)
+		c, ok := lo.Find(gateway.Status.Conditions, func(c metav1.Condition) bool {
+			return c.Type == string(gatewayv1.GatewayConditionProgrammed)
+		})
+		if ok && c.Message != "" {
+			msg = fmt.Sprintf("%s: %s", msg, c.Message)
+		}
+		provisioning = newAIGatewayProvisioningCondition(aigateway,
+			metav1.ConditionTrue, v1alpha1.AIGatewayConditionReasonDeploying, msg)
+		endpointReady = newAIGatewayEndpointReadyCondition(aigateway,
+			metav1.ConditionFalse, v1alpha1.AIGatewayConditionReasonDeploying, msg)
+	default:
+		provisioning = newAIGatewayProvisioningCondition(aigateway,
+			metav1.ConditionFalse, v1alpha1.AIGatewayConditionReasonDeployed, "")
+		endpointReady = newAIGatewayEndpointReadyCondition(aigateway,
+			metav1.ConditionTrue, v1alpha1.AIGatewayConditionReasonDeployed, "")
+	}
+
+	k8sutils.SetCondition(provisioning, aigateway)
+	k8sutils.SetCondition(endpointReady, aigateway)
+	k8sutils.SetCondition(newAIGatewayEnterpriseRequiredCondition(aigateway), aigateway)
+	aigateway.Status.Endpoints = aiGatewayEndpoints(aigateway, gateway, provisioning, endpointReady)
+}
+
+// aiGatewayEndpoints returns an endpoint for each of the addresses of the
+// AIGateway's Gateway and each of the AIGateway's consumers. Each of the
+// AIGateway's models is served on the endpoint's URL under the path of the
+// model's HTTPRoute.
+func aiGatewayEndpoints(
+	aigateway *v1alpha1.AIGateway,
+	gateway *gatewayv1.Gateway,
+	conditions ...metav1.Condition,
+) []v1alpha1.AIGatewayEndpoint {
+	if gateway == nil || len(gateway.Status.Addresses) == 0 {
+		return nil
+	}
+
+	models := make([]string, 0)
+	if aigateway.Spec.LargeLanguageModels != nil {
+		for _, llm := range aigateway.Spec.LargeLanguageModels.CloudHosted {
+			models = append(models, llm.Identifier)
+		}
+		for _, llm := range aigateway.Spec.LargeLanguageModels.SelfHosted {
+			models = append(models, llm.Identifier)
+		}
+		for _, llm := range aigateway.Spec.LargeLanguageModels.Catalog {
+			models = append(models, llm.Identifier)
+		}
+	}
+
+	// Without consumers the endpoints can be accessed without credentials,
+	// otherwise each consumer gets its own endpoints.
+	consumers := []v1alpha1.AIGatewayConsumerRef{{}}
+	if len(aigateway.Spec.Consumers) != 0 {
+		consumers = make([]v1alpha1.AIGatewayConsumerRef, 0, len(aigateway.Spec.Consumers))
+		for _, consumer := range aigateway.Spec.Consumers {
+			consumers = append(consumers, v1alpha1.AIGatewayConsumerRef{
+				Name:      aiGatewayConsumerCredentialsName(aigateway, consumer.Name),
+				Namespace: aigateway.Namespace,
+			})
+		}
+	}
+
+	endpoints := make([]v1alpha1.AIGatewayEndpoint, 0, len(gateway.Status.Addresses)*len(consumers))
+	for _, address := range gateway.Status.Addresses {
+		for _, consumer := range consumers {
+			endpoints = append(endpoints, v1alpha1.AIGatewayEndpoint{
+				NetworkAccessHint: v1alpha1.NetworkInternetAccessible,
+				URL:               aiGatewayEndpointURL(address.Value),
+				AvailableModels:   models,
+				Consumer:          consumer,
+				Conditions:        conditions,
+			})
+		}
+	}
+	return endpoints
+}
+
+// aiGatewayEndpointURL returns the URL of the AIGateway's HTTP listener
+// reachable on the provided address.
+func aiGatewayEndpointURL(address string) string {
+	host := address
+	if AIGatewayEgressServicePort != 80 {
+		host = net.JoinHostPort(address, strconv.Itoa(AIGatewayEgressServicePort))
+	} else if ip := net.ParseIP(address); ip != nil && ip.To4() == nil {
+		host = "[" + address + "]"
+	}
+	return (&url.URL{Scheme: "http", Host: host}).String()
+}
@@ -0,0 +1,232 @@
+package specialized
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kong/gateway-operator/api/v1alpha1"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+)
+
+func TestSetAIGatewayStatus(t *testing.T) {
+	aigateway := &v1alpha1.AIGateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "aigateway",
+			Namespace:  "default",
+			Generation: 2,
+		},
+		Spec: v1alpha1.AIGatewaySpec{
+			LargeLanguageModels: &v1alpha1.LargeLanguageModels{
+				CloudHosted: []v1alpha1.CloudHostedLargeLanguageModel{
+					{Identifier: "gpt"},
+					{Identifier: "mistral"},
+				},
+				SelfHosted: []v1alpha1.SelfHostedLargeLanguageModel{
+					{Identifier: "llama"},
+				},
+			},
+		},
+	}
+	gatewayWithProgrammed := func(status metav1.ConditionStatus, reason gatewayv1.GatewayConditionReason, message string) *gatewayv1.Gateway {
+		return &gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "aigateway",
+				Namespace: "default",
+			},
+			Status: gatewayv1.GatewayStatus{
+				Addresses: []gatewayv1.GatewayStatusAddress{
+					{Type: lo.ToPtr(gatewayv1.IPAddressType), Value: "203.0.113.10"},
+					{Type: lo.ToPtr(gatewayv1.IPAddressType), Value: "2001:db8::1"},
+					{Type: lo.ToPtr(gatewayv1.HostnameAddressType), Value: "ai.example.com"},
+				},
+				Conditions: []metav1.Condition{
+					{
+						Type:    string(gatewayv1.GatewayConditionProgrammed),
+						Status:  status,
+						Reason:  string(reason),
+						Message: message,
+					},
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name                    string
+		gateway                 *gatewayv1.Gateway
+		provisionErr            error
+		expectedProvisioning    metav1.ConditionStatus
+		expectedEndpointReady   metav1.ConditionStatus
+		expectedReason          string
+		expectedMessage         string
+		expectedEndpointsURLs   []string
+		expectedAvailableModels []string
+	}{
+		{
+			name:                  "gateway not created yet",
+			expectedProvisioning:  metav1.ConditionTrue,
+			expectedEndpointReady: metav1.ConditionFalse,
+			expectedReason:        v1alpha1.AIGatewayConditionReasonDeploying,
+			expectedMessage:       "waiting for the gateway to be created",
+		},
+		{
+			name:                  "gateway not programmed",
+			gateway:               gatewayWithProgrammed(metav1.ConditionFalse, gatewayv1.GatewayReasonAddressNotAssigned, "address not assigned"),
+			expectedProvisioning:  metav1.ConditionTrue,
+			expectedEndpointReady: metav1.ConditionFalse,
+			expectedReason:        v1alpha1.AIGatewayConditionReasonDeploying,
+			expectedMessage:       "waiting for gateway aigateway to be programmed: address not assigned",
+			expectedEndpointsURLs: []string{
+				"http://203.0.113.10",
+				"http://[2001:db8::1]",
+				"http://ai.example.com",
+			},
+			expectedAvailableModels: []string{"gpt", "mistral", "llama"},
+		},
+		{
+			name:                  "gateway programmed",
+			gateway:               gatewayWithProgrammed(metav1.ConditionTrue, gatewayv1.GatewayReasonProgrammed, ""),
+			expectedProvisioning:  metav1.ConditionFalse,
+			expectedEndpointReady: metav1.ConditionTrue,
+			expectedReason:        v1alpha1.AIGatewayConditionReasonDeployed,
+			expectedEndpointsURLs: []string{
+				"http://203.0.113.10",
+				"http://[2001:db8::1]",
+				"http://ai.example.com",
+			},
+			expectedAvailableModels: []string{"gpt", "mistral", "llama"},
+		},
+		{
+			name:                  "provisioning failed",
+			provisionErr:          errors.New("missing credentials"),
+			expectedProvisioning:  metav1.ConditionFalse,
+			expectedEndpointReady: metav1.ConditionFalse,
+			expectedReason:        v1alpha1.AIGatewayConditionReasonFailed,
+			expectedMessage:       "missing credentials",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			aigateway := aigateway.DeepCopy()
+			setAIGatewayStatus(aigateway, tc.gateway, tc.provisionErr)
+
+			provisioning, ok := k8sutils.GetCondition(k8sutils.ConditionType(v1alpha1.AIGatewayConditionTypeProvisioning), aigateway)
+			require.True(t, ok)
+			require.Equal(t, tc.expectedProvisioning, provisioning.Status)
+			require.Equal(t, tc.expectedReason, provisioning.Reason)
+			require.Equal(t, tc.expectedMessage, provisioning.Message)
+			require.Equal(t, aigateway.Generation, provisioning.ObservedGeneration)
+
+			endpointReady, ok := k8sutils.GetCondition(k8sutils.ConditionType(v1alpha1.AIGatewayConditionTypeEndpointReady), aigateway)
+			require.True(t, ok)
+			require.Equal(t, tc.expectedEndpointReady, endpointReady.Status)
+			require.Equal(t, tc.expectedReason, endpointReady.Reason)
+
+			require.Len(t, aigateway.Status.Endpoints, len(tc.expectedEndpointsURLs))
+			for i, endpoint := range aigateway.Status.Endpoints {
+				require.Equal(t, tc.expectedEndpointsURLs[i], endpoint.URL)
+				require.Equal(t, tc.expectedAvailableModels, endpoint.AvailableModels)
+				require.Equal(t, v1alpha1.NetworkInternetAccessible, endpoint.NetworkAccessHint)
+				require.Equal(t, []metav1.Condition{provisioning, endpointReady}, endpoint.Conditions)
+			}
+		})
+	}
+}
+
+func TestAIGatewayEndpointsWithConsumers(t *testing.T) {
+	aigateway := &v1alpha1.AIGateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "aigateway",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.AIGatewaySpec{
+			LargeLanguageModels: &v1alpha1.LargeLanguageModels{
+				CloudHosted: []v1alpha1.CloudHostedLargeLanguageModel{
+					{Identifier: "gpt"},
+				},
+			},
+			Consumers: []v1alpha1.AIGatewayConsumer{
+				{Name: "devteam"},
+				{Name: "marketing"},
+			},
+		},
+	}
+	gateway := &gatewayv1.Gateway{
+		Status: gatewayv1.GatewayStatus{
+			Addresses: []gatewayv1.GatewayStatusAddress{
+				{Type: lo.ToPtr(gatewayv1.IPAddressType), Value: "203.0.113.10"},
+			},
+		},
+	}
+
+	endpoints := aiGatewayEndpoints(aigateway, gateway)
+	require.Len(t, endpoints, 2)
+	require.Equal(t, v1alpha1.AIGatewayConsumerRef{Name: "aigateway-devteam-credentials", Namespace: "default"}, endpoints[0].Consumer)
+	require.Equal(t, v1alpha1.AIGatewayConsumerRef{Name: "aigateway-marketing-credentials", Namespace: "default"}, endpoints[1].Consumer)
+	for _, endpoint := range endpoints {
+		require.Equal(t, "http://203.0.113.10", endpoint.URL)
+		require.Equal(t, []string{"gpt"}, endpoint.AvailableModels)
+	}
+}
+
+func TestAIGatewayEnterpriseRequiredCondition(t *testing.T) {
+	aigateway := &v1alpha1.AIGateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "aigateway",
+			Namespace:  "default",
+			Generation: 3,
+		},
+		Spec: v1alpha1.AIGatewaySpec{
+			LargeLanguageModels: &v1alpha1.LargeLanguageModels{
+				CloudHosted: []v1alpha1.CloudHostedLargeLanguageModel{
+					{Identifier: "gpt"},
+				},
+			},
+			Consumers: []v1alpha1.AIGatewayConsumer{
+				{
+					Name: "devteam",
+					Quotas: []v1alpha1.AIGatewayConsumerQuota{
+						{Model: "gpt", Requests: lo.ToPtr(100)},
+					},
+				},
+			},
+		},
+	}
+
+	setAIGatewayStatus(aigateway, nil, nil)
+	condition, ok := k8sutils.GetCondition(k8sutils.ConditionType(v1alpha1.AIGatewayConditionTypeEnterpriseRequired), aigateway)
+	require.True(t, ok)
+	require.Equal(t, metav1.ConditionFalse, condition.Status)
+	require.Equal(t, v1alpha1.AIGatewayConditionReasonNoEnterprisePluginsConfigured, condition.Reason)
+	require.Equal(t, aigateway.Generation, condition.ObservedGeneration)
+
+	aigateway.Spec.Consumers[0].Quotas[0].Tokens = lo.ToPtr(10000)
+	setAIGatewayStatus(aigateway, nil, nil)
+	condition, ok = k8sutils.GetCondition(k8sutils.ConditionType(v1alpha1.AIGatewayConditionTypeEnterpriseRequired), aigateway)
+	require.True(t, ok)
+	require.Equal(t, metav1.ConditionTrue, condition.Status)
+	require.Equal(t, v1alpha1.AIGatewayConditionReasonEnterprisePluginsConfigured, condition.Reason)
+	require.Equal(t,
+		`DataPlanes have to run a Kong Gateway Enterprise image for: token quota of consumer "devteam" for model "gpt" (ai-rate-limiting-advanced plugin)`,
+		condition.Message,
+	)
+
+	aigateway.Spec.LargeLanguageModels.CloudHosted[0].Backends = []v1alpha1.CloudHostedLLMBackend{
+		{AICloudProvider: v1alpha1.AICloudProvider{Name: v1alpha1.AICloudProviderAzure}},
+	}
+	setAIGatewayStatus(aigateway, nil, nil)
+	condition, ok = k8sutils.GetCondition(k8sutils.ConditionType(v1alpha1.AIGatewayConditionTypeEnterpriseRequired), aigateway)
+	require.True(t, ok)
+	require.Equal(t, metav1.ConditionTrue, condition.Status)
+	require.Equal(t,
+		`DataPlanes have to run a Kong Gateway Enterprise image for: backends of model "gpt" (ai-proxy-advanced plugin); `+
+			`token quota of consumer "devteam" for model "gpt" (ai-rate-limiting-advanced plugin)`,
+		condition.Message,
+	)
+}
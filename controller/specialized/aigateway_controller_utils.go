@@ -1,12 +1,16 @@
 package specialized
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	configurationv1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1"
+	"github.com/samber/lo"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -14,6 +18,7 @@ import (
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	v1alpha1 "github.com/kong/gateway-operator/api/v1alpha1"
+	"github.com/kong/gateway-operator/internal/annotations"
 	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
 )
 
@@ -43,6 +48,10 @@ func getAuthHeaderForInference(provider v1alpha1.AICloudProvider) (map[string]st
 				"HeaderName":    "Authorization",
 				"HeaderPattern": "Bearer %s",
 			},
+			v1alpha1.AICloudProviderAnthropic: {
+				"HeaderName":    "x-api-key",
+				"HeaderPattern": "%s",
+			},
 		}
 	})
 
@@ -95,18 +104,22 @@ func aiGatewayToGateway(
 	return gateway
 }
 
-// aiCloudGatewayToDecoratorPlugin take an accepted/validated vXalphaY.CloudHostedLargeLanguageModel struct
-// and produces an ai-prompt-decorator vX.KongPlugin if required
+// aiCloudGatewayToDecoratorPlugin takes the identifier and the default and appended prompts of an
+// accepted/validated cloud hosted or self hosted LLM and produces an ai-prompt-decorator vX.KongPlugin
+// if required
 func aiCloudGatewayToKongPromptDecoratorPlugin(
-	aiCloudGateway *v1alpha1.CloudHostedLargeLanguageModel,
+	identifier string,
+	defaultPrompts []v1alpha1.LLMPrompt,
+	appendedPrompts []v1alpha1.LLMPrompt,
 	aigateway *v1alpha1.AIGateway,
 ) (*configurationv1.KongPlugin, error) {
 	var thisDecoratorPlugin *configurationv1.KongPlugin
 
-	if len(aiCloudGateway.DefaultPrompts) > 0 {
+	if len(defaultPrompts) > 0 || len(appendedPrompts) > 0 {
 		thisPluginConfig := AICloudPromptDecoratorConfig{
 			&AICloudPromptDecoratorPrompts{
-				Prepend: aiCloudGateway.DefaultPrompts,
+				Prepend: defaultPrompts,
+				Append:  appendedPrompts,
 			},
 		}
 
@@ -114,7 +127,7 @@ func aiCloudGatewayToKongPromptDecoratorPlugin(
 		if err != nil {
 			return nil, fmt.Errorf(
 				"ai cloud gateway with Identifier '%s' resource could not be parsed into a ai-prompt-decorator KongPlugin configuration, check object",
-				aiCloudGateway.Identifier,
+				identifier,
 			)
 		}
 
@@ -124,13 +137,13 @@ func aiCloudGatewayToKongPromptDecoratorPlugin(
 				APIVersion: configurationv1.SchemeGroupVersion.String(),
 			},
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      fmt.Sprintf("%s-ai-prompt-decorator", aiCloudGateway.Identifier),
+				Name:      fmt.Sprintf("%s-ai-prompt-decorator", identifier),
 				Namespace: aigateway.Namespace,
 			},
 
 			PluginName:   "ai-prompt-decorator",
 			Protocols:    configurationv1.StringsToKongProtocols([]string{"http", "https"}),
-			InstanceName: fmt.Sprintf("%s-ai-prompt-decorator", aiCloudGateway.Identifier),
+			InstanceName: fmt.Sprintf("%s-ai-prompt-decorator", identifier),
 			Config: v1.JSON{
 				Raw: thisPluginConfBytes,
 			},
@@ -177,21 +190,21 @@ func aiCloudGatewayToKubeSvc(aiGateway *v1alpha1.AIGateway) *corev1.Service {
 	return svc
 }
 
-// aiCloudGatewayToHTTPRoute takes an AIGateway, and a CloudHostedLargeLanguageModel,
+// aiCloudGatewayToHTTPRoute takes an AIGateway, and the identifier of a cloud hosted or self hosted LLM,
 // and produces an HTTPRoute that will become the egress point for this provider/model combo.
 func aiCloudGatewayToHTTPRoute(
-	aiCloudLLM *v1alpha1.CloudHostedLargeLanguageModel,
+	identifier string,
 	aigateway *v1alpha1.AIGateway,
 	kubeSvc *corev1.Service,
 	plugins []string,
 ) *gatewayv1.HTTPRoute {
 	backendKind := "Service"
 	matchType := "Exact"
-	exactPath := fmt.Sprintf("/%s", aiCloudLLM.Identifier)
+	exactPath := fmt.Sprintf("/%s", identifier)
 
 	httpRoute := &gatewayv1.HTTPRoute{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-egress", aiCloudLLM.Identifier),
+			Name:      fmt.Sprintf("%s-egress", identifier),
 			Namespace: aigateway.Namespace,
 			Annotations: map[string]string{
 				"konghq.com/plugins": strings.Join(plugins, ","),
@@ -245,24 +258,143 @@ func aiCloudGatewayToKongPlugin(
 	aigateway *v1alpha1.AIGateway,
 	credentialData *[]byte,
 ) (*configurationv1.KongPlugin, error) {
-	providerName := string(aiCloudLLM.AICloudProvider.Name)
-	routeType := ""
-	switch *aiCloudLLM.PromptType {
-	case v1alpha1.LLMPromptTypeChat:
-		routeType = "llm/v1/chat"
+	thisAIProxyPluginConfig, err := aiCloudProviderLLMConfig(aiCloudLLM, aiCloudLLM.AICloudProvider, aiCloudLLM.Model, *credentialData)
+	if err != nil {
+		return nil, err
+	}
 
-	case v1alpha1.LLMPromptTypeCompletion:
-		routeType = "llm/v1/completions"
+	return aiGatewayKongPlugin(aigateway, fmt.Sprintf("%s-ai-proxy", aiCloudLLM.Identifier), "ai-proxy", thisAIProxyPluginConfig)
+}
 
-	default:
-		return nil, fmt.Errorf(
-			"ai cloud gateway with Identifier '%s' uses prompt type '%s' but it is not yet supported",
-			aiCloudLLM.Identifier,
-			string(*aiCloudLLM.PromptType))
+// aiCloudGatewayToKongAdvancedPlugin takes an accepted/validated vXalphaY.CloudHostedLargeLanguageModel
+// struct with additional backends and transforms it into an ai-proxy-advanced vX.KongPlugin which
+// spreads the requests across the LLM's primary backend and its additional backends. The credentials
+// of each of the backends' cloud providers are looked up in credentials by the provider's name.
+func aiCloudGatewayToKongAdvancedPlugin(
+	aiCloudLLM *v1alpha1.CloudHostedLargeLanguageModel,
+	aigateway *v1alpha1.AIGateway,
+	credentials map[string][]byte,
+) (*configurationv1.KongPlugin, error) {
+	loadBalancing := aiCloudLLM.LoadBalancing
+	if loadBalancing == nil {
+		loadBalancing = &v1alpha1.LLMLoadBalancing{}
+	}
+
+	backends := make([]v1alpha1.CloudHostedLLMBackend, 0, len(aiCloudLLM.Backends)+1)
+	backends = append(backends, v1alpha1.CloudHostedLLMBackend{
+		AICloudProvider: aiCloudLLM.AICloudProvider,
+		Model:           aiCloudLLM.Model,
+		Weight:          loadBalancing.PrimaryWeight,
+	})
+	backends = append(backends, aiCloudLLM.Backends...)
+
+	targets := make([]AIProxyAdvancedTargetConfig, 0, len(backends))
+	for i, backend := range backends {
+		credentialData, ok := credentials[string(backend.AICloudProvider.Name)]
+		if !ok {
+			return nil, fmt.Errorf(
+				"ai gateway '%s' references provider '%s' but it has no API key stored in the credentials secret",
+				aigateway.Name, string(backend.AICloudProvider.Name),
+			)
+		}
+		config, err := aiCloudProviderLLMConfig(aiCloudLLM, backend.AICloudProvider, backend.Model, credentialData)
+		if err != nil {
+			return nil, err
+		}
+
+		// The priority algorithm prefers the targets with the highest weight,
+		// so failover order is expressed through decreasing weights.
+		weight := aiProxyAdvancedDefaultWeight
+		if loadBalancing.Strategy == v1alpha1.LLMLoadBalancingStrategyFailover {
+			weight = len(backends) - i
+		} else if backend.Weight != nil {
+			weight = *backend.Weight
+		}
+
+		targets = append(targets, AIProxyAdvancedTargetConfig{
+			AICloudProviderLLMConfig: *config,
+			Weight:                   weight,
+		})
+	}
+
+	algorithm := "round-robin"
+	if loadBalancing.Strategy == v1alpha1.LLMLoadBalancingStrategyFailover {
+		algorithm = "priority"
+	}
+	retries := len(backends) - 1
+	if loadBalancing.Retries != nil {
+		retries = *loadBalancing.Retries
+	}
+
+	return aiGatewayKongPlugin(aigateway, fmt.Sprintf("%s-ai-proxy", aiCloudLLM.Identifier), "ai-proxy-advanced", &AIProxyAdvancedConfig{
+		Balancer: &AIProxyAdvancedBalancerConfig{
+			Algorithm:        algorithm,
+			Retries:          &retries,
+			FailoverCriteria: aiProxyAdvancedFailoverCriteria,
+		},
+		Targets: targets,
+	})
+}
+
+// aiProxyAdvancedDefaultWeight is the weight of the ai-proxy-advanced targets
+// which don't specify one.
+const aiProxyAdvancedDefaultWeight = 100
+
+// aiProxyAdvancedFailoverCriteria are the failures of a cloud provider upon
+// which the ai-proxy-advanced plugin retries a request on another target.
+var aiProxyAdvancedFailoverCriteria = []string{
+	"error",
+	"timeout",
+	"http_429",
+	"http_500",
+	"http_502",
+	"http_503",
+	"http_504",
+}
+
+// aiModelCatalogEntryToLLM produces the cloud hosted LLM an AIGateway serves
+// for its reference to a model of an AIModelCatalog.
+func aiModelCatalogEntryToLLM(
+	ref v1alpha1.AIModelCatalogModelRef,
+	entry *v1alpha1.AIModelCatalogEntry,
+) v1alpha1.CloudHostedLargeLanguageModel {
+	return v1alpha1.CloudHostedLargeLanguageModel{
+		Identifier:          ref.Identifier,
+		Model:               entry.Model,
+		PromptType:          entry.PromptType,
+		DefaultPrompts:      entry.DefaultPrompts,
+		DefaultPromptParams: entry.DefaultPromptParams,
+		AICloudProvider:     entry.AICloudProvider,
+	}
+}
+
+// aiCloudGatewayProviderNames returns the names of the cloud providers of the
+// primary and the additional backends of a cloud hosted LLM.
+func aiCloudGatewayProviderNames(aiCloudLLM *v1alpha1.CloudHostedLargeLanguageModel) []string {
+	names := []string{string(aiCloudLLM.AICloudProvider.Name)}
+	for _, backend := range aiCloudLLM.Backends {
+		names = append(names, string(backend.AICloudProvider.Name))
+	}
+	return lo.Uniq(names)
+}
+
+// aiCloudProviderLLMConfig produces the configuration of the cloud provider
+// and model combination serving a cloud hosted LLM, authenticated with the
+// provided credential.
+func aiCloudProviderLLMConfig(
+	aiCloudLLM *v1alpha1.CloudHostedLargeLanguageModel,
+	provider v1alpha1.AICloudProvider,
+	model *string,
+	credentialData []byte,
+) (*AICloudProviderLLMConfig, error) {
+	providerName := string(provider.Name)
+	routeType, err := aiProxyRouteType(aiCloudLLM.Identifier, aiCloudLLM.PromptType)
+	if err != nil {
+		return nil, err
 	}
 
 	// Find and parse the auth header format
-	authHeader, err := getAuthHeaderForInference(aiCloudLLM.AICloudProvider)
+	authHeader, err := getAuthHeaderForInference(provider)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"ai cloud gateway with Identifier '%s' does not have auth header info defined, %w",
@@ -270,7 +402,7 @@ func aiCloudGatewayToKongPlugin(
 			err)
 	}
 	authHeaderName := authHeader["HeaderName"]
-	authHeaderValue := fmt.Sprintf(authHeader["HeaderPattern"], string(*credentialData))
+	authHeaderValue := fmt.Sprintf(authHeader["HeaderPattern"], string(credentialData))
 
 	thisAIProxyPluginConfig := AICloudProviderLLMConfig{
 		RouteType: &routeType,
@@ -278,50 +410,392 @@ func aiCloudGatewayToKongPlugin(
 			HeaderName:  &authHeaderName,
 			HeaderValue: &authHeaderValue,
 		},
-		Logging: &AICloudProviderLoggingConfig{
-			// TODO make sure this is set(table) in AICloudGateway declaration
-			LogStatistics: true,
-			LogPayloads:   false,
+		Logging: aiProxyLoggingConfig(aiCloudLLM.Logging),
+		Model: &AICloudProviderModelConfig{
+			Provider: &providerName,
+			Name:     model,
+			Options:  aiProxyOptionsConfig(aiCloudLLM.DefaultPromptParams),
 		},
+	}
+
+	// Anthropic requires the version of its API to be provided
+	if provider.Name == v1alpha1.AICloudProviderAnthropic {
+		anthropicVersion := AIGatewayAnthropicVersion
+		thisAIProxyPluginConfig.Model.Options.AnthropicVersion = &anthropicVersion
+	}
+
+	return &thisAIProxyPluginConfig, nil
+}
+
+// aiSelfHostedToKongPlugin takes an accepted/validated vXalphaY.SelfHostedLargeLanguageModel struct
+// and transforms it into a vX.KongPlugin from Kong Kubernetes-Ingress-Controller which proxies
+// the requests to the Kubernetes Service serving the LLM.
+func aiSelfHostedToKongPlugin(
+	aiSelfHostedLLM *v1alpha1.SelfHostedLargeLanguageModel,
+	aigateway *v1alpha1.AIGateway,
+) (*configurationv1.KongPlugin, error) {
+	routeType, err := aiProxyRouteType(aiSelfHostedLLM.Identifier, aiSelfHostedLLM.PromptType)
+	if err != nil {
+		return nil, err
+	}
+
+	options := aiProxyOptionsConfig(aiSelfHostedLLM.DefaultPromptParams)
+	providerName := aiSelfHostedProviderName(aiSelfHostedLLM.Format)
+	var path string
+	switch aiSelfHostedLLM.Format {
+	case v1alpha1.SelfHostedLLMFormatOpenAI, "":
+		path = "/v1/completions"
+		if routeType == aiProxyRouteTypeChat {
+			path = "/v1/chat/completions"
+		}
+
+	case v1alpha1.SelfHostedLLMFormatLlama2:
+		path = "/"
+		llama2Format := "raw"
+		options.Llama2Format = &llama2Format
+
+	default:
+		return nil, fmt.Errorf(
+			"ai self hosted gateway with Identifier '%s' uses format '%s' but it is not yet supported",
+			aiSelfHostedLLM.Identifier,
+			string(aiSelfHostedLLM.Format))
+	}
+	if aiSelfHostedLLM.ServiceRef.Path != nil {
+		path = *aiSelfHostedLLM.ServiceRef.Path
+	}
+	upstreamURL := fmt.Sprintf("http://%s.%s.svc:%d%s",
+		aiSelfHostedLLM.ServiceRef.Name, aigateway.Namespace, aiSelfHostedLLM.ServiceRef.Port, path,
+	)
+	options.UpstreamURL = &upstreamURL
+
+	thisAIProxyPluginConfig := AICloudProviderLLMConfig{
+		RouteType: &routeType,
+		Logging:   aiProxyLoggingConfig(aiSelfHostedLLM.Logging),
 		Model: &AICloudProviderModelConfig{
 			Provider: &providerName,
-			Name:     aiCloudLLM.Model,
-			Options:  &AICloudProviderOptionsConfig{},
+			Name:     aiSelfHostedLLM.Model,
+			Options:  options,
 		},
 	}
 
-	// Auxiliary config options for model tuning
-	if aiCloudLLM.DefaultPromptParams != nil {
-		thisAIProxyPluginConfig.Model.Options.MaxTokens = aiCloudLLM.DefaultPromptParams.MaxTokens
-		thisAIProxyPluginConfig.Model.Options.Temperature = aiCloudLLM.DefaultPromptParams.Temperature
+	return aiGatewayKongPlugin(aigateway, fmt.Sprintf("%s-ai-proxy", aiSelfHostedLLM.Identifier), "ai-proxy", &thisAIProxyPluginConfig)
+}
+
+// aiProxyOptionsConfig produces the options of the AI plugins' model
+// configuration from the default prompt parameters of an LLM, which are
+// auxiliary config options for model tuning.
+func aiProxyOptionsConfig(params *v1alpha1.LLMPromptParams) *AICloudProviderOptionsConfig {
+	options := &AICloudProviderOptionsConfig{}
+	if params != nil {
+		options.MaxTokens = params.MaxTokens
+		options.Temperature = params.Temperature
+		options.TopK = params.TopK
+		options.TopP = params.TopP
 	}
+	return options
+}
 
-	thisAIProxyPluginConfigJSON, err := json.Marshal(&thisAIProxyPluginConfig)
+// aiProxyLoggingConfig produces the logging configuration of the AI plugins
+// from the logging policy of an LLM. Usage statistics are logged and payloads
+// are not unless the policy states otherwise.
+func aiProxyLoggingConfig(logging *v1alpha1.LLMLogging) *AICloudProviderLoggingConfig {
+	config := &AICloudProviderLoggingConfig{
+		LogStatistics: true,
+		LogPayloads:   false,
+	}
+	if logging != nil {
+		if logging.LogStatistics != nil {
+			config.LogStatistics = *logging.LogStatistics
+		}
+		if logging.LogPayloads != nil {
+			config.LogPayloads = *logging.LogPayloads
+		}
+	}
+	return config
+}
+
+// aiSelfHostedProviderName returns the name of the provider the AI plugins
+// use for a self hosted LLM with the provided format.
+func aiSelfHostedProviderName(format v1alpha1.SelfHostedLLMFormat) string {
+	if format == v1alpha1.SelfHostedLLMFormatLlama2 {
+		return string(v1alpha1.SelfHostedLLMFormatLlama2)
+	}
+	return string(v1alpha1.AICloudProviderOpenAI)
+}
+
+const (
+	aiProxyRouteTypeChat        = "llm/v1/chat"
+	aiProxyRouteTypeCompletions = "llm/v1/completions"
+)
+
+// aiProxyRouteType returns the ai-proxy plugin route type for the prompt type
+// of an LLM, defaulting to completions when the prompt type isn't set.
+func aiProxyRouteType(identifier string, promptType *v1alpha1.LLMPromptType) (string, error) {
+	if promptType == nil {
+		return aiProxyRouteTypeCompletions, nil
+	}
+
+	switch *promptType {
+	case v1alpha1.LLMPromptTypeChat:
+		return aiProxyRouteTypeChat, nil
+
+	case v1alpha1.LLMPromptTypeCompletion:
+		return aiProxyRouteTypeCompletions, nil
+
+	default:
+		return "", fmt.Errorf(
+			"ai cloud gateway with Identifier '%s' uses prompt type '%s' but it is not yet supported",
+			identifier,
+			string(*promptType))
+	}
+}
+
+// aiGatewayKongPlugin wraps the configuration of a plugin into a vX.KongPlugin
+// with the provided name owned by the AIGateway.
+func aiGatewayKongPlugin(
+	aigateway *v1alpha1.AIGateway,
+	name string,
+	pluginName string,
+	config any,
+) (*configurationv1.KongPlugin, error) {
+	configJSON, err := json.Marshal(config)
 	if err != nil {
 		return nil, fmt.Errorf(
-			"ai cloud gateway with Identifier '%s' resource could not be parsed into a KongPlugin configuration, check object",
-			aiCloudLLM.Identifier)
+			"ai gateway '%s' could not generate the configuration of the %s KongPlugin '%s', %w",
+			aigateway.Name, pluginName, name, err)
 	}
 
-	thisAIProxyPlugin := configurationv1.KongPlugin{
+	plugin := configurationv1.KongPlugin{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "KongPlugin",
 			APIVersion: configurationv1.SchemeGroupVersion.String(),
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-ai-proxy", aiCloudLLM.Identifier),
+			Name:      name,
 			Namespace: aigateway.Namespace,
 		},
 
-		PluginName:   "ai-proxy",
+		PluginName:   pluginName,
 		Protocols:    configurationv1.StringsToKongProtocols([]string{"http", "https"}),
-		InstanceName: fmt.Sprintf("%s-ai-proxy", aiCloudLLM.Identifier),
+		InstanceName: name,
 		Config: v1.JSON{
-			Raw: thisAIProxyPluginConfigJSON,
+			Raw: configJSON,
+		},
+	}
+
+	k8sutils.SetOwnerForObject(&plugin, aigateway)
+
+	return &plugin, nil
+}
+
+// ----------------------------------------------------------------------------
+// AIGateway - Consumers
+// ----------------------------------------------------------------------------
+
+// aiGatewayKeyAuthPluginName returns the name of the key-auth KongPlugin
+// which authenticates the consumers of the AIGateway.
+func aiGatewayKeyAuthPluginName(aigateway *v1alpha1.AIGateway) string {
+	return fmt.Sprintf("%s-key-auth", aigateway.Name)
+}
+
+// aiGatewayConsumerName returns the name of the KongConsumer of an AIGateway's consumer.
+func aiGatewayConsumerName(aigateway *v1alpha1.AIGateway, consumer string) string {
+	return fmt.Sprintf("%s-%s", aigateway.Name, consumer)
+}
+
+// aiGatewayConsumerCredentialsName returns the name of the Secret holding the
+// credentials of an AIGateway's consumer.
+func aiGatewayConsumerCredentialsName(aigateway *v1alpha1.AIGateway, consumer string) string {
+	return fmt.Sprintf("%s-%s-credentials", aigateway.Name, consumer)
+}
+
+// aiGatewayQuotaPluginNames returns the names of the rate-limiting and the
+// ai-rate-limiting-advanced KongPlugins enforcing a consumer's quota for the
+// LLM with the provided identifier.
+func aiGatewayQuotaPluginNames(identifier, consumer string) (requests string, tokens string) {
+	return fmt.Sprintf("%s-%s-rate-limiting", identifier, consumer),
+		fmt.Sprintf("%s-%s-ai-rate-limiting", identifier, consumer)
+}
+
+// aiGatewayToKeyAuthPlugin produces the key-auth vX.KongPlugin which
+// authenticates the consumers of the AIGateway.
+func aiGatewayToKeyAuthPlugin(aigateway *v1alpha1.AIGateway) (*configurationv1.KongPlugin, error) {
+	return aiGatewayKongPlugin(aigateway, aiGatewayKeyAuthPluginName(aigateway), "key-auth", &KeyAuthConfig{
+		KeyNames:        []string{AIGatewayConsumerKeyName},
+		HideCredentials: true,
+	})
+}
+
+// aiGatewayToQuotaPlugins produces the vX.KongPlugins enforcing the quotas of
+// the AIGateway's consumers for the LLM with the provided identifier, which is
+// served by the providers with the provided names. Requests are limited by the
+// rate-limiting plugin and tokens by the ai-rate-limiting-advanced plugin.
+func aiGatewayToQuotaPlugins(
+	aigateway *v1alpha1.AIGateway,
+	identifier string,
+	providerNames []string,
+) ([]*configurationv1.KongPlugin, error) {
+	var plugins []*configurationv1.KongPlugin
+	for _, consumer := range aigateway.Spec.Consumers {
+		for _, quota := range consumer.Quotas {
+			if quota.Model != identifier {
+				continue
+			}
+
+			requestsPluginName, tokensPluginName := aiGatewayQuotaPluginNames(identifier, consumer.Name)
+			if quota.Requests != nil {
+				config := RateLimitingConfig{
+					LimitBy: "consumer",
+					Policy:  "local",
+				}
+				switch quota.Window {
+				case v1alpha1.AIGatewayQuotaWindowHour:
+					config.Hour = quota.Requests
+				case v1alpha1.AIGatewayQuotaWindowDay:
+					config.Day = quota.Requests
+				default:
+					config.Minute = quota.Requests
+				}
+				plugin, err := aiGatewayKongPlugin(aigateway, requestsPluginName, "rate-limiting", &config)
+				if err != nil {
+					return nil, err
+				}
+				plugins = append(plugins, plugin)
+			}
+
+			if quota.Tokens != nil {
+				limits := make([]AIRateLimitingAdvancedProviderLimit, 0, len(providerNames))
+				for _, providerName := range providerNames {
+					limits = append(limits, AIRateLimitingAdvancedProviderLimit{
+						Name:       providerName,
+						Limit:      []int{*quota.Tokens},
+						WindowSize: []int{aiGatewayQuotaWindowSeconds(quota.Window)},
+					})
+				}
+				plugin, err := aiGatewayKongPlugin(aigateway, tokensPluginName, "ai-rate-limiting-advanced", &AIRateLimitingAdvancedConfig{
+					Identifier:   "consumer",
+					Strategy:     "local",
+					LLMProviders: limits,
+				})
+				if err != nil {
+					return nil, err
+				}
+				plugins = append(plugins, plugin)
+			}
+		}
+	}
+
+	return plugins, nil
+}
+
+// aiGatewayEnterpriseFeatures returns descriptions of the AIGateway's features
+// which are provided by plugins only available in Kong Gateway Enterprise.
+func aiGatewayEnterpriseFeatures(aigateway *v1alpha1.AIGateway) []string {
+	var features []string
+	if aigateway.Spec.LargeLanguageModels != nil {
+		for _, llm := range aigateway.Spec.LargeLanguageModels.CloudHosted {
+			if len(llm.Backends) != 0 {
+				features = append(features, fmt.Sprintf(
+					"backends of model %q (ai-proxy-advanced plugin)", llm.Identifier,
+				))
+			}
+		}
+	}
+	for _, consumer := range aigateway.Spec.Consumers {
+		for _, quota := range consumer.Quotas {
+			if quota.Tokens != nil {
+				features = append(features, fmt.Sprintf(
+					"token quota of consumer %q for model %q (ai-rate-limiting-advanced plugin)", consumer.Name, quota.Model,
+				))
+			}
+		}
+	}
+	return features
+}
+
+// aiGatewayQuotaWindowSeconds returns the length of a quota window in seconds.
+func aiGatewayQuotaWindowSeconds(window v1alpha1.AIGatewayQuotaWindow) int {
+	switch window {
+	case v1alpha1.AIGatewayQuotaWindowHour:
+		return int(time.Hour.Seconds())
+	case v1alpha1.AIGatewayQuotaWindowDay:
+		return int((24 * time.Hour).Seconds())
+	default:
+		return int(time.Minute.Seconds())
+	}
+}
+
+// aiGatewayConsumerToKongConsumer takes a consumer of the AIGateway and
+// produces its vX.KongConsumer, which references the consumer's credentials
+// and the KongPlugins enforcing its quotas.
+func aiGatewayConsumerToKongConsumer(
+	aigateway *v1alpha1.AIGateway,
+	consumer *v1alpha1.AIGatewayConsumer,
+) *configurationv1.KongConsumer {
+	plugins := make([]string, 0, len(consumer.Quotas))
+	for _, quota := range consumer.Quotas {
+		requestsPluginName, tokensPluginName := aiGatewayQuotaPluginNames(quota.Model, consumer.Name)
+		if quota.Requests != nil {
+			plugins = append(plugins, requestsPluginName)
+		}
+		if quota.Tokens != nil {
+			plugins = append(plugins, tokensPluginName)
+		}
+	}
+
+	consumerAnnotations := map[string]string{
+		annotations.IngressClassKey: AIGatewayConsumerIngressClass,
+	}
+	if len(plugins) > 0 {
+		consumerAnnotations["konghq.com/plugins"] = strings.Join(plugins, ",")
+	}
+
+	kongConsumer := &configurationv1.KongConsumer{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "KongConsumer",
+			APIVersion: configurationv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        aiGatewayConsumerName(aigateway, consumer.Name),
+			Namespace:   aigateway.Namespace,
+			Annotations: consumerAnnotations,
+		},
+		Username:    aiGatewayConsumerName(aigateway, consumer.Name),
+		Credentials: []string{aiGatewayConsumerCredentialsName(aigateway, consumer.Name)},
+	}
+
+	k8sutils.SetOwnerForObject(kongConsumer, aigateway)
+
+	return kongConsumer
+}
+
+// aiGatewayConsumerToCredentialSecret takes a consumer of the AIGateway and
+// produces the key-auth credential Secret of its KongConsumer with a randomly
+// generated API key.
+func aiGatewayConsumerToCredentialSecret(
+	aigateway *v1alpha1.AIGateway,
+	consumer *v1alpha1.AIGatewayConsumer,
+) (*corev1.Secret, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate an API key for consumer '%s' of ai gateway '%s', %w", consumer.Name, aigateway.Name, err)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      aiGatewayConsumerCredentialsName(aigateway, consumer.Name),
+			Namespace: aigateway.Namespace,
+			Labels: map[string]string{
+				"konghq.com/credential": "key-auth",
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			"key": []byte(hex.EncodeToString(key)),
 		},
 	}
 
-	k8sutils.SetOwnerForObject(&thisAIProxyPlugin, aigateway)
+	k8sutils.SetOwnerForObject(secret, aigateway)
 
-	return &thisAIProxyPlugin, nil
+	return secret, nil
 }
@@ -0,0 +1,389 @@
+package specialized
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/gateway-operator/api/v1alpha1"
+)
+
+func TestAICloudGatewayToKongPlugin(t *testing.T) {
+	aigateway := &v1alpha1.AIGateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "aigateway",
+			Namespace: "default",
+		},
+	}
+	llm := &v1alpha1.CloudHostedLargeLanguageModel{
+		Identifier: "claude",
+		Model:      lo.ToPtr("claude-2.1"),
+		PromptType: lo.ToPtr(v1alpha1.LLMPromptTypeChat),
+		DefaultPromptParams: &v1alpha1.LLMPromptParams{
+			Temperature: lo.ToPtr("0.5"),
+			MaxTokens:   lo.ToPtr(256),
+			TopK:        lo.ToPtr(40),
+			TopP:        lo.ToPtr("0.9"),
+		},
+		AICloudProvider: v1alpha1.AICloudProvider{Name: v1alpha1.AICloudProviderAnthropic},
+		Logging: &v1alpha1.LLMLogging{
+			LogPayloads: lo.ToPtr(true),
+		},
+	}
+
+	plugin, err := aiCloudGatewayToKongPlugin(llm, aigateway, lo.ToPtr([]byte("token")))
+	require.NoError(t, err)
+	require.Equal(t, "claude-ai-proxy", plugin.Name)
+
+	var config AICloudProviderLLMConfig
+	require.NoError(t, json.Unmarshal(plugin.Config.Raw, &config))
+	require.Equal(t, aiProxyRouteTypeChat, *config.RouteType)
+	require.Equal(t, "x-api-key", *config.Auth.HeaderName)
+	require.Equal(t, "token", *config.Auth.HeaderValue)
+	require.Equal(t, "anthropic", *config.Model.Provider)
+	require.Equal(t, "claude-2.1", *config.Model.Name)
+	require.Equal(t, AIGatewayAnthropicVersion, *config.Model.Options.AnthropicVersion)
+	require.Equal(t, "0.5", *config.Model.Options.Temperature)
+	require.Equal(t, 256, *config.Model.Options.MaxTokens)
+	require.Equal(t, 40, *config.Model.Options.TopK)
+	require.Equal(t, "0.9", *config.Model.Options.TopP)
+	require.True(t, config.Logging.LogStatistics)
+	require.True(t, config.Logging.LogPayloads)
+}
+
+func TestAIPromptDecoratorPlugin(t *testing.T) {
+	aigateway := &v1alpha1.AIGateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "aigateway",
+			Namespace: "default",
+		},
+	}
+	defaultPrompts := []v1alpha1.LLMPrompt{
+		{Role: lo.ToPtr(v1alpha1.LLMPromptRoleSystem), Content: "You are a helpful assistant."},
+	}
+	appendedPrompts := []v1alpha1.LLMPrompt{
+		{Role: lo.ToPtr(v1alpha1.LLMPromptRoleUser), Content: "Answer in less than 50 words."},
+	}
+
+	t.Run("no prompts", func(t *testing.T) {
+		plugin, err := aiCloudGatewayToKongPromptDecoratorPlugin("chat", nil, nil, aigateway)
+		require.NoError(t, err)
+		require.Nil(t, plugin)
+	})
+
+	t.Run("default and appended prompts", func(t *testing.T) {
+		plugin, err := aiCloudGatewayToKongPromptDecoratorPlugin("chat", defaultPrompts, appendedPrompts, aigateway)
+		require.NoError(t, err)
+		require.Equal(t, "chat-ai-prompt-decorator", plugin.Name)
+
+		var config AICloudPromptDecoratorConfig
+		require.NoError(t, json.Unmarshal(plugin.Config.Raw, &config))
+		require.Equal(t, defaultPrompts, config.Prompts.Prepend)
+		require.Equal(t, appendedPrompts, config.Prompts.Append)
+	})
+
+	t.Run("appended prompts only", func(t *testing.T) {
+		plugin, err := aiCloudGatewayToKongPromptDecoratorPlugin("chat", nil, appendedPrompts, aigateway)
+		require.NoError(t, err)
+
+		var config AICloudPromptDecoratorConfig
+		require.NoError(t, json.Unmarshal(plugin.Config.Raw, &config))
+		require.Empty(t, config.Prompts.Prepend)
+		require.Equal(t, appendedPrompts, config.Prompts.Append)
+	})
+}
+
+func TestAICloudGatewayToKongAdvancedPlugin(t *testing.T) {
+	aigateway := &v1alpha1.AIGateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "aigateway",
+			Namespace: "default",
+		},
+	}
+	credentials := map[string][]byte{
+		"openai":    []byte("openai-token"),
+		"anthropic": []byte("anthropic-token"),
+	}
+
+	tests := []struct {
+		name              string
+		llm               *v1alpha1.CloudHostedLargeLanguageModel
+		credentials       map[string][]byte
+		expectedAlgorithm string
+		expectedRetries   int
+		expectedProviders []string
+		expectedWeights   []int
+		expectedErr       bool
+	}{
+		{
+			name: "weighted strategy is the default",
+			llm: &v1alpha1.CloudHostedLargeLanguageModel{
+				Identifier:      "chat",
+				Model:           lo.ToPtr("gpt-4"),
+				PromptType:      lo.ToPtr(v1alpha1.LLMPromptTypeChat),
+				AICloudProvider: v1alpha1.AICloudProvider{Name: v1alpha1.AICloudProviderOpenAI},
+				Backends: []v1alpha1.CloudHostedLLMBackend{
+					{
+						AICloudProvider: v1alpha1.AICloudProvider{Name: v1alpha1.AICloudProviderAnthropic},
+						Model:           lo.ToPtr("claude-2.1"),
+						Weight:          lo.ToPtr(50),
+					},
+				},
+			},
+			credentials:       credentials,
+			expectedAlgorithm: "round-robin",
+			expectedRetries:   1,
+			expectedProviders: []string{"openai", "anthropic"},
+			expectedWeights:   []int{100, 50},
+		},
+		{
+			name: "failover strategy prioritizes the backends in order",
+			llm: &v1alpha1.CloudHostedLargeLanguageModel{
+				Identifier:      "chat",
+				Model:           lo.ToPtr("gpt-4"),
+				PromptType:      lo.ToPtr(v1alpha1.LLMPromptTypeChat),
+				AICloudProvider: v1alpha1.AICloudProvider{Name: v1alpha1.AICloudProviderOpenAI},
+				Backends: []v1alpha1.CloudHostedLLMBackend{
+					{
+						AICloudProvider: v1alpha1.AICloudProvider{Name: v1alpha1.AICloudProviderAnthropic},
+						Model:           lo.ToPtr("claude-2.1"),
+					},
+					{
+						AICloudProvider: v1alpha1.AICloudProvider{Name: v1alpha1.AICloudProviderOpenAI},
+						Model:           lo.ToPtr("gpt-3.5-turbo"),
+					},
+				},
+				LoadBalancing: &v1alpha1.LLMLoadBalancing{
+					Strategy: v1alpha1.LLMLoadBalancingStrategyFailover,
+					Retries:  lo.ToPtr(5),
+				},
+			},
+			credentials:       credentials,
+			expectedAlgorithm: "priority",
+			expectedRetries:   5,
+			expectedProviders: []string{"openai", "anthropic", "openai"},
+			expectedWeights:   []int{3, 2, 1},
+		},
+		{
+			name: "missing backend credentials",
+			llm: &v1alpha1.CloudHostedLargeLanguageModel{
+				Identifier:      "chat",
+				AICloudProvider: v1alpha1.AICloudProvider{Name: v1alpha1.AICloudProviderOpenAI},
+				Backends: []v1alpha1.CloudHostedLLMBackend{
+					{AICloudProvider: v1alpha1.AICloudProvider{Name: v1alpha1.AICloudProviderAnthropic}},
+				},
+			},
+			credentials: map[string][]byte{"openai": []byte("openai-token")},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			plugin, err := aiCloudGatewayToKongAdvancedPlugin(tc.llm, aigateway, tc.credentials)
+			if tc.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "chat-ai-proxy", plugin.Name)
+			require.Equal(t, "ai-proxy-advanced", plugin.PluginName)
+
+			var config AIProxyAdvancedConfig
+			require.NoError(t, json.Unmarshal(plugin.Config.Raw, &config))
+			require.Equal(t, tc.expectedAlgorithm, config.Balancer.Algorithm)
+			require.Equal(t, tc.expectedRetries, *config.Balancer.Retries)
+			require.Len(t, config.Targets, len(tc.expectedProviders))
+			for i, target := range config.Targets {
+				require.Equal(t, tc.expectedProviders[i], *target.Model.Provider)
+				require.Equal(t, tc.expectedWeights[i], target.Weight)
+				require.Contains(t, *target.Auth.HeaderValue, string(tc.credentials[tc.expectedProviders[i]]))
+			}
+		})
+	}
+}
+
+func TestAISelfHostedToKongPlugin(t *testing.T) {
+	aigateway := &v1alpha1.AIGateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "aigateway",
+			Namespace: "default",
+		},
+	}
+
+	tests := []struct {
+		name                 string
+		llm                  *v1alpha1.SelfHostedLargeLanguageModel
+		expectedProvider     string
+		expectedRouteType    string
+		expectedUpstreamURL  string
+		expectedLlama2Format *string
+		expectedErr          bool
+	}{
+		{
+			name: "openai format with chat prompts uses the chat completions path",
+			llm: &v1alpha1.SelfHostedLargeLanguageModel{
+				Identifier: "mistral",
+				PromptType: lo.ToPtr(v1alpha1.LLMPromptTypeChat),
+				Format:     v1alpha1.SelfHostedLLMFormatOpenAI,
+				ServiceRef: v1alpha1.SelfHostedLLMServiceRef{Name: "mistral", Port: 8000},
+			},
+			expectedProvider:    "openai",
+			expectedRouteType:   aiProxyRouteTypeChat,
+			expectedUpstreamURL: "http://mistral.default.svc:8000/v1/chat/completions",
+		},
+		{
+			name: "unset format and prompt type default to openai completions",
+			llm: &v1alpha1.SelfHostedLargeLanguageModel{
+				Identifier: "mistral",
+				ServiceRef: v1alpha1.SelfHostedLLMServiceRef{Name: "mistral", Port: 8000},
+			},
+			expectedProvider:    "openai",
+			expectedRouteType:   aiProxyRouteTypeCompletions,
+			expectedUpstreamURL: "http://mistral.default.svc:8000/v1/completions",
+		},
+		{
+			name: "llama2 format with a custom path",
+			llm: &v1alpha1.SelfHostedLargeLanguageModel{
+				Identifier: "llama",
+				PromptType: lo.ToPtr(v1alpha1.LLMPromptTypeCompletion),
+				Format:     v1alpha1.SelfHostedLLMFormatLlama2,
+				ServiceRef: v1alpha1.SelfHostedLLMServiceRef{Name: "llama", Port: 8080, Path: lo.ToPtr("/completion")},
+			},
+			expectedProvider:     "llama2",
+			expectedRouteType:    aiProxyRouteTypeCompletions,
+			expectedUpstreamURL:  "http://llama.default.svc:8080/completion",
+			expectedLlama2Format: lo.ToPtr("raw"),
+		},
+		{
+			name: "unknown format is an error",
+			llm: &v1alpha1.SelfHostedLargeLanguageModel{
+				Identifier: "llama",
+				Format:     "unknown",
+				ServiceRef: v1alpha1.SelfHostedLLMServiceRef{Name: "llama", Port: 8080},
+			},
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			plugin, err := aiSelfHostedToKongPlugin(tt.llm, aigateway)
+			if tt.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.llm.Identifier+"-ai-proxy", plugin.Name)
+
+			var config AICloudProviderLLMConfig
+			require.NoError(t, json.Unmarshal(plugin.Config.Raw, &config))
+			require.Nil(t, config.Auth)
+			require.Equal(t, tt.expectedRouteType, *config.RouteType)
+			require.Equal(t, tt.expectedProvider, *config.Model.Provider)
+			require.Equal(t, tt.expectedUpstreamURL, *config.Model.Options.UpstreamURL)
+			require.Equal(t, tt.expectedLlama2Format, config.Model.Options.Llama2Format)
+		})
+	}
+}
+
+func TestAIGatewayConsumers(t *testing.T) {
+	aigateway := &v1alpha1.AIGateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "aigateway",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.AIGatewaySpec{
+			Consumers: []v1alpha1.AIGatewayConsumer{
+				{
+					Name: "devteam",
+					Quotas: []v1alpha1.AIGatewayConsumerQuota{
+						{Model: "gpt", Window: v1alpha1.AIGatewayQuotaWindowHour, Requests: lo.ToPtr(100), Tokens: lo.ToPtr(10000)},
+						{Model: "llama", Requests: lo.ToPtr(10)},
+					},
+				},
+				{
+					Name: "marketing",
+				},
+			},
+		},
+	}
+
+	t.Run("quota plugins", func(t *testing.T) {
+		plugins, err := aiGatewayToQuotaPlugins(aigateway, "gpt", []string{"openai"})
+		require.NoError(t, err)
+		require.Len(t, plugins, 2)
+
+		require.Equal(t, "gpt-devteam-rate-limiting", plugins[0].Name)
+		require.Equal(t, "rate-limiting", plugins[0].PluginName)
+		var requestsConfig RateLimitingConfig
+		require.NoError(t, json.Unmarshal(plugins[0].Config.Raw, &requestsConfig))
+		require.Equal(t, RateLimitingConfig{Hour: lo.ToPtr(100), LimitBy: "consumer", Policy: "local"}, requestsConfig)
+
+		require.Equal(t, "gpt-devteam-ai-rate-limiting", plugins[1].Name)
+		require.Equal(t, "ai-rate-limiting-advanced", plugins[1].PluginName)
+		var tokensConfig AIRateLimitingAdvancedConfig
+		require.NoError(t, json.Unmarshal(plugins[1].Config.Raw, &tokensConfig))
+		require.Equal(t, []AIRateLimitingAdvancedProviderLimit{
+			{Name: "openai", Limit: []int{10000}, WindowSize: []int{3600}},
+		}, tokensConfig.LLMProviders)
+
+		plugins, err = aiGatewayToQuotaPlugins(aigateway, "claude", []string{"anthropic"})
+		require.NoError(t, err)
+		require.Empty(t, plugins)
+	})
+
+	t.Run("kong consumers", func(t *testing.T) {
+		kongConsumer := aiGatewayConsumerToKongConsumer(aigateway, &aigateway.Spec.Consumers[0])
+		require.Equal(t, "aigateway-devteam", kongConsumer.Name)
+		require.Equal(t, "aigateway-devteam", kongConsumer.Username)
+		require.Equal(t, []string{"aigateway-devteam-credentials"}, kongConsumer.Credentials)
+		require.Equal(t, map[string]string{
+			"kubernetes.io/ingress.class": AIGatewayConsumerIngressClass,
+			"konghq.com/plugins":          "gpt-devteam-rate-limiting,gpt-devteam-ai-rate-limiting,llama-devteam-rate-limiting",
+		}, kongConsumer.Annotations)
+
+		kongConsumer = aiGatewayConsumerToKongConsumer(aigateway, &aigateway.Spec.Consumers[1])
+		require.NotContains(t, kongConsumer.Annotations, "konghq.com/plugins")
+	})
+
+	t.Run("credential secrets", func(t *testing.T) {
+		secret, err := aiGatewayConsumerToCredentialSecret(aigateway, &aigateway.Spec.Consumers[0])
+		require.NoError(t, err)
+		require.Equal(t, "aigateway-devteam-credentials", secret.Name)
+		require.Equal(t, "key-auth", secret.Labels["konghq.com/credential"])
+		require.Len(t, secret.Data["key"], 64)
+	})
+}
+
+func TestAIModelCatalogEntryToLLM(t *testing.T) {
+	entry := &v1alpha1.AIModelCatalogEntry{
+		Name:       "gpt-4-sherlock",
+		Model:      lo.ToPtr("gpt-4"),
+		PromptType: lo.ToPtr(v1alpha1.LLMPromptTypeChat),
+		DefaultPrompts: []v1alpha1.LLMPrompt{
+			{Role: lo.ToPtr(v1alpha1.LLMPromptRoleSystem), Content: "You are Sherlock Holmes."},
+		},
+		DefaultPromptParams: &v1alpha1.LLMPromptParams{MaxTokens: lo.ToPtr(50)},
+		AICloudProvider:     v1alpha1.AICloudProvider{Name: v1alpha1.AICloudProviderOpenAI},
+	}
+	ref := v1alpha1.AIModelCatalogModelRef{
+		Identifier:  "devteam-chatgpt",
+		CatalogName: "acme-models",
+		ModelName:   "gpt-4-sherlock",
+	}
+
+	llm := aiModelCatalogEntryToLLM(ref, entry)
+	require.Equal(t, v1alpha1.CloudHostedLargeLanguageModel{
+		Identifier:          "devteam-chatgpt",
+		Model:               entry.Model,
+		PromptType:          entry.PromptType,
+		DefaultPrompts:      entry.DefaultPrompts,
+		DefaultPromptParams: entry.DefaultPromptParams,
+		AICloudProvider:     entry.AICloudProvider,
+	}, llm)
+	require.Equal(t, []string{"openai"}, aiCloudGatewayProviderNames(&llm))
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"reflect"
 
+	"github.com/samber/lo"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -76,3 +77,39 @@ func (r *AIGatewayReconciler) listAIGatewaysForGatewayClass(ctx context.Context,
 
 	return
 }
+
+func (r *AIGatewayReconciler) listAIGatewaysForAIModelCatalog(ctx context.Context, obj client.Object) (recs []reconcile.Request) {
+	catalog, ok := obj.(*v1alpha1.AIModelCatalog)
+	if !ok {
+		log.FromContext(ctx).Error(
+			operatorerrors.ErrUnexpectedObject,
+			"failed to run map funcs",
+			"expected", "AIModelCatalog", "found", reflect.TypeOf(obj),
+		)
+		return
+	}
+
+	aigateways := new(v1alpha1.AIGatewayList)
+	if err := r.Client.List(ctx, aigateways, client.InNamespace(catalog.Namespace)); err != nil {
+		log.FromContext(ctx).Error(err, "could not list aigateways in map func")
+		return
+	}
+
+	for _, aigateway := range aigateways.Items {
+		if aigateway.Spec.LargeLanguageModels == nil {
+			continue
+		}
+		if lo.ContainsBy(aigateway.Spec.LargeLanguageModels.Catalog, func(ref v1alpha1.AIModelCatalogModelRef) bool {
+			return ref.CatalogName == catalog.Name
+		}) {
+			recs = append(recs, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: aigateway.Namespace,
+					Name:      aigateway.Name,
+				},
+			})
+		}
+	}
+
+	return
+}
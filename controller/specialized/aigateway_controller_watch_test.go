@@ -0,0 +1,56 @@
+package specialized
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kong/gateway-operator/api/v1alpha1"
+	"github.com/kong/gateway-operator/modules/manager/scheme"
+)
+
+func TestListAIGatewaysForAIModelCatalog(t *testing.T) {
+	aigateway := func(name, namespace, catalogName string) *v1alpha1.AIGateway {
+		return &v1alpha1.AIGateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: v1alpha1.AIGatewaySpec{
+				LargeLanguageModels: &v1alpha1.LargeLanguageModels{
+					Catalog: []v1alpha1.AIModelCatalogModelRef{
+						{Identifier: "gpt", CatalogName: catalogName, ModelName: "gpt-4"},
+					},
+				},
+			},
+		}
+	}
+	catalog := &v1alpha1.AIModelCatalog{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "acme-models",
+			Namespace: "default",
+		},
+	}
+
+	cl := fakectrlruntimeclient.NewClientBuilder().
+		WithScheme(scheme.Get()).
+		WithObjects(
+			catalog,
+			aigateway("referencing", "default", "acme-models"),
+			aigateway("other-catalog", "default", "other-models"),
+			aigateway("other-namespace", "other", "acme-models"),
+			&v1alpha1.AIGateway{ObjectMeta: metav1.ObjectMeta{Name: "no-llms", Namespace: "default"}},
+		).
+		Build()
+	r := &AIGatewayReconciler{Client: cl}
+
+	require.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "default", Name: "referencing"}},
+	}, r.listAIGatewaysForAIModelCatalog(context.Background(), catalog))
+	require.Empty(t, r.listAIGatewaysForAIModelCatalog(context.Background(), &v1alpha1.AIGateway{}))
+}
@@ -21,6 +21,8 @@ require (
 	github.com/kong/kubernetes-testing-framework v0.47.0
 	github.com/kong/semver/v4 v4.0.1
 	github.com/kr/pretty v0.3.1
+	github.com/prometheus/client_model v0.6.0
+	github.com/prometheus/common v0.52.3
 	github.com/samber/lo v1.39.0
 	github.com/stretchr/testify v1.9.0
 	k8s.io/api v0.30.1
@@ -163,8 +165,6 @@ require (
 	github.com/pkg/errors v0.9.1 // indirect
 	github.com/pmezard/go-difflib v1.0.1-0.20181226105442-5d4384ee4fb2 // indirect
 	github.com/prometheus/client_golang v1.19.0 // indirect
-	github.com/prometheus/client_model v0.6.0 // indirect
-	github.com/prometheus/common v0.52.3 // indirect
 	github.com/prometheus/procfs v0.12.0 // indirect
 	github.com/sirupsen/logrus v1.9.3 // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
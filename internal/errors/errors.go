@@ -33,6 +33,10 @@ var ErrTooManyDataPlaneNetworkPolicies = errors.New("too many data plane network
 // .spec.ParametersRef field of the given object is nil
 var ErrObjectMissingParametersRef = errors.New("no reference to related objects")
 
+// ErrInvalidParametersRef is a custom error that must be used when the
+// .spec.ParametersRef field of the given object is set but incomplete.
+var ErrInvalidParametersRef = errors.New("invalid ParametersRef")
+
 // -----------------------------------------------------------------------------
 // ControlPlane - Errors
 // -----------------------------------------------------------------------------
@@ -2,6 +2,7 @@ package types
 
 import (
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
 type (
@@ -13,6 +14,12 @@ type (
 	HTTPRoute            = gatewayv1.HTTPRoute
 	HTTPRouteSpec        = gatewayv1.HTTPRouteSpec
 	HTTPRouteList        = gatewayv1.HTTPRouteList
+	TCPRoute             = gatewayv1alpha2.TCPRoute
+	TCPRouteList         = gatewayv1alpha2.TCPRouteList
+	UDPRoute             = gatewayv1alpha2.UDPRoute
+	UDPRouteList         = gatewayv1alpha2.UDPRouteList
+	TLSRoute             = gatewayv1alpha2.TLSRoute
+	TLSRouteList         = gatewayv1alpha2.TLSRouteList
 	ParentReference      = gatewayv1.ParentReference
 	CommonRouteSpec      = gatewayv1.CommonRouteSpec
 	Kind                 = gatewayv1.Kind
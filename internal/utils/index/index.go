@@ -8,6 +8,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	operatorv1alpha1 "github.com/kong/gateway-operator/api/v1alpha1"
 	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
 )
 
@@ -37,3 +38,43 @@ func DataPlaneNameOnControlPlane(ctx context.Context, c cache.Cache) error {
 		return []string{}
 	})
 }
+
+const (
+	// DataPlaneMetricsExtensionIndex is the key to be used to access the
+	// DataPlaneMetricsExtensions referenced in ControlPlanes' .spec.extensions.
+	DataPlaneMetricsExtensionIndex = "dataplanemetricsextension"
+)
+
+// DataPlaneMetricsExtensionOnControlPlane indexes the ControlPlane .spec.extensions
+// DataPlaneMetricsExtension references on the "dataplanemetricsextension" key.
+// Indexed values are in the "namespace/name" form where the namespace defaults
+// to the ControlPlane's namespace when not set in the reference.
+func DataPlaneMetricsExtensionOnControlPlane(ctx context.Context, c cache.Cache) error {
+	if _, err := c.GetInformer(ctx, &operatorv1beta1.ControlPlane{}); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get informer for v1beta1 ControlPlane: %w, disabling indexing DataPlaneMetricsExtensions for ControlPlanes' .spec.extensions", err)
+	}
+
+	return c.IndexField(ctx, &operatorv1beta1.ControlPlane{}, DataPlaneMetricsExtensionIndex, func(o client.Object) []string {
+		controlPlane, ok := o.(*operatorv1beta1.ControlPlane)
+		if !ok {
+			return []string{}
+		}
+
+		var ret []string
+		for _, ext := range controlPlane.Spec.Extensions {
+			if ext.Group != operatorv1alpha1.SchemeGroupVersion.Group ||
+				ext.Kind != operatorv1alpha1.DataPlaneMetricsExtensionKind {
+				continue
+			}
+			namespace := controlPlane.Namespace
+			if ext.Namespace != nil && *ext.Namespace != "" {
+				namespace = *ext.Namespace
+			}
+			ret = append(ret, namespace+"/"+ext.Name)
+		}
+		return ret
+	})
+}
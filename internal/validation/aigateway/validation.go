@@ -0,0 +1,230 @@
+package aigateway
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/samber/lo"
+
+	operatorv1alpha1 "github.com/kong/gateway-operator/api/v1alpha1"
+)
+
+// supportedAICloudProviders is the list of the AI cloud providers supported
+// by the AIGateway controller.
+var supportedAICloudProviders = []operatorv1alpha1.AICloudProviderName{
+	operatorv1alpha1.AICloudProviderOpenAI,
+	operatorv1alpha1.AICloudProviderAzure,
+	operatorv1alpha1.AICloudProviderCohere,
+	operatorv1alpha1.AICloudProviderMistral,
+	operatorv1alpha1.AICloudProviderAnthropic,
+}
+
+// supportedLLMLoadBalancingStrategies is the list of the strategies supported
+// to spread the requests for an LLM across its backends.
+var supportedLLMLoadBalancingStrategies = []operatorv1alpha1.LLMLoadBalancingStrategy{
+	operatorv1alpha1.LLMLoadBalancingStrategyWeighted,
+	operatorv1alpha1.LLMLoadBalancingStrategyFailover,
+}
+
+// supportedQuotaWindows is the list of the time windows supported for
+// consumers' quotas.
+var supportedQuotaWindows = []operatorv1alpha1.AIGatewayQuotaWindow{
+	operatorv1alpha1.AIGatewayQuotaWindowMinute,
+	operatorv1alpha1.AIGatewayQuotaWindowHour,
+	operatorv1alpha1.AIGatewayQuotaWindowDay,
+}
+
+// supportedSelfHostedLLMFormats is the list of the upstream API formats
+// supported for self hosted LLMs.
+var supportedSelfHostedLLMFormats = []operatorv1alpha1.SelfHostedLLMFormat{
+	operatorv1alpha1.SelfHostedLLMFormatOpenAI,
+	operatorv1alpha1.SelfHostedLLMFormatLlama2,
+}
+
+// Validator validates AIGateway objects.
+type Validator struct{}
+
+// NewValidator creates an AIGateway validator.
+func NewValidator() *Validator {
+	return &Validator{}
+}
+
+// Validate validates an AIGateway object and return the first validation error found.
+func (v *Validator) Validate(aigateway *operatorv1alpha1.AIGateway) error {
+	if aigateway.Spec.LargeLanguageModels == nil {
+		return errors.New("AIGateway requires largeLanguageModels")
+	}
+
+	llms := aigateway.Spec.LargeLanguageModels
+	if len(llms.CloudHosted) == 0 && len(llms.SelfHosted) == 0 && len(llms.Catalog) == 0 {
+		return errors.New("AIGateway requires at least one cloud hosted, self hosted or catalog LLM")
+	}
+
+	if err := v.ValidateCloudHostedLargeLanguageModels(llms.CloudHosted); err != nil {
+		return err
+	}
+	if err := v.ValidateSelfHostedLargeLanguageModels(llms.SelfHosted); err != nil {
+		return err
+	}
+	if err := v.ValidateCatalogLargeLanguageModels(llms.Catalog); err != nil {
+		return err
+	}
+
+	for _, model := range llms.SelfHosted {
+		if lo.ContainsBy(llms.CloudHosted, func(m operatorv1alpha1.CloudHostedLargeLanguageModel) bool {
+			return m.Identifier == model.Identifier
+		}) {
+			return fmt.Errorf("LLM identifier %q is used by both a cloud hosted and a self hosted LLM", model.Identifier)
+		}
+	}
+
+	identifiers := make([]string, 0, len(llms.CloudHosted)+len(llms.SelfHosted)+len(llms.Catalog))
+	for _, model := range llms.CloudHosted {
+		identifiers = append(identifiers, model.Identifier)
+	}
+	for _, model := range llms.SelfHosted {
+		identifiers = append(identifiers, model.Identifier)
+	}
+	for _, model := range llms.Catalog {
+		if lo.Contains(identifiers, model.Identifier) {
+			return fmt.Errorf("LLM identifier %q is used by both a catalog LLM and a cloud hosted or self hosted LLM", model.Identifier)
+		}
+	}
+	for _, model := range llms.Catalog {
+		identifiers = append(identifiers, model.Identifier)
+	}
+	return v.ValidateConsumers(aigateway.Spec.Consumers, identifiers)
+}
+
+// ValidateCloudHostedLargeLanguageModels validates the cloud hosted LLMs of an AIGateway.
+// The models' identifiers have to be unique as they're used to route the
+// requests to the models and their providers, including the providers of
+// their additional backends, have to be supported.
+func (v *Validator) ValidateCloudHostedLargeLanguageModels(models []operatorv1alpha1.CloudHostedLargeLanguageModel) error {
+	identifiers := make(map[string]struct{}, len(models))
+	for _, model := range models {
+		if model.Identifier == "" {
+			return errors.New("cloud hosted LLM identifier cannot be empty")
+		}
+		if _, ok := identifiers[model.Identifier]; ok {
+			return fmt.Errorf("duplicate cloud hosted LLM identifier %q", model.Identifier)
+		}
+		identifiers[model.Identifier] = struct{}{}
+
+		if !lo.Contains(supportedAICloudProviders, model.AICloudProvider.Name) {
+			return fmt.Errorf("cloud hosted LLM %q uses unknown AI cloud provider %q", model.Identifier, model.AICloudProvider.Name)
+		}
+
+		for _, backend := range model.Backends {
+			if !lo.Contains(supportedAICloudProviders, backend.AICloudProvider.Name) {
+				return fmt.Errorf("cloud hosted LLM %q has a backend with unknown AI cloud provider %q", model.Identifier, backend.AICloudProvider.Name)
+			}
+			if backend.Weight != nil && (*backend.Weight < 1 || *backend.Weight > 65535) {
+				return fmt.Errorf("cloud hosted LLM %q has a backend with invalid weight %d", model.Identifier, *backend.Weight)
+			}
+		}
+		if lb := model.LoadBalancing; lb != nil {
+			if lb.Strategy != "" && !lo.Contains(supportedLLMLoadBalancingStrategies, lb.Strategy) {
+				return fmt.Errorf("cloud hosted LLM %q uses unknown load balancing strategy %q", model.Identifier, lb.Strategy)
+			}
+			if lb.PrimaryWeight != nil && (*lb.PrimaryWeight < 1 || *lb.PrimaryWeight > 65535) {
+				return fmt.Errorf("cloud hosted LLM %q uses invalid primary weight %d", model.Identifier, *lb.PrimaryWeight)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateSelfHostedLargeLanguageModels validates the self hosted LLMs of an AIGateway.
+// The models' identifiers have to be unique, their upstream formats have to be
+// supported and they have to reference a Service port.
+func (v *Validator) ValidateSelfHostedLargeLanguageModels(models []operatorv1alpha1.SelfHostedLargeLanguageModel) error {
+	identifiers := make(map[string]struct{}, len(models))
+	for _, model := range models {
+		if model.Identifier == "" {
+			return errors.New("self hosted LLM identifier cannot be empty")
+		}
+		if _, ok := identifiers[model.Identifier]; ok {
+			return fmt.Errorf("duplicate self hosted LLM identifier %q", model.Identifier)
+		}
+		identifiers[model.Identifier] = struct{}{}
+
+		if model.Format != "" && !lo.Contains(supportedSelfHostedLLMFormats, model.Format) {
+			return fmt.Errorf("self hosted LLM %q uses unknown format %q", model.Identifier, model.Format)
+		}
+		if model.ServiceRef.Name == "" {
+			return fmt.Errorf("self hosted LLM %q requires a Service name", model.Identifier)
+		}
+		if model.ServiceRef.Port < 1 || model.ServiceRef.Port > 65535 {
+			return fmt.Errorf("self hosted LLM %q uses invalid Service port %d", model.Identifier, model.ServiceRef.Port)
+		}
+	}
+
+	return nil
+}
+
+// ValidateCatalogLargeLanguageModels validates the LLMs an AIGateway references
+// from AIModelCatalogs. The models' identifiers have to be unique and they have
+// to reference both a catalog and a model within it. Whether the referenced
+// models exist is only known when the AIGateway is reconciled.
+func (v *Validator) ValidateCatalogLargeLanguageModels(models []operatorv1alpha1.AIModelCatalogModelRef) error {
+	identifiers := make(map[string]struct{}, len(models))
+	for _, model := range models {
+		if model.Identifier == "" {
+			return errors.New("catalog LLM identifier cannot be empty")
+		}
+		if _, ok := identifiers[model.Identifier]; ok {
+			return fmt.Errorf("duplicate catalog LLM identifier %q", model.Identifier)
+		}
+		identifiers[model.Identifier] = struct{}{}
+
+		if model.CatalogName == "" {
+			return fmt.Errorf("catalog LLM %q requires an AIModelCatalog name", model.Identifier)
+		}
+		if model.ModelName == "" {
+			return fmt.Errorf("catalog LLM %q requires a model name", model.Identifier)
+		}
+	}
+
+	return nil
+}
+
+// ValidateConsumers validates the consumers of an AIGateway. The consumers'
+// names have to be unique and their quotas have to reference the identifiers
+// of the AIGateway's LLMs, at most once per consumer.
+func (v *Validator) ValidateConsumers(consumers []operatorv1alpha1.AIGatewayConsumer, identifiers []string) error {
+	names := make(map[string]struct{}, len(consumers))
+	for _, consumer := range consumers {
+		if consumer.Name == "" {
+			return errors.New("consumer name cannot be empty")
+		}
+		if _, ok := names[consumer.Name]; ok {
+			return fmt.Errorf("duplicate consumer name %q", consumer.Name)
+		}
+		names[consumer.Name] = struct{}{}
+
+		models := make(map[string]struct{}, len(consumer.Quotas))
+		for _, quota := range consumer.Quotas {
+			if !lo.Contains(identifiers, quota.Model) {
+				return fmt.Errorf("consumer %q has a quota for unknown LLM %q", consumer.Name, quota.Model)
+			}
+			if _, ok := models[quota.Model]; ok {
+				return fmt.Errorf("consumer %q has multiple quotas for LLM %q", consumer.Name, quota.Model)
+			}
+			models[quota.Model] = struct{}{}
+
+			if quota.Window != "" && !lo.Contains(supportedQuotaWindows, quota.Window) {
+				return fmt.Errorf("consumer %q uses unknown quota window %q for LLM %q", consumer.Name, quota.Window, quota.Model)
+			}
+			if quota.Requests == nil && quota.Tokens == nil {
+				return fmt.Errorf("consumer %q quota for LLM %q requires requests or tokens", consumer.Name, quota.Model)
+			}
+			if (quota.Requests != nil && *quota.Requests < 1) || (quota.Tokens != nil && *quota.Tokens < 1) {
+				return fmt.Errorf("consumer %q quota for LLM %q has to be positive", consumer.Name, quota.Model)
+			}
+		}
+	}
+
+	return nil
+}
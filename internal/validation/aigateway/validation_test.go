@@ -0,0 +1,373 @@
+package aigateway
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	operatorv1alpha1 "github.com/kong/gateway-operator/api/v1alpha1"
+)
+
+func TestValidator_ValidateCloudHostedLargeLanguageModels(t *testing.T) {
+	tests := []struct {
+		name    string
+		models  []operatorv1alpha1.CloudHostedLargeLanguageModel
+		wantErr string
+	}{
+		{
+			name: "unique identifiers with supported providers work",
+			models: []operatorv1alpha1.CloudHostedLargeLanguageModel{
+				{
+					Identifier:      "devteam-gpt",
+					AICloudProvider: operatorv1alpha1.AICloudProvider{Name: operatorv1alpha1.AICloudProviderOpenAI},
+				},
+				{
+					Identifier:      "devteam-command",
+					AICloudProvider: operatorv1alpha1.AICloudProvider{Name: operatorv1alpha1.AICloudProviderCohere},
+				},
+			},
+		},
+		{
+			name: "empty identifier is an error",
+			models: []operatorv1alpha1.CloudHostedLargeLanguageModel{
+				{
+					AICloudProvider: operatorv1alpha1.AICloudProvider{Name: operatorv1alpha1.AICloudProviderOpenAI},
+				},
+			},
+			wantErr: "cloud hosted LLM identifier cannot be empty",
+		},
+		{
+			name: "duplicate identifier is an error",
+			models: []operatorv1alpha1.CloudHostedLargeLanguageModel{
+				{
+					Identifier:      "devteam-gpt",
+					AICloudProvider: operatorv1alpha1.AICloudProvider{Name: operatorv1alpha1.AICloudProviderOpenAI},
+				},
+				{
+					Identifier:      "devteam-gpt",
+					AICloudProvider: operatorv1alpha1.AICloudProvider{Name: operatorv1alpha1.AICloudProviderAzure},
+				},
+			},
+			wantErr: `duplicate cloud hosted LLM identifier "devteam-gpt"`,
+		},
+		{
+			name: "unknown provider is an error",
+			models: []operatorv1alpha1.CloudHostedLargeLanguageModel{
+				{
+					Identifier:      "devteam-llm",
+					AICloudProvider: operatorv1alpha1.AICloudProvider{Name: "unknown"},
+				},
+			},
+			wantErr: `cloud hosted LLM "devteam-llm" uses unknown AI cloud provider "unknown"`,
+		},
+		{
+			name: "backends with supported providers work",
+			models: []operatorv1alpha1.CloudHostedLargeLanguageModel{
+				{
+					Identifier:      "devteam-chat",
+					AICloudProvider: operatorv1alpha1.AICloudProvider{Name: operatorv1alpha1.AICloudProviderOpenAI},
+					Backends: []operatorv1alpha1.CloudHostedLLMBackend{
+						{AICloudProvider: operatorv1alpha1.AICloudProvider{Name: operatorv1alpha1.AICloudProviderAzure}, Weight: lo.ToPtr(50)},
+						{AICloudProvider: operatorv1alpha1.AICloudProvider{Name: operatorv1alpha1.AICloudProviderAnthropic}},
+					},
+					LoadBalancing: &operatorv1alpha1.LLMLoadBalancing{Strategy: operatorv1alpha1.LLMLoadBalancingStrategyFailover},
+				},
+			},
+		},
+		{
+			name: "backend with unknown provider is an error",
+			models: []operatorv1alpha1.CloudHostedLargeLanguageModel{
+				{
+					Identifier:      "devteam-chat",
+					AICloudProvider: operatorv1alpha1.AICloudProvider{Name: operatorv1alpha1.AICloudProviderOpenAI},
+					Backends: []operatorv1alpha1.CloudHostedLLMBackend{
+						{AICloudProvider: operatorv1alpha1.AICloudProvider{Name: "unknown"}},
+					},
+				},
+			},
+			wantErr: `cloud hosted LLM "devteam-chat" has a backend with unknown AI cloud provider "unknown"`,
+		},
+		{
+			name: "unknown load balancing strategy is an error",
+			models: []operatorv1alpha1.CloudHostedLargeLanguageModel{
+				{
+					Identifier:      "devteam-chat",
+					AICloudProvider: operatorv1alpha1.AICloudProvider{Name: operatorv1alpha1.AICloudProviderOpenAI},
+					LoadBalancing:   &operatorv1alpha1.LLMLoadBalancing{Strategy: "random"},
+				},
+			},
+			wantErr: `cloud hosted LLM "devteam-chat" uses unknown load balancing strategy "random"`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewValidator().ValidateCloudHostedLargeLanguageModels(tt.models)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidator_ValidateSelfHostedLargeLanguageModels(t *testing.T) {
+	tests := []struct {
+		name    string
+		models  []operatorv1alpha1.SelfHostedLargeLanguageModel
+		wantErr string
+	}{
+		{
+			name: "unique identifiers with supported formats work",
+			models: []operatorv1alpha1.SelfHostedLargeLanguageModel{
+				{
+					Identifier: "devteam-llama",
+					Format:     operatorv1alpha1.SelfHostedLLMFormatLlama2,
+					ServiceRef: operatorv1alpha1.SelfHostedLLMServiceRef{Name: "llama", Port: 8080},
+				},
+				{
+					Identifier: "devteam-mistral",
+					ServiceRef: operatorv1alpha1.SelfHostedLLMServiceRef{Name: "mistral", Port: 80},
+				},
+			},
+		},
+		{
+			name: "duplicate identifier is an error",
+			models: []operatorv1alpha1.SelfHostedLargeLanguageModel{
+				{
+					Identifier: "devteam-llama",
+					ServiceRef: operatorv1alpha1.SelfHostedLLMServiceRef{Name: "llama", Port: 8080},
+				},
+				{
+					Identifier: "devteam-llama",
+					ServiceRef: operatorv1alpha1.SelfHostedLLMServiceRef{Name: "llama-2", Port: 8080},
+				},
+			},
+			wantErr: `duplicate self hosted LLM identifier "devteam-llama"`,
+		},
+		{
+			name: "unknown format is an error",
+			models: []operatorv1alpha1.SelfHostedLargeLanguageModel{
+				{
+					Identifier: "devteam-llama",
+					Format:     "unknown",
+					ServiceRef: operatorv1alpha1.SelfHostedLLMServiceRef{Name: "llama", Port: 8080},
+				},
+			},
+			wantErr: `self hosted LLM "devteam-llama" uses unknown format "unknown"`,
+		},
+		{
+			name: "missing Service port is an error",
+			models: []operatorv1alpha1.SelfHostedLargeLanguageModel{
+				{
+					Identifier: "devteam-llama",
+					ServiceRef: operatorv1alpha1.SelfHostedLLMServiceRef{Name: "llama"},
+				},
+			},
+			wantErr: `self hosted LLM "devteam-llama" uses invalid Service port 0`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewValidator().ValidateSelfHostedLargeLanguageModels(tt.models)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidator_Validate(t *testing.T) {
+	credentials := &operatorv1alpha1.AICloudProviderAPITokenRef{Name: "ai-credentials"}
+	cloudHosted := []operatorv1alpha1.CloudHostedLargeLanguageModel{
+		{
+			Identifier:      "devteam-claude",
+			AICloudProvider: operatorv1alpha1.AICloudProvider{Name: operatorv1alpha1.AICloudProviderAnthropic},
+		},
+	}
+	selfHosted := []operatorv1alpha1.SelfHostedLargeLanguageModel{
+		{
+			Identifier: "devteam-llama",
+			ServiceRef: operatorv1alpha1.SelfHostedLLMServiceRef{Name: "llama", Port: 8080},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		spec    operatorv1alpha1.AIGatewaySpec
+		wantErr string
+	}{
+		{
+			name: "cloud hosted and self hosted LLMs work",
+			spec: operatorv1alpha1.AIGatewaySpec{
+				LargeLanguageModels: &operatorv1alpha1.LargeLanguageModels{
+					CloudHosted: cloudHosted,
+					SelfHosted:  selfHosted,
+				},
+				CloudProviderCredentials: credentials,
+			},
+		},
+		{
+			name: "self hosted LLMs work without credentials",
+			spec: operatorv1alpha1.AIGatewaySpec{
+				LargeLanguageModels: &operatorv1alpha1.LargeLanguageModels{
+					SelfHosted: selfHosted,
+				},
+			},
+		},
+		{
+			name: "no LLMs is an error",
+			spec: operatorv1alpha1.AIGatewaySpec{
+				LargeLanguageModels: &operatorv1alpha1.LargeLanguageModels{},
+			},
+			wantErr: "AIGateway requires at least one cloud hosted, self hosted or catalog LLM",
+		},
+		{
+			name: "identifier shared between cloud hosted and self hosted LLMs is an error",
+			spec: operatorv1alpha1.AIGatewaySpec{
+				LargeLanguageModels: &operatorv1alpha1.LargeLanguageModels{
+					CloudHosted: cloudHosted,
+					SelfHosted: []operatorv1alpha1.SelfHostedLargeLanguageModel{
+						{
+							Identifier: "devteam-claude",
+							ServiceRef: operatorv1alpha1.SelfHostedLLMServiceRef{Name: "llama", Port: 8080},
+						},
+					},
+				},
+				CloudProviderCredentials: credentials,
+			},
+			wantErr: `LLM identifier "devteam-claude" is used by both a cloud hosted and a self hosted LLM`,
+		},
+		{
+			name: "catalog LLMs work without credentials",
+			spec: operatorv1alpha1.AIGatewaySpec{
+				LargeLanguageModels: &operatorv1alpha1.LargeLanguageModels{
+					Catalog: []operatorv1alpha1.AIModelCatalogModelRef{
+						{Identifier: "devteam-gpt", CatalogName: "shared-models", ModelName: "gpt-4"},
+					},
+				},
+			},
+		},
+		{
+			name: "identifier shared between catalog and self hosted LLMs is an error",
+			spec: operatorv1alpha1.AIGatewaySpec{
+				LargeLanguageModels: &operatorv1alpha1.LargeLanguageModels{
+					SelfHosted: selfHosted,
+					Catalog: []operatorv1alpha1.AIModelCatalogModelRef{
+						{Identifier: "devteam-llama", CatalogName: "shared-models", ModelName: "llama2"},
+					},
+				},
+			},
+			wantErr: `LLM identifier "devteam-llama" is used by both a catalog LLM and a cloud hosted or self hosted LLM`,
+		},
+		{
+			name: "catalog LLM without a model name is an error",
+			spec: operatorv1alpha1.AIGatewaySpec{
+				LargeLanguageModels: &operatorv1alpha1.LargeLanguageModels{
+					Catalog: []operatorv1alpha1.AIModelCatalogModelRef{
+						{Identifier: "devteam-gpt", CatalogName: "shared-models"},
+					},
+				},
+			},
+			wantErr: `catalog LLM "devteam-gpt" requires a model name`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewValidator().Validate(&operatorv1alpha1.AIGateway{Spec: tt.spec})
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidator_ValidateConsumers(t *testing.T) {
+	identifiers := []string{"devteam-gpt", "devteam-llama"}
+
+	tests := []struct {
+		name      string
+		consumers []operatorv1alpha1.AIGatewayConsumer
+		wantErr   string
+	}{
+		{
+			name: "consumers with quotas for known LLMs work",
+			consumers: []operatorv1alpha1.AIGatewayConsumer{
+				{
+					Name: "devteam",
+					Quotas: []operatorv1alpha1.AIGatewayConsumerQuota{
+						{Model: "devteam-gpt", Requests: lo.ToPtr(100)},
+						{Model: "devteam-llama", Window: operatorv1alpha1.AIGatewayQuotaWindowDay, Tokens: lo.ToPtr(10000)},
+					},
+				},
+				{
+					Name: "marketing",
+				},
+			},
+		},
+		{
+			name: "duplicate consumer name is an error",
+			consumers: []operatorv1alpha1.AIGatewayConsumer{
+				{Name: "devteam"},
+				{Name: "devteam"},
+			},
+			wantErr: `duplicate consumer name "devteam"`,
+		},
+		{
+			name: "quota for unknown LLM is an error",
+			consumers: []operatorv1alpha1.AIGatewayConsumer{
+				{
+					Name: "devteam",
+					Quotas: []operatorv1alpha1.AIGatewayConsumerQuota{
+						{Model: "devteam-claude", Requests: lo.ToPtr(100)},
+					},
+				},
+			},
+			wantErr: `consumer "devteam" has a quota for unknown LLM "devteam-claude"`,
+		},
+		{
+			name: "multiple quotas for the same LLM is an error",
+			consumers: []operatorv1alpha1.AIGatewayConsumer{
+				{
+					Name: "devteam",
+					Quotas: []operatorv1alpha1.AIGatewayConsumerQuota{
+						{Model: "devteam-gpt", Requests: lo.ToPtr(100)},
+						{Model: "devteam-gpt", Tokens: lo.ToPtr(100)},
+					},
+				},
+			},
+			wantErr: `consumer "devteam" has multiple quotas for LLM "devteam-gpt"`,
+		},
+		{
+			name: "quota without requests or tokens is an error",
+			consumers: []operatorv1alpha1.AIGatewayConsumer{
+				{
+					Name: "devteam",
+					Quotas: []operatorv1alpha1.AIGatewayConsumerQuota{
+						{Model: "devteam-gpt"},
+					},
+				},
+			},
+			wantErr: `consumer "devteam" quota for LLM "devteam-gpt" requires requests or tokens`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewValidator().ValidateConsumers(tt.consumers, identifiers)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -41,7 +41,7 @@ func (v *Validator) Validate(dataplane *operatorv1beta1.DataPlane) error {
 		return err
 	}
 
-	if err := v.ValidateDataPlaneDeploymentRollout(dataplane.Spec.Deployment.Rollout); err != nil {
+	if err := v.ValidateDataPlaneDeploymentRollout(dataplane.Spec.Deployment.Rollout, dataplane.Spec.Deployment.Scaling); err != nil {
 		return err
 	}
 
@@ -57,7 +57,7 @@ func (v *Validator) Validate(dataplane *operatorv1beta1.DataPlane) error {
 }
 
 // ValidateDataPlaneDeploymentRollout validates the Rollout field of DataPlane object.
-func (v *Validator) ValidateDataPlaneDeploymentRollout(rollout *operatorv1beta1.Rollout) error {
+func (v *Validator) ValidateDataPlaneDeploymentRollout(rollout *operatorv1beta1.Rollout, scaling *operatorv1beta1.Scaling) error {
 	if rollout != nil && rollout.Strategy.BlueGreen != nil && rollout.Strategy.BlueGreen.Promotion.Strategy == operatorv1beta1.AutomaticPromotion {
 		// Can't use AutomaticPromotion just yet.
 		// Related: https://github.com/Kong/gateway-operator/issues/1006.
@@ -71,6 +71,21 @@ func (v *Validator) ValidateDataPlaneDeploymentRollout(rollout *operatorv1beta1.
 		return errors.New("DataPlane Deployment resource plan DeleteOnPromotionRecreateOnRollout cannot be used yet")
 	}
 
+	if rollout != nil && rollout.Strategy.Canary != nil {
+		if scaling != nil && scaling.HorizontalScaling != nil {
+			// Canary weights are applied by scaling the Deployments which
+			// would conflict with the HorizontalPodAutoscaler.
+			return errors.New("DataPlane Canary rollout cannot be used with horizontal scaling")
+		}
+		steps := rollout.Strategy.Canary.Steps
+		for i := 1; i < len(steps); i++ {
+			if steps[i].Weight <= steps[i-1].Weight {
+				return fmt.Errorf("DataPlane Canary rollout step %d weight (%d) has to be greater than the previous step weight (%d)",
+					i, steps[i].Weight, steps[i-1].Weight)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -162,12 +177,32 @@ func (v *Validator) ValidateDataPlaneIngressServiceOptions(
 
 		}
 
+		kongStreamListen, hasStreamListen, err := k8sutils.GetEnvValueFromContainer(context.Background(), proxyContainer, namespace, consts.EnvVarKongStreamListen, v.c)
+		if err != nil {
+			return err
+		}
+		var streamListenPortNumbers []int32 = make([]int32, 0)
+		if hasStreamListen && kongStreamListen != "off" {
+			streamListenPortNumbers, err = parseKongProxyListenPortNumbers(kongStreamListen)
+			if err != nil {
+				return err
+			}
+		}
+
 		for _, port := range opts.Ports {
 			targetPortNumber, err := getTargetPortNumber(port.TargetPort, proxyContainer)
 			if err != nil {
 				return fmt.Errorf("failed to get target port of port %d (port name %s) of ingress service: %w",
 					port.Port, port.Name, err)
 			}
+			// Ports targeting stream listeners are not subject to KONG_PORT_MAPS
+			// and KONG_PROXY_LISTEN.
+			if lo.Contains(streamListenPortNumbers, targetPortNumber) {
+				continue
+			}
+			if port.Protocol == corev1.ProtocolUDP {
+				return fmt.Errorf("UDP target port %s not included in %s", port.TargetPort.String(), consts.EnvVarKongStreamListen)
+			}
 			if hasKongPortMaps && portNumberMap[port.Port] != targetPortNumber {
 				return fmt.Errorf("KONG_PORT_MAPS specified but target port %s not properly set", port.TargetPort.String())
 			}
@@ -177,6 +212,20 @@ func (v *Validator) ValidateDataPlaneIngressServiceOptions(
 		}
 	}
 
+	if opts.LoadBalancerIP != "" {
+		if opts.Type != "" && opts.Type != corev1.ServiceTypeLoadBalancer {
+			return fmt.Errorf("loadBalancerIP can only be set for Services of type %s", corev1.ServiceTypeLoadBalancer)
+		}
+		if net.ParseIP(opts.LoadBalancerIP) == nil {
+			return fmt.Errorf("loadBalancerIP %s is not a valid IP address", opts.LoadBalancerIP)
+		}
+	}
+	for _, ip := range opts.ExternalIPs {
+		if net.ParseIP(ip) == nil {
+			return fmt.Errorf("external IP %s is not a valid IP address", ip)
+		}
+	}
+
 	return nil
 }
 
@@ -542,6 +542,199 @@ func TestDataPlaneIngressServiceOptions(t *testing.T) {
 			hasError: true,
 			errMsg:   "target port 8888 not included in KONG_PROXY_LISTEN",
 		},
+		{
+			msg: "dataplane with ingress service options having target ports in KONG_STREAM_LISTEN should be valid",
+			dataplane: &operatorv1beta1.DataPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-stream-listen",
+					Namespace: "default",
+				},
+				Spec: operatorv1beta1.DataPlaneSpec{
+					DataPlaneOptions: operatorv1beta1.DataPlaneOptions{
+						Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+							DeploymentOptions: operatorv1beta1.DeploymentOptions{
+								PodTemplateSpec: &corev1.PodTemplateSpec{
+									Spec: corev1.PodSpec{
+										Containers: []corev1.Container{
+											{
+												Name: consts.DataPlaneProxyContainerName,
+												Env: []corev1.EnvVar{
+													{Name: "KONG_PORT_MAPS", Value: "80:8080"},
+													{Name: "KONG_PROXY_LISTEN", Value: "0.0.0.0:8080 reuseport backlog=16384"},
+													{Name: "KONG_STREAM_LISTEN", Value: "0.0.0.0:8888 reuseport backlog=16384, 0.0.0.0:8899 udp reuseport"},
+												},
+												Image: consts.DefaultDataPlaneImage,
+											},
+										},
+									},
+								},
+							},
+						},
+						Network: operatorv1beta1.DataPlaneNetworkOptions{
+							Services: &operatorv1beta1.DataPlaneServices{
+								Ingress: &operatorv1beta1.DataPlaneServiceOptions{
+									Ports: []operatorv1beta1.DataPlaneServicePort{
+										{Name: "http", Port: int32(80), TargetPort: intstr.FromInt(8080)},
+										{Name: "tcp", Port: int32(8888), TargetPort: intstr.FromInt(8888)},
+										{Name: "udp", Port: int32(8899), TargetPort: intstr.FromInt(8899), Protocol: corev1.ProtocolUDP},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			hasError: false,
+		},
+		{
+			msg: "dataplane with ingress service options having UDP target port not in KONG_STREAM_LISTEN should be invalid",
+			dataplane: &operatorv1beta1.DataPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-stream-listen",
+					Namespace: "default",
+				},
+				Spec: operatorv1beta1.DataPlaneSpec{
+					DataPlaneOptions: operatorv1beta1.DataPlaneOptions{
+						Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+							DeploymentOptions: operatorv1beta1.DeploymentOptions{
+								PodTemplateSpec: &corev1.PodTemplateSpec{
+									Spec: corev1.PodSpec{
+										Containers: []corev1.Container{
+											{
+												Name:  consts.DataPlaneProxyContainerName,
+												Image: consts.DefaultDataPlaneImage,
+											},
+										},
+									},
+								},
+							},
+						},
+						Network: operatorv1beta1.DataPlaneNetworkOptions{
+							Services: &operatorv1beta1.DataPlaneServices{
+								Ingress: &operatorv1beta1.DataPlaneServiceOptions{
+									Ports: []operatorv1beta1.DataPlaneServicePort{
+										{Name: "udp", Port: int32(8899), TargetPort: intstr.FromInt(8899), Protocol: corev1.ProtocolUDP},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			hasError: true,
+			errMsg:   "UDP target port 8899 not included in KONG_STREAM_LISTEN",
+		},
+		{
+			msg: "dataplane with ingress service load balancer IP and external IPs should be valid",
+			dataplane: &operatorv1beta1.DataPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-addresses",
+					Namespace: "default",
+				},
+				Spec: operatorv1beta1.DataPlaneSpec{
+					DataPlaneOptions: operatorv1beta1.DataPlaneOptions{
+						Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+							DeploymentOptions: operatorv1beta1.DeploymentOptions{
+								PodTemplateSpec: &corev1.PodTemplateSpec{
+									Spec: corev1.PodSpec{
+										Containers: []corev1.Container{
+											{
+												Name:  consts.DataPlaneProxyContainerName,
+												Image: consts.DefaultDataPlaneImage,
+											},
+										},
+									},
+								},
+							},
+						},
+						Network: operatorv1beta1.DataPlaneNetworkOptions{
+							Services: &operatorv1beta1.DataPlaneServices{
+								Ingress: &operatorv1beta1.DataPlaneServiceOptions{
+									LoadBalancerIP: "203.0.113.10",
+									ExternalIPs:    []string{"203.0.113.11", "2001:db8::1"},
+								},
+							},
+						},
+					},
+				},
+			},
+			hasError: false,
+		},
+		{
+			msg: "dataplane with ingress service load balancer IP on a ClusterIP Service should be invalid",
+			dataplane: &operatorv1beta1.DataPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-addresses",
+					Namespace: "default",
+				},
+				Spec: operatorv1beta1.DataPlaneSpec{
+					DataPlaneOptions: operatorv1beta1.DataPlaneOptions{
+						Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+							DeploymentOptions: operatorv1beta1.DeploymentOptions{
+								PodTemplateSpec: &corev1.PodTemplateSpec{
+									Spec: corev1.PodSpec{
+										Containers: []corev1.Container{
+											{
+												Name:  consts.DataPlaneProxyContainerName,
+												Image: consts.DefaultDataPlaneImage,
+											},
+										},
+									},
+								},
+							},
+						},
+						Network: operatorv1beta1.DataPlaneNetworkOptions{
+							Services: &operatorv1beta1.DataPlaneServices{
+								Ingress: &operatorv1beta1.DataPlaneServiceOptions{
+									LoadBalancerIP: "203.0.113.10",
+									ServiceOptions: operatorv1beta1.ServiceOptions{
+										Type: corev1.ServiceTypeClusterIP,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			hasError: true,
+			errMsg:   "loadBalancerIP can only be set for Services of type LoadBalancer",
+		},
+		{
+			msg: "dataplane with ingress service invalid external IP should be invalid",
+			dataplane: &operatorv1beta1.DataPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-addresses",
+					Namespace: "default",
+				},
+				Spec: operatorv1beta1.DataPlaneSpec{
+					DataPlaneOptions: operatorv1beta1.DataPlaneOptions{
+						Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+							DeploymentOptions: operatorv1beta1.DeploymentOptions{
+								PodTemplateSpec: &corev1.PodTemplateSpec{
+									Spec: corev1.PodSpec{
+										Containers: []corev1.Container{
+											{
+												Name:  consts.DataPlaneProxyContainerName,
+												Image: consts.DefaultDataPlaneImage,
+											},
+										},
+									},
+								},
+							},
+						},
+						Network: operatorv1beta1.DataPlaneNetworkOptions{
+							Services: &operatorv1beta1.DataPlaneServices{
+								Ingress: &operatorv1beta1.DataPlaneServiceOptions{
+									ExternalIPs: []string{"not-an-ip"},
+								},
+							},
+						},
+					},
+				},
+			},
+			hasError: true,
+			errMsg:   "external IP not-an-ip is not a valid IP address",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -561,6 +754,69 @@ func TestDataPlaneIngressServiceOptions(t *testing.T) {
 	}
 }
 
+func TestValidateDataPlaneDeploymentRollout(t *testing.T) {
+	canary := func(weights ...int32) *operatorv1beta1.Rollout {
+		steps := make([]operatorv1beta1.CanaryStep, 0, len(weights))
+		for _, w := range weights {
+			steps = append(steps, operatorv1beta1.CanaryStep{Weight: w})
+		}
+		return &operatorv1beta1.Rollout{
+			Strategy: operatorv1beta1.RolloutStrategy{
+				Canary: &operatorv1beta1.CanaryStrategy{Steps: steps},
+			},
+		}
+	}
+
+	testCases := []struct {
+		msg     string
+		rollout *operatorv1beta1.Rollout
+		scaling *operatorv1beta1.Scaling
+		errMsg  string
+	}{
+		{
+			msg: "no rollout",
+		},
+		{
+			msg:     "Canary with increasing weights",
+			rollout: canary(10, 50, 100),
+		},
+		{
+			msg:     "Canary with a single step",
+			rollout: canary(100),
+		},
+		{
+			msg:     "Canary with decreasing weights",
+			rollout: canary(50, 10),
+			errMsg:  "DataPlane Canary rollout step 1 weight (10) has to be greater than the previous step weight (50)",
+		},
+		{
+			msg:     "Canary with repeated weights",
+			rollout: canary(10, 50, 50),
+			errMsg:  "DataPlane Canary rollout step 2 weight (50) has to be greater than the previous step weight (50)",
+		},
+		{
+			msg:     "Canary with horizontal scaling",
+			rollout: canary(10, 50),
+			scaling: &operatorv1beta1.Scaling{
+				HorizontalScaling: &operatorv1beta1.HorizontalScaling{MaxReplicas: 10},
+			},
+			errMsg: "DataPlane Canary rollout cannot be used with horizontal scaling",
+		},
+	}
+
+	v := &Validator{}
+	for _, tc := range testCases {
+		t.Run(tc.msg, func(t *testing.T) {
+			err := v.ValidateDataPlaneDeploymentRollout(tc.rollout, tc.scaling)
+			if tc.errMsg == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.errMsg)
+			}
+		})
+	}
+}
+
 func TestValidateUpdate(t *testing.T) {
 	b := fakeclient.NewClientBuilder()
 
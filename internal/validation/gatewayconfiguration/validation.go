@@ -0,0 +1,145 @@
+package gatewayconfiguration
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/internal/validation/controlplane"
+	"github.com/kong/gateway-operator/internal/validation/dataplane"
+	"github.com/kong/gateway-operator/internal/versions"
+	"github.com/kong/gateway-operator/pkg/consts"
+	gatewayutils "github.com/kong/gateway-operator/pkg/utils/gateway"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+)
+
+// Validator validates GatewayConfiguration objects.
+type Validator struct {
+	dataplaneValidator    *dataplane.Validator
+	controlplaneValidator *controlplane.Validator
+	// developmentMode is nil when it is not known whether the operator runs in
+	// development mode.
+	developmentMode *bool
+}
+
+// ValidatorOption is a functional option for the GatewayConfiguration validator.
+type ValidatorOption func(*Validator)
+
+// WithDevelopmentMode sets whether the operator runs in development mode, in which
+// the DataPlane and ControlPlane controllers accept any image. Outside of it only
+// images whose tag is a supported version are accepted. Without this option images
+// with tags which are not versions are accepted, as they might be allowed.
+func WithDevelopmentMode(developmentMode bool) ValidatorOption {
+	return func(v *Validator) {
+		v.developmentMode = &developmentMode
+	}
+}
+
+// NewValidator creates a GatewayConfiguration validator.
+func NewValidator(c client.Client, opts ...ValidatorOption) *Validator {
+	v := &Validator{
+		dataplaneValidator:    dataplane.NewValidator(c),
+		controlplaneValidator: controlplane.NewValidator(c),
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// Validate validates a GatewayConfiguration object and return the first validation error found.
+//
+// The DataPlane and ControlPlane options are validated the same way the
+// DataPlanes and ControlPlanes created from them are, after filling in the
+// default images which are set by the Gateway controller when they are unset.
+func (v *Validator) Validate(gatewayConfig *operatorv1beta1.GatewayConfiguration) error {
+	if opts := gatewayConfig.Spec.DataPlaneOptions; opts != nil {
+		if err := v.ValidateDataPlaneOptions(gatewayConfig.Namespace, opts); err != nil {
+			return fmt.Errorf("invalid dataPlaneOptions: %w", err)
+		}
+	}
+
+	if opts := gatewayConfig.Spec.ControlPlaneOptions; opts != nil {
+		if err := v.ValidateControlPlaneOptions(opts); err != nil {
+			return fmt.Errorf("invalid controlPlaneOptions: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ValidateDataPlaneOptions validates the DataPlane options of a GatewayConfiguration.
+// The ingress Service ports are not part of these options: they are derived from
+// the listeners of each Gateway and validated along with the DataPlanes created
+// for them.
+func (v *Validator) ValidateDataPlaneOptions(namespace string, opts *operatorv1beta1.GatewayConfigDataPlaneOptions) error {
+	dp := &operatorv1beta1.DataPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+		},
+		Spec: operatorv1beta1.DataPlaneSpec{
+			DataPlaneOptions: *gatewayutils.GatewayConfigDataPlaneOptionsToDataPlaneOptions(*opts.DeepCopy()),
+		},
+	}
+
+	container := ensureContainerWithImage(&dp.Spec.Deployment.PodTemplateSpec, consts.DataPlaneProxyContainerName, consts.DefaultDataPlaneImage)
+	if err := v.validateImageVersion(container.Image, versions.IsDataPlaneImageVersionSupported); err != nil {
+		return err
+	}
+
+	return v.dataplaneValidator.Validate(dp)
+}
+
+// ValidateControlPlaneOptions validates the ControlPlane options of a GatewayConfiguration.
+func (v *Validator) ValidateControlPlaneOptions(opts *operatorv1beta1.ControlPlaneOptions) error {
+	deploymentOpts := opts.Deployment.DeepCopy()
+
+	container := ensureContainerWithImage(&deploymentOpts.PodTemplateSpec, consts.ControlPlaneControllerContainerName, consts.DefaultControlPlaneImage)
+	if err := v.validateImageVersion(container.Image, versions.IsControlPlaneImageVersionSupported); err != nil {
+		return err
+	}
+
+	return v.controlplaneValidator.ValidateDeploymentOptions(deploymentOpts)
+}
+
+// ensureContainerWithImage returns the container with the provided name from
+// the pod template, adding it when it doesn't exist and setting its image to
+// the default one when it's unset.
+func ensureContainerWithImage(pts **corev1.PodTemplateSpec, name, defaultImage string) *corev1.Container {
+	if *pts == nil {
+		*pts = &corev1.PodTemplateSpec{}
+	}
+	spec := &(*pts).Spec
+	container := k8sutils.GetPodContainerByName(spec, name)
+	if container == nil {
+		spec.Containers = append(spec.Containers, corev1.Container{Name: name})
+		container = &spec.Containers[len(spec.Containers)-1]
+	}
+	if container.Image == "" {
+		container.Image = defaultImage
+	}
+	return container
+}
+
+// validateImageVersion rejects images whose tag is a version which is not supported
+// and, outside of development mode, images whose tag is not a version.
+// Images are not validated in development mode.
+func (v *Validator) validateImageVersion(image string, isSupported func(string) (bool, error)) error {
+	if v.developmentMode != nil && *v.developmentMode {
+		return nil
+	}
+	supported, err := isSupported(image)
+	if err != nil {
+		if v.developmentMode == nil {
+			return nil
+		}
+		return fmt.Errorf("invalid image %s: %w", image, err)
+	}
+	if !supported {
+		return fmt.Errorf("unsupported image %s", image)
+	}
+	return nil
+}
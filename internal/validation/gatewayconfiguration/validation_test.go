@@ -0,0 +1,90 @@
+package gatewayconfiguration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/pkg/consts"
+)
+
+func TestValidate(t *testing.T) {
+	gatewayConfigWithDataPlaneImage := func(image string) *operatorv1beta1.GatewayConfiguration {
+		return &operatorv1beta1.GatewayConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gwconfig"},
+			Spec: operatorv1beta1.GatewayConfigurationSpec{
+				DataPlaneOptions: &operatorv1beta1.GatewayConfigDataPlaneOptions{
+					Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+						DeploymentOptions: operatorv1beta1.DeploymentOptions{
+							PodTemplateSpec: &corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{
+										{
+											Name:  consts.DataPlaneProxyContainerName,
+											Image: image,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		gatewayConfig *operatorv1beta1.GatewayConfiguration
+		opts          []ValidatorOption
+		expectedError string
+	}{
+		{
+			name:          "default images",
+			gatewayConfig: gatewayConfigWithDataPlaneImage(""),
+		},
+		{
+			name:          "unsupported image version",
+			gatewayConfig: gatewayConfigWithDataPlaneImage("kong:2.8"),
+			expectedError: "invalid dataPlaneOptions: unsupported image kong:2.8",
+		},
+		{
+			name:          "unsupported image version in development mode",
+			gatewayConfig: gatewayConfigWithDataPlaneImage("kong:2.8"),
+			opts:          []ValidatorOption{WithDevelopmentMode(true)},
+		},
+		{
+			name:          "image tag which is not a version when the development mode is unknown",
+			gatewayConfig: gatewayConfigWithDataPlaneImage("kong:latest"),
+		},
+		{
+			name:          "image tag which is not a version outside of development mode",
+			gatewayConfig: gatewayConfigWithDataPlaneImage("kong:latest"),
+			opts:          []ValidatorOption{WithDevelopmentMode(false)},
+			expectedError: "invalid dataPlaneOptions: invalid image kong:latest",
+		},
+		{
+			name:          "image tag which is not a version in development mode",
+			gatewayConfig: gatewayConfigWithDataPlaneImage("kong:latest"),
+			opts:          []ValidatorOption{WithDevelopmentMode(true)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cl := fakeclient.NewClientBuilder().Build()
+			gatewayConfig := tc.gatewayConfig.DeepCopy()
+			err := NewValidator(cl, tc.opts...).Validate(gatewayConfig)
+			require.Equal(t, tc.gatewayConfig, gatewayConfig, "validation should not modify the GatewayConfiguration")
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
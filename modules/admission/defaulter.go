@@ -0,0 +1,117 @@
+package admission
+
+import (
+	"context"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/controller/pkg/controlplane"
+	dputils "github.com/kong/gateway-operator/internal/utils/dataplane"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+	k8sresources "github.com/kong/gateway-operator/pkg/utils/kubernetes/resources"
+)
+
+// defaulter fills in the defaults which the DataPlane and ControlPlane
+// controllers would otherwise apply when generating the Deployments, so that
+// the stored spec reflects what actually runs.
+// It never overrides fields which have already been set.
+type defaulter struct {
+	developmentMode   bool
+	dataPlaneImage    string
+	controlPlaneImage string
+}
+
+func newDefaulter(developmentMode bool) *defaulter {
+	d := &defaulter{
+		developmentMode:   developmentMode,
+		dataPlaneImage:    consts.DefaultDataPlaneImage,
+		controlPlaneImage: consts.DefaultControlPlaneImage,
+	}
+	// RELATED_IMAGE_KONG and RELATED_IMAGE_KONG_CONTROLLER are set by the
+	// operator-sdk when building the operator bundle and take precedence over
+	// the default images in the controllers.
+	// https://github.com/Kong/gateway-operator-archive/issues/261
+	if relatedKongImage := os.Getenv("RELATED_IMAGE_KONG"); relatedKongImage != "" {
+		d.dataPlaneImage = relatedKongImage
+	}
+	if relatedKongControllerImage := os.Getenv("RELATED_IMAGE_KONG_CONTROLLER"); relatedKongControllerImage != "" {
+		d.controlPlaneImage = relatedKongControllerImage
+	}
+	return d
+}
+
+// DefaultDataPlane sets the default image, environment variables, readiness
+// probe and resources of the DataPlane's proxy container.
+func (d *defaulter) DefaultDataPlane(_ context.Context, dataPlane *operatorv1beta1.DataPlane) {
+	deployment := &dataPlane.Spec.Deployment
+	if deployment.PodTemplateSpec == nil {
+		deployment.PodTemplateSpec = &corev1.PodTemplateSpec{}
+	}
+
+	container := ensureContainer(&deployment.PodTemplateSpec.Spec, consts.DataPlaneProxyContainerName)
+	if container.Image == "" {
+		container.Image = d.dataPlaneImage
+	}
+	if container.ReadinessProbe == nil {
+		container.ReadinessProbe = k8sresources.GenerateDataPlaneReadinessProbe(consts.DataPlaneStatusEndpoint)
+	}
+	if isResourceRequirementsEmpty(container.Resources) {
+		container.Resources = *k8sresources.DefaultDataPlaneResources()
+	}
+
+	dputils.FillDataPlaneProxyContainerEnvs(nil, deployment.PodTemplateSpec)
+}
+
+// DefaultControlPlane sets the default image, environment variables, probes
+// and resources of the ControlPlane's controller container.
+// Environment variables which are already set are left untouched.
+func (d *defaulter) DefaultControlPlane(_ context.Context, controlPlane *operatorv1beta1.ControlPlane) {
+	deployment := &controlPlane.Spec.Deployment
+	if deployment.PodTemplateSpec == nil {
+		deployment.PodTemplateSpec = &corev1.PodTemplateSpec{}
+	}
+
+	container := ensureContainer(&deployment.PodTemplateSpec.Spec, consts.ControlPlaneControllerContainerName)
+	if container.Image == "" {
+		container.Image = d.controlPlaneImage
+	}
+	if container.LivenessProbe == nil {
+		container.LivenessProbe = k8sresources.GenerateControlPlaneProbe("/healthz", intstr.FromInt(10254))
+	}
+	if container.ReadinessProbe == nil {
+		container.ReadinessProbe = k8sresources.GenerateControlPlaneProbe("/readyz", intstr.FromInt(10254))
+	}
+	if isResourceRequirementsEmpty(container.Resources) {
+		container.Resources = *k8sresources.DefaultControlPlaneResources()
+	}
+
+	dontOverride := make(map[string]struct{}, len(container.Env))
+	for _, env := range container.Env {
+		dontOverride[env.Name] = struct{}{}
+	}
+	// The DataPlane services are not known at admission time, the ControlPlane
+	// controller sets the environment variables referring to them.
+	_ = controlplane.SetDefaults(&controlPlane.Spec.ControlPlaneOptions, dontOverride, controlplane.DefaultsArgs{
+		Namespace:               controlPlane.Namespace,
+		ControlPlaneName:        controlPlane.Name,
+		AnonymousReportsEnabled: controlplane.DeduceAnonymousReportsEnabled(d.developmentMode, &controlPlane.Spec.ControlPlaneOptions),
+	})
+}
+
+// ensureContainer returns the container with the provided name from the pod
+// spec, adding it when it doesn't exist.
+func ensureContainer(podSpec *corev1.PodSpec, name string) *corev1.Container {
+	if container := k8sutils.GetPodContainerByName(podSpec, name); container != nil {
+		return container
+	}
+	podSpec.Containers = append(podSpec.Containers, corev1.Container{Name: name})
+	return &podSpec.Containers[len(podSpec.Containers)-1]
+}
+
+func isResourceRequirementsEmpty(r corev1.ResourceRequirements) bool {
+	return len(r.Requests) == 0 && len(r.Limits) == 0 && len(r.Claims) == 0
+}
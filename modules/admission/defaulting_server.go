@@ -0,0 +1,128 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrladmission "sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/pkg/consts"
+)
+
+// Defaulter is the interface of defaulting
+type Defaulter interface {
+	DefaultControlPlane(ctx context.Context, controlplane *operatorv1beta1.ControlPlane)
+	DefaultDataPlane(ctx context.Context, dataplane *operatorv1beta1.DataPlane)
+}
+
+// DefaultingRequestHandler handles the requests of defaulting objects.
+type DefaultingRequestHandler struct {
+	// Defaulter sets the defaults on the entities that the k8s API-server
+	// asks the server to mutate.
+	Defaulter Defaulter
+	Logger    logr.Logger
+}
+
+// NewDefaultingRequestHandler creates a DefaultingRequestHandler to handle defaulting requests.
+func NewDefaultingRequestHandler(l logr.Logger, developmentMode bool) *DefaultingRequestHandler {
+	return &DefaultingRequestHandler{
+		Defaulter: newDefaulter(developmentMode),
+		Logger:    l.WithValues("component", "defaulting-server"),
+	}
+}
+
+// ServeHTTP serves for HTTP requests.
+func (h *DefaultingRequestHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	serveAdmissionReview(w, r, h.Logger, h.handleDefaulting)
+}
+
+func (h *DefaultingRequestHandler) handleDefaulting(ctx context.Context, req *admissionv1.AdmissionRequest) (
+	*admissionv1.AdmissionResponse, error,
+) {
+	if req == nil {
+		return &admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Code:    http.StatusBadRequest,
+				Reason:  metav1.StatusReasonBadRequest,
+				Message: "empty request",
+				Status:  metav1.StatusFailure,
+			},
+		}, nil
+	}
+
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return allowedWithoutPatch(req), nil
+	}
+
+	var (
+		obj          client.Object
+		deserializer = codecs.UniversalDeserializer()
+	)
+
+	switch req.Resource {
+	case controlPlaneGVResource:
+		controlPlane := &operatorv1beta1.ControlPlane{}
+		if _, _, err := deserializer.Decode(req.Object.Raw, nil, controlPlane); err != nil {
+			return nil, err
+		}
+		if !isManagedByGateway(controlPlane) {
+			h.Defaulter.DefaultControlPlane(ctx, controlPlane)
+		}
+		obj = controlPlane
+	case dataPlaneGVResource:
+		dataPlane := &operatorv1beta1.DataPlane{}
+		if _, _, err := deserializer.Decode(req.Object.Raw, nil, dataPlane); err != nil {
+			return nil, err
+		}
+		if !isManagedByGateway(dataPlane) {
+			h.Defaulter.DefaultDataPlane(ctx, dataPlane)
+		}
+		obj = dataPlane
+	default:
+		return allowedWithoutPatch(req), nil
+	}
+
+	return patchResponse(req, obj)
+}
+
+// isManagedByGateway returns true when the provided object is managed by
+// a Gateway. Such objects are not defaulted here because the Gateway
+// controller sets the defaults on them and keeps their spec in sync with
+// the GatewayConfiguration, which would otherwise conflict with the webhook.
+func isManagedByGateway(obj client.Object) bool {
+	return obj.GetLabels()[consts.GatewayOperatorManagedByLabel] == consts.GatewayManagedLabelValue
+}
+
+// patchResponse returns an AdmissionResponse which allows the request and
+// carries the JSON patch transforming the requested object into the provided one.
+func patchResponse(req *admissionv1.AdmissionRequest, obj runtime.Object) (*admissionv1.AdmissionResponse, error) {
+	defaulted, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := ctrladmission.PatchResponseFromRaw(req.Object.Raw, defaulted)
+	if err := resp.Complete(ctrladmission.Request{AdmissionRequest: *req}); err != nil {
+		return nil, err
+	}
+	return &resp.AdmissionResponse, nil
+}
+
+func allowedWithoutPatch(req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	return &admissionv1.AdmissionResponse{
+		UID:     req.UID,
+		Allowed: true,
+		Result: &metav1.Status{
+			Code:   http.StatusOK,
+			Status: metav1.StatusSuccess,
+		},
+	}
+}
@@ -0,0 +1,199 @@
+package admission
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+	k8sresources "github.com/kong/gateway-operator/pkg/utils/kubernetes/resources"
+)
+
+func TestHandleDefaulting(t *testing.T) {
+	handler := NewDefaultingRequestHandler(logr.Discard(), false)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	testCases := []struct {
+		name        string
+		resource    metav1.GroupVersionResource
+		obj         client.Object
+		expectPatch bool
+	}{
+		{
+			name:     "dataplane is defaulted",
+			resource: dataPlaneGVResource,
+			obj: &operatorv1beta1.DataPlane{
+				ObjectMeta: metav1.ObjectMeta{Name: "dp", Namespace: "default"},
+			},
+			expectPatch: true,
+		},
+		{
+			name:     "controlplane is defaulted",
+			resource: controlPlaneGVResource,
+			obj: &operatorv1beta1.ControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Name: "cp", Namespace: "default"},
+			},
+			expectPatch: true,
+		},
+		{
+			name:     "dataplane managed by a Gateway is not defaulted",
+			resource: dataPlaneGVResource,
+			obj: &operatorv1beta1.DataPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "dp",
+					Namespace: "default",
+					Labels: map[string]string{
+						consts.GatewayOperatorManagedByLabel: consts.GatewayManagedLabelValue,
+					},
+				},
+			},
+		},
+		{
+			name:     "other resources are not defaulted",
+			resource: gatewayConfigurationGVResource,
+			obj: &operatorv1beta1.GatewayConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "gc", Namespace: "default"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			resp := sendAdmissionReview(t, server.URL, tc.resource, tc.obj)
+			require.True(t, resp.Allowed)
+			require.EqualValues(t, http.StatusOK, resp.Result.Code)
+			if tc.expectPatch {
+				require.NotNil(t, resp.PatchType)
+				require.Equal(t, admissionv1.PatchTypeJSONPatch, *resp.PatchType)
+				require.NotEmpty(t, resp.Patch)
+			} else {
+				require.Nil(t, resp.PatchType)
+				require.Empty(t, resp.Patch)
+			}
+		})
+	}
+}
+
+func TestDefaulter_DefaultDataPlane(t *testing.T) {
+	d := newDefaulter(false)
+
+	t.Run("empty spec", func(t *testing.T) {
+		dataplane := &operatorv1beta1.DataPlane{}
+		d.DefaultDataPlane(context.Background(), dataplane)
+
+		container := k8sutils.GetPodContainerByName(&dataplane.Spec.Deployment.PodTemplateSpec.Spec, consts.DataPlaneProxyContainerName)
+		require.NotNil(t, container)
+		require.Equal(t, consts.DefaultDataPlaneImage, container.Image)
+		require.Equal(t, k8sresources.GenerateDataPlaneReadinessProbe(consts.DataPlaneStatusEndpoint), container.ReadinessProbe)
+		require.Equal(t, *k8sresources.DefaultDataPlaneResources(), container.Resources)
+		require.Equal(t, "off", k8sutils.EnvValueByName(container.Env, "KONG_DATABASE"))
+	})
+
+	t.Run("provided values are not overridden", func(t *testing.T) {
+		resources := corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("500m"),
+			},
+		}
+		dataplane := &operatorv1beta1.DataPlane{
+			Spec: operatorv1beta1.DataPlaneSpec{
+				DataPlaneOptions: operatorv1beta1.DataPlaneOptions{
+					Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+						DeploymentOptions: operatorv1beta1.DeploymentOptions{
+							PodTemplateSpec: &corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{
+										{
+											Name:  consts.DataPlaneProxyContainerName,
+											Image: "kong:3.6",
+											Env: []corev1.EnvVar{
+												{Name: "KONG_NGINX_WORKER_PROCESSES", Value: "4"},
+											},
+											ReadinessProbe: k8sresources.GenerateDataPlaneReadinessProbe(consts.DataPlaneStatusReadyEndpoint),
+											Resources:      resources,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		d.DefaultDataPlane(context.Background(), dataplane)
+
+		container := k8sutils.GetPodContainerByName(&dataplane.Spec.Deployment.PodTemplateSpec.Spec, consts.DataPlaneProxyContainerName)
+		require.NotNil(t, container)
+		require.Equal(t, "kong:3.6", container.Image)
+		require.Equal(t, k8sresources.GenerateDataPlaneReadinessProbe(consts.DataPlaneStatusReadyEndpoint), container.ReadinessProbe)
+		require.Equal(t, resources, container.Resources)
+		require.Equal(t, "4", k8sutils.EnvValueByName(container.Env, "KONG_NGINX_WORKER_PROCESSES"))
+		require.Equal(t, "off", k8sutils.EnvValueByName(container.Env, "KONG_DATABASE"))
+	})
+}
+
+func TestDefaulter_DefaultControlPlane(t *testing.T) {
+	d := newDefaulter(false)
+
+	t.Run("empty spec", func(t *testing.T) {
+		controlplane := &operatorv1beta1.ControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "cp", Namespace: "default"},
+		}
+		d.DefaultControlPlane(context.Background(), controlplane)
+
+		container := k8sutils.GetPodContainerByName(&controlplane.Spec.Deployment.PodTemplateSpec.Spec, consts.ControlPlaneControllerContainerName)
+		require.NotNil(t, container)
+		require.Equal(t, consts.DefaultControlPlaneImage, container.Image)
+		require.NotNil(t, container.LivenessProbe)
+		require.NotNil(t, container.ReadinessProbe)
+		require.Equal(t, *k8sresources.DefaultControlPlaneResources(), container.Resources)
+		require.Equal(t, "cp.konghq.com", k8sutils.EnvValueByName(container.Env, "CONTROLLER_ELECTION_ID"))
+		require.Equal(t, "true", k8sutils.EnvValueByName(container.Env, "CONTROLLER_ANONYMOUS_REPORTS"))
+	})
+
+	t.Run("provided env vars are not overridden", func(t *testing.T) {
+		controlplane := &operatorv1beta1.ControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "cp", Namespace: "default"},
+			Spec: operatorv1beta1.ControlPlaneSpec{
+				ControlPlaneOptions: operatorv1beta1.ControlPlaneOptions{
+					Deployment: operatorv1beta1.ControlPlaneDeploymentOptions{
+						PodTemplateSpec: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name:  consts.ControlPlaneControllerContainerName,
+										Image: "kong/kubernetes-ingress-controller:3.1.5",
+										Env: []corev1.EnvVar{
+											{Name: "CONTROLLER_ELECTION_ID", Value: "custom.konghq.com"},
+											{Name: "CONTROLLER_ANONYMOUS_REPORTS", Value: "false"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		d.DefaultControlPlane(context.Background(), controlplane)
+
+		container := k8sutils.GetPodContainerByName(&controlplane.Spec.Deployment.PodTemplateSpec.Spec, consts.ControlPlaneControllerContainerName)
+		require.NotNil(t, container)
+		require.Equal(t, "kong/kubernetes-ingress-controller:3.1.5", container.Image)
+		require.Equal(t, "custom.konghq.com", k8sutils.EnvValueByName(container.Env, "CONTROLLER_ELECTION_ID"))
+		require.Equal(t, "false", k8sutils.EnvValueByName(container.Env, "CONTROLLER_ANONYMOUS_REPORTS"))
+	})
+}
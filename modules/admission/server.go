@@ -13,8 +13,12 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	operatorv1alpha1 "github.com/kong/gateway-operator/api/v1alpha1"
 	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/internal/validation/aigateway"
+	"github.com/kong/gateway-operator/internal/validation/controlplane"
 	"github.com/kong/gateway-operator/internal/validation/dataplane"
+	"github.com/kong/gateway-operator/internal/validation/gatewayconfiguration"
 )
 
 var (
@@ -26,6 +30,8 @@ var (
 type Validator interface {
 	ValidateControlPlane(ctx context.Context, controlplane operatorv1beta1.ControlPlane) error
 	ValidateDataPlane(ctx context.Context, dataplane operatorv1beta1.DataPlane, old operatorv1beta1.DataPlane, op admissionv1.Operation) error
+	ValidateGatewayConfiguration(ctx context.Context, gatewayConfiguration operatorv1beta1.GatewayConfiguration) error
+	ValidateAIGateway(ctx context.Context, aigateway operatorv1alpha1.AIGateway) error
 }
 
 // RequestHandler handles the requests of validating objects.
@@ -37,10 +43,15 @@ type RequestHandler struct {
 }
 
 // NewRequestHandler create a RequestHandler to handle validation requests.
-func NewRequestHandler(c client.Client, l logr.Logger) *RequestHandler {
+// GatewayConfigurations are validated the same way as by the GatewayConfiguration
+// controller, which depends on whether the operator runs in development mode.
+func NewRequestHandler(c client.Client, l logr.Logger, developmentMode bool) *RequestHandler {
 	return &RequestHandler{
 		Validator: &validator{
-			dataplaneValidator: dataplane.NewValidator(c),
+			dataplaneValidator:            dataplane.NewValidator(c),
+			controlplaneValidator:         controlplane.NewValidator(c),
+			gatewayConfigurationValidator: gatewayconfiguration.NewValidator(c, gatewayconfiguration.WithDevelopmentMode(developmentMode)),
+			aigatewayValidator:            aigateway.NewValidator(),
 		},
 		Logger: l.WithValues("component", "validation-server"),
 	}
@@ -48,36 +59,48 @@ func NewRequestHandler(c client.Client, l logr.Logger) *RequestHandler {
 
 // ServeHTTP serves for HTTP requests.
 func (h *RequestHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	serveAdmissionReview(w, r, h.Logger, h.handleValidation)
+}
+
+// serveAdmissionReview decodes the AdmissionReview sent in the request, passes
+// its AdmissionRequest to the provided handle function and writes back the
+// AdmissionReview with the returned AdmissionResponse.
+func serveAdmissionReview(
+	w http.ResponseWriter,
+	r *http.Request,
+	logger logr.Logger,
+	handle func(context.Context, *admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, error),
+) {
 	data, err := io.ReadAll(r.Body)
 	if err != nil {
-		h.Logger.Error(err, "failed to read request from client")
+		logger.Error(err, "failed to read request from client")
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	review := &admissionv1.AdmissionReview{}
 	if err := json.Unmarshal(data, review); err != nil {
-		h.Logger.Error(err, "failed to parse AdmissionReview object")
+		logger.Error(err, "failed to parse AdmissionReview object")
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	response, err := h.handleValidation(r.Context(), review.Request)
+	response, err := handle(r.Context(), review.Request)
 	if err != nil {
-		h.Logger.Error(err, "failed to run validation")
+		logger.Error(err, "failed to handle admission request")
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	review.Response = response
 	data, err = json.Marshal(review)
 	if err != nil {
-		h.Logger.Error(err, "failed to marshal response")
+		logger.Error(err, "failed to marshal response")
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	_, err = w.Write(data)
 	if err != nil {
-		h.Logger.Error(err, "failed to write response")
+		logger.Error(err, "failed to write response")
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -94,6 +117,16 @@ var (
 		Version:  operatorv1beta1.SchemeGroupVersion.Version,
 		Resource: "dataplanes",
 	}
+	gatewayConfigurationGVResource = metav1.GroupVersionResource{
+		Group:    operatorv1beta1.SchemeGroupVersion.Group,
+		Version:  operatorv1beta1.SchemeGroupVersion.Version,
+		Resource: "gatewayconfigurations",
+	}
+	aiGatewayGVResource = metav1.GroupVersionResource{
+		Group:    operatorv1alpha1.SchemeGroupVersion.Group,
+		Version:  operatorv1alpha1.SchemeGroupVersion.Version,
+		Resource: "aigateways",
+	}
 )
 
 func (h *RequestHandler) handleValidation(ctx context.Context, req *admissionv1.AdmissionRequest) (
@@ -149,6 +182,32 @@ func (h *RequestHandler) handleValidation(ctx context.Context, req *admissionv1.
 				msg = err.Error()
 			}
 		}
+	case gatewayConfigurationGVResource:
+		if req.Operation == admissionv1.Create || req.Operation == admissionv1.Update {
+			gatewayConfiguration := operatorv1beta1.GatewayConfiguration{}
+			_, _, err := deserializer.Decode(req.Object.Raw, nil, &gatewayConfiguration)
+			if err != nil {
+				return nil, err
+			}
+			err = h.Validator.ValidateGatewayConfiguration(ctx, gatewayConfiguration)
+			if err != nil {
+				ok = false
+				msg = err.Error()
+			}
+		}
+	case aiGatewayGVResource:
+		if req.Operation == admissionv1.Create || req.Operation == admissionv1.Update {
+			aiGateway := operatorv1alpha1.AIGateway{}
+			_, _, err := deserializer.Decode(req.Object.Raw, nil, &aiGateway)
+			if err != nil {
+				return nil, err
+			}
+			err = h.Validator.ValidateAIGateway(ctx, aiGateway)
+			if err != nil {
+				ok = false
+				msg = err.Error()
+			}
+		}
 	}
 
 	response.UID = req.UID
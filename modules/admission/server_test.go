@@ -10,13 +10,16 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	operatorv1alpha1 "github.com/kong/gateway-operator/api/v1alpha1"
 	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
 	"github.com/kong/gateway-operator/pkg/consts"
 )
@@ -57,7 +60,7 @@ func TestHandleDataPlaneValidation(t *testing.T) {
 	)
 	c := b.Build()
 
-	handler := NewRequestHandler(c, logr.Discard())
+	handler := NewRequestHandler(c, logr.Discard(), false)
 	server := httptest.NewServer(handler)
 
 	testCases := []struct {
@@ -433,3 +436,277 @@ func TestHandleDataPlaneValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleGatewayConfigurationValidation(t *testing.T) {
+	c := fakeclient.NewClientBuilder().Build()
+	handler := NewRequestHandler(c, logr.Discard(), false)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	testCases := []struct {
+		name          string
+		gatewayConfig *operatorv1beta1.GatewayConfiguration
+		errMsg        string
+	}{
+		{
+			name: "validate_ok:empty",
+			gatewayConfig: &operatorv1beta1.GatewayConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-empty", Namespace: "default"},
+			},
+		},
+		{
+			name: "validate_ok:default_images",
+			gatewayConfig: &operatorv1beta1.GatewayConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-default-images", Namespace: "default"},
+				Spec: operatorv1beta1.GatewayConfigurationSpec{
+					DataPlaneOptions:    &operatorv1beta1.GatewayConfigDataPlaneOptions{},
+					ControlPlaneOptions: &operatorv1beta1.ControlPlaneOptions{},
+				},
+			},
+		},
+		{
+			name: "validate_error:unsupported_dataplane_image",
+			gatewayConfig: &operatorv1beta1.GatewayConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-unsupported-image", Namespace: "default"},
+				Spec: operatorv1beta1.GatewayConfigurationSpec{
+					DataPlaneOptions: &operatorv1beta1.GatewayConfigDataPlaneOptions{
+						Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+							DeploymentOptions: operatorv1beta1.DeploymentOptions{
+								PodTemplateSpec: &corev1.PodTemplateSpec{
+									Spec: corev1.PodSpec{
+										Containers: []corev1.Container{
+											{
+												Name:  consts.DataPlaneProxyContainerName,
+												Image: "kong:2.8",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			errMsg: "invalid dataPlaneOptions: unsupported image kong:2.8",
+		},
+		{
+			name: "validate_error:dataplane_database_postgres",
+			gatewayConfig: &operatorv1beta1.GatewayConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-db-postgres", Namespace: "default"},
+				Spec: operatorv1beta1.GatewayConfigurationSpec{
+					DataPlaneOptions: &operatorv1beta1.GatewayConfigDataPlaneOptions{
+						Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+							DeploymentOptions: operatorv1beta1.DeploymentOptions{
+								PodTemplateSpec: &corev1.PodTemplateSpec{
+									Spec: corev1.PodSpec{
+										Containers: []corev1.Container{
+											{
+												Name: consts.DataPlaneProxyContainerName,
+												Env: []corev1.EnvVar{
+													{
+														Name:  consts.EnvVarKongDatabase,
+														Value: "postgres",
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			errMsg: "invalid dataPlaneOptions: database backend postgres of DataPlane not supported currently",
+		},
+		{
+			name: "validate_error:controlplane_replicas",
+			gatewayConfig: &operatorv1beta1.GatewayConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-cp-replicas", Namespace: "default"},
+				Spec: operatorv1beta1.GatewayConfigurationSpec{
+					ControlPlaneOptions: &operatorv1beta1.ControlPlaneOptions{
+						Deployment: operatorv1beta1.ControlPlaneDeploymentOptions{
+							Replicas: lo.ToPtr(int32(2)),
+						},
+					},
+				},
+			},
+			errMsg: "invalid controlPlaneOptions: ControlPlane only supports replicas of 1",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			validationResp := sendAdmissionReview(t, server.URL, gatewayConfigurationGVResource, tc.gatewayConfig)
+			if tc.errMsg == "" {
+				require.EqualValues(t, http.StatusOK, validationResp.Result.Code, "response code should be 200 OK")
+			} else {
+				require.EqualValues(t, http.StatusBadRequest, validationResp.Result.Code, "response code should be 400 Bad Request")
+				require.Equal(t, tc.errMsg, validationResp.Result.Message, "result message should contain expected content")
+			}
+		})
+	}
+}
+
+func TestHandleGatewayConfigurationValidationDevelopmentMode(t *testing.T) {
+	gatewayConfig := &operatorv1beta1.GatewayConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-nightly-image", Namespace: "default"},
+		Spec: operatorv1beta1.GatewayConfigurationSpec{
+			DataPlaneOptions: &operatorv1beta1.GatewayConfigDataPlaneOptions{
+				Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+					DeploymentOptions: operatorv1beta1.DeploymentOptions{
+						PodTemplateSpec: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name:  consts.DataPlaneProxyContainerName,
+										Image: "kong/kong-gateway:nightly",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name            string
+		developmentMode bool
+		expectedCode    int
+	}{
+		{
+			name:            "image tag which is not a version is rejected outside of development mode",
+			developmentMode: false,
+			expectedCode:    http.StatusBadRequest,
+		},
+		{
+			name:            "image tag which is not a version is accepted in development mode",
+			developmentMode: true,
+			expectedCode:    http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			c := fakeclient.NewClientBuilder().Build()
+			server := httptest.NewServer(NewRequestHandler(c, logr.Discard(), tc.developmentMode))
+			defer server.Close()
+
+			validationResp := sendAdmissionReview(t, server.URL, gatewayConfigurationGVResource, gatewayConfig)
+			require.EqualValues(t, tc.expectedCode, validationResp.Result.Code)
+		})
+	}
+}
+
+func TestHandleAIGatewayValidation(t *testing.T) {
+	c := fakeclient.NewClientBuilder().Build()
+	handler := NewRequestHandler(c, logr.Discard(), false)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	newAIGateway := func(models ...operatorv1alpha1.CloudHostedLargeLanguageModel) *operatorv1alpha1.AIGateway {
+		return &operatorv1alpha1.AIGateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-aigateway", Namespace: "default"},
+			Spec: operatorv1alpha1.AIGatewaySpec{
+				GatewayClassName: "kong",
+				LargeLanguageModels: &operatorv1alpha1.LargeLanguageModels{
+					CloudHosted: models,
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name      string
+		aigateway *operatorv1alpha1.AIGateway
+		errMsg    string
+	}{
+		{
+			name: "validate_ok",
+			aigateway: newAIGateway(
+				operatorv1alpha1.CloudHostedLargeLanguageModel{
+					Identifier:      "devteam-gpt",
+					AICloudProvider: operatorv1alpha1.AICloudProvider{Name: operatorv1alpha1.AICloudProviderOpenAI},
+				},
+				operatorv1alpha1.CloudHostedLargeLanguageModel{
+					Identifier:      "devteam-mistral",
+					AICloudProvider: operatorv1alpha1.AICloudProvider{Name: operatorv1alpha1.AICloudProviderMistral},
+				},
+			),
+		},
+		{
+			name: "validate_error:duplicate_identifier",
+			aigateway: newAIGateway(
+				operatorv1alpha1.CloudHostedLargeLanguageModel{
+					Identifier:      "devteam-gpt",
+					AICloudProvider: operatorv1alpha1.AICloudProvider{Name: operatorv1alpha1.AICloudProviderOpenAI},
+				},
+				operatorv1alpha1.CloudHostedLargeLanguageModel{
+					Identifier:      "devteam-gpt",
+					AICloudProvider: operatorv1alpha1.AICloudProvider{Name: operatorv1alpha1.AICloudProviderAzure},
+				},
+			),
+			errMsg: `duplicate cloud hosted LLM identifier "devteam-gpt"`,
+		},
+		{
+			name: "validate_error:unknown_provider",
+			aigateway: newAIGateway(
+				operatorv1alpha1.CloudHostedLargeLanguageModel{
+					Identifier:      "devteam-llm",
+					AICloudProvider: operatorv1alpha1.AICloudProvider{Name: "unknown"},
+				},
+			),
+			errMsg: `cloud hosted LLM "devteam-llm" uses unknown AI cloud provider "unknown"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			validationResp := sendAdmissionReview(t, server.URL, aiGatewayGVResource, tc.aigateway)
+			if tc.errMsg == "" {
+				require.EqualValues(t, http.StatusOK, validationResp.Result.Code, "response code should be 200 OK")
+			} else {
+				require.EqualValues(t, http.StatusBadRequest, validationResp.Result.Code, "response code should be 400 Bad Request")
+				require.Equal(t, tc.errMsg, validationResp.Result.Message, "result message should contain expected content")
+			}
+		})
+	}
+}
+
+// sendAdmissionReview sends an AdmissionReview creating the provided object
+// to the validation server and returns the response.
+func sendAdmissionReview(
+	t *testing.T, url string, resource metav1.GroupVersionResource, obj client.Object,
+) *admissionv1.AdmissionResponse {
+	t.Helper()
+
+	review := &admissionv1.AdmissionReview{
+		Request: &admissionv1.AdmissionRequest{
+			Resource:  resource,
+			Name:      obj.GetName(),
+			Namespace: obj.GetNamespace(),
+			Operation: admissionv1.Create,
+			Object: runtime.RawExtension{
+				Object: obj,
+			},
+		},
+	}
+
+	buf, err := json.Marshal(review)
+	require.NoError(t, err)
+	req, err := http.NewRequest("POST", url, bytes.NewReader(buf))
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	respReview := &admissionv1.AdmissionReview{}
+	require.NoError(t, json.Unmarshal(body, respReview))
+	return respReview.Response
+}
@@ -5,14 +5,19 @@ import (
 
 	admissionv1 "k8s.io/api/admission/v1"
 
+	operatorv1alpha1 "github.com/kong/gateway-operator/api/v1alpha1"
 	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	aigatewayvalidation "github.com/kong/gateway-operator/internal/validation/aigateway"
 	controlplanevalidation "github.com/kong/gateway-operator/internal/validation/controlplane"
 	dataplanevalidation "github.com/kong/gateway-operator/internal/validation/dataplane"
+	gatewayconfigurationvalidation "github.com/kong/gateway-operator/internal/validation/gatewayconfiguration"
 )
 
 type validator struct {
-	dataplaneValidator    *dataplanevalidation.Validator
-	controlplaneValidator *controlplanevalidation.Validator
+	dataplaneValidator            *dataplanevalidation.Validator
+	controlplaneValidator         *controlplanevalidation.Validator
+	gatewayConfigurationValidator *gatewayconfigurationvalidation.Validator
+	aigatewayValidator            *aigatewayvalidation.Validator
 }
 
 // ValidateControlPlane validates the ControlPlane resource.
@@ -37,3 +42,13 @@ func (v *validator) ValidateDataPlane(ctx context.Context, dataPlane, old operat
 		return nil
 	}
 }
+
+// ValidateGatewayConfiguration validates the GatewayConfiguration resource.
+func (v *validator) ValidateGatewayConfiguration(ctx context.Context, gatewayConfiguration operatorv1beta1.GatewayConfiguration) error {
+	return v.gatewayConfigurationValidator.Validate(&gatewayConfiguration)
+}
+
+// ValidateAIGateway validates the AIGateway resource.
+func (v *validator) ValidateAIGateway(ctx context.Context, aigateway operatorv1alpha1.AIGateway) error {
+	return v.aigatewayValidator.Validate(&aigateway)
+}
@@ -8,8 +8,11 @@ import (
 	"strings"
 
 	"github.com/samber/lo"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/controller/pkg/secrets"
 	"github.com/kong/gateway-operator/modules/manager"
 	"github.com/kong/gateway-operator/modules/manager/logging"
 	"github.com/kong/gateway-operator/modules/manager/metadata"
@@ -32,8 +35,33 @@ func New() *CLI {
 		"Disable leader election for controller manager. Disabling this will not ensure there is only one active controller manager.")
 
 	flagSet.StringVar(&cfg.ControllerName, "controller-name", "", "Controller name to use if other than the default, only needed for multi-tenancy.")
+	flagSet.StringVar(&deferCfg.WatchNamespaces, "watch-namespaces", "",
+		"Comma separated list of namespaces the operator watches and reconciles resources in. If not set, all namespaces are watched. "+
+			"Allows several operator instances to run side by side, with permissions for namespaced resources granted only in their namespaces "+
+			"(see the config/watch_namespaces kustomize overlay). "+
+			"GatewayClasses referencing a GatewayConfiguration outside of these namespaces are not accepted. "+
+			"The admission webhooks only handle objects in these namespaces; with the webhook enabled, instances have to run in separate namespaces.")
 	flagSet.StringVar(&cfg.ClusterCASecretName, "cluster-ca-secret", "kong-operator-ca", "Name of the Secret containing the cluster CA certificate.")
 	flagSet.StringVar(&deferCfg.ClusterCASecretNamespace, "cluster-ca-secret-namespace", "", "Name of the namespace for Secret containing the cluster CA certificate.")
+	flagSet.DurationVar(&cfg.ClusterCARenewBefore, "cluster-ca-renew-before", manager.DefaultConfig().ClusterCARenewBefore,
+		"Duration before the expiry of the cluster CA certificate (when generated by the operator) at which it is rolled over. The previous CA certificate is trusted until it expires.")
+	flagSet.DurationVar(&cfg.ClusterCertificateRenewBefore, "cluster-certificate-renew-before", manager.DefaultConfig().ClusterCertificateRenewBefore,
+		"Duration before the expiry of certificates issued using the cluster CA at which they are reissued. Must be lower than -cluster-ca-renew-before.")
+	flagSet.StringVar(&deferCfg.ClusterCAKeyType, "cluster-ca-key-type", string(manager.DefaultConfig().ClusterCAKeyType),
+		"Type of the private key of the cluster CA (when generated by the operator). A CA generated with a different key type is rolled over. "+
+			"One of: "+keyTypesList()+".")
+	flagSet.StringVar(&deferCfg.ClusterCertificateKeyType, "cluster-certificate-key-type", string(manager.DefaultConfig().ClusterCertificateKeyType),
+		"Type of the private keys of certificates issued to ControlPlanes and DataPlanes. Certificates with a different key type are reissued. "+
+			"One of: "+keyTypesList()+".")
+	flagSet.StringVar(&deferCfg.CertificateIssuer, "certificate-issuer", string(manager.DefaultConfig().CertificateIssuer.Type),
+		"Issuer of the certificates used for mTLS between ControlPlanes and DataPlanes which do not configure their own. "+
+			"One of: LocalCA (signed with the cluster CA), CertificateSigningRequest (requested through the certificates.k8s.io API, "+
+			"the cluster CA Secret has to hold the signer's CA certificates), Secret (read from a pre-provisioned Secret).")
+	flagSet.StringVar(&deferCfg.CertificateIssuerSignerName, "certificate-issuer-signer-name", "",
+		"Signer name set on CertificateSigningRequests. Required when -certificate-issuer is CertificateSigningRequest.")
+	flagSet.StringVar(&deferCfg.CertificateIssuerSecretName, "certificate-issuer-secret", "",
+		"Name prefix of the pre-provisioned Secrets, in the namespace of each ControlPlane and DataPlane, to read certificates from. "+
+			"A Secret is read per purpose: <name>-dataplane-server, <name>-controlplane-client and <name>-controlplane-server. Required when -certificate-issuer is Secret.")
 
 	// controllers for standard APIs and features
 	flagSet.BoolVar(&cfg.GatewayControllerEnabled, "enable-controller-gateway", true, "Enable the Gateway controller.")
@@ -43,6 +71,7 @@ func New() *CLI {
 
 	// controllers for specialized APIs and features
 	flagSet.BoolVar(&cfg.AIGatewayControllerEnabled, "enable-controller-aigateway", false, "Enable the AIGateway controller. (Experimental).")
+	flagSet.BoolVar(&cfg.ControlPlaneExtensionsControllerEnabled, "enable-controller-controlplaneextensions", false, "Enable the controller for ControlPlane extensions (e.g. DataPlaneMetricsExtension). (Experimental).")
 
 	// webhook and validation options
 	flagSet.BoolVar(&deferCfg.ValidatingWebhookEnabled, "enable-validating-webhook", true, "Enable the validating webhook.")
@@ -83,6 +112,14 @@ type flagsForFurtherEvaluation struct {
 	ClusterCASecretNamespace string
 	ValidatingWebhookEnabled bool
 	Version                  bool
+	WatchNamespaces          string
+
+	CertificateIssuer           string
+	CertificateIssuerSignerName string
+	CertificateIssuerSecretName string
+
+	ClusterCAKeyType          string
+	ClusterCertificateKeyType string
 }
 
 const (
@@ -194,6 +231,34 @@ func (c *CLI) Parse(arguments []string) manager.Config {
 		}
 	}
 
+	if c.cfg.ClusterCertificateRenewBefore >= c.cfg.ClusterCARenewBefore {
+		fmt.Println("ERROR: -cluster-certificate-renew-before has to be lower than -cluster-ca-renew-before")
+		os.Exit(1)
+	}
+
+	certificateIssuer, err := c.certificateIssuer()
+	if err != nil {
+		fmt.Printf("ERROR: %v\n", err)
+		os.Exit(1)
+	}
+
+	watchNamespaces, err := c.watchNamespaces()
+	if err != nil {
+		fmt.Printf("ERROR: invalid -watch-namespaces: %v\n", err)
+		os.Exit(1)
+	}
+
+	clusterCAKeyType, err := secrets.ParseKeyType(c.deferFlagValues.ClusterCAKeyType)
+	if err != nil {
+		fmt.Printf("ERROR: invalid -cluster-ca-key-type: %v\n", err)
+		os.Exit(1)
+	}
+	clusterCertificateKeyType, err := secrets.ParseKeyType(c.deferFlagValues.ClusterCertificateKeyType)
+	if err != nil {
+		fmt.Printf("ERROR: invalid -cluster-certificate-key-type: %v\n", err)
+		os.Exit(1)
+	}
+
 	c.cfg.DevelopmentMode = developmentModeEnabled
 	c.cfg.LeaderElection = leaderElection
 	c.cfg.ControllerNamespace = controllerNamespace
@@ -204,10 +269,67 @@ func (c *CLI) Parse(arguments []string) manager.Config {
 	c.cfg.WebhookPort = manager.DefaultConfig().WebhookPort
 	c.cfg.LeaderElectionNamespace = controllerNamespace
 	c.cfg.AnonymousReports = anonymousReportsEnabled
+	c.cfg.CertificateIssuer = certificateIssuer
+	c.cfg.ClusterCAKeyType = clusterCAKeyType
+	c.cfg.ClusterCertificateKeyType = clusterCertificateKeyType
+	c.cfg.WatchNamespaces = watchNamespaces
 
 	return *c.cfg
 }
 
+// certificateIssuer returns the CertificateIssuer configured with the -certificate-issuer* flags.
+func (c *CLI) certificateIssuer() (operatorv1beta1.CertificateIssuer, error) {
+	issuer := operatorv1beta1.CertificateIssuer{
+		Type: operatorv1beta1.CertificateIssuerType(c.deferFlagValues.CertificateIssuer),
+	}
+	switch issuer.Type {
+	case operatorv1beta1.CertificateIssuerTypeLocalCA:
+	case operatorv1beta1.CertificateIssuerTypeCertificateSigningRequest:
+		if c.deferFlagValues.CertificateIssuerSignerName == "" {
+			return issuer, fmt.Errorf("-certificate-issuer-signer-name is required with -certificate-issuer=%s", issuer.Type)
+		}
+		issuer.CertificateSigningRequest = &operatorv1beta1.CertificateSigningRequestIssuer{
+			SignerName: c.deferFlagValues.CertificateIssuerSignerName,
+		}
+	case operatorv1beta1.CertificateIssuerTypeSecret:
+		if c.deferFlagValues.CertificateIssuerSecretName == "" {
+			return issuer, fmt.Errorf("-certificate-issuer-secret is required with -certificate-issuer=%s", issuer.Type)
+		}
+		issuer.Secret = &operatorv1beta1.SecretIssuer{
+			Name: c.deferFlagValues.CertificateIssuerSecretName,
+		}
+	default:
+		return issuer, fmt.Errorf("unsupported -certificate-issuer %q", issuer.Type)
+	}
+	return issuer, nil
+}
+
+// watchNamespaces returns the namespaces configured with the -watch-namespaces flag,
+// or nil when all namespaces should be watched.
+func (c *CLI) watchNamespaces() ([]string, error) {
+	var namespaces []string
+	for _, ns := range strings.Split(c.deferFlagValues.WatchNamespaces, ",") {
+		ns = strings.TrimSpace(ns)
+		if ns == "" {
+			continue
+		}
+		if errs := validation.IsDNS1123Label(ns); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid namespace %q: %s", ns, strings.Join(errs, ", "))
+		}
+		if !lo.Contains(namespaces, ns) {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces, nil
+}
+
+// keyTypesList returns a comma separated list of the supported private key types.
+func keyTypesList() string {
+	return strings.Join(lo.Map(secrets.KeyTypes(), func(kt secrets.KeyType, _ int) string {
+		return string(kt)
+	}), ", ")
+}
+
 // FlagSet returns bare underlying flagset of the cli. It can be used to register
 // additional flags. They will be parsed by Parse() method. Caller needs to take
 // care of values set by flags added to this flagset.
@@ -2,12 +2,15 @@ package cli
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/require"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/controller/pkg/secrets"
 	"github.com/kong/gateway-operator/modules/manager"
 	"github.com/kong/gateway-operator/modules/manager/logging"
 )
@@ -76,6 +79,64 @@ func TestParse(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			name: "certificate issuer using the CertificateSigningRequest API",
+			args: []string{
+				"--certificate-issuer=CertificateSigningRequest",
+				"--certificate-issuer-signer-name=example.com/mtls",
+			},
+			expectedCfg: func() manager.Config {
+				cfg := expectedDefaultCfg()
+				cfg.CertificateIssuer = operatorv1beta1.CertificateIssuer{
+					Type: operatorv1beta1.CertificateIssuerTypeCertificateSigningRequest,
+					CertificateSigningRequest: &operatorv1beta1.CertificateSigningRequestIssuer{
+						SignerName: "example.com/mtls",
+					},
+				}
+				return cfg
+			},
+		},
+		{
+			name: "certificate issuer using a pre-provisioned Secret",
+			args: []string{
+				"--certificate-issuer=Secret",
+				"--certificate-issuer-secret=kong-mtls",
+			},
+			expectedCfg: func() manager.Config {
+				cfg := expectedDefaultCfg()
+				cfg.CertificateIssuer = operatorv1beta1.CertificateIssuer{
+					Type: operatorv1beta1.CertificateIssuerTypeSecret,
+					Secret: &operatorv1beta1.SecretIssuer{
+						Name: "kong-mtls",
+					},
+				}
+				return cfg
+			},
+		},
+		{
+			name: "private key types",
+			args: []string{
+				"--cluster-ca-key-type=rsa-4096",
+				"--cluster-certificate-key-type=ed25519",
+			},
+			expectedCfg: func() manager.Config {
+				cfg := expectedDefaultCfg()
+				cfg.ClusterCAKeyType = secrets.KeyTypeRSA4096
+				cfg.ClusterCertificateKeyType = secrets.KeyTypeEd25519
+				return cfg
+			},
+		},
+		{
+			name: "watch namespaces",
+			args: []string{
+				"--watch-namespaces=team-a, team-b,,team-a",
+			},
+			expectedCfg: func() manager.Config {
+				cfg := expectedDefaultCfg()
+				cfg.WatchNamespaces = []string{"team-a", "team-b"}
+				return cfg
+			},
+		},
 	}
 
 	for _, tC := range testCases {
@@ -143,11 +204,18 @@ func expectedDefaultCfg() manager.Config {
 		KubeconfigPath:                      "",
 		ClusterCASecretName:                 "kong-operator-ca",
 		ClusterCASecretNamespace:            "kong-system",
+		ClusterCARenewBefore:                90 * 24 * time.Hour,
+		ClusterCertificateRenewBefore:       30 * 24 * time.Hour,
+		ClusterCAKeyType:                    secrets.KeyTypeECDSAP256,
+		ClusterCertificateKeyType:           secrets.KeyTypeECDSAP256,
 		GatewayControllerEnabled:            true,
 		ControlPlaneControllerEnabled:       true,
 		DataPlaneControllerEnabled:          true,
 		DataPlaneBlueGreenControllerEnabled: true,
 		ValidatingWebhookEnabled:            true,
 		LoggerOpts:                          &zap.Options{},
+		CertificateIssuer: operatorv1beta1.CertificateIssuer{
+			Type: operatorv1beta1.CertificateIssuerTypeLocalCA,
+		},
 	}
 }
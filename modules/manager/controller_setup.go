@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 
+	configurationv1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1"
 	"github.com/samber/lo"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -14,11 +15,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
+	operatorv1alpha1 "github.com/kong/gateway-operator/api/v1alpha1"
 	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
 	"github.com/kong/gateway-operator/controller/controlplane"
+	"github.com/kong/gateway-operator/controller/controlplane_extensions"
 	"github.com/kong/gateway-operator/controller/dataplane"
 	"github.com/kong/gateway-operator/controller/gateway"
 	"github.com/kong/gateway-operator/controller/gatewayclass"
+	"github.com/kong/gateway-operator/controller/gatewayconfiguration"
 	"github.com/kong/gateway-operator/controller/specialized"
 	"github.com/kong/gateway-operator/internal/utils/index"
 	dataplanevalidator "github.com/kong/gateway-operator/internal/validation/dataplane"
@@ -31,6 +35,8 @@ const (
 	GatewayClassControllerName = "GatewayClass"
 	// GatewayControllerName is the name of the GatewayClass controller.
 	GatewayControllerName = "Gateway"
+	// GatewayConfigurationControllerName is the name of the GatewayConfiguration controller.
+	GatewayConfigurationControllerName = "GatewayConfiguration"
 	// ControlPlaneControllerName is the name of the GatewayClass controller.
 	ControlPlaneControllerName = "ControlPlane"
 	// DataPlaneControllerName is the name of the GatewayClass controller.
@@ -45,6 +51,8 @@ const (
 	DataPlaneOwnedDeploymentFinalizerControllerName = "DataPlaneOwnedDeploymentFinalizer"
 	// AIGatewayControllerName is the name of the GatewayClass controller.
 	AIGatewayControllerName = "AIGateway"
+	// ControlPlaneExtensionsControllerName is the name of the ControlPlane extensions controller.
+	ControlPlaneExtensionsControllerName = "ControlPlaneExtensions"
 )
 
 // SetupControllersShim runs SetupControllers and returns its result as a slice of the map values.
@@ -96,6 +104,11 @@ func setupIndexes(ctx context.Context, mgr manager.Manager, cfg Config) error {
 			return fmt.Errorf("failed to setup index for DataPlane names on ControlPlane: %w", err)
 		}
 	}
+	if cfg.ControlPlaneExtensionsControllerEnabled {
+		if err := index.DataPlaneMetricsExtensionOnControlPlane(ctx, mgr.GetCache()); err != nil {
+			return fmt.Errorf("failed to setup index for DataPlaneMetricsExtensions on ControlPlane: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -140,6 +153,24 @@ func SetupControllers(mgr manager.Manager, c *Config) (map[string]ControllerDef,
 				},
 			},
 		},
+		{
+			Condition: c.AIGatewayControllerEnabled,
+			GVRs: []schema.GroupVersionResource{
+				operatorv1alpha1.AIModelCatalogGVR(),
+			},
+		},
+		{
+			Condition: c.ControlPlaneExtensionsControllerEnabled,
+			GVRs: []schema.GroupVersionResource{
+				operatorv1beta1.ControlPlaneGVR(),
+				operatorv1alpha1.DataPlaneMetricsExtensionGVR(),
+				{
+					Group:    configurationv1.SchemeGroupVersion.Group,
+					Version:  configurationv1.SchemeGroupVersion.Version,
+					Resource: "kongplugins",
+				},
+			},
+		},
 	}
 	checker := k8sutils.CRDChecker{Client: mgr.GetClient()}
 	for _, check := range crdChecks {
@@ -164,6 +195,7 @@ func SetupControllers(mgr manager.Manager, c *Config) (map[string]ControllerDef,
 				Client:          mgr.GetClient(),
 				Scheme:          mgr.GetScheme(),
 				DevelopmentMode: c.DevelopmentMode,
+				WatchNamespaces: c.WatchNamespaces,
 			},
 		},
 		// Gateway controller
@@ -176,27 +208,42 @@ func SetupControllers(mgr manager.Manager, c *Config) (map[string]ControllerDef,
 				DefaultDataPlaneImage: consts.DefaultDataPlaneImage,
 			},
 		},
+		// GatewayConfiguration controller
+		GatewayConfigurationControllerName: {
+			Enabled: c.GatewayControllerEnabled,
+			Controller: &gatewayconfiguration.Reconciler{
+				Client:          mgr.GetClient(),
+				Scheme:          mgr.GetScheme(),
+				DevelopmentMode: c.DevelopmentMode,
+			},
+		},
 		// ControlPlane controller
 		ControlPlaneControllerName: {
 			Enabled: c.GatewayControllerEnabled || c.ControlPlaneControllerEnabled,
 			Controller: &controlplane.Reconciler{
-				Client:                   mgr.GetClient(),
-				Scheme:                   mgr.GetScheme(),
-				ClusterCASecretName:      c.ClusterCASecretName,
-				ClusterCASecretNamespace: c.ClusterCASecretNamespace,
-				DevelopmentMode:          c.DevelopmentMode,
+				Client:                        mgr.GetClient(),
+				Scheme:                        mgr.GetScheme(),
+				ClusterCASecretName:           c.ClusterCASecretName,
+				ClusterCASecretNamespace:      c.ClusterCASecretNamespace,
+				ClusterCertificateRenewBefore: c.ClusterCertificateRenewBefore,
+				CertificateIssuer:             c.CertificateIssuer,
+				ClusterCertificateKeyType:     c.ClusterCertificateKeyType,
+				DevelopmentMode:               c.DevelopmentMode,
 			},
 		},
 		// DataPlane controller
 		DataPlaneControllerName: {
 			Enabled: (c.DataPlaneControllerEnabled || c.GatewayControllerEnabled) && !c.DataPlaneBlueGreenControllerEnabled,
 			Controller: &dataplane.Reconciler{
-				Client:                   mgr.GetClient(),
-				Scheme:                   mgr.GetScheme(),
-				ClusterCASecretName:      c.ClusterCASecretName,
-				ClusterCASecretNamespace: c.ClusterCASecretNamespace,
-				DevelopmentMode:          c.DevelopmentMode,
-				Validator:                dataplanevalidator.NewValidator(mgr.GetClient()),
+				Client:                        mgr.GetClient(),
+				Scheme:                        mgr.GetScheme(),
+				ClusterCASecretName:           c.ClusterCASecretName,
+				ClusterCASecretNamespace:      c.ClusterCASecretNamespace,
+				ClusterCertificateRenewBefore: c.ClusterCertificateRenewBefore,
+				CertificateIssuer:             c.CertificateIssuer,
+				ClusterCertificateKeyType:     c.ClusterCertificateKeyType,
+				DevelopmentMode:               c.DevelopmentMode,
+				Validator:                     dataplanevalidator.NewValidator(mgr.GetClient()),
 				Callbacks: dataplane.DataPlaneCallbacks{
 					BeforeDeployment: dataplane.CreateCallbackManager(),
 					AfterDeployment:  dataplane.CreateCallbackManager(),
@@ -208,18 +255,24 @@ func SetupControllers(mgr manager.Manager, c *Config) (map[string]ControllerDef,
 		DataPlaneBlueGreenControllerName: {
 			Enabled: c.DataPlaneBlueGreenControllerEnabled,
 			Controller: &dataplane.BlueGreenReconciler{
-				Client:                   mgr.GetClient(),
-				DevelopmentMode:          c.DevelopmentMode,
-				ClusterCASecretName:      c.ClusterCASecretName,
-				ClusterCASecretNamespace: c.ClusterCASecretNamespace,
+				Client:                        mgr.GetClient(),
+				DevelopmentMode:               c.DevelopmentMode,
+				ClusterCASecretName:           c.ClusterCASecretName,
+				ClusterCASecretNamespace:      c.ClusterCASecretNamespace,
+				ClusterCertificateRenewBefore: c.ClusterCertificateRenewBefore,
+				CertificateIssuer:             c.CertificateIssuer,
+				ClusterCertificateKeyType:     c.ClusterCertificateKeyType,
 				DataPlaneController: &dataplane.Reconciler{
-					Client:                   mgr.GetClient(),
-					Scheme:                   mgr.GetScheme(),
-					ClusterCASecretName:      c.ClusterCASecretName,
-					ClusterCASecretNamespace: c.ClusterCASecretNamespace,
-					DevelopmentMode:          c.DevelopmentMode,
-					Validator:                dataplanevalidator.NewValidator(mgr.GetClient()),
-					DefaultImage:             consts.DefaultDataPlaneImage,
+					Client:                        mgr.GetClient(),
+					Scheme:                        mgr.GetScheme(),
+					ClusterCASecretName:           c.ClusterCASecretName,
+					ClusterCASecretNamespace:      c.ClusterCASecretNamespace,
+					ClusterCertificateRenewBefore: c.ClusterCertificateRenewBefore,
+					CertificateIssuer:             c.CertificateIssuer,
+					ClusterCertificateKeyType:     c.ClusterCertificateKeyType,
+					DevelopmentMode:               c.DevelopmentMode,
+					Validator:                     dataplanevalidator.NewValidator(mgr.GetClient()),
+					DefaultImage:                  consts.DefaultDataPlaneImage,
 					Callbacks: dataplane.DataPlaneCallbacks{
 						BeforeDeployment: dataplane.CreateCallbackManager(),
 						AfterDeployment:  dataplane.CreateCallbackManager(),
@@ -262,6 +315,14 @@ func SetupControllers(mgr manager.Manager, c *Config) (map[string]ControllerDef,
 				DevelopmentMode: c.DevelopmentMode,
 			},
 		},
+		// ControlPlane extensions controller
+		ControlPlaneExtensionsControllerName: {
+			Enabled: c.ControlPlaneExtensionsControllerEnabled,
+			Controller: &controlplane_extensions.Reconciler{
+				Client:          mgr.GetClient(),
+				DevelopmentMode: c.DevelopmentMode,
+			},
+		},
 	}
 
 	return controllers, nil
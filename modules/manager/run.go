@@ -17,17 +17,19 @@ limitations under the License.
 package manager
 
 import (
+	"bytes"
 	"context"
-	"crypto/ecdsa"
-	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
 	"fmt"
+	"hash/fnv"
 	"math"
 	"math/big"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -38,6 +40,7 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -45,8 +48,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/controller/pkg/secrets"
 	"github.com/kong/gateway-operator/internal/telemetry"
 	"github.com/kong/gateway-operator/modules/manager/metadata"
+	"github.com/kong/gateway-operator/pkg/consts"
 	"github.com/kong/gateway-operator/pkg/vars"
 )
 
@@ -76,6 +82,29 @@ type Config struct {
 	ClusterCASecretNamespace string
 	LoggerOpts               *zap.Options
 
+	// ClusterCARenewBefore is the duration before the expiry of the cluster CA
+	// (when generated by the operator) at which it gets rolled over.
+	// The previous CA is kept in the trust bundle until it expires.
+	ClusterCARenewBefore time.Duration
+	// ClusterCertificateRenewBefore is the duration before the expiry of
+	// certificates issued using the cluster CA at which they get reissued.
+	ClusterCertificateRenewBefore time.Duration
+	// CertificateIssuer is the issuer of the certificates used for mTLS between
+	// ControlPlanes and DataPlanes which do not configure their own.
+	// The cluster CA is generated by the operator only when the LocalCA issuer is used.
+	CertificateIssuer operatorv1beta1.CertificateIssuer
+	// ClusterCAKeyType is the type of the private key generated for the cluster CA.
+	// A cluster CA generated by the operator with a different key type is rolled over.
+	ClusterCAKeyType secrets.KeyType
+	// ClusterCertificateKeyType is the type of the private keys generated for
+	// certificates issued to ControlPlanes and DataPlanes. Certificates with
+	// private keys of a different type are reissued.
+	ClusterCertificateKeyType secrets.KeyType
+	// WatchNamespaces limits the namespaced resources watched and reconciled
+	// by the controllers to the listed namespaces. When empty, all namespaces
+	// are watched.
+	WatchNamespaces []string
+
 	// controllers for standard APIs and features
 	GatewayControllerEnabled            bool
 	ControlPlaneControllerEnabled       bool
@@ -83,7 +112,8 @@ type Config struct {
 	DataPlaneBlueGreenControllerEnabled bool
 
 	// Controllers for speciality APIs and experimental features.
-	AIGatewayControllerEnabled bool
+	AIGatewayControllerEnabled              bool
+	ControlPlaneExtensionsControllerEnabled bool
 
 	// webhook and validation options
 	ValidatingWebhookEnabled bool
@@ -106,6 +136,13 @@ func DefaultConfig() Config {
 		LeaderElectionNamespace:       defaultLeaderElectionNamespace,
 		ClusterCASecretName:           "kong-operator-ca",
 		ClusterCASecretNamespace:      defaultNamespace,
+		ClusterCARenewBefore:          90 * 24 * time.Hour,
+		ClusterCertificateRenewBefore: 30 * 24 * time.Hour,
+		ClusterCAKeyType:              secrets.DefaultKeyType,
+		ClusterCertificateKeyType:     secrets.DefaultKeyType,
+		CertificateIssuer: operatorv1beta1.CertificateIssuer{
+			Type: operatorv1beta1.CertificateIssuerTypeLocalCA,
+		},
 		ControllerNamespace:           defaultNamespace,
 		LoggerOpts:                    &zap.Options{},
 		GatewayControllerEnabled:      true,
@@ -155,8 +192,15 @@ func Run(
 		setupLog.Info("leader election disabled")
 	}
 
+	if len(cfg.WatchNamespaces) > 0 {
+		setupLog.Info("watching namespaces", "namespaces", cfg.WatchNamespaces)
+	} else {
+		setupLog.Info("watching all namespaces")
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme: scheme,
+		Cache:  cacheOptions(cfg),
 		Metrics: server.Options{
 			BindAddress: cfg.MetricsAddr,
 		},
@@ -168,22 +212,28 @@ func Run(
 		HealthProbeBindAddress:  cfg.ProbeAddr,
 		LeaderElection:          cfg.LeaderElection,
 		LeaderElectionNamespace: cfg.LeaderElectionNamespace,
-		LeaderElectionID:        "a7feedc84.konghq.com",
+		LeaderElectionID:        leaderElectionID(cfg),
 		NewClient:               cfg.NewClientFunc,
 	})
 	if err != nil {
 		return err
 	}
 
-	caMgr := &caManager{
-		logger:          ctrl.Log.WithName("ca_manager"),
-		client:          mgr.GetClient(),
-		secretName:      cfg.ClusterCASecretName,
-		secretNamespace: cfg.ClusterCASecretNamespace,
-	}
-	err = mgr.Add(caMgr)
-	if err != nil {
-		return fmt.Errorf("unable to start manager: %w", err)
+	// With other issuers the cluster CA Secret holds the trust bundle of an external
+	// CA, hence it must not be generated by the operator.
+	if cfg.CertificateIssuer.Type == "" || cfg.CertificateIssuer.Type == operatorv1beta1.CertificateIssuerTypeLocalCA {
+		caMgr := &caManager{
+			logger:          ctrl.Log.WithName("ca_manager"),
+			client:          mgr.GetClient(),
+			secretName:      cfg.ClusterCASecretName,
+			secretNamespace: cfg.ClusterCASecretNamespace,
+			renewBefore:     cfg.ClusterCARenewBefore,
+			keyType:         cfg.ClusterCAKeyType,
+		}
+		err = mgr.Add(caMgr)
+		if err != nil {
+			return fmt.Errorf("unable to start manager: %w", err)
+		}
 	}
 
 	if err := setupIndexes(context.Background(), mgr, cfg); err != nil {
@@ -263,11 +313,76 @@ func Run(
 	return nil
 }
 
+// cacheOptions returns the options of the manager's cache. When the manager is
+// limited to a set of namespaces, namespaced objects are only cached from these
+// namespaces. Secrets are cached from the namespaces of the cluster CA Secret
+// and of the operator (which holds the webhook certificate) as well, so that
+// they can be read without watching any other resources in these namespaces.
+// Other namespaced objects outside of the watched namespaces can't be read, which
+// is why the GatewayClass controller rejects parametersRefs pointing there.
+func cacheOptions(cfg Config) cache.Options {
+	if len(cfg.WatchNamespaces) == 0 {
+		return cache.Options{}
+	}
+
+	namespaces := func(names ...string) map[string]cache.Config {
+		m := make(map[string]cache.Config, len(names))
+		for _, name := range names {
+			if name != "" {
+				m[name] = cache.Config{}
+			}
+		}
+		return m
+	}
+
+	return cache.Options{
+		DefaultNamespaces: namespaces(cfg.WatchNamespaces...),
+		ByObject: map[client.Object]cache.ByObject{
+			&corev1.Secret{}: {
+				Namespaces: namespaces(append(
+					slices.Clone(cfg.WatchNamespaces), cfg.ClusterCASecretNamespace, cfg.ControllerNamespace,
+				)...),
+			},
+		},
+	}
+}
+
+// leaderElectionID returns the ID of the manager's leader election lock. Managers
+// limited to different sets of namespaces use different locks, so that they can
+// run side by side in the same namespace.
+func leaderElectionID(cfg Config) string {
+	const defaultLeaderElectionID = "a7feedc84.konghq.com"
+	if len(cfg.WatchNamespaces) == 0 {
+		return defaultLeaderElectionID
+	}
+	return fmt.Sprintf("a7feedc84-%s.konghq.com", watchNamespacesHash(cfg))
+}
+
+// watchNamespacesHash returns a hash of the namespaces the manager is limited to,
+// regardless of their order. It is used to tell apart the cluster-wide resources
+// of managers limited to different sets of namespaces.
+func watchNamespacesHash(cfg Config) string {
+	namespaces := slices.Clone(cfg.WatchNamespaces)
+	slices.Sort(namespaces)
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(strings.Join(namespaces, ",")))
+	return fmt.Sprintf("%08x", h.Sum32())
+}
+
+// caManagerCheckInterval is the interval at which the CA manager checks whether
+// the cluster CA has to be rolled over.
+const caManagerCheckInterval = time.Hour
+
+// clusterCAValidity is the validity of the cluster CA certificates generated by the operator.
+const clusterCAValidity = time.Second * 315400000
+
 type caManager struct {
 	logger          logr.Logger
 	client          client.Client
 	secretName      string
 	secretNamespace string
+	renewBefore     time.Duration
+	keyType         secrets.KeyType
 }
 
 // Start starts the CA manager.
@@ -278,47 +393,32 @@ func (m *caManager) Start(ctx context.Context) error {
 	if m.secretNamespace == "" {
 		return fmt.Errorf("cannot use an empty secret namespace when creating a CA secret")
 	}
-	return m.maybeCreateCACertificate(ctx)
+	if err := m.maybeCreateCACertificate(ctx); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(caManagerCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := m.maybeCreateCACertificate(ctx); err != nil {
+				m.logger.Error(err, "failed checking the CA certificate")
+			}
+		}
+	}
 }
 
 func (m *caManager) maybeCreateCACertificate(ctx context.Context) error {
-	// TODO https://github.com/Kong/gateway-operator/issues/108 this also needs to check if the CA is expired and
-	// managed, and needs to reissue it (and all issued certificates) if so
 	ca := &corev1.Secret{}
 	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
 	defer cancel()
 	err := m.client.Get(ctx, client.ObjectKey{Namespace: m.secretNamespace, Name: m.secretName}, ca)
 	if k8serrors.IsNotFound(err) {
-		serial, err := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
-		if err != nil {
-			return err
-		}
 		m.logger.Info(fmt.Sprintf("no CA certificate Secret %s found, generating CA certificate", m.secretName))
-		template := x509.Certificate{
-			Subject: pkix.Name{
-				CommonName:   "Kong Gateway Operator CA",
-				Organization: []string{"Kong, Inc."},
-				Country:      []string{"US"},
-			},
-			SerialNumber:          serial,
-			SignatureAlgorithm:    x509.ECDSAWithSHA256,
-			NotBefore:             time.Now(),
-			NotAfter:              time.Now().Add(time.Second * 315400000),
-			KeyUsage:              x509.KeyUsageCertSign + x509.KeyUsageKeyEncipherment + x509.KeyUsageDigitalSignature,
-			BasicConstraintsValid: true,
-			IsCA:                  true,
-		}
-
-		priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
-		if err != nil {
-			return err
-		}
-		privDer, err := x509.MarshalECPrivateKey(priv)
-		if err != nil {
-			return err
-		}
-
-		der, err := x509.CreateCertificate(rand.Reader, &template, &template, priv.Public(), priv)
+		certPEM, keyPEM, err := generateCACertificate(m.keyType)
 		if err != nil {
 			return err
 		}
@@ -327,29 +427,161 @@ func (m *caManager) maybeCreateCACertificate(ctx context.Context) error {
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace: m.secretNamespace,
 				Name:      m.secretName,
+				Labels: map[string]string{
+					consts.GatewayOperatorManagedByLabel: consts.ClusterCAManagedLabelValue,
+				},
 			},
 			Type: corev1.SecretTypeTLS,
-			StringData: map[string]string{
-				"tls.crt": string(pem.EncodeToMemory(&pem.Block{
-					Type:  "CERTIFICATE",
-					Bytes: der,
-				})),
-
-				"tls.key": string(pem.EncodeToMemory(&pem.Block{
-					Type:  "EC PRIVATE KEY",
-					Bytes: privDer,
-				})),
+			Data: map[string][]byte{
+				consts.TLSCRT: certPEM,
+				consts.TLSKey: keyPEM,
+				consts.CACRT:  certPEM,
 			},
 		}
-		err = m.client.Create(ctx, signedSecret)
+		return m.client.Create(ctx, signedSecret)
+	} else if err != nil {
+		return err
+	}
+
+	return m.maybeRolloverCACertificate(ctx, ca)
+}
+
+// maybeRolloverCACertificate replaces the CA certificate stored in the provided
+// Secret with a new one when it is about to expire or when its private key is not
+// of the configured type. The previous CA certificate
+// is kept in the Secret's trust bundle until it expires so that certificates
+// issued by it keep being trusted until they get reissued.
+// CA certificates which were not generated by the operator are never rolled over.
+func (m *caManager) maybeRolloverCACertificate(ctx context.Context, ca *corev1.Secret) error {
+	certs, err := secrets.ParsePEMCertificates(ca.Data[consts.TLSCRT])
+	if err != nil || len(certs) == 0 {
+		return fmt.Errorf("failed parsing CA certificate from Secret %s/%s: %w", ca.Namespace, ca.Name, err)
+	}
+	caCert := certs[0]
+	if !isManagedCACertificate(ca, caCert) {
+		return nil
+	}
+
+	bundle, err := secrets.ParsePEMCertificates(secrets.CATrustBundle(ca))
+	if err != nil {
+		return fmt.Errorf("failed parsing CA trust bundle from Secret %s/%s: %w", ca.Namespace, ca.Name, err)
+	}
+
+	old := ca.DeepCopy()
+	rollover := false
+	if time.Until(caCert.NotAfter) < m.renewBefore {
+		m.logger.Info(fmt.Sprintf("CA certificate in Secret %s expires at %s, rolling it over", m.secretName, caCert.NotAfter.Format(time.RFC3339)))
+		rollover = true
+	} else if !m.hasConfiguredKeyType(ca) {
+		m.logger.Info(fmt.Sprintf("CA certificate in Secret %s does not use a %s key, rolling it over", m.secretName, m.configuredKeyType()))
+		rollover = true
+	}
+	if rollover {
+		certPEM, keyPEM, err := generateCACertificate(m.keyType)
 		if err != nil {
 			return err
 		}
+		ca.Data[consts.TLSCRT] = certPEM
+		ca.Data[consts.TLSKey] = keyPEM
+		ca.Data[consts.CACRT] = append(certPEM, encodeUnexpiredCertificates(bundle)...)
+	} else {
+		// Once the previous CA certificates have expired they can be removed from the bundle.
+		ca.Data[consts.CACRT] = encodeUnexpiredCertificates(bundle)
+	}
 
-	} else if err != nil {
-		return err
+	if bytes.Equal(old.Data[consts.CACRT], ca.Data[consts.CACRT]) {
+		return nil
 	}
-	return nil
+	return m.client.Patch(ctx, ca, client.MergeFrom(old))
+}
+
+// configuredKeyType returns the configured type of the CA private key.
+func (m *caManager) configuredKeyType() secrets.KeyType {
+	if m.keyType == "" {
+		return secrets.DefaultKeyType
+	}
+	return m.keyType
+}
+
+// hasConfiguredKeyType returns true when the private key of the CA stored in
+// the provided Secret is of the configured type.
+func (m *caManager) hasConfiguredKeyType(ca *corev1.Secret) bool {
+	priv, err := secrets.ParsePrivateKey(ca.Data[consts.TLSKey])
+	if err != nil {
+		return false
+	}
+	keyType, err := secrets.KeyTypeOf(priv)
+	return err == nil && keyType == m.configuredKeyType()
+}
+
+// isManagedCACertificate returns true when the CA stored in the provided Secret
+// has been generated by the operator.
+func isManagedCACertificate(ca *corev1.Secret, caCert *x509.Certificate) bool {
+	if ca.Labels[consts.GatewayOperatorManagedByLabel] == consts.ClusterCAManagedLabelValue {
+		return true
+	}
+	// CA Secrets generated by older versions of the operator are not labeled.
+	return caCert.Subject.CommonName == consts.ClusterCACommonName &&
+		len(caCert.Subject.Organization) == 1 && caCert.Subject.Organization[0] == "Kong, Inc."
+}
+
+// encodeUnexpiredCertificates returns PEM encoded certificates from the provided
+// list which have not expired yet.
+func encodeUnexpiredCertificates(certs []*x509.Certificate) []byte {
+	var out []byte
+	for _, cert := range certs {
+		if time.Now().After(cert.NotAfter) {
+			continue
+		}
+		out = append(out, pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: cert.Raw,
+		})...)
+	}
+	return out
+}
+
+// generateCACertificate generates a new self-signed CA certificate with a private
+// key of the provided type and returns it along with its private key, both PEM encoded.
+func generateCACertificate(keyType secrets.KeyType) (certPEM []byte, keyPEM []byte, err error) {
+	serial, err := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
+	if err != nil {
+		return nil, nil, err
+	}
+	template := x509.Certificate{
+		Subject: pkix.Name{
+			CommonName:   consts.ClusterCACommonName,
+			Organization: []string{"Kong, Inc."},
+			Country:      []string{"US"},
+		},
+		SerialNumber:          serial,
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(clusterCAValidity),
+		KeyUsage:              x509.KeyUsageCertSign + x509.KeyUsageKeyEncipherment + x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	priv, err := secrets.GeneratePrivateKey(keyType)
+	if err != nil {
+		return nil, nil, err
+	}
+	template.SignatureAlgorithm = secrets.SignatureAlgorithm(priv)
+	keyPEM, err = secrets.EncodePrivateKey(priv)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, priv.Public(), priv)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certPEM = pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: der,
+	})
+	return certPEM, keyPEM, nil
 }
 
 func getKubeconfig(apiServerPath string, kubeconfig string) (*rest.Config, error) {
@@ -0,0 +1,164 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kong/gateway-operator/controller/pkg/secrets"
+	"github.com/kong/gateway-operator/pkg/consts"
+	"github.com/kong/gateway-operator/test/helpers"
+)
+
+func TestCAManagerMaybeCreateCACertificate(t *testing.T) {
+	const (
+		secretName      = "kong-operator-ca"
+		secretNamespace = "kong-system"
+	)
+	secretKey := client.ObjectKey{Namespace: secretNamespace, Name: secretName}
+
+	newCAManager := func(cl client.Client, renewBefore time.Duration) *caManager {
+		return &caManager{
+			logger:          logr.Discard(),
+			client:          cl,
+			secretName:      secretName,
+			secretNamespace: secretNamespace,
+			renewBefore:     renewBefore,
+		}
+	}
+
+	t.Run("CA is generated and rolled over when it is about to expire", func(t *testing.T) {
+		ctx := context.Background()
+		cl := fakectrlruntimeclient.NewClientBuilder().WithScheme(clientgoscheme.Scheme).Build()
+
+		require.NoError(t, newCAManager(cl, time.Hour).maybeCreateCACertificate(ctx))
+		ca := &corev1.Secret{}
+		require.NoError(t, cl.Get(ctx, secretKey, ca))
+		require.Equal(t, consts.ClusterCAManagedLabelValue, ca.Labels[consts.GatewayOperatorManagedByLabel])
+		require.Equal(t, ca.Data[consts.TLSCRT], ca.Data[consts.CACRT])
+		initialCACert := ca.Data[consts.TLSCRT]
+
+		t.Log("verifying that the CA is kept when it is not about to expire")
+		require.NoError(t, newCAManager(cl, time.Hour).maybeCreateCACertificate(ctx))
+		require.NoError(t, cl.Get(ctx, secretKey, ca))
+		require.Equal(t, initialCACert, ca.Data[consts.TLSCRT])
+
+		t.Log("verifying that the CA is rolled over when it is about to expire and both CAs are trusted")
+		require.NoError(t, newCAManager(cl, 2*clusterCAValidity).maybeCreateCACertificate(ctx))
+		require.NoError(t, cl.Get(ctx, secretKey, ca))
+		require.NotEqual(t, initialCACert, ca.Data[consts.TLSCRT])
+		bundle, err := secrets.ParsePEMCertificates(ca.Data[consts.CACRT])
+		require.NoError(t, err)
+		require.Len(t, bundle, 2)
+		current, err := secrets.ParsePEMCertificates(ca.Data[consts.TLSCRT])
+		require.NoError(t, err)
+		previous, err := secrets.ParsePEMCertificates(initialCACert)
+		require.NoError(t, err)
+		require.True(t, bundle[0].Equal(current[0]))
+		require.True(t, bundle[1].Equal(previous[0]))
+	})
+
+	t.Run("CA is rolled over when its key type changes", func(t *testing.T) {
+		ctx := context.Background()
+		cl := fakectrlruntimeclient.NewClientBuilder().WithScheme(clientgoscheme.Scheme).Build()
+
+		require.NoError(t, newCAManager(cl, time.Hour).maybeCreateCACertificate(ctx))
+		ca := &corev1.Secret{}
+		require.NoError(t, cl.Get(ctx, secretKey, ca))
+		initialCACert := ca.Data[consts.TLSCRT]
+
+		t.Log("verifying that the CA is rolled over with a key of the configured type and both CAs are trusted")
+		m := newCAManager(cl, time.Hour)
+		m.keyType = secrets.KeyTypeRSA2048
+		require.NoError(t, m.maybeCreateCACertificate(ctx))
+		require.NoError(t, cl.Get(ctx, secretKey, ca))
+		require.NotEqual(t, initialCACert, ca.Data[consts.TLSCRT])
+		priv, err := secrets.ParsePrivateKey(ca.Data[consts.TLSKey])
+		require.NoError(t, err)
+		keyType, err := secrets.KeyTypeOf(priv)
+		require.NoError(t, err)
+		require.Equal(t, secrets.KeyTypeRSA2048, keyType)
+		bundle, err := secrets.ParsePEMCertificates(ca.Data[consts.CACRT])
+		require.NoError(t, err)
+		require.Len(t, bundle, 2)
+
+		t.Log("verifying that the CA is kept once it uses a key of the configured type")
+		rolledOverCACert := ca.Data[consts.TLSCRT]
+		require.NoError(t, m.maybeCreateCACertificate(ctx))
+		require.NoError(t, cl.Get(ctx, secretKey, ca))
+		require.Equal(t, rolledOverCACert, ca.Data[consts.TLSCRT])
+	})
+
+	t.Run("CA which was not generated by the operator is not rolled over", func(t *testing.T) {
+		ctx := context.Background()
+		userCACert := helpers.CreateCA(t)
+		userCA := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secretName,
+				Namespace: secretNamespace,
+			},
+			Data: map[string][]byte{
+				consts.TLSCRT: userCACert.CertPEM.Bytes(),
+				consts.TLSKey: userCACert.KeyPEM.Bytes(),
+			},
+		}
+		cl := fakectrlruntimeclient.NewClientBuilder().
+			WithScheme(clientgoscheme.Scheme).
+			WithObjects(userCA.DeepCopy()).
+			Build()
+
+		require.NoError(t, newCAManager(cl, 2*clusterCAValidity).maybeCreateCACertificate(ctx))
+		ca := &corev1.Secret{}
+		require.NoError(t, cl.Get(ctx, secretKey, ca))
+		require.Equal(t, userCA.Data, ca.Data)
+	})
+}
+
+func TestCacheOptions(t *testing.T) {
+	cfg := DefaultConfig()
+	require.Empty(t, cacheOptions(cfg).DefaultNamespaces)
+	require.Empty(t, cacheOptions(cfg).ByObject)
+
+	cfg.WatchNamespaces = []string{"team-a", "team-b"}
+	cfg.ClusterCASecretNamespace = "kong-ca"
+	opts := cacheOptions(cfg)
+	require.Equal(t, map[string]cache.Config{
+		"team-a": {},
+		"team-b": {},
+	}, opts.DefaultNamespaces)
+	require.Len(t, opts.ByObject, 1)
+	for obj, byObject := range opts.ByObject {
+		require.IsType(t, &corev1.Secret{}, obj)
+		require.Equal(t, map[string]cache.Config{
+			"team-a":      {},
+			"team-b":      {},
+			"kong-ca":     {},
+			"kong-system": {},
+		}, byObject.Namespaces)
+	}
+	require.Equal(t, []string{"team-a", "team-b"}, cfg.WatchNamespaces, "watched namespaces must not be modified")
+}
+
+func TestLeaderElectionID(t *testing.T) {
+	cfg := DefaultConfig()
+	require.Equal(t, "a7feedc84.konghq.com", leaderElectionID(cfg))
+
+	cfg.WatchNamespaces = []string{"team-a", "team-b"}
+	teams := leaderElectionID(cfg)
+	require.Regexp(t, `^a7feedc84-[0-9a-f]{8}\.konghq\.com$`, teams)
+
+	cfg.WatchNamespaces = []string{"team-b", "team-a"}
+	require.Equal(t, teams, leaderElectionID(cfg), "order of namespaces must not matter")
+
+	cfg.WatchNamespaces = []string{"team-c"}
+	require.NotEqual(t, teams, leaderElectionID(cfg))
+}
@@ -18,10 +18,12 @@ package manager
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path"
+	"strings"
 	"sync"
 	"time"
 
@@ -60,7 +62,7 @@ type webhookManager struct {
 
 // AdmissionRequestHandlerFunc is a function that returns an implementation of admission.RequestHandler,
 // (validation webhook) it's passed to Run function and called later.
-type AdmissionRequestHandlerFunc func(c client.Client, l logr.Logger) *admission.RequestHandler
+type AdmissionRequestHandlerFunc func(c client.Client, l logr.Logger, developmentMode bool) *admission.RequestHandler
 
 // PrepareWebhookServerWithControllers creates a webhook server and adds it to the controller manager.
 // Because the controller runtime 0.14.x doed not allow adding readiness probe after manager starts,
@@ -104,17 +106,17 @@ func (m *webhookManager) PrepareWebhookServerWithControllers(
 func (m *webhookManager) Start(ctx context.Context) error {
 	m.logger.Info("starting webhook manager")
 
-	// create the webhook resources (if they already exist, it is no-op)
-	if err := m.createWebhookResources(ctx); err != nil {
-		return err
-	}
-
 	certSecret := &corev1.Secret{}
 	// check if the certificate secret already exists
 	if err := m.client.Get(ctx, types.NamespacedName{Namespace: m.cfg.ControllerNamespace, Name: consts.WebhookCertificateConfigSecretName}, certSecret); err != nil {
 		if !k8serrors.IsNotFound(err) {
 			return err
 		}
+		// create the webhook resources (if they already exist, it is no-op), the
+		// certificate config jobs patch them with the CA bundle of the new certificate
+		if err := m.createWebhookResources(ctx, nil); err != nil {
+			return err
+		}
 		// no certificate secret found, create all the resources needed to produce it (if they already exist, it is no-op)
 		if err := m.createCertificateConfigResources(ctx); err != nil {
 			return err
@@ -125,6 +127,17 @@ func (m *webhookManager) Start(ctx context.Context) error {
 		if err != nil {
 			return err
 		}
+		// the certificate config jobs only patch the ValidatingWebhookConfiguration,
+		// so that they don't override the failure policy of the defaulting webhook:
+		// the MutatingWebhookConfiguration (and the rules of a pre-existing
+		// ValidatingWebhookConfiguration) are updated with the CA bundle here instead
+		if err := m.createWebhookResources(ctx, certSecret.Data[consts.CAFieldSecret]); err != nil {
+			return err
+		}
+	} else if err := m.createWebhookResources(ctx, certSecret.Data[consts.CAFieldSecret]); err != nil {
+		// the certificate already exists (e.g. on upgrades), hence the certificate config jobs
+		// don't run: the webhook resources are created, or updated, with its CA bundle
+		return err
 	}
 
 	// write the webhook certificate files on the filesystem
@@ -147,8 +160,9 @@ func (m *webhookManager) Start(ctx context.Context) error {
 		}
 	}
 
-	handler := m.admissionRequestHandler(m.mgr.GetClient(), m.logger)
+	handler := m.admissionRequestHandler(m.mgr.GetClient(), m.logger, m.cfg.DevelopmentMode)
 	m.server.Register("/validate", handler)
+	m.server.Register("/mutate", admission.NewDefaultingRequestHandler(m.logger, m.cfg.DevelopmentMode))
 	if err := m.mgr.Add(m.server); err != nil {
 		return err
 	}
@@ -171,7 +185,7 @@ func (m *webhookManager) Start(ctx context.Context) error {
 // createCertificateConfigResources create all the resources needed by the CertificateConfig jobs
 func (m *webhookManager) createCertificateConfigResources(ctx context.Context) error {
 	// create the certificateConfig ServiceAccount
-	serviceAccount := k8sresources.GenerateNewServiceAccountForCertificateConfig(m.cfg.ControllerNamespace, consts.WebhookCertificateConfigName, consts.WebhookCertificateConfigLabelvalue)
+	serviceAccount := k8sresources.GenerateNewServiceAccountForCertificateConfig(m.cfg.ControllerNamespace, m.certificateConfigName(), consts.WebhookCertificateConfigLabelvalue)
 	if err := m.client.Create(ctx, serviceAccount); err != nil {
 		if !k8serrors.IsAlreadyExists(err) {
 			return err
@@ -179,7 +193,7 @@ func (m *webhookManager) createCertificateConfigResources(ctx context.Context) e
 	}
 
 	// create the certificateConfig ClusterRole
-	clusterRole := k8sresources.GenerateNewClusterRoleForCertificateConfig(m.cfg.ControllerNamespace, consts.WebhookCertificateConfigName, consts.WebhookCertificateConfigLabelvalue)
+	clusterRole := k8sresources.GenerateNewClusterRoleForCertificateConfig(m.cfg.ControllerNamespace, m.certificateConfigName(), consts.WebhookCertificateConfigLabelvalue)
 	if err := m.setNamespaceAsOwner(ctx, clusterRole); err != nil {
 		return err
 	}
@@ -190,7 +204,7 @@ func (m *webhookManager) createCertificateConfigResources(ctx context.Context) e
 	}
 
 	// create the certificateConfig ClusterRoleBinding
-	clusterRoleBinding := k8sresources.GenerateNewClusterRoleBindingForCertificateConfig(m.cfg.ControllerNamespace, consts.WebhookCertificateConfigName, consts.WebhookCertificateConfigLabelvalue)
+	clusterRoleBinding := k8sresources.GenerateNewClusterRoleBindingForCertificateConfig(m.cfg.ControllerNamespace, m.certificateConfigName(), consts.WebhookCertificateConfigLabelvalue)
 	if err := m.setNamespaceAsOwner(ctx, clusterRoleBinding); err != nil {
 		return err
 	}
@@ -201,7 +215,7 @@ func (m *webhookManager) createCertificateConfigResources(ctx context.Context) e
 	}
 
 	// create the certificateConfig Role
-	role := k8sresources.GenerateNewRoleForCertificateConfig(m.cfg.ControllerNamespace, consts.WebhookCertificateConfigName, consts.WebhookCertificateConfigLabelvalue)
+	role := k8sresources.GenerateNewRoleForCertificateConfig(m.cfg.ControllerNamespace, m.certificateConfigName(), consts.WebhookCertificateConfigLabelvalue)
 	if err := m.client.Create(ctx, role); err != nil {
 		if !k8serrors.IsAlreadyExists(err) {
 			return err
@@ -209,7 +223,7 @@ func (m *webhookManager) createCertificateConfigResources(ctx context.Context) e
 	}
 
 	// create the certificateConfig RoleBinding
-	roleBinding := k8sresources.GenerateNewRoleBindingForCertificateConfig(m.cfg.ControllerNamespace, consts.WebhookCertificateConfigName, consts.WebhookCertificateConfigLabelvalue)
+	roleBinding := k8sresources.GenerateNewRoleBindingForCertificateConfig(m.cfg.ControllerNamespace, m.certificateConfigName(), consts.WebhookCertificateConfigLabelvalue)
 	if err := m.client.Create(ctx, roleBinding); err != nil {
 		if !k8serrors.IsAlreadyExists(err) {
 			return err
@@ -224,17 +238,24 @@ func (m *webhookManager) createCertificateConfigResources(ctx context.Context) e
 	return nil
 }
 
-func (m *webhookManager) createWebhookResources(ctx context.Context) error {
+// createWebhookResources creates the webhook resources. When caBundle is set, the
+// existing ValidatingWebhookConfiguration and MutatingWebhookConfiguration are
+// updated with the current webhooks and rules and to trust it.
+func (m *webhookManager) createWebhookResources(ctx context.Context, caBundle []byte) error {
 	// create the operator ValidatingWebhookConfiguration
-	validatingWebhookConfiguration := k8sresources.
-		NewValidatingWebhookConfigurationBuilder(consts.WebhookName).
+	validatingWebhookConfigurationBuilder := k8sresources.
+		NewValidatingWebhookConfigurationBuilder(m.webhookConfigurationName()).
 		WithClientConfigKubernetesService(
 			types.NamespacedName{
 				Name:      consts.WebhookServiceName,
 				Namespace: m.cfg.ControllerNamespace,
 			},
 		).
-		Build()
+		WithCABundle(caBundle)
+	if len(m.cfg.WatchNamespaces) > 0 {
+		validatingWebhookConfigurationBuilder.WithNamespaceSelector(m.cfg.WatchNamespaces)
+	}
+	validatingWebhookConfiguration := validatingWebhookConfigurationBuilder.Build()
 	if err := m.setNamespaceAsOwner(ctx, validatingWebhookConfiguration); err != nil {
 		return err
 	}
@@ -242,6 +263,53 @@ func (m *webhookManager) createWebhookResources(ctx context.Context) error {
 		if !k8serrors.IsAlreadyExists(err) {
 			return err
 		}
+		// the ValidatingWebhookConfiguration may have been created by an older version
+		// of the operator, hence its webhooks are replaced so that the rules for the
+		// resources validated by this version are registered
+		if caBundle != nil {
+			patch, err := json.Marshal(map[string]any{"webhooks": validatingWebhookConfiguration.Webhooks})
+			if err != nil {
+				return err
+			}
+			if err := m.client.Patch(ctx, validatingWebhookConfiguration, client.RawPatch(types.MergePatchType, patch)); err != nil {
+				return fmt.Errorf("failed updating ValidatingWebhookConfiguration %s: %w", validatingWebhookConfiguration.Name, err)
+			}
+		}
+	}
+
+	// create the operator MutatingWebhookConfiguration
+	mutatingWebhookConfigurationBuilder := k8sresources.
+		NewMutatingWebhookConfigurationBuilder(m.webhookConfigurationName()).
+		WithClientConfigKubernetesService(
+			types.NamespacedName{
+				Name:      consts.WebhookServiceName,
+				Namespace: m.cfg.ControllerNamespace,
+			},
+		).
+		WithCABundle(caBundle)
+	if len(m.cfg.WatchNamespaces) > 0 {
+		mutatingWebhookConfigurationBuilder.WithNamespaceSelector(m.cfg.WatchNamespaces)
+	}
+	mutatingWebhookConfiguration := mutatingWebhookConfigurationBuilder.Build()
+	if err := m.setNamespaceAsOwner(ctx, mutatingWebhookConfiguration); err != nil {
+		return err
+	}
+	if err := m.client.Create(ctx, mutatingWebhookConfiguration); err != nil {
+		if !k8serrors.IsAlreadyExists(err) {
+			return err
+		}
+		// the MutatingWebhookConfiguration may have been created by an older version
+		// of the operator or before the certificate has been (re)generated, hence
+		// its webhooks are replaced to make sure they trust the current certificate
+		if caBundle != nil {
+			patch, err := json.Marshal(map[string]any{"webhooks": mutatingWebhookConfiguration.Webhooks})
+			if err != nil {
+				return err
+			}
+			if err := m.client.Patch(ctx, mutatingWebhookConfiguration, client.RawPatch(types.MergePatchType, patch)); err != nil {
+				return fmt.Errorf("failed updating MutatingWebhookConfiguration %s: %w", mutatingWebhookConfiguration.Name, err)
+			}
+		}
 	}
 
 	// create the Service needed to expose the operator Webhook
@@ -264,10 +332,10 @@ func (m *webhookManager) createCertificateConfigJobs(ctx context.Context) error
 	}
 	job := k8sresources.GenerateNewWebhookCertificateConfigJob(
 		m.cfg.ControllerNamespace,
-		consts.WebhookCertificateConfigName,
+		m.certificateConfigName(),
 		jobCertificateConfigImage,
 		consts.WebhookCertificateConfigSecretName,
-		consts.WebhookName,
+		m.webhookConfigurationName(),
 	)
 
 	if err := m.client.Create(ctx, job); err != nil {
@@ -290,7 +358,7 @@ func (m *webhookManager) cleanup(ctx context.Context) error {
 func (m *webhookManager) cleanupWebhookResources(ctx context.Context) error {
 	// delete the operator ValidatingWebhookConfiguration
 	validatingWebhookConfiguration := k8sresources.
-		NewValidatingWebhookConfigurationBuilder(consts.WebhookName).
+		NewValidatingWebhookConfigurationBuilder(m.webhookConfigurationName()).
 		WithClientConfigKubernetesService(
 			types.NamespacedName{
 				Name:      consts.WebhookServiceName,
@@ -304,6 +372,22 @@ func (m *webhookManager) cleanupWebhookResources(ctx context.Context) error {
 		}
 	}
 
+	// delete the operator MutatingWebhookConfiguration
+	mutatingWebhookConfiguration := k8sresources.
+		NewMutatingWebhookConfigurationBuilder(m.webhookConfigurationName()).
+		WithClientConfigKubernetesService(
+			types.NamespacedName{
+				Name:      consts.WebhookServiceName,
+				Namespace: m.cfg.ControllerNamespace,
+			},
+		).
+		Build()
+	if err := m.client.Delete(ctx, mutatingWebhookConfiguration); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+
 	// delete the Service needed to expose the operator Webhook
 	webhookService := k8sresources.GenerateNewServiceForCertificateConfig(m.cfg.ControllerNamespace, consts.WebhookServiceName)
 	if err := m.client.Delete(ctx, webhookService); err != nil {
@@ -329,7 +413,7 @@ func (m *webhookManager) cleanupWebhookResources(ctx context.Context) error {
 
 func (m *webhookManager) cleanupCertificateConfigResources(ctx context.Context) error {
 	// delete the certificateConfig ServiceAccount
-	serviceAccount := k8sresources.GenerateNewServiceAccountForCertificateConfig(m.cfg.ControllerNamespace, consts.WebhookCertificateConfigName, consts.WebhookCertificateConfigLabelvalue)
+	serviceAccount := k8sresources.GenerateNewServiceAccountForCertificateConfig(m.cfg.ControllerNamespace, m.certificateConfigName(), consts.WebhookCertificateConfigLabelvalue)
 	if err := m.client.Delete(ctx, serviceAccount); err != nil {
 		if !k8serrors.IsNotFound(err) {
 			return err
@@ -337,7 +421,7 @@ func (m *webhookManager) cleanupCertificateConfigResources(ctx context.Context)
 	}
 
 	// delete the certificateConfig ClusterRole
-	clusterRole := k8sresources.GenerateNewClusterRoleForCertificateConfig(m.cfg.ControllerNamespace, consts.WebhookCertificateConfigName, consts.WebhookCertificateConfigLabelvalue)
+	clusterRole := k8sresources.GenerateNewClusterRoleForCertificateConfig(m.cfg.ControllerNamespace, m.certificateConfigName(), consts.WebhookCertificateConfigLabelvalue)
 	if err := m.client.Delete(ctx, clusterRole); err != nil {
 		if !k8serrors.IsNotFound(err) {
 			return err
@@ -345,7 +429,7 @@ func (m *webhookManager) cleanupCertificateConfigResources(ctx context.Context)
 	}
 
 	// delete the certificateConfig ClusterRoleBinding
-	clusterRoleBinding := k8sresources.GenerateNewClusterRoleBindingForCertificateConfig(m.cfg.ControllerNamespace, consts.WebhookCertificateConfigName, consts.WebhookCertificateConfigLabelvalue)
+	clusterRoleBinding := k8sresources.GenerateNewClusterRoleBindingForCertificateConfig(m.cfg.ControllerNamespace, m.certificateConfigName(), consts.WebhookCertificateConfigLabelvalue)
 	if err := m.client.Delete(ctx, clusterRoleBinding); err != nil {
 		if !k8serrors.IsNotFound(err) {
 			return err
@@ -353,7 +437,7 @@ func (m *webhookManager) cleanupCertificateConfigResources(ctx context.Context)
 	}
 
 	// delete the certificateConfig Role
-	role := k8sresources.GenerateNewRoleForCertificateConfig(m.cfg.ControllerNamespace, consts.WebhookCertificateConfigName, consts.WebhookCertificateConfigLabelvalue)
+	role := k8sresources.GenerateNewRoleForCertificateConfig(m.cfg.ControllerNamespace, m.certificateConfigName(), consts.WebhookCertificateConfigLabelvalue)
 	if err := m.client.Delete(ctx, role); err != nil {
 		if !k8serrors.IsNotFound(err) {
 			return err
@@ -361,7 +445,7 @@ func (m *webhookManager) cleanupCertificateConfigResources(ctx context.Context)
 	}
 
 	// delete the certificateConfig RoleBinding
-	roleBinding := k8sresources.GenerateNewRoleBindingForCertificateConfig(m.cfg.ControllerNamespace, consts.WebhookCertificateConfigName, consts.WebhookCertificateConfigLabelvalue)
+	roleBinding := k8sresources.GenerateNewRoleBindingForCertificateConfig(m.cfg.ControllerNamespace, m.certificateConfigName(), consts.WebhookCertificateConfigLabelvalue)
 	if err := m.client.Delete(ctx, roleBinding); err != nil {
 		if !k8serrors.IsNotFound(err) {
 			return err
@@ -414,6 +498,27 @@ func (m *webhookManager) waitForWebhookCertificate(ctx context.Context, pollTime
 	}
 }
 
+// webhookConfigurationName returns the name of the ValidatingWebhookConfiguration
+// and the MutatingWebhookConfiguration. Managers limited to different sets of
+// namespaces use different names, so that they don't take over or delete each
+// other's webhooks.
+func (m *webhookManager) webhookConfigurationName() string {
+	if len(m.cfg.WatchNamespaces) == 0 {
+		return consts.WebhookName
+	}
+	return fmt.Sprintf("%s-%s.konghq.com", strings.TrimSuffix(consts.WebhookName, ".konghq.com"), watchNamespacesHash(*m.cfg))
+}
+
+// certificateConfigName returns the name of the resources related to the
+// certificate config Jobs, which include a cluster-wide ClusterRole and
+// ClusterRoleBinding named after the manager's set of namespaces.
+func (m *webhookManager) certificateConfigName() string {
+	if len(m.cfg.WatchNamespaces) == 0 {
+		return consts.WebhookCertificateConfigName
+	}
+	return fmt.Sprintf("%s-%s", consts.WebhookCertificateConfigName, watchNamespacesHash(*m.cfg))
+}
+
 // setNamespaceAsOwner sets the namespace as ownerReference for the given objects.
 // This is needed by the operator-related cluster-wide resources that have to be
 // collected when the namespace in which the operator lives is deleted
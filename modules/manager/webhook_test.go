@@ -22,14 +22,20 @@ import (
 	"testing"
 	"time"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/kong/gateway-operator/pkg/consts"
+	k8sresources "github.com/kong/gateway-operator/pkg/utils/kubernetes/resources"
 )
 
 func TestWaitForWebhookCertificate(t *testing.T) {
@@ -102,3 +108,173 @@ func TestWaitForWebhookCertificate(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateWebhookResourcesWithExistingCertificate(t *testing.T) {
+	const namespace = "kong-system"
+	ctx := context.Background()
+
+	testScheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(testScheme))
+
+	// a MutatingWebhookConfiguration left without a CA bundle, e.g. after an upgrade
+	// where the webhook certificate Secret already existed and the certificate
+	// config jobs did not run
+	existing := k8sresources.NewMutatingWebhookConfigurationBuilder(consts.WebhookName).
+		WithClientConfigKubernetesService(types.NamespacedName{Name: consts.WebhookServiceName, Namespace: namespace}).
+		Build()
+	existing.Webhooks[0].FailurePolicy = nil
+	fakeClient := fakectrlruntimeclient.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}},
+			existing,
+		).
+		Build()
+
+	webhookMgr := webhookManager{
+		client: fakeClient,
+		cfg: &Config{
+			ControllerNamespace: namespace,
+		},
+	}
+
+	caBundle := []byte("ca-bundle")
+	require.NoError(t, webhookMgr.createWebhookResources(ctx, caBundle))
+
+	mwc := &admissionregistrationv1.MutatingWebhookConfiguration{}
+	require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: consts.WebhookName}, mwc))
+	require.Len(t, mwc.Webhooks, 1)
+	require.Equal(t, caBundle, mwc.Webhooks[0].ClientConfig.CABundle)
+	require.Equal(t, lo.ToPtr(admissionregistrationv1.Ignore), mwc.Webhooks[0].FailurePolicy)
+	require.Equal(t, namespace, mwc.Webhooks[0].ClientConfig.Service.Namespace)
+
+	t.Run("resources are created with the CA bundle", func(t *testing.T) {
+		fakeClient := fakectrlruntimeclient.NewClientBuilder().
+			WithScheme(testScheme).
+			WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}).
+			Build()
+		webhookMgr.client = fakeClient
+
+		require.NoError(t, webhookMgr.createWebhookResources(ctx, caBundle))
+
+		mwc := &admissionregistrationv1.MutatingWebhookConfiguration{}
+		require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: consts.WebhookName}, mwc))
+		require.Equal(t, caBundle, mwc.Webhooks[0].ClientConfig.CABundle)
+		require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: consts.WebhookServiceName, Namespace: namespace}, &corev1.Service{}))
+	})
+}
+
+func TestCreateWebhookResourcesUpdatesExistingValidatingWebhookRules(t *testing.T) {
+	const namespace = "kong-system"
+	ctx := context.Background()
+
+	testScheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(testScheme))
+
+	// a ValidatingWebhookConfiguration created by an older version of the operator,
+	// which only validated DataPlanes and ControlPlanes
+	existing := k8sresources.NewValidatingWebhookConfigurationBuilder(consts.WebhookName).
+		WithClientConfigKubernetesService(types.NamespacedName{Name: consts.WebhookServiceName, Namespace: namespace}).
+		WithCABundle([]byte("old-ca-bundle")).
+		Build()
+	existing.Webhooks[0].Rules = existing.Webhooks[0].Rules[:2]
+	fakeClient := fakectrlruntimeclient.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}},
+			existing,
+		).
+		Build()
+
+	webhookMgr := webhookManager{
+		client: fakeClient,
+		cfg: &Config{
+			ControllerNamespace: namespace,
+		},
+	}
+
+	caBundle := []byte("ca-bundle")
+	require.NoError(t, webhookMgr.createWebhookResources(ctx, caBundle))
+
+	vwc := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: consts.WebhookName}, vwc))
+	require.Len(t, vwc.Webhooks, 1)
+	require.Equal(t, caBundle, vwc.Webhooks[0].ClientConfig.CABundle)
+	resources := lo.FlatMap(vwc.Webhooks[0].Rules, func(r admissionregistrationv1.RuleWithOperations, _ int) []string {
+		return r.Resources
+	})
+	require.ElementsMatch(t, []string{"dataplanes", "controlplanes", "gatewayconfigurations", "aigateways"}, resources)
+}
+
+func TestWebhookResourcesWithWatchNamespaces(t *testing.T) {
+	ctx := context.Background()
+
+	testScheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(testScheme))
+
+	fakeClient := fakectrlruntimeclient.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a-system"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b-system"}},
+		).
+		Build()
+
+	teamA := webhookManager{
+		client: fakeClient,
+		cfg: &Config{
+			ControllerNamespace: "team-a-system",
+			WatchNamespaces:     []string{"team-a"},
+		},
+	}
+	teamB := webhookManager{
+		client: fakeClient,
+		cfg: &Config{
+			ControllerNamespace: "team-b-system",
+			WatchNamespaces:     []string{"team-b"},
+		},
+	}
+	require.NotEqual(t, teamA.webhookConfigurationName(), teamB.webhookConfigurationName())
+	require.NotEqual(t, teamA.certificateConfigName(), teamB.certificateConfigName())
+
+	caBundle := []byte("ca-bundle")
+	for _, m := range []webhookManager{teamA, teamB} {
+		require.NoError(t, m.createWebhookResources(ctx, caBundle))
+		require.NoError(t, m.createCertificateConfigResources(ctx))
+	}
+
+	namespaceSelector := func(namespace string) *metav1.LabelSelector {
+		return &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{
+					Key:      corev1.LabelMetadataName,
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   []string{namespace},
+				},
+			},
+		}
+	}
+	for m, namespace := range map[*webhookManager]string{&teamA: "team-a", &teamB: "team-b"} {
+		vwc := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+		require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: m.webhookConfigurationName()}, vwc))
+		require.Equal(t, namespaceSelector(namespace), vwc.Webhooks[0].NamespaceSelector)
+		require.Equal(t, m.cfg.ControllerNamespace, vwc.Webhooks[0].ClientConfig.Service.Namespace)
+
+		mwc := &admissionregistrationv1.MutatingWebhookConfiguration{}
+		require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: m.webhookConfigurationName()}, mwc))
+		require.Equal(t, namespaceSelector(namespace), mwc.Webhooks[0].NamespaceSelector)
+
+		require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: m.certificateConfigName()}, &rbacv1.ClusterRole{}))
+		require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: m.certificateConfigName()}, &rbacv1.ClusterRoleBinding{}))
+	}
+
+	t.Log("verifying that cleaning up the resources of one manager leaves the other's in place")
+	require.NoError(t, teamA.cleanup(ctx))
+	err := fakeClient.Get(ctx, types.NamespacedName{Name: teamA.webhookConfigurationName()}, &admissionregistrationv1.ValidatingWebhookConfiguration{})
+	require.True(t, k8serrors.IsNotFound(err))
+	err = fakeClient.Get(ctx, types.NamespacedName{Name: teamA.certificateConfigName()}, &rbacv1.ClusterRole{})
+	require.True(t, k8serrors.IsNotFound(err))
+	require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: teamB.webhookConfigurationName()}, &admissionregistrationv1.ValidatingWebhookConfiguration{}))
+	require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: teamB.webhookConfigurationName()}, &admissionregistrationv1.MutatingWebhookConfiguration{}))
+	require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: teamB.certificateConfigName()}, &rbacv1.ClusterRole{}))
+}
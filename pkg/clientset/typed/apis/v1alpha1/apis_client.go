@@ -29,6 +29,7 @@ import (
 type ApisV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	AIGatewaysGetter
+	AIModelCatalogsGetter
 	DataPlaneMetricsExtensionsGetter
 }
 
@@ -41,6 +42,10 @@ func (c *ApisV1alpha1Client) AIGateways(namespace string) AIGatewayInterface {
 	return newAIGateways(c, namespace)
 }
 
+func (c *ApisV1alpha1Client) AIModelCatalogs(namespace string) AIModelCatalogInterface {
+	return newAIModelCatalogs(c, namespace)
+}
+
 func (c *ApisV1alpha1Client) DataPlaneMetricsExtensions(namespace string) DataPlaneMetricsExtensionInterface {
 	return newDataPlaneMetricsExtensions(c, namespace)
 }
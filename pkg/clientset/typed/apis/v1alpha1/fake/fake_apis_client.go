@@ -32,6 +32,10 @@ func (c *FakeApisV1alpha1) AIGateways(namespace string) v1alpha1.AIGatewayInterf
 	return &FakeAIGateways{c, namespace}
 }
 
+func (c *FakeApisV1alpha1) AIModelCatalogs(namespace string) v1alpha1.AIModelCatalogInterface {
+	return &FakeAIModelCatalogs{c, namespace}
+}
+
 func (c *FakeApisV1alpha1) DataPlaneMetricsExtensions(namespace string) v1alpha1.DataPlaneMetricsExtensionInterface {
 	return &FakeDataPlaneMetricsExtensions{c, namespace}
 }
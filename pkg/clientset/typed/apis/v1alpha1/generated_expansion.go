@@ -20,4 +20,6 @@ package v1alpha1
 
 type AIGatewayExpansion interface{}
 
+type AIModelCatalogExpansion interface{}
+
 type DataPlaneMetricsExtensionExpansion interface{}
@@ -0,0 +1,66 @@
+package consts
+
+import k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+
+// -----------------------------------------------------------------------------
+// Consts - Cluster CA
+// -----------------------------------------------------------------------------
+
+const (
+	// ClusterCACommonName is the common name of the cluster CA certificate
+	// generated by the operator.
+	ClusterCACommonName = "Kong Gateway Operator CA"
+
+	// ClusterCAManagedLabelValue indicates that the cluster CA Secret has been
+	// generated by the operator, and hence that the operator is allowed to
+	// roll it over when it approaches its expiry.
+	ClusterCAManagedLabelValue = "cluster-ca"
+)
+
+// -----------------------------------------------------------------------------
+// Consts - Certificate issuers
+// -----------------------------------------------------------------------------
+
+const (
+	// CertificateSigningRequestAnnotation is set on Secrets holding a certificate
+	// that has been requested through the certificates.k8s.io API but has not
+	// been issued yet. Its value is the name of the CertificateSigningRequest.
+	CertificateSigningRequestAnnotation = OperatorAnnotationPrefix + "certificate-signing-request"
+)
+
+// -----------------------------------------------------------------------------
+// Consts - Certificate rotation conditions
+// -----------------------------------------------------------------------------
+
+const (
+	// CertificateRotatedType is a condition type set on ControlPlanes and DataPlanes
+	// when one of the certificates issued for them by the operator was reissued.
+	// Its LastTransitionTime holds the time of the last reissue.
+	CertificateRotatedType k8sutils.ConditionType = "CertificateRotated"
+)
+
+const (
+	// CertificateRotatedReasonExpiring indicates that a certificate was reissued
+	// because it was about to expire.
+	CertificateRotatedReasonExpiring k8sutils.ConditionReason = "Expiring"
+
+	// CertificateRotatedReasonCAChanged indicates that a certificate was reissued
+	// because the cluster CA (or its trust bundle) has changed.
+	CertificateRotatedReasonCAChanged k8sutils.ConditionReason = "CAChanged"
+
+	// CertificateRotatedReasonSubjectChanged indicates that a certificate was reissued
+	// because it was issued for a different subject.
+	CertificateRotatedReasonSubjectChanged k8sutils.ConditionReason = "SubjectChanged"
+
+	// CertificateRotatedReasonInvalid indicates that a certificate was reissued
+	// because the existing one could not be parsed.
+	CertificateRotatedReasonInvalid k8sutils.ConditionReason = "Invalid"
+
+	// CertificateRotatedReasonSourceChanged indicates that a certificate was
+	// reissued because the pre-provisioned Secret it was read from has changed.
+	CertificateRotatedReasonSourceChanged k8sutils.ConditionReason = "SourceChanged"
+
+	// CertificateRotatedReasonKeyTypeChanged indicates that a certificate was
+	// reissued because its private key is not of the configured key type.
+	CertificateRotatedReasonKeyTypeChanged k8sutils.ConditionReason = "KeyTypeChanged"
+)
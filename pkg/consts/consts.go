@@ -51,6 +51,18 @@ const (
 	// the gateway controller.
 	GatewayManagedLabelValue = "gateway"
 
+	// AnnotationLastAppliedInfrastructureLabels is the annotation key to store the
+	// labels applied to an object from a Gateway's spec.infrastructure.labels.
+	// It allows the operator to remove the labels which were dropped from the
+	// Gateway without interfering with labels from other sources (e.g. users).
+	AnnotationLastAppliedInfrastructureLabels = OperatorLabelPrefix + "last-applied-infrastructure-labels"
+
+	// AnnotationLastAppliedInfrastructureAnnotations is the annotation key to store
+	// the annotations applied to an object from a Gateway's spec.infrastructure.annotations.
+	// It allows the operator to remove the annotations which were dropped from the
+	// Gateway without interfering with annotations from other sources (e.g. users).
+	AnnotationLastAppliedInfrastructureAnnotations = OperatorLabelPrefix + "last-applied-infrastructure-annotations"
+
 	// ServiceSecretLabel is a label that is added to operator related Service
 	// Secrets to designate which Service this particular Secret it used by.
 	ServiceSecretLabel = OperatorLabelPrefix + "service-secret"
@@ -129,7 +141,8 @@ const (
 const (
 	// WebhookCertificateConfigBaseImage is the image to use by the certificate config Jobs.
 	WebhookCertificateConfigBaseImage = "registry.k8s.io/ingress-nginx/kube-webhook-certgen:v1.3.0"
-	// WebhookName is the ValidatingWebhookConfiguration name.
+	// WebhookName is the name of the ValidatingWebhookConfiguration and the
+	// MutatingWebhookConfiguration.
 	WebhookName = "gateway-operator-validation.konghq.com"
 	// WebhookCertificateConfigSecretName is the name of the secret containing the webhook certificate.
 	WebhookCertificateConfigSecretName = "gateway-operator-webhook-certs"
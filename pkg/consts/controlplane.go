@@ -19,6 +19,26 @@ const (
 	// ControlPlaneManagedLabelValue indicates that an object's lifecycle is managed
 	// by the controlplane controller.
 	ControlPlaneManagedLabelValue = "controlplane"
+
+	// KongPluginsAnnotation is the annotation used by the ControlPlane to attach
+	// KongPlugins to Kubernetes resources, e.g. Services.
+	// Its value is a comma separated list of KongPlugin names.
+	KongPluginsAnnotation = "konghq.com/plugins"
+)
+
+// -----------------------------------------------------------------------------
+// Consts - ControlPlane extensions
+// -----------------------------------------------------------------------------
+
+const (
+	// DataPlaneMetricsExtensionPluginNameSuffix is the suffix appended to the
+	// DataPlaneMetricsExtension name to build the name of the Prometheus
+	// KongPlugin managed for it.
+	DataPlaneMetricsExtensionPluginNameSuffix = "-prometheus"
+
+	// DataPlaneMetricsExtensionLabel is the label set on the KongPlugins managed
+	// for DataPlaneMetricsExtensions. Its value is the name of the extension.
+	DataPlaneMetricsExtensionLabel = OperatorLabelPrefix + "dataplane-metrics-extension"
 )
 
 // -----------------------------------------------------------------------------
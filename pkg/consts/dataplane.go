@@ -107,6 +107,12 @@ const (
 	// gateway-operator.konghq.com/service-selector-override: "key1=value,key2=value2"
 	ServiceSelectorOverrideAnnotation = "gateway-operator.konghq.com/service-selector-override"
 
+	// ExternalDNSHostnameAnnotation is the annotation used to request hostnames for
+	// a LoadBalancer Service from external-dns. Hostnames requested in Gateway's
+	// spec.addresses are published through this annotation on the DataPlane's
+	// live ingress Service.
+	ExternalDNSHostnameAnnotation = "external-dns.alpha.kubernetes.io/hostname"
+
 	// DataPlaneProxyContainerName is the name of the Kong proxy container
 	DataPlaneProxyContainerName = "proxy"
 
@@ -167,6 +173,16 @@ const (
 	// backend used for dataplane(Kong gateway). Currently only DBLess mode
 	// (empty, or "off") is supported.
 	EnvVarKongDatabase = "KONG_DATABASE"
+
+	// EnvVarKongStreamListen is the environment variable name to specify the
+	// addresses and ports on which the dataplane(Kong gateway) proxies TCP, UDP
+	// and TLS streams.
+	EnvVarKongStreamListen = "KONG_STREAM_LISTEN"
+
+	// EnvVarKongPortMaps is the environment variable name to specify the mapping
+	// of the ports from which traffic is forwarded to the dataplane(Kong gateway)
+	// to the ports it listens on.
+	EnvVarKongPortMaps = "KONG_PORT_MAPS"
 )
 
 // -----------------------------------------------------------------------------
@@ -178,3 +194,30 @@ const (
 	// to ensure that the resources are not deleted before the DataPlane is deleted.
 	DataPlaneOwnedWaitForOwnerFinalizer = "gateway-operator.konghq.com/wait-for-owner"
 )
+
+// -----------------------------------------------------------------------------
+// Consts - DataPlane Konnect client certificate
+// -----------------------------------------------------------------------------
+
+const (
+	// KonnectClientCertificateVolume is the name of the volume that holds the
+	// client certificate and key used by the DataPlane to authenticate with Konnect.
+	KonnectClientCertificateVolume = "konnect-client-certificate"
+
+	// KonnectClientCertificateVolumeMountPath holds the path where the Konnect
+	// client certificate volume will be mounted.
+	KonnectClientCertificateVolumeMountPath = "/var/konnect-client-certificate"
+
+	// KonnectClientCertificateLabel is the label set on the Secrets (and cert-manager
+	// Certificates) holding the DataPlane's Konnect client certificate.
+	KonnectClientCertificateLabel = OperatorLabelPrefix + "konnect-client-certificate"
+
+	// KonnectClientCertificateLabelValue is the value of the KonnectClientCertificateLabel.
+	KonnectClientCertificateLabelValue = "true"
+
+	// KonnectClientCertificateHashAnnotation is the annotation set on the DataPlane
+	// Deployment's pod template which holds the hash of the Konnect client certificate
+	// currently in use. Whenever the certificate gets rotated the annotation changes
+	// which triggers a rollout of the DataPlane pods so that they pick up the new certificate.
+	KonnectClientCertificateHashAnnotation = OperatorAnnotationPrefix + "konnect-client-certificate-hash"
+)
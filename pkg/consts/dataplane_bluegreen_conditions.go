@@ -16,6 +16,11 @@ const (
 	// make a judgement call if the promotion should happen.
 	DataPlaneConditionReasonRolloutAwaitingPromotion k8sutils.ConditionReason = "AwaitingPromotion"
 
+	// DataPlaneConditionReasonRolloutAwaitingAdvancement is a reason which indicates
+	// a DataPlane Canary rollout has completed its current step and is awaiting
+	// a manual advancement to the next one.
+	DataPlaneConditionReasonRolloutAwaitingAdvancement k8sutils.ConditionReason = "AwaitingAdvancement"
+
 	// DataPlaneConditionReasonRolloutFailed is a reason which indicates a DataPlane
 	// has failed to roll out. This may be caused for example by a Deployment or
 	// a Service failing to get created during a rollout.
@@ -0,0 +1,228 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	operatorerrors "github.com/kong/gateway-operator/internal/errors"
+	gwtypes "github.com/kong/gateway-operator/internal/types"
+)
+
+// -----------------------------------------------------------------------------
+// Gateway Utils - Public Functions - GatewayConfiguration references
+// -----------------------------------------------------------------------------
+
+// ListGatewayClassesForGatewayConfiguration is a helper function to map a list
+// of GatewayClasses which reference the provided GatewayConfiguration in
+// their parametersRef.
+func ListGatewayClassesForGatewayConfiguration(
+	ctx context.Context,
+	c client.Client,
+	gatewayConfig *operatorv1beta1.GatewayConfiguration,
+) ([]gatewayv1.GatewayClass, error) {
+	gatewayClassList := &gatewayv1.GatewayClassList{}
+	if err := c.List(ctx, gatewayClassList); err != nil {
+		return nil, err
+	}
+
+	gatewayClasses := make([]gatewayv1.GatewayClass, 0)
+	for _, gatewayClass := range gatewayClassList.Items {
+		if IsGatewayConfigurationReferencedByGatewayClass(gatewayConfig, &gatewayClass) {
+			gatewayClasses = append(gatewayClasses, gatewayClass)
+		}
+	}
+
+	return gatewayClasses, nil
+}
+
+// ListGatewaysForGatewayConfiguration is a helper function to map a list of
+// Gateways which use the provided GatewayConfiguration, either through their
+// GatewayClass or directly through their infrastructure parametersRef.
+func ListGatewaysForGatewayConfiguration(
+	ctx context.Context,
+	c client.Client,
+	gatewayConfig *operatorv1beta1.GatewayConfiguration,
+) ([]gwtypes.Gateway, error) {
+	gatewayClasses, err := ListGatewayClassesForGatewayConfiguration(ctx, c, gatewayConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	matchingGatewayClasses := make(map[string]struct{}, len(gatewayClasses))
+	for _, gatewayClass := range gatewayClasses {
+		matchingGatewayClasses[gatewayClass.Name] = struct{}{}
+	}
+
+	gatewayList := &gatewayv1.GatewayList{}
+	if err := c.List(ctx, gatewayList); err != nil {
+		return nil, err
+	}
+
+	gateways := make([]gwtypes.Gateway, 0)
+	for _, gateway := range gatewayList.Items {
+		if _, ok := matchingGatewayClasses[string(gateway.Spec.GatewayClassName)]; ok ||
+			IsGatewayConfigurationReferencedByGateway(gatewayConfig, &gateway) {
+			gateways = append(gateways, gateway)
+		}
+	}
+
+	return gateways, nil
+}
+
+// IsGatewayConfigurationReferencedByGatewayClass returns true when the provided
+// GatewayClass references the provided GatewayConfiguration in its parametersRef.
+func IsGatewayConfigurationReferencedByGatewayClass(
+	gatewayConfig *operatorv1beta1.GatewayConfiguration,
+	gatewayClass *gatewayv1.GatewayClass,
+) bool {
+	ref := gatewayClass.Spec.ParametersRef
+	return ref != nil &&
+		string(ref.Group) == operatorv1beta1.SchemeGroupVersion.Group &&
+		string(ref.Kind) == "GatewayConfiguration" &&
+		ref.Namespace != nil && string(*ref.Namespace) == gatewayConfig.Namespace &&
+		ref.Name == gatewayConfig.Name
+}
+
+// IsGatewayConfigurationReferencedByGateway returns true when the provided
+// Gateway references the provided GatewayConfiguration in its infrastructure
+// parametersRef.
+func IsGatewayConfigurationReferencedByGateway(
+	gatewayConfig *operatorv1beta1.GatewayConfiguration,
+	gateway *gwtypes.Gateway,
+) bool {
+	if gateway.Spec.Infrastructure == nil {
+		return false
+	}
+	ref := gateway.Spec.Infrastructure.ParametersRef
+	return ref != nil &&
+		string(ref.Group) == operatorv1beta1.SchemeGroupVersion.Group &&
+		string(ref.Kind) == "GatewayConfiguration" &&
+		gateway.Namespace == gatewayConfig.Namespace &&
+		ref.Name == gatewayConfig.Name
+}
+
+// GetGatewayConfigurationForGatewayClass resolves the parametersRef of the
+// provided GatewayClass and returns the GatewayConfiguration it references.
+// It returns ErrObjectMissingParametersRef when the GatewayClass has no
+// parametersRef, an Invalid StatusError when the parametersRef doesn't
+// reference a GatewayConfiguration, ErrInvalidParametersRef when its namespace
+// or name is missing and a NotFound error when the referenced
+// GatewayConfiguration doesn't exist.
+func GetGatewayConfigurationForGatewayClass(
+	ctx context.Context,
+	c client.Client,
+	gatewayClass *gatewayv1.GatewayClass,
+) (*operatorv1beta1.GatewayConfiguration, error) {
+	if gatewayClass.Spec.ParametersRef == nil {
+		return nil, fmt.Errorf("%w, gatewayClass = %s", operatorerrors.ErrObjectMissingParametersRef, gatewayClass.Name)
+	}
+
+	if string(gatewayClass.Spec.ParametersRef.Group) != operatorv1beta1.SchemeGroupVersion.Group ||
+		string(gatewayClass.Spec.ParametersRef.Kind) != "GatewayConfiguration" {
+		return nil, &k8serrors.StatusError{
+			ErrStatus: metav1.Status{
+				Status: metav1.StatusFailure,
+				Code:   http.StatusBadRequest,
+				Reason: metav1.StatusReasonInvalid,
+				Details: &metav1.StatusDetails{
+					Kind: string(gatewayClass.Spec.ParametersRef.Kind),
+					Causes: []metav1.StatusCause{{
+						Type: metav1.CauseTypeFieldValueNotSupported,
+						Message: fmt.Sprintf("controller only supports %s %s resources for GatewayClass parametersRef",
+							operatorv1beta1.SchemeGroupVersion.Group, "GatewayConfiguration"),
+					}},
+				},
+			},
+		}
+	}
+
+	if gatewayClass.Spec.ParametersRef.Namespace == nil ||
+		*gatewayClass.Spec.ParametersRef.Namespace == "" ||
+		gatewayClass.Spec.ParametersRef.Name == "" {
+		return nil, fmt.Errorf("%w: GatewayClass %s: both namespace and name must be provided", operatorerrors.ErrInvalidParametersRef, gatewayClass.Name)
+	}
+
+	gatewayConfig := new(operatorv1beta1.GatewayConfiguration)
+	return gatewayConfig, c.Get(ctx, client.ObjectKey{
+		Namespace: string(*gatewayClass.Spec.ParametersRef.Namespace),
+		Name:      gatewayClass.Spec.ParametersRef.Name,
+	}, gatewayConfig)
+}
+
+// GetGatewayConfigurationForGateway resolves the infrastructure parametersRef
+// of the provided Gateway and returns the GatewayConfiguration it references,
+// which has to reside in the Gateway's namespace.
+// It returns ErrObjectMissingParametersRef when the Gateway has no
+// infrastructure parametersRef, an Invalid StatusError when the parametersRef
+// doesn't reference a GatewayConfiguration and a NotFound error when the
+// referenced GatewayConfiguration doesn't exist.
+func GetGatewayConfigurationForGateway(
+	ctx context.Context,
+	c client.Client,
+	gateway *gwtypes.Gateway,
+) (*operatorv1beta1.GatewayConfiguration, error) {
+	if gateway.Spec.Infrastructure == nil || gateway.Spec.Infrastructure.ParametersRef == nil {
+		return nil, fmt.Errorf("%w, gateway = %s/%s", operatorerrors.ErrObjectMissingParametersRef, gateway.Namespace, gateway.Name)
+	}
+
+	ref := gateway.Spec.Infrastructure.ParametersRef
+	if string(ref.Group) != operatorv1beta1.SchemeGroupVersion.Group ||
+		string(ref.Kind) != "GatewayConfiguration" {
+		return nil, &k8serrors.StatusError{
+			ErrStatus: metav1.Status{
+				Status: metav1.StatusFailure,
+				Code:   http.StatusBadRequest,
+				Reason: metav1.StatusReasonInvalid,
+				Details: &metav1.StatusDetails{
+					Kind: string(ref.Kind),
+					Causes: []metav1.StatusCause{{
+						Type: metav1.CauseTypeFieldValueNotSupported,
+						Message: fmt.Sprintf("controller only supports %s %s resources for Gateway infrastructure parametersRef",
+							operatorv1beta1.SchemeGroupVersion.Group, "GatewayConfiguration"),
+					}},
+				},
+			},
+		}
+	}
+
+	gatewayConfig := new(operatorv1beta1.GatewayConfiguration)
+	return gatewayConfig, c.Get(ctx, client.ObjectKey{
+		Namespace: gateway.Namespace,
+		Name:      ref.Name,
+	}, gatewayConfig)
+}
+
+// -----------------------------------------------------------------------------
+// Gateway Utils - Public Functions - GatewayConfiguration options
+// -----------------------------------------------------------------------------
+
+// GatewayConfigDataPlaneOptionsToDataPlaneOptions converts the DataPlane options
+// of a GatewayConfiguration to the options of the DataPlanes created from it.
+func GatewayConfigDataPlaneOptionsToDataPlaneOptions(opts operatorv1beta1.GatewayConfigDataPlaneOptions) *operatorv1beta1.DataPlaneOptions {
+	dataPlaneOptions := &operatorv1beta1.DataPlaneOptions{
+		Deployment: opts.Deployment,
+	}
+
+	if opts.Network.Services != nil && opts.Network.Services.Ingress != nil {
+		dataPlaneOptions.Network = operatorv1beta1.DataPlaneNetworkOptions{
+			Services: &operatorv1beta1.DataPlaneServices{
+				Ingress: &operatorv1beta1.DataPlaneServiceOptions{
+					ServiceOptions: operatorv1beta1.ServiceOptions{
+						Type:                  opts.Network.Services.Ingress.Type,
+						Annotations:           opts.Network.Services.Ingress.Annotations,
+						ExternalTrafficPolicy: opts.Network.Services.Ingress.ExternalTrafficPolicy,
+					},
+				},
+			},
+		}
+	}
+
+	return dataPlaneOptions
+}
@@ -0,0 +1,69 @@
+package gateway
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+)
+
+func TestGatewayConfigDataPlaneOptionsToDataPlaneOptions(t *testing.T) {
+	deployment := operatorv1beta1.DataPlaneDeploymentOptions{
+		DeploymentOptions: operatorv1beta1.DeploymentOptions{
+			Replicas: new(int32),
+		},
+	}
+
+	for _, tt := range []struct {
+		name   string
+		input  operatorv1beta1.GatewayConfigDataPlaneOptions
+		output *operatorv1beta1.DataPlaneOptions
+	}{
+		{
+			name: "deployment options only",
+			input: operatorv1beta1.GatewayConfigDataPlaneOptions{
+				Deployment: deployment,
+			},
+			output: &operatorv1beta1.DataPlaneOptions{
+				Deployment: deployment,
+			},
+		},
+		{
+			name: "ingress service options are carried into the network options",
+			input: operatorv1beta1.GatewayConfigDataPlaneOptions{
+				Deployment: deployment,
+				Network: operatorv1beta1.GatewayConfigDataPlaneNetworkOptions{
+					Services: &operatorv1beta1.GatewayConfigDataPlaneServices{
+						Ingress: &operatorv1beta1.GatewayConfigServiceOptions{
+							ServiceOptions: operatorv1beta1.ServiceOptions{
+								Type:                  corev1.ServiceTypeClusterIP,
+								Annotations:           map[string]string{"foo": "bar"},
+								ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyLocal,
+							},
+						},
+					},
+				},
+			},
+			output: &operatorv1beta1.DataPlaneOptions{
+				Deployment: deployment,
+				Network: operatorv1beta1.DataPlaneNetworkOptions{
+					Services: &operatorv1beta1.DataPlaneServices{
+						Ingress: &operatorv1beta1.DataPlaneServiceOptions{
+							ServiceOptions: operatorv1beta1.ServiceOptions{
+								Type:                  corev1.ServiceTypeClusterIP,
+								Annotations:           map[string]string{"foo": "bar"},
+								ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyLocal,
+							},
+						},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.output, GatewayConfigDataPlaneOptionsToDataPlaneOptions(tt.input))
+		})
+	}
+}
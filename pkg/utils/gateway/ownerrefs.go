@@ -112,12 +112,7 @@ func ListHTTPRoutesForGateway(
 
 	var httpRoutes []gwtypes.HTTPRoute
 	for _, httpRoute := range httpRoutesList.Items {
-		if !lo.ContainsBy(httpRoute.Spec.ParentRefs, func(parentRef gwtypes.ParentReference) bool {
-			gwGVK := gateway.GroupVersionKind()
-			return (parentRef.Group != nil && string(*parentRef.Group) == gwGVK.Group) &&
-				(parentRef.Kind != nil && string(*parentRef.Kind) == gwGVK.Kind) &&
-				string(parentRef.Name) == gateway.Name
-		}) {
+		if !isGatewayInParentRefs(gateway, httpRoute.Spec.ParentRefs) {
 			continue
 		}
 
@@ -127,6 +122,83 @@ func ListHTTPRoutesForGateway(
 	return httpRoutes, nil
 }
 
+// ListTCPRoutesForGateway is a helper function which returns a list of TCPRoutes
+// that have the provided Gateway set as parent in their spec.
+func ListTCPRoutesForGateway(
+	ctx context.Context,
+	c client.Client,
+	gateway *gwtypes.Gateway,
+	opts ...client.ListOption,
+) ([]gwtypes.TCPRoute, error) {
+	if gateway.Namespace == "" {
+		return nil, fmt.Errorf("can't list TCPRoutes for gateway: Gateway %s was missing namespace", gateway.Name)
+	}
+
+	var tcpRoutesList gwtypes.TCPRouteList
+	if err := c.List(ctx, &tcpRoutesList, opts...); err != nil {
+		return nil, fmt.Errorf("can't list TCPRoutes for gateway: %w", err)
+	}
+
+	return lo.Filter(tcpRoutesList.Items, func(r gwtypes.TCPRoute, _ int) bool {
+		return isGatewayInParentRefs(gateway, r.Spec.ParentRefs)
+	}), nil
+}
+
+// ListUDPRoutesForGateway is a helper function which returns a list of UDPRoutes
+// that have the provided Gateway set as parent in their spec.
+func ListUDPRoutesForGateway(
+	ctx context.Context,
+	c client.Client,
+	gateway *gwtypes.Gateway,
+	opts ...client.ListOption,
+) ([]gwtypes.UDPRoute, error) {
+	if gateway.Namespace == "" {
+		return nil, fmt.Errorf("can't list UDPRoutes for gateway: Gateway %s was missing namespace", gateway.Name)
+	}
+
+	var udpRoutesList gwtypes.UDPRouteList
+	if err := c.List(ctx, &udpRoutesList, opts...); err != nil {
+		return nil, fmt.Errorf("can't list UDPRoutes for gateway: %w", err)
+	}
+
+	return lo.Filter(udpRoutesList.Items, func(r gwtypes.UDPRoute, _ int) bool {
+		return isGatewayInParentRefs(gateway, r.Spec.ParentRefs)
+	}), nil
+}
+
+// ListTLSRoutesForGateway is a helper function which returns a list of TLSRoutes
+// that have the provided Gateway set as parent in their spec.
+func ListTLSRoutesForGateway(
+	ctx context.Context,
+	c client.Client,
+	gateway *gwtypes.Gateway,
+	opts ...client.ListOption,
+) ([]gwtypes.TLSRoute, error) {
+	if gateway.Namespace == "" {
+		return nil, fmt.Errorf("can't list TLSRoutes for gateway: Gateway %s was missing namespace", gateway.Name)
+	}
+
+	var tlsRoutesList gwtypes.TLSRouteList
+	if err := c.List(ctx, &tlsRoutesList, opts...); err != nil {
+		return nil, fmt.Errorf("can't list TLSRoutes for gateway: %w", err)
+	}
+
+	return lo.Filter(tlsRoutesList.Items, func(r gwtypes.TLSRoute, _ int) bool {
+		return isGatewayInParentRefs(gateway, r.Spec.ParentRefs)
+	}), nil
+}
+
+// isGatewayInParentRefs returns true if the provided Gateway is referenced in the
+// provided route's parent references.
+func isGatewayInParentRefs(gateway *gwtypes.Gateway, parentRefs []gwtypes.ParentReference) bool {
+	return lo.ContainsBy(parentRefs, func(parentRef gwtypes.ParentReference) bool {
+		gwGVK := gateway.GroupVersionKind()
+		return (parentRef.Group != nil && string(*parentRef.Group) == gwGVK.Group) &&
+			(parentRef.Kind != nil && string(*parentRef.Kind) == gwGVK.Kind) &&
+			string(parentRef.Name) == gateway.Name
+	})
+}
+
 // GetDataPlaneForControlPlane retrieves the DataPlane object referenced by a ControlPlane
 func GetDataPlaneForControlPlane(
 	ctx context.Context,
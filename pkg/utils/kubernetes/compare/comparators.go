@@ -25,6 +25,14 @@ func ControlPlaneDeploymentOptionsDeepEqual(o1, o2 *operatorv1beta1.ControlPlane
 		return false
 	}
 
+	if !reflect.DeepEqual(o1.Scaling, o2.Scaling) {
+		return false
+	}
+
+	if !reflect.DeepEqual(o1.PodDisruptionBudget, o2.PodDisruptionBudget) {
+		return false
+	}
+
 	opts := []cmp.Option{
 		cmp.Comparer(resources.ResourceRequirementsEqual),
 		cmp.Comparer(func(a, b []corev1.EnvVar) bool {
@@ -25,6 +25,7 @@ func GenerateNewClusterRoleForCertificateConfig(namespace, name, labelValue stri
 					"admissionregistration.k8s.io",
 				},
 				Resources: []string{
+					"mutatingwebhookconfigurations",
 					"validatingwebhookconfigurations",
 				},
 				Verbs: []string{
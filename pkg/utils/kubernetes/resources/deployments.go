@@ -79,7 +79,8 @@ func GenerateNewDeploymentForControlPlane(params GenerateNewDeploymentForControl
 				ObjectMeta: metav1.ObjectMeta{
 					CreationTimestamp: metav1.Time{},
 					Labels: map[string]string{
-						"app": params.ControlPlane.Name,
+						"app":                                params.ControlPlane.Name,
+						consts.GatewayOperatorManagedByLabel: consts.ControlPlaneManagedLabelValue,
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -101,6 +102,24 @@ func GenerateNewDeploymentForControlPlane(params GenerateNewDeploymentForControl
 			},
 		},
 	}
+	cpOpts := params.ControlPlane.Spec.Deployment
+	switch {
+	// When the replicas are set and scaling is unset then set the replicas
+	// to the value of the replicas field.
+	case cpOpts.Replicas != nil && cpOpts.Scaling == nil:
+		deployment.Spec.Replicas = cpOpts.Replicas
+
+	// When replicas field is unset and scaling is set, we set the replicas
+	// to the minReplicas value (if it's specified).
+	// We do this to ensure immediate scaling up to the minReplicas value
+	// before the HPA kicks in.
+	case cpOpts.Replicas == nil &&
+		cpOpts.Scaling != nil &&
+		cpOpts.Scaling.HorizontalScaling != nil &&
+		cpOpts.Scaling.HorizontalScaling.MinReplicas != nil:
+		deployment.Spec.Replicas = cpOpts.Scaling.HorizontalScaling.MinReplicas
+	}
+
 	SetDefaultsPodTemplateSpec(&deployment.Spec.Template)
 	LabelObjectAsControlPlaneManaged(deployment)
 
@@ -211,7 +230,8 @@ func GenerateNewDeploymentForDataPlane(
 				ObjectMeta: metav1.ObjectMeta{
 					CreationTimestamp: metav1.Time{},
 					Labels: map[string]string{
-						"app": dataplane.Name,
+						"app":                                dataplane.Name,
+						consts.GatewayOperatorManagedByLabel: consts.DataPlaneManagedLabelValue,
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -433,6 +453,40 @@ func ClusterCertificateVolumeMount() corev1.VolumeMount {
 	}
 }
 
+// KonnectClientCertificateVolume returns a volume holding the Konnect client certificate
+// given a Secret holding the certificate.
+// Only the certificate and the key are projected as the CA certificate might not be
+// present in the Secret, depending on the issuer which signed the certificate.
+func KonnectClientCertificateVolume(certSecretName string) corev1.Volume {
+	konnectClientCertificateVolume := corev1.Volume{}
+	konnectClientCertificateVolume.Secret = &corev1.SecretVolumeSource{}
+	SetDefaultsVolume(&konnectClientCertificateVolume)
+	konnectClientCertificateVolume.Name = consts.KonnectClientCertificateVolume
+	konnectClientCertificateVolume.VolumeSource.Secret = &corev1.SecretVolumeSource{
+		SecretName: certSecretName,
+		Items: []corev1.KeyToPath{
+			{
+				Key:  "tls.crt",
+				Path: "tls.crt",
+			},
+			{
+				Key:  "tls.key",
+				Path: "tls.key",
+			},
+		},
+	}
+	return konnectClientCertificateVolume
+}
+
+// KonnectClientCertificateVolumeMount returns a volume mount for the Konnect client certificate.
+func KonnectClientCertificateVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      consts.KonnectClientCertificateVolume,
+		ReadOnly:  true,
+		MountPath: consts.KonnectClientCertificateVolumeMountPath,
+	}
+}
+
 // Deployment is a wrapper for appsv1.Deployment. It provides additional methods to modify parts of the Deployment,
 // such as to add a Volume or set an environment variable. These "With" methods do not return errors to allow chaining,
 // and may no-op if target subsection is not available or overwrite existing conflicting configuration. If the presence
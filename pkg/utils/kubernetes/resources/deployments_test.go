@@ -187,8 +187,9 @@ func TestGenerateNewDeploymentForDataPlane(t *testing.T) {
 			testFunc: func(t *testing.T, deploymentSpec *appsv1.DeploymentSpec) {
 				require.Equal(t,
 					map[string]string{
-						"app":     "dataplane-name",
-						"label-a": "value-a",
+						"app":                                "dataplane-name",
+						consts.GatewayOperatorManagedByLabel: consts.DataPlaneManagedLabelValue,
+						"label-a":                            "value-a",
 					},
 					deploymentSpec.Template.Labels,
 				)
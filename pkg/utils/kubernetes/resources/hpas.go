@@ -8,6 +8,7 @@ import (
 	pkgapisautoscalingv2 "k8s.io/kubernetes/pkg/apis/autoscaling/v2"
 
 	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/pkg/consts"
 	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
 )
 
@@ -17,17 +18,57 @@ import (
 func GenerateHPAForDataPlane(dataplane *operatorv1beta1.DataPlane, deploymentName string) (
 	*autoscalingv2.HorizontalPodAutoscaler, error,
 ) {
-	if scaling := dataplane.Spec.Deployment.DeploymentOptions.Scaling; scaling == nil || scaling.HorizontalScaling == nil {
+	scaling := dataplane.Spec.Deployment.DeploymentOptions.Scaling
+	if scaling == nil || scaling.HorizontalScaling == nil {
 		return nil, fmt.Errorf("cannot generate HPA for DataPlane %s which doesn't have horizontal autoscaling turned on", dataplane.Name)
 	}
 
-	labels := GetManagedLabelForOwner(dataplane)
-	labels["app"] = dataplane.Name
+	hpa := generateHPA(dataplane, *scaling.HorizontalScaling, deploymentName)
+	hpa.Name = dataplane.Name
+
+	k8sutils.SetOwnerForObject(hpa, dataplane)
+
+	// Set defaults for the HPA so that we don't get a diff when we compare
+	// it with what's in the cluster.
+	pkgapisautoscalingv2.SetDefaults_HorizontalPodAutoscaler(hpa)
+
+	return hpa, nil
+}
+
+// GenerateHPAForControlPlane generate an HPA for the given ControlPlane.
+// The provided deploymentName is the name of the Deployment that the HPA
+// will target using its ScaleTargetRef.
+func GenerateHPAForControlPlane(controlplane *operatorv1beta1.ControlPlane, deploymentName string) (
+	*autoscalingv2.HorizontalPodAutoscaler, error,
+) {
+	scaling := controlplane.Spec.Deployment.Scaling
+	if scaling == nil || scaling.HorizontalScaling == nil {
+		return nil, fmt.Errorf("cannot generate HPA for ControlPlane %s which doesn't have horizontal autoscaling turned on", controlplane.Name)
+	}
+
+	hpa := generateHPA(controlplane, *scaling.HorizontalScaling, deploymentName)
+	hpa.GenerateName = k8sutils.TrimGenerateName(fmt.Sprintf("%s-%s-", consts.ControlPlanePrefix, controlplane.Name))
+
+	k8sutils.SetOwnerForObject(hpa, controlplane)
 
-	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+	// Set defaults for the HPA so that we don't get a diff when we compare
+	// it with what's in the cluster.
+	pkgapisautoscalingv2.SetDefaults_HorizontalPodAutoscaler(hpa)
+
+	return hpa, nil
+}
+
+func generateHPA(
+	owner metav1.Object,
+	scaling operatorv1beta1.HorizontalScaling,
+	deploymentName string,
+) *autoscalingv2.HorizontalPodAutoscaler {
+	labels := GetManagedLabelForOwner(owner)
+	labels["app"] = owner.GetName()
+
+	return &autoscalingv2.HorizontalPodAutoscaler{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      dataplane.Name,
-			Namespace: dataplane.Namespace,
+			Namespace: owner.GetNamespace(),
 			Labels:    labels,
 		},
 		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
@@ -36,19 +77,10 @@ func GenerateHPAForDataPlane(dataplane *operatorv1beta1.DataPlane, deploymentNam
 				Kind:       "Deployment",
 				Name:       deploymentName,
 			},
+			MinReplicas: scaling.MinReplicas,
+			MaxReplicas: scaling.MaxReplicas,
+			Behavior:    scaling.Behavior,
+			Metrics:     scaling.Metrics,
 		},
 	}
-	scaling := dataplane.Spec.Deployment.DeploymentOptions.Scaling
-	hpa.Spec.MinReplicas = scaling.HorizontalScaling.MinReplicas
-	hpa.Spec.MaxReplicas = scaling.HorizontalScaling.MaxReplicas
-	hpa.Spec.Behavior = scaling.HorizontalScaling.Behavior
-	hpa.Spec.Metrics = scaling.HorizontalScaling.Metrics
-
-	k8sutils.SetOwnerForObject(hpa, dataplane)
-
-	// Set defaults for the HPA so that we don't get a diff when we compare
-	// it with what's in the cluster.
-	pkgapisautoscalingv2.SetDefaults_HorizontalPodAutoscaler(hpa)
-
-	return hpa, nil
 }
@@ -0,0 +1,187 @@
+package resources
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/gateway-operator/pkg/consts"
+)
+
+// -----------------------------------------------------------------------------
+// Infrastructure metadata
+// -----------------------------------------------------------------------------
+
+// SetInfrastructureMetadata applies the provided labels and annotations, which
+// come from a Gateway's spec.infrastructure, to the provided object.
+//
+// The keys which were applied are tracked in the AnnotationLastAppliedInfrastructureLabels
+// and AnnotationLastAppliedInfrastructureAnnotations annotations so that the ones
+// which are no longer provided get removed from the object on subsequent calls.
+// Keys which are already set on the object and weren't applied by a previous
+// call are left untouched so that labels and annotations set by the operator,
+// e.g. the ones used in selectors, can't be overridden.
+//
+// It returns true when the object's labels or annotations were changed.
+func SetInfrastructureMetadata(obj metav1.Object, labels, annotations map[string]string) bool {
+	objAnnotations := obj.GetAnnotations()
+	lastAppliedLabels := decodeLastApplied(objAnnotations, consts.AnnotationLastAppliedInfrastructureLabels)
+	lastAppliedAnnotations := decodeLastApplied(objAnnotations, consts.AnnotationLastAppliedInfrastructureAnnotations)
+
+	// Annotations are updated first, without the tracking annotations, so that
+	// the tracking annotations can't be overridden by the ones being applied.
+	objAnnotations = cloneOrEmpty(objAnnotations)
+	delete(objAnnotations, consts.AnnotationLastAppliedInfrastructureLabels)
+	delete(objAnnotations, consts.AnnotationLastAppliedInfrastructureAnnotations)
+	appliedAnnotations := applyTracked(objAnnotations, lastAppliedAnnotations, annotations)
+	objLabels := cloneOrEmpty(obj.GetLabels())
+	appliedLabels := applyTracked(objLabels, lastAppliedLabels, labels)
+
+	setLastApplied(objAnnotations, consts.AnnotationLastAppliedInfrastructureLabels, appliedLabels)
+	setLastApplied(objAnnotations, consts.AnnotationLastAppliedInfrastructureAnnotations, appliedAnnotations)
+
+	changed := !maps.Equal(objLabels, obj.GetLabels()) || !maps.Equal(objAnnotations, obj.GetAnnotations())
+	if changed {
+		obj.SetLabels(nilIfEmpty(objLabels))
+		obj.SetAnnotations(nilIfEmpty(objAnnotations))
+	}
+	return changed
+}
+
+// GetInfrastructureMetadata returns the labels and annotations which were applied
+// to the provided object by SetInfrastructureMetadata.
+func GetInfrastructureMetadata(obj metav1.Object) (labels, annotations map[string]string) {
+	objLabels := obj.GetLabels()
+	objAnnotations := obj.GetAnnotations()
+
+	labels = decodeLastApplied(objAnnotations, consts.AnnotationLastAppliedInfrastructureLabels)
+	for k := range labels {
+		labels[k] = objLabels[k]
+	}
+	annotations = decodeLastApplied(objAnnotations, consts.AnnotationLastAppliedInfrastructureAnnotations)
+	for k := range annotations {
+		annotations[k] = objAnnotations[k]
+	}
+	return labels, annotations
+}
+
+// InheritInfrastructureMetadata applies the labels and annotations which were
+// applied to the owner by SetInfrastructureMetadata to the provided objects,
+// e.g. to the resources generated for a DataPlane or a ControlPlane.
+func InheritInfrastructureMetadata(owner metav1.Object, objs ...metav1.Object) {
+	labels, annotations := GetInfrastructureMetadata(owner)
+	if len(labels) == 0 && len(annotations) == 0 {
+		return
+	}
+	for _, obj := range objs {
+		SetInfrastructureMetadata(obj, labels, annotations)
+	}
+}
+
+// EnsureInfrastructureAnnotationsAreUpdated is an option for k8sutils.EnsureObjectMetaIsUpdated
+// which enforces the annotations applied to the generated object metadata by
+// SetInfrastructureMetadata on the existing one, removing the ones that were
+// applied previously but are no longer present.
+// Labels don't need it as EnsureObjectMetaIsUpdated enforces them as a whole.
+func EnsureInfrastructureAnnotationsAreUpdated(existingMeta, generatedMeta metav1.ObjectMeta) (bool, metav1.ObjectMeta) {
+	annotations := maps.Clone(existingMeta.Annotations)
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	lastApplied := decodeLastApplied(annotations, consts.AnnotationLastAppliedInfrastructureAnnotations)
+	_, desired := GetInfrastructureMetadata(&generatedMeta)
+	for k := range lastApplied {
+		if _, ok := desired[k]; !ok {
+			delete(annotations, k)
+		}
+	}
+	for k, v := range desired {
+		annotations[k] = v
+	}
+	for _, key := range []string{
+		consts.AnnotationLastAppliedInfrastructureLabels,
+		consts.AnnotationLastAppliedInfrastructureAnnotations,
+	} {
+		if v, ok := generatedMeta.Annotations[key]; ok {
+			annotations[key] = v
+		} else {
+			delete(annotations, key)
+		}
+	}
+
+	if maps.Equal(annotations, existingMeta.Annotations) {
+		return false, existingMeta
+	}
+	existingMeta.Annotations = nilIfEmpty(annotations)
+	return true, existingMeta
+}
+
+// applyTracked removes from m the keys from lastApplied which are not present
+// in desired and sets the desired keys which are either not set in m or were
+// applied previously. It returns the keys which were applied.
+func applyTracked(m, lastApplied, desired map[string]string) []string {
+	for k := range lastApplied {
+		if _, ok := desired[k]; !ok {
+			delete(m, k)
+		}
+	}
+
+	applied := make([]string, 0, len(desired))
+	for k, v := range desired {
+		_, wasApplied := lastApplied[k]
+		if _, exists := m[k]; exists && !wasApplied {
+			continue
+		}
+		m[k] = v
+		applied = append(applied, k)
+	}
+	return applied
+}
+
+func decodeLastApplied(annotations map[string]string, key string) map[string]string {
+	lastApplied := map[string]string{}
+	encoded, ok := annotations[key]
+	if !ok {
+		return lastApplied
+	}
+	var keys []string
+	if err := json.Unmarshal([]byte(encoded), &keys); err != nil {
+		// The annotation is managed by the operator, if it got corrupted
+		// the best we can do is to start tracking from scratch.
+		return lastApplied
+	}
+	for _, k := range keys {
+		lastApplied[k] = ""
+	}
+	return lastApplied
+}
+
+func setLastApplied(annotations map[string]string, key string, keys []string) {
+	if len(keys) == 0 {
+		delete(annotations, key)
+		return
+	}
+	slices.Sort(keys)
+	encoded, err := json.Marshal(keys)
+	if err != nil {
+		return
+	}
+	annotations[key] = string(encoded)
+}
+
+func nilIfEmpty(m map[string]string) map[string]string {
+	if len(m) == 0 {
+		return nil
+	}
+	return m
+}
+
+func cloneOrEmpty(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	return maps.Clone(m)
+}
@@ -0,0 +1,125 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/gateway-operator/pkg/consts"
+)
+
+func TestSetInfrastructureMetadata(t *testing.T) {
+	obj := &metav1.ObjectMeta{
+		Labels: map[string]string{
+			"app":  "dataplane",
+			"user": "label",
+		},
+		Annotations: map[string]string{
+			"user": "annotation",
+		},
+	}
+
+	t.Run("labels and annotations are applied without overriding existing ones", func(t *testing.T) {
+		changed := SetInfrastructureMetadata(obj,
+			map[string]string{"team": "a", "cost-center": "1", "app": "overridden"},
+			map[string]string{"owner": "a", "user": "overridden"},
+		)
+		require.True(t, changed)
+		require.Equal(t, map[string]string{
+			"app":         "dataplane",
+			"user":        "label",
+			"team":        "a",
+			"cost-center": "1",
+		}, obj.Labels)
+		require.Equal(t, map[string]string{
+			"user":  "annotation",
+			"owner": "a",
+			consts.AnnotationLastAppliedInfrastructureLabels:      `["cost-center","team"]`,
+			consts.AnnotationLastAppliedInfrastructureAnnotations: `["owner"]`,
+		}, obj.Annotations)
+
+		labels, annotations := GetInfrastructureMetadata(obj)
+		require.Equal(t, map[string]string{"team": "a", "cost-center": "1"}, labels)
+		require.Equal(t, map[string]string{"owner": "a"}, annotations)
+	})
+
+	t.Run("applying the same values is a no-op", func(t *testing.T) {
+		changed := SetInfrastructureMetadata(obj,
+			map[string]string{"team": "a", "cost-center": "1", "app": "overridden"},
+			map[string]string{"owner": "a", "user": "overridden"},
+		)
+		require.False(t, changed)
+	})
+
+	t.Run("dropped values are removed and changed values are updated", func(t *testing.T) {
+		changed := SetInfrastructureMetadata(obj,
+			map[string]string{"team": "b"},
+			nil,
+		)
+		require.True(t, changed)
+		require.Equal(t, map[string]string{
+			"app":  "dataplane",
+			"user": "label",
+			"team": "b",
+		}, obj.Labels)
+		require.Equal(t, map[string]string{
+			"user": "annotation",
+			consts.AnnotationLastAppliedInfrastructureLabels: `["team"]`,
+		}, obj.Annotations)
+	})
+
+	t.Run("removing all values removes the tracking annotations", func(t *testing.T) {
+		changed := SetInfrastructureMetadata(obj, nil, nil)
+		require.True(t, changed)
+		require.Equal(t, map[string]string{
+			"app":  "dataplane",
+			"user": "label",
+		}, obj.Labels)
+		require.Equal(t, map[string]string{
+			"user": "annotation",
+		}, obj.Annotations)
+	})
+}
+
+func TestInheritInfrastructureMetadata(t *testing.T) {
+	owner := &metav1.ObjectMeta{}
+	SetInfrastructureMetadata(owner, map[string]string{"team": "a"}, map[string]string{"owner": "a"})
+
+	obj := &metav1.ObjectMeta{
+		Labels: map[string]string{"app": "dataplane"},
+	}
+	InheritInfrastructureMetadata(owner, obj)
+	require.Equal(t, map[string]string{"app": "dataplane", "team": "a"}, obj.Labels)
+	require.Equal(t, map[string]string{
+		"owner": "a",
+		consts.AnnotationLastAppliedInfrastructureLabels:      `["team"]`,
+		consts.AnnotationLastAppliedInfrastructureAnnotations: `["owner"]`,
+	}, obj.Annotations)
+
+	untouched := &metav1.ObjectMeta{}
+	InheritInfrastructureMetadata(&metav1.ObjectMeta{}, untouched)
+	require.Nil(t, untouched.Labels)
+	require.Nil(t, untouched.Annotations)
+}
+
+func TestEnsureInfrastructureAnnotationsAreUpdated(t *testing.T) {
+	existing := metav1.ObjectMeta{}
+	SetInfrastructureMetadata(&existing, nil, map[string]string{"owner": "a", "dropped": "x"})
+	existing.Annotations["user"] = "annotation"
+
+	generated := metav1.ObjectMeta{}
+	SetInfrastructureMetadata(&generated, map[string]string{"team": "a"}, map[string]string{"owner": "b"})
+
+	changed, updated := EnsureInfrastructureAnnotationsAreUpdated(existing, generated)
+	require.True(t, changed)
+	require.Equal(t, map[string]string{
+		"user":  "annotation",
+		"owner": "b",
+		consts.AnnotationLastAppliedInfrastructureLabels:      `["team"]`,
+		consts.AnnotationLastAppliedInfrastructureAnnotations: `["owner"]`,
+	}, updated.Annotations)
+
+	changed, _ = EnsureInfrastructureAnnotationsAreUpdated(updated, generated)
+	require.False(t, changed)
+}
@@ -0,0 +1,128 @@
+package resources
+
+import (
+	"github.com/samber/lo"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+// -----------------------------------------------------------------------------
+// MutatingWebhookConfiguration generators
+// -----------------------------------------------------------------------------
+
+// MutatingWebhookConfigurationBuilder is a helper to generate a MutatingWebhookConfiguration.
+type MutatingWebhookConfigurationBuilder struct {
+	mwc *admissionregistrationv1.MutatingWebhookConfiguration
+}
+
+// NewMutatingWebhookConfigurationBuilder returns builder for MutatingWebhookConfiguration.
+// Check method to learn more about the default values and available options.
+func NewMutatingWebhookConfigurationBuilder(webhookName string) *MutatingWebhookConfigurationBuilder {
+	namespacedScope := admissionregistrationv1.NamespacedScope
+	return &MutatingWebhookConfigurationBuilder{
+		mwc: &admissionregistrationv1.MutatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: webhookName,
+			},
+			Webhooks: []admissionregistrationv1.MutatingWebhook{
+				{
+					Name: webhookName,
+					Rules: []admissionregistrationv1.RuleWithOperations{
+						{
+							Rule: admissionregistrationv1.Rule{
+								APIGroups:   []string{"gateway-operator.konghq.com"},
+								APIVersions: []string{"v1beta1"},
+								Resources:   []string{"dataplanes"},
+								Scope:       &namespacedScope,
+							},
+							Operations: []admissionregistrationv1.OperationType{
+								admissionregistrationv1.Create,
+								admissionregistrationv1.Update,
+							},
+						},
+						{
+							Rule: admissionregistrationv1.Rule{
+								APIGroups:   []string{"gateway-operator.konghq.com"},
+								APIVersions: []string{"v1beta1"},
+								Resources:   []string{"controlplanes"},
+								Scope:       &namespacedScope,
+							},
+							Operations: []admissionregistrationv1.OperationType{
+								admissionregistrationv1.Create,
+								admissionregistrationv1.Update,
+							},
+						},
+					},
+					AdmissionReviewVersions: []string{"v1", "v1beta1"},
+					// Defaulting is best effort: DataPlanes and ControlPlanes are still admitted
+					// (without the defaults applied) when the webhook cannot be reached.
+					FailurePolicy:      lo.ToPtr(admissionregistrationv1.Ignore),
+					SideEffects:        lo.ToPtr(admissionregistrationv1.SideEffectClassNone),
+					TimeoutSeconds:     lo.ToPtr(int32(5)),
+					ReinvocationPolicy: lo.ToPtr(admissionregistrationv1.IfNeededReinvocationPolicy),
+				},
+			},
+		},
+	}
+}
+
+// WithClientConfigKubernetesService sets the client config to use a Kubernetes service.
+func (m *MutatingWebhookConfigurationBuilder) WithClientConfigKubernetesService(svc k8stypes.NamespacedName) *MutatingWebhookConfigurationBuilder {
+	for i := range m.mwc.Webhooks {
+		m.mwc.Webhooks[i].ClientConfig.Service = &admissionregistrationv1.ServiceReference{
+			Namespace: svc.Namespace,
+			Name:      svc.Name,
+			Path:      lo.ToPtr("/mutate"),
+		}
+	}
+	return m
+}
+
+// WithClientConfigURL sets the client config to use a URL.
+func (m *MutatingWebhookConfigurationBuilder) WithClientConfigURL(url string) *MutatingWebhookConfigurationBuilder {
+	for i := range m.mwc.Webhooks {
+		m.mwc.Webhooks[i].ClientConfig.URL = &url
+	}
+	return m
+}
+
+// WithCABundle sets the CA bundle.
+func (m *MutatingWebhookConfigurationBuilder) WithCABundle(caBundle []byte) *MutatingWebhookConfigurationBuilder {
+	for i := range m.mwc.Webhooks {
+		m.mwc.Webhooks[i].ClientConfig.CABundle = caBundle
+	}
+	return m
+}
+
+// WithScopeAllNamespaces sets the scope for all namespaces (default for the builder is namespace code).
+func (m *MutatingWebhookConfigurationBuilder) WithScopeAllNamespaces() *MutatingWebhookConfigurationBuilder {
+	for i := range m.mwc.Webhooks {
+		for j := range m.mwc.Webhooks[i].Rules {
+			m.mwc.Webhooks[i].Rules[j].Scope = lo.ToPtr(admissionregistrationv1.AllScopes)
+		}
+	}
+	return m
+}
+
+// WithNamespaceSelector limits the webhooks to objects in the provided namespaces.
+func (m *MutatingWebhookConfigurationBuilder) WithNamespaceSelector(namespaces []string) *MutatingWebhookConfigurationBuilder {
+	for i := range m.mwc.Webhooks {
+		m.mwc.Webhooks[i].NamespaceSelector = &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{
+					Key:      corev1.LabelMetadataName,
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   namespaces,
+				},
+			},
+		}
+	}
+	return m
+}
+
+// Build returns the MutatingWebhookConfiguration.
+func (m *MutatingWebhookConfigurationBuilder) Build() *admissionregistrationv1.MutatingWebhookConfiguration {
+	return m.mwc
+}
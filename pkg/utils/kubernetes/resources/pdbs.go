@@ -0,0 +1,73 @@
+package resources
+
+import (
+	"fmt"
+
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+)
+
+// GeneratePodDisruptionBudgetForDataPlane generates a PodDisruptionBudget for
+// the given DataPlane. It selects the pods of all the DataPlane's Deployments,
+// including the preview ones created during rollouts.
+func GeneratePodDisruptionBudgetForDataPlane(dataplane *operatorv1beta1.DataPlane) (*policyv1.PodDisruptionBudget, error) {
+	spec := dataplane.Spec.Deployment.DeploymentOptions.PodDisruptionBudget
+	if spec == nil {
+		return nil, fmt.Errorf("cannot generate PodDisruptionBudget for DataPlane %s which doesn't have it configured", dataplane.Name)
+	}
+
+	pdb := generatePodDisruptionBudget(dataplane, consts.DataPlanePrefix, *spec)
+	k8sutils.SetOwnerForObject(pdb, dataplane)
+	return pdb, nil
+}
+
+// GeneratePodDisruptionBudgetForControlPlane generates a PodDisruptionBudget
+// for the given ControlPlane.
+func GeneratePodDisruptionBudgetForControlPlane(controlplane *operatorv1beta1.ControlPlane) (*policyv1.PodDisruptionBudget, error) {
+	spec := controlplane.Spec.Deployment.PodDisruptionBudget
+	if spec == nil {
+		return nil, fmt.Errorf("cannot generate PodDisruptionBudget for ControlPlane %s which doesn't have it configured", controlplane.Name)
+	}
+
+	pdb := generatePodDisruptionBudget(controlplane, consts.ControlPlanePrefix, *spec)
+	k8sutils.SetOwnerForObject(pdb, controlplane)
+	return pdb, nil
+}
+
+func generatePodDisruptionBudget(
+	owner metav1.Object,
+	prefix string,
+	spec operatorv1beta1.PodDisruptionBudget,
+) *policyv1.PodDisruptionBudget {
+	labels := GetManagedLabelForOwner(owner)
+	labels["app"] = owner.GetName()
+	// The managed-by label tells apart the Pods of DataPlanes and ControlPlanes
+	// which share the same name.
+	selector := GetManagedLabelForOwner(owner)
+	selector["app"] = owner.GetName()
+
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:    owner.GetNamespace(),
+			GenerateName: k8sutils.TrimGenerateName(fmt.Sprintf("%s-%s-", prefix, owner.GetName())),
+			Labels:       labels,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: selector,
+			},
+			MinAvailable:               spec.MinAvailable,
+			MaxUnavailable:             spec.MaxUnavailable,
+			UnhealthyPodEvictionPolicy: spec.UnhealthyPodEvictionPolicy,
+		},
+	}
+	if pdb.Spec.MinAvailable == nil && pdb.Spec.MaxUnavailable == nil {
+		pdb.Spec.MaxUnavailable = &intstr.IntOrString{Type: intstr.Int, IntVal: 1}
+	}
+	return pdb
+}
@@ -0,0 +1,117 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/pkg/consts"
+)
+
+func TestGeneratePodDisruptionBudgetForDataPlane(t *testing.T) {
+	testCases := []struct {
+		name        string
+		pdb         *operatorv1beta1.PodDisruptionBudget
+		expectedErr bool
+		expected    policyv1.PodDisruptionBudgetSpec
+	}{
+		{
+			name:        "not configured",
+			expectedErr: true,
+		},
+		{
+			name: "defaults to one unavailable pod",
+			pdb:  &operatorv1beta1.PodDisruptionBudget{},
+			expected: policyv1.PodDisruptionBudgetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{
+					"app":                                "dp-1",
+					consts.GatewayOperatorManagedByLabel: consts.DataPlaneManagedLabelValue,
+				}},
+				MaxUnavailable: lo.ToPtr(intstr.FromInt32(1)),
+			},
+		},
+		{
+			name: "min available and eviction policy",
+			pdb: &operatorv1beta1.PodDisruptionBudget{
+				MinAvailable:               lo.ToPtr(intstr.FromString("50%")),
+				UnhealthyPodEvictionPolicy: lo.ToPtr(policyv1.AlwaysAllow),
+			},
+			expected: policyv1.PodDisruptionBudgetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{
+					"app":                                "dp-1",
+					consts.GatewayOperatorManagedByLabel: consts.DataPlaneManagedLabelValue,
+				}},
+				MinAvailable:               lo.ToPtr(intstr.FromString("50%")),
+				UnhealthyPodEvictionPolicy: lo.ToPtr(policyv1.AlwaysAllow),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dataplane := &operatorv1beta1.DataPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns",
+					Name:      "dp-1",
+					UID:       "dp-uid",
+				},
+			}
+			dataplane.Spec.Deployment.PodDisruptionBudget = tc.pdb
+
+			pdb, err := GeneratePodDisruptionBudgetForDataPlane(dataplane)
+			if tc.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, pdb.Spec)
+			require.Equal(t, "ns", pdb.Namespace)
+			require.Equal(t, "dataplane-dp-1-", pdb.GenerateName)
+			require.Equal(t, map[string]string{
+				consts.GatewayOperatorManagedByLabel: consts.DataPlaneManagedLabelValue,
+				"app":                                "dp-1",
+			}, pdb.Labels)
+			require.Len(t, pdb.OwnerReferences, 1)
+			require.Equal(t, dataplane.UID, pdb.OwnerReferences[0].UID)
+		})
+	}
+}
+
+func TestGeneratePodDisruptionBudgetForControlPlane(t *testing.T) {
+	controlplane := &operatorv1beta1.ControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "cp-1",
+			UID:       "cp-uid",
+		},
+	}
+
+	_, err := GeneratePodDisruptionBudgetForControlPlane(controlplane)
+	require.Error(t, err)
+
+	controlplane.Spec.Deployment.PodDisruptionBudget = &operatorv1beta1.PodDisruptionBudget{
+		MaxUnavailable: lo.ToPtr(intstr.FromInt32(2)),
+	}
+	pdb, err := GeneratePodDisruptionBudgetForControlPlane(controlplane)
+	require.NoError(t, err)
+	require.Equal(t, policyv1.PodDisruptionBudgetSpec{
+		Selector: &metav1.LabelSelector{MatchLabels: map[string]string{
+			"app":                                "cp-1",
+			consts.GatewayOperatorManagedByLabel: consts.ControlPlaneManagedLabelValue,
+		}},
+		MaxUnavailable: lo.ToPtr(intstr.FromInt32(2)),
+	}, pdb.Spec)
+	require.Equal(t, "controlplane-cp-1-", pdb.GenerateName)
+	require.Equal(t, map[string]string{
+		consts.GatewayOperatorManagedByLabel: consts.ControlPlaneManagedLabelValue,
+		"app":                                "cp-1",
+	}, pdb.Labels)
+	require.Len(t, pdb.OwnerReferences, 1)
+	require.Equal(t, controlplane.UID, pdb.OwnerReferences[0].UID)
+}
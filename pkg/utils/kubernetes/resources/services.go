@@ -3,6 +3,7 @@ package resources
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/google/go-cmp/cmp"
@@ -65,6 +66,7 @@ func GenerateNewIngressServiceForDataPlane(dataplane *operatorv1beta1.DataPlane,
 			ExternalTrafficPolicy: getDataPlaneIngressServiceExternalTrafficPolicy(dataplane),
 		},
 	}
+	setDataPlaneIngressServiceAddresses(svc, dataplane)
 	LabelObjectAsDataPlaneManaged(svc)
 
 	for _, opt := range opts {
@@ -120,6 +122,22 @@ func getDataPlaneIngressServiceExternalTrafficPolicy(dataplane *operatorv1beta1.
 	return dataplane.Spec.Network.Services.Ingress.ExternalTrafficPolicy
 }
 
+// setDataPlaneIngressServiceAddresses sets the load balancer IP and the external IPs
+// requested in the DataPlane's ingress Service options on the provided Service.
+func setDataPlaneIngressServiceAddresses(svc *corev1.Service, dataplane *operatorv1beta1.DataPlane) {
+	if dataplane == nil || dataplane.Spec.Network.Services == nil || dataplane.Spec.Network.Services.Ingress == nil {
+		return
+	}
+
+	ingress := dataplane.Spec.Network.Services.Ingress
+	if ingress.LoadBalancerIP != "" && svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		svc.Spec.LoadBalancerIP = ingress.LoadBalancerIP //nolint:staticcheck
+	}
+	if len(ingress.ExternalIPs) > 0 {
+		svc.Spec.ExternalIPs = slices.Clone(ingress.ExternalIPs)
+	}
+}
+
 // ServiceOpt is an option function for a Service.
 type ServiceOpt func(*corev1.Service)
 
@@ -153,22 +171,36 @@ func ServicePortsFromDataPlaneIngressOpt(dataplane *operatorv1beta1.DataPlane) S
 			len(dataplane.Spec.Network.Services.Ingress.Ports) == 0 {
 			return
 		}
+		type portWithProtocol struct {
+			port     int32
+			protocol corev1.Protocol
+		}
 		newPorts := make([]corev1.ServicePort, 0)
-		alreadyUsedPorts := make(map[int32]struct{})
+		alreadyUsedPorts := make(map[portWithProtocol]struct{})
 		for _, p := range dataplane.Spec.Network.Services.Ingress.Ports {
 			targetPort := intstr.FromInt(consts.DataPlaneProxyPort)
 			if !cmp.Equal(p.TargetPort, intstr.IntOrString{}) {
 				targetPort = p.TargetPort
 			}
-			if _, ok := alreadyUsedPorts[p.Port]; !ok {
+			protocol := corev1.ProtocolTCP
+			if p.Protocol != "" {
+				protocol = p.Protocol
+			}
+			key := portWithProtocol{port: p.Port, protocol: protocol}
+			if _, ok := alreadyUsedPorts[key]; !ok {
+				// Port names have to be unique, hence the protocol suffix for
+				// ports other than TCP which can share the port number.
+				name := fmt.Sprintf("port-%d", p.Port)
+				if protocol != corev1.ProtocolTCP {
+					name = fmt.Sprintf("port-%d-%s", p.Port, strings.ToLower(string(protocol)))
+				}
 				newPorts = append(newPorts, corev1.ServicePort{
-					// Currently, only TCP protocol supported.
-					Name:       fmt.Sprintf("port-%d", p.Port),
-					Protocol:   corev1.ProtocolTCP,
+					Name:       name,
+					Protocol:   protocol,
 					Port:       p.Port,
 					TargetPort: targetPort,
 				})
-				alreadyUsedPorts[p.Port] = struct{}{}
+				alreadyUsedPorts[key] = struct{}{}
 			}
 		}
 		service.Spec.Ports = newPorts
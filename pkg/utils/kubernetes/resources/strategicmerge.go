@@ -100,7 +100,9 @@ func SetDefaultsVolume(v *corev1.Volume) {
 	// and DataPlanes so we're good for now.
 	//
 	// TODO: https://github.com/Kong/gateway-operator/issues/1226
-	if v.Name != consts.ClusterCertificateVolume && v.Name != consts.ControlPlaneAdmissionWebhookVolumeName {
+	if v.Name != consts.ClusterCertificateVolume &&
+		v.Name != consts.ControlPlaneAdmissionWebhookVolumeName &&
+		v.Name != consts.KonnectClientCertificateVolume {
 		pkgapiscorev1.SetDefaults_Volume(v)
 		if v.HostPath != nil {
 			pkgapiscorev1.SetDefaults_HostPathVolumeSource(v.HostPath)
@@ -3,6 +3,7 @@ package resources
 import (
 	"github.com/samber/lo"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 )
@@ -53,6 +54,30 @@ func NewValidatingWebhookConfigurationBuilder(webhookName string) *ValidatingWeb
 								admissionregistrationv1.Update,
 							},
 						},
+						{
+							Rule: admissionregistrationv1.Rule{
+								APIGroups:   []string{"gateway-operator.konghq.com"},
+								APIVersions: []string{"v1beta1"},
+								Resources:   []string{"gatewayconfigurations"},
+								Scope:       &namespacedScope,
+							},
+							Operations: []admissionregistrationv1.OperationType{
+								admissionregistrationv1.Create,
+								admissionregistrationv1.Update,
+							},
+						},
+						{
+							Rule: admissionregistrationv1.Rule{
+								APIGroups:   []string{"gateway-operator.konghq.com"},
+								APIVersions: []string{"v1alpha1"},
+								Resources:   []string{"aigateways"},
+								Scope:       &namespacedScope,
+							},
+							Operations: []admissionregistrationv1.OperationType{
+								admissionregistrationv1.Create,
+								admissionregistrationv1.Update,
+							},
+						},
 					},
 					AdmissionReviewVersions: []string{"v1", "v1beta1"},
 					SideEffects:             lo.ToPtr(admissionregistrationv1.SideEffectClassNone),
@@ -101,6 +126,22 @@ func (v *ValidatingWebhookConfigurationBuilder) WithScopeAllNamespaces() *Valida
 	return v
 }
 
+// WithNamespaceSelector limits the webhooks to objects in the provided namespaces.
+func (v *ValidatingWebhookConfigurationBuilder) WithNamespaceSelector(namespaces []string) *ValidatingWebhookConfigurationBuilder {
+	for i := range v.vwc.Webhooks {
+		v.vwc.Webhooks[i].NamespaceSelector = &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{
+					Key:      corev1.LabelMetadataName,
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   namespaces,
+				},
+			},
+		}
+	}
+	return v
+}
+
 // Build returns the ValidatingWebhookConfiguration.
 func (v *ValidatingWebhookConfigurationBuilder) Build() *admissionregistrationv1.ValidatingWebhookConfiguration {
 	return v.vwc
@@ -92,4 +92,8 @@ const (
 	// AIGatewayCRDPath points to the CRD descriptor for AI Gateways, for use
 	// throughout tests.
 	AIGatewayCRDPath = "/bases/gateway-operator.konghq.com_aigateways.yaml"
+
+	// AIModelCatalogCRDPath points to the CRD descriptor for AI model catalogs,
+	// for use throughout tests.
+	AIModelCatalogCRDPath = "/bases/gateway-operator.konghq.com_aimodelcatalogs.yaml"
 )
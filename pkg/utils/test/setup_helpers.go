@@ -200,6 +200,9 @@ func DeployCRDs(ctx context.Context, crdPath string, operatorClient *operatorcli
 	if err := clusters.ApplyManifestByURL(ctx, env.Cluster(), path.Join(crdPath, AIGatewayCRDPath)); err != nil {
 		return err
 	}
+	if err := clusters.ApplyManifestByURL(ctx, env.Cluster(), path.Join(crdPath, AIModelCatalogCRDPath)); err != nil {
+		return err
+	}
 
 	// NOTE: this check is not ideal, because we don't know if CRDs were deployed, it assumes that all for KGO are deployed
 	// and checks it by waiting for a single arbitrary chosen CRDs for each API group.